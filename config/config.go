@@ -2,12 +2,15 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	config_destination "github.com/kubecano/cano-collector/config/destination"
 	config_team "github.com/kubecano/cano-collector/config/team"
+	config_webhook "github.com/kubecano/cano-collector/config/webhook"
 	config_workflow "github.com/kubecano/cano-collector/config/workflow"
 )
 
@@ -30,6 +33,129 @@ type AnnotationEnrichmentConfig struct {
 	ExcludeAnnotations []string `json:"excludeAnnotations"`
 }
 
+// ServerConfig configures the HTTP server
+type ServerConfig struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// InternalPort serves /metrics and the health endpoints on a separate listener; 0 keeps them on Port
+	InternalPort int `json:"internalPort"`
+
+	TLSCertFile string `json:"tlsCertFile"`
+	TLSKeyFile  string `json:"tlsKeyFile"`
+	// TLSReloadInterval is how often the certificate files are checked for changes
+	TLSReloadInterval time.Duration `json:"tlsReloadInterval"`
+
+	// MaxBodyBytes limits the size of request bodies accepted by the /api endpoints
+	MaxBodyBytes int64 `json:"maxBodyBytes"`
+
+	ReadTimeout       time.Duration `json:"readTimeout"`
+	ReadHeaderTimeout time.Duration `json:"readHeaderTimeout"`
+	WriteTimeout      time.Duration `json:"writeTimeout"`
+	IdleTimeout       time.Duration `json:"idleTimeout"`
+
+	// ShutdownDelay is how long the server keeps serving after a termination signal while
+	// reporting not ready, so load balancers stop routing to it before connections are drained
+	ShutdownDelay   time.Duration `json:"shutdownDelay"`
+	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
+}
+
+// TLSEnabled reports whether the server should serve HTTPS
+func (s ServerConfig) TLSEnabled() bool {
+	return s.TLSCertFile != "" && s.TLSKeyFile != ""
+}
+
+// Address returns the listen address of the API server
+func (s ServerConfig) Address() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// InternalAddress returns the listen address of the internal server
+func (s ServerConfig) InternalAddress() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.InternalPort))
+}
+
+// InternalEnabled reports whether metrics and health endpoints are served on a separate listener
+func (s ServerConfig) InternalEnabled() bool {
+	return s.InternalPort > 0 && s.InternalPort != s.Port
+}
+
+// AuthConfig configures authentication of the /api endpoints.
+// Every value points to a file mounted from a Kubernetes secret; unset methods are disabled.
+type AuthConfig struct {
+	BearerTokensFile   string   `json:"bearerTokensFile"`
+	BasicAuthFile      string   `json:"basicAuthFile"`
+	HMACSecretFile     string   `json:"hmacSecretFile"`
+	HMACHeader         string   `json:"hmacHeader"`
+	ClientCAFile       string   `json:"clientCAFile"`
+	AllowedClientNames []string `json:"allowedClientNames"`
+}
+
+// HAConfig configures running several replicas with leader election on a coordination.k8s.io Lease.
+// Only the leader dispatches issues; followers forward requests to it or buffer them.
+type HAConfig struct {
+	Enabled   bool   `json:"enabled"`
+	Namespace string `json:"namespace"`
+	LeaseName string `json:"leaseName"`
+	// Identity is the unique name of this replica, usually the pod name
+	Identity string `json:"identity"`
+	// AdvertiseAddress is the base URL followers use to forward requests to this replica
+	AdvertiseAddress string `json:"advertiseAddress"`
+
+	LeaseDuration time.Duration `json:"leaseDuration"`
+	RenewDeadline time.Duration `json:"renewDeadline"`
+	RetryPeriod   time.Duration `json:"retryPeriod"`
+
+	// StateConfigMap stores the thread and deduplication state shared between leaders
+	StateConfigMap    string        `json:"stateConfigMap"`
+	StateSyncInterval time.Duration `json:"stateSyncInterval"`
+	// DedupWindow is how long identical requests are ignored after the first one; 0 disables it
+	DedupWindow time.Duration `json:"dedupWindow"`
+
+	ForwardTimeout time.Duration `json:"forwardTimeout"`
+	// BufferSize is the number of requests a follower keeps while no leader is reachable
+	BufferSize int `json:"bufferSize"`
+}
+
+// ShardingConfig configures distributing issues across replicas by fingerprint. Every replica
+// processes the fingerprints it owns and forwards the others to their owners.
+type ShardingConfig struct {
+	Enabled bool `json:"enabled"`
+	// Discovery is "endpointslice" to list the endpoints of Service, or "dns" to resolve Service as a headless Service name
+	Discovery string `json:"discovery"`
+	Service   string `json:"service"`
+	Namespace string `json:"namespace"`
+	// SelfAddress is the IP address of this replica as published by the Service, usually the pod IP
+	SelfAddress string `json:"selfAddress"`
+	// PeerPort is the internal port peers receive forwarded issues on
+	PeerPort int `json:"peerPort"`
+	// TokenFile holds a token peers must present when forwarding issues; required when Enabled
+	TokenFile string `json:"tokenFile"`
+
+	RefreshInterval time.Duration `json:"refreshInterval"`
+	ForwardTimeout  time.Duration `json:"forwardTimeout"`
+	// VirtualNodes is the number of points each replica has on the hash ring
+	VirtualNodes int `json:"virtualNodes"`
+}
+
+// ReloadConfig configures reloading destinations, teams and workflows while running.
+// A configuration that fails validation is rejected and the active one is kept.
+type ReloadConfig struct {
+	Enabled bool `json:"enabled"`
+	// Interval is how often the configuration is loaded and compared with the active one in
+	// addition to the reloads triggered by changes of the watched files
+	Interval time.Duration `json:"interval"`
+}
+
+// CRDConfig configures loading CanoDestination, CanoTeam and CanoWorkflow resources in
+// addition to the mounted configuration files
+type CRDConfig struct {
+	Enabled bool `json:"enabled"`
+	// WatchNamespace limits the watched resources to one namespace; empty watches all namespaces
+	WatchNamespace string `json:"watchNamespace"`
+	// SyncTimeout bounds the initial listing of the resources at startup
+	SyncTimeout time.Duration `json:"syncTimeout"`
+}
+
 type Config struct {
 	AppName         string
 	AppVersion      string
@@ -43,7 +169,14 @@ type Config struct {
 	Destinations    config_destination.DestinationsConfig
 	Teams           config_team.TeamsConfig
 	Workflows       config_workflow.WorkflowConfig
+	Webhooks        config_webhook.WebhooksConfig
 	Enrichment      EnrichmentConfig
+	Server          ServerConfig
+	Auth            AuthConfig
+	HA              HAConfig
+	Sharding        ShardingConfig
+	Reload          ReloadConfig
+	CRD             CRDConfig
 }
 
 //go:generate mockgen -destination=../mocks/fullconfig_loader_mock.go -package=mocks github.com/kubecano/cano-collector/config FullConfigLoader
@@ -51,6 +184,11 @@ type FullConfigLoader interface {
 	Load() (config_destination.DestinationsConfig, config_team.TeamsConfig, config_workflow.WorkflowConfig, error)
 }
 
+// FileSource is implemented by loaders that read files, so the files can be watched for changes
+type FileSource interface {
+	Files() []string
+}
+
 // LoadConfigWithLoader reads the Config from the provided loader
 func LoadConfigWithLoader(loader FullConfigLoader) (Config, error) {
 	destinations, teams, workflows, err := loader.Load()
@@ -58,6 +196,7 @@ func LoadConfigWithLoader(loader FullConfigLoader) (Config, error) {
 		return Config{}, err
 	}
 
+	server := loadServerConfig()
 	config := Config{
 		AppName:         getEnvString("APP_NAME", "cano-collector"),
 		AppVersion:      getEnvString("APP_VERSION", "dev"),
@@ -71,13 +210,30 @@ func LoadConfigWithLoader(loader FullConfigLoader) (Config, error) {
 		Destinations:    destinations,
 		Teams:           teams,
 		Workflows:       workflows,
-		Enrichment:      loadEnrichmentConfig(),
+		Enrichment:      LoadEnrichmentConfig(),
+		Server:          server,
+		Auth:            loadAuthConfig(),
+		HA:              loadHAConfig(),
+		Sharding:        loadShardingConfig(server),
+		Reload:          loadReloadConfig(),
+		CRD:             loadCRDConfig(),
 	}
 
 	// Validate required fields
 	if config.ClusterName == "" {
 		return Config{}, fmt.Errorf("CLUSTER_NAME environment variable is required")
 	}
+	if config.HA.Enabled && config.Sharding.Enabled {
+		return Config{}, fmt.Errorf("HA_ENABLED and SHARDING_ENABLED cannot be used together")
+	}
+	// Forwarded issues skip API authentication, so they are only accepted on the internal
+	// listener and must carry the shared token
+	if config.Sharding.Enabled && !config.Server.InternalEnabled() {
+		return Config{}, fmt.Errorf("SHARDING_ENABLED requires SERVER_INTERNAL_PORT")
+	}
+	if config.Sharding.Enabled && config.Sharding.TokenFile == "" {
+		return Config{}, fmt.Errorf("SHARDING_ENABLED requires SHARDING_TOKEN_FILE")
+	}
 
 	return config, nil
 }
@@ -86,6 +242,8 @@ type fileConfigLoader struct {
 	destinationsPath string
 	teamsPath        string
 	workflowsPath    string
+	// secrets resolves the *_secret_ref credentials of destinations
+	secrets config_destination.SecretResolver
 }
 
 func NewFileConfigLoader(destinationsPath, teamsPath, workflowsPath string) FullConfigLoader {
@@ -98,6 +256,7 @@ func NewFileConfigLoader(destinationsPath, teamsPath, workflowsPath string) Full
 
 func (f *fileConfigLoader) Load() (config_destination.DestinationsConfig, config_team.TeamsConfig, config_workflow.WorkflowConfig, error) {
 	destLoader := config_destination.NewFileDestinationsLoader(f.destinationsPath)
+	destLoader.Secrets = f.secrets
 	teamLoader := config_team.NewFileTeamsLoader(f.teamsPath)
 	workflowLoader := config_workflow.NewConfigLoader(f.workflowsPath)
 
@@ -119,13 +278,43 @@ func (f *fileConfigLoader) Load() (config_destination.DestinationsConfig, config
 	return *d, *t, *w, nil
 }
 
+// Files returns the destinations, teams and workflows files
+func (f *fileConfigLoader) Files() []string {
+	return []string{f.destinationsPath, f.teamsPath, f.workflowsPath}
+}
+
+// Paths the Helm chart mounts the configuration files at
+const (
+	DestinationsPath = "/etc/cano-collector/destinations/destinations.yaml"
+	TeamsPath        = "/etc/cano-collector/teams/teams.yaml"
+	WorkflowsPath    = "/etc/cano-collector/workflows/workflows.yaml"
+	WebhooksPath     = "/etc/cano-collector/webhooks/webhooks.yaml"
+)
+
+// NewDefaultConfigLoader loads destinations, teams and workflows from the files mounted by the Helm
+// chart. Credentials referenced with *_secret_ref are read from Secrets in the installation namespace.
+func NewDefaultConfigLoader() FullConfigLoader {
+	return &fileConfigLoader{
+		destinationsPath: DestinationsPath,
+		teamsPath:        TeamsPath,
+		workflowsPath:    WorkflowsPath,
+		secrets:          config_destination.NewInClusterSecretResolver(getEnvString("INSTALLATION_NAMESPACE", "default")),
+	}
+}
+
 func LoadConfig() (Config, error) {
-	loader := NewFileConfigLoader(
-		"/etc/cano-collector/destinations/destinations.yaml",
-		"/etc/cano-collector/teams/teams.yaml",
-		"/etc/cano-collector/workflows/workflows.yaml",
-	)
-	return LoadConfigWithLoader(loader)
+	config, err := LoadConfigWithLoader(NewDefaultConfigLoader())
+	if err != nil {
+		return Config{}, err
+	}
+
+	webhooks, err := config_webhook.NewFileWebhooksLoader(WebhooksPath).Load()
+	if err != nil {
+		return Config{}, err
+	}
+	config.Webhooks = *webhooks
+
+	return config, nil
 }
 
 // Helpers
@@ -165,7 +354,109 @@ func getEnvStringSlice(key string, defaultValue []string) []string {
 	return strings.Split(value, ",")
 }
 
-func loadEnrichmentConfig() EnrichmentConfig {
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	if parsed, err := strconv.Atoi(value); err == nil {
+		return parsed
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	if parsed, err := time.ParseDuration(value); err == nil && parsed >= 0 {
+		return parsed
+	}
+	return defaultValue
+}
+
+func loadServerConfig() ServerConfig {
+	return ServerConfig{
+		Host:              getEnvString("SERVER_HOST", ""),
+		Port:              getEnvInt("SERVER_PORT", 8080),
+		InternalPort:      getEnvInt("SERVER_INTERNAL_PORT", 0),
+		TLSCertFile:       getEnvString("TLS_CERT_FILE", ""),
+		TLSKeyFile:        getEnvString("TLS_KEY_FILE", ""),
+		TLSReloadInterval: getEnvDuration("TLS_RELOAD_INTERVAL", time.Minute),
+		MaxBodyBytes:      int64(getEnvInt("SERVER_MAX_BODY_BYTES", 10<<20)),
+		ReadTimeout:       getEnvDuration("SERVER_READ_TIMEOUT", 30*time.Second),
+		ReadHeaderTimeout: getEnvDuration("SERVER_READ_HEADER_TIMEOUT", 10*time.Second),
+		WriteTimeout:      getEnvDuration("SERVER_WRITE_TIMEOUT", 60*time.Second),
+		IdleTimeout:       getEnvDuration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+		ShutdownDelay:     getEnvDuration("SERVER_SHUTDOWN_DELAY", 5*time.Second),
+		ShutdownTimeout:   getEnvDuration("SERVER_SHUTDOWN_TIMEOUT", 20*time.Second),
+	}
+}
+
+func loadAuthConfig() AuthConfig {
+	return AuthConfig{
+		BearerTokensFile:   getEnvString("AUTH_BEARER_TOKENS_FILE", ""),
+		BasicAuthFile:      getEnvString("AUTH_BASIC_AUTH_FILE", ""),
+		HMACSecretFile:     getEnvString("AUTH_HMAC_SECRET_FILE", ""),
+		HMACHeader:         getEnvString("AUTH_HMAC_HEADER", "X-Cano-Signature"),
+		ClientCAFile:       getEnvString("AUTH_CLIENT_CA_FILE", ""),
+		AllowedClientNames: getEnvStringSlice("AUTH_ALLOWED_CLIENT_NAMES", []string{}),
+	}
+}
+
+func loadHAConfig() HAConfig {
+	hostname, _ := os.Hostname()
+	return HAConfig{
+		Enabled:           getEnvBool("HA_ENABLED", false),
+		Namespace:         getEnvString("INSTALLATION_NAMESPACE", "default"),
+		LeaseName:         getEnvString("HA_LEASE_NAME", "cano-collector-leader"),
+		Identity:          getEnvString("POD_NAME", hostname),
+		AdvertiseAddress:  getEnvString("HA_ADVERTISE_ADDRESS", ""),
+		LeaseDuration:     getEnvDuration("HA_LEASE_DURATION", 15*time.Second),
+		RenewDeadline:     getEnvDuration("HA_RENEW_DEADLINE", 10*time.Second),
+		RetryPeriod:       getEnvDuration("HA_RETRY_PERIOD", 2*time.Second),
+		StateConfigMap:    getEnvString("HA_STATE_CONFIGMAP", "cano-collector-state"),
+		StateSyncInterval: getEnvDuration("HA_STATE_SYNC_INTERVAL", 5*time.Second),
+		DedupWindow:       getEnvDuration("HA_DEDUP_WINDOW", time.Minute),
+		ForwardTimeout:    getEnvDuration("HA_FORWARD_TIMEOUT", 10*time.Second),
+		BufferSize:        getEnvInt("HA_BUFFER_SIZE", 1000),
+	}
+}
+
+// loadShardingConfig defaults the peer port to the internal port of server
+func loadShardingConfig(server ServerConfig) ShardingConfig {
+	return ShardingConfig{
+		Enabled:         getEnvBool("SHARDING_ENABLED", false),
+		Discovery:       getEnvEnum("SHARDING_DISCOVERY", []string{"endpointslice", "dns"}, "endpointslice"),
+		Service:         getEnvString("SHARDING_SERVICE", ""),
+		Namespace:       getEnvString("INSTALLATION_NAMESPACE", "default"),
+		SelfAddress:     getEnvString("POD_IP", ""),
+		PeerPort:        getEnvInt("SHARDING_PEER_PORT", server.InternalPort),
+		TokenFile:       getEnvString("SHARDING_TOKEN_FILE", ""),
+		RefreshInterval: getEnvDuration("SHARDING_REFRESH_INTERVAL", 10*time.Second),
+		ForwardTimeout:  getEnvDuration("SHARDING_FORWARD_TIMEOUT", 10*time.Second),
+		VirtualNodes:    getEnvInt("SHARDING_VIRTUAL_NODES", 128),
+	}
+}
+
+func loadReloadConfig() ReloadConfig {
+	return ReloadConfig{
+		Enabled:  getEnvBool("CONFIG_RELOAD_ENABLED", true),
+		Interval: getEnvDuration("CONFIG_RELOAD_INTERVAL", 5*time.Minute),
+	}
+}
+
+func loadCRDConfig() CRDConfig {
+	return CRDConfig{
+		Enabled:        getEnvBool("CRD_ENABLED", false),
+		WatchNamespace: getEnvString("CRD_WATCH_NAMESPACE", ""),
+		SyncTimeout:    getEnvDuration("CRD_SYNC_TIMEOUT", time.Minute),
+	}
+}
+
+// LoadEnrichmentConfig reads the label and annotation enrichment settings from the environment
+func LoadEnrichmentConfig() EnrichmentConfig {
 	return EnrichmentConfig{
 		Labels: LabelEnrichmentConfig{
 			Enabled:       getEnvBool("ENRICHMENT_LABELS_ENABLED", true),
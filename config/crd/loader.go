@@ -0,0 +1,316 @@
+package config_crd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubecano/cano-collector/config"
+	config_destination "github.com/kubecano/cano-collector/config/destination"
+	config_team "github.com/kubecano/cano-collector/config/team"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+)
+
+// loadTimeout bounds writing the statuses during one load
+const loadTimeout = 30 * time.Second
+
+// Loader adds the destinations, teams and workflows defined by custom resources to the
+// configuration of a base loader. The resources are watched, so Load reads them from a cache
+// and Changed signals when they are created, modified or deleted.
+type Loader struct {
+	cfg     config.CRDConfig
+	base    config.FullConfigLoader
+	client  dynamic.Interface
+	kube    kubernetes.Interface
+	logger  logger_interfaces.LoggerInterface
+	factory dynamicinformer.DynamicSharedInformerFactory
+	listers map[schema.GroupVersionResource]cache.GenericLister
+	changed chan struct{}
+	now     func() time.Time
+}
+
+// NewLoader creates a loader for the resources in cfg.WatchNamespace. base may be nil to use the
+// custom resources only; kube reads the Secrets referenced by CanoDestinations.
+func NewLoader(
+	cfg config.CRDConfig,
+	base config.FullConfigLoader,
+	client dynamic.Interface,
+	kube kubernetes.Interface,
+	logger logger_interfaces.LoggerInterface,
+) (*Loader, error) {
+	l := &Loader{
+		cfg:     cfg,
+		base:    base,
+		client:  client,
+		kube:    kube,
+		logger:  logger,
+		factory: dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, cfg.WatchNamespace, nil),
+		listers: make(map[schema.GroupVersionResource]cache.GenericLister),
+		changed: make(chan struct{}, 1),
+		now:     time.Now,
+	}
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) { l.notify() },
+		// status updates written by the loader do not change the generation
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldResource, oldOK := oldObj.(*unstructured.Unstructured)
+			newResource, newOK := newObj.(*unstructured.Unstructured)
+			if !oldOK || !newOK || oldResource.GetGeneration() != newResource.GetGeneration() {
+				l.notify()
+			}
+		},
+		DeleteFunc: func(interface{}) { l.notify() },
+	}
+	for _, gvr := range []schema.GroupVersionResource{DestinationResource, TeamResource, WorkflowResource} {
+		informer := l.factory.ForResource(gvr)
+		if _, err := informer.Informer().AddEventHandler(handler); err != nil {
+			return nil, fmt.Errorf("failed to watch %s: %w", gvr.Resource, err)
+		}
+		l.listers[gvr] = informer.Lister()
+	}
+	return l, nil
+}
+
+// Start watches the resources until ctx is cancelled and waits until they are listed
+func (l *Loader) Start(ctx context.Context) error {
+	l.factory.Start(ctx.Done())
+
+	syncCtx, cancel := context.WithTimeout(ctx, l.cfg.SyncTimeout)
+	defer cancel()
+	for gvr, synced := range l.factory.WaitForCacheSync(syncCtx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to list %s, check that the CRDs are installed and the collector may list them", gvr.Resource)
+		}
+	}
+	return nil
+}
+
+// Changed receives a value after resources were created, modified or deleted
+func (l *Loader) Changed() <-chan struct{} {
+	return l.changed
+}
+
+// Files returns the files read by the base loader
+func (l *Loader) Files() []string {
+	if source, ok := l.base.(config.FileSource); ok {
+		return source.Files()
+	}
+	return nil
+}
+
+func (l *Loader) notify() {
+	select {
+	case l.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Load returns the configuration of the base loader extended with every valid resource and
+// writes the Ready condition of each resource. Invalid resources are left out, so they cannot
+// affect the configuration of other namespaces.
+func (l *Loader) Load() (config_destination.DestinationsConfig, config_team.TeamsConfig, config_workflow.WorkflowConfig, error) {
+	var destinations config_destination.DestinationsConfig
+	var teams config_team.TeamsConfig
+	var workflows config_workflow.WorkflowConfig
+	if l.base != nil {
+		var err error
+		if destinations, teams, workflows, err = l.base.Load(); err != nil {
+			return config_destination.DestinationsConfig{}, config_team.TeamsConfig{}, config_workflow.WorkflowConfig{}, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+	defer cancel()
+
+	readyDestinations := make(map[string]bool)
+	for _, resource := range l.list(DestinationResource) {
+		destination, err := l.destination(resource)
+		l.updateStatus(ctx, DestinationResource, resource, err)
+		if err != nil {
+			continue
+		}
+		destinations.Destinations.Slack = append(destinations.Destinations.Slack, destination)
+		readyDestinations[destination.Name] = true
+	}
+
+	for _, resource := range l.list(TeamResource) {
+		team, err := teamFromResource(resource, readyDestinations)
+		l.updateStatus(ctx, TeamResource, resource, err)
+		if err != nil {
+			continue
+		}
+		teams.Teams = append(teams.Teams, team)
+	}
+
+	for _, resource := range l.list(WorkflowResource) {
+		workflow, err := workflowFromResource(resource)
+		l.updateStatus(ctx, WorkflowResource, resource, err)
+		if err != nil {
+			continue
+		}
+		workflows.ActiveWorkflows = append(workflows.ActiveWorkflows, workflow)
+	}
+
+	return destinations, teams, workflows, nil
+}
+
+// DestinationsLoader returns a loader for the destinations of Load
+func (l *Loader) DestinationsLoader() config_destination.DestinationsLoader {
+	return &destinationsLoader{loader: l}
+}
+
+type destinationsLoader struct {
+	loader *Loader
+}
+
+func (d *destinationsLoader) Load() (*config_destination.DestinationsConfig, error) {
+	destinations, _, _, err := d.loader.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &destinations, nil
+}
+
+// list returns the cached resources sorted by namespace and name, so unchanged resources
+// always produce the same configuration
+func (l *Loader) list(gvr schema.GroupVersionResource) []*unstructured.Unstructured {
+	objects, err := l.listers[gvr].List(labels.Everything())
+	if err != nil {
+		l.logger.Error("Failed to list custom resources", zap.String("resource", gvr.Resource), zap.Error(err))
+		return nil
+	}
+
+	resources := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, object := range objects {
+		if resource, ok := object.(*unstructured.Unstructured); ok {
+			resources = append(resources, resource)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return qualifiedName(resources[i]) < qualifiedName(resources[j])
+	})
+	return resources
+}
+
+func (l *Loader) destination(resource *unstructured.Unstructured) (config_destination.DestinationSlack, error) {
+	var spec DestinationSpec
+	if err := decodeSpec(resource, &spec); err != nil {
+		return config_destination.DestinationSlack{}, err
+	}
+	if spec.Slack == nil {
+		return config_destination.DestinationSlack{}, invalidSpec(errors.New("spec.slack is required"))
+	}
+	if spec.Slack.APIKeySecretRef == nil {
+		return config_destination.DestinationSlack{}, invalidSpec(errors.New("spec.slack.api_key_secret_ref is required"))
+	}
+
+	secrets := config_destination.NewKubernetesSecretResolver(l.kube, resource.GetNamespace())
+	apiKey, err := secrets.ResolveSecret(*spec.Slack.APIKeySecretRef)
+	if err != nil {
+		return config_destination.DestinationSlack{}, invalidReference(err)
+	}
+	destination, err := config_destination.PrepareSlackDestination(config_destination.DestinationSlack{
+		Name:             qualifiedName(resource),
+		APIKey:           config_destination.Secret(apiKey),
+		SlackChannel:     spec.Slack.SlackChannel,
+		GroupingInterval: spec.Slack.GroupingInterval,
+		UnfurlLinks:      spec.Slack.UnfurlLinks,
+		Threading:        spec.Slack.Threading,
+		Enrichments:      spec.Slack.Enrichments,
+	})
+	if err != nil {
+		return config_destination.DestinationSlack{}, invalidSpec(err)
+	}
+	return destination, nil
+}
+
+func teamFromResource(resource *unstructured.Unstructured, readyDestinations map[string]bool) (config_team.Team, error) {
+	var spec TeamSpec
+	if err := decodeSpec(resource, &spec); err != nil {
+		return config_team.Team{}, err
+	}
+
+	namespace := resource.GetNamespace()
+	team := config_team.Team{Name: qualifiedName(resource), Namespaces: []string{namespace}}
+	for _, name := range spec.Destinations {
+		if strings.Contains(name, "/") {
+			return config_team.Team{}, invalidSpec(fmt.Errorf("destination %q must be the name of a CanoDestination in namespace %s", name, namespace))
+		}
+		destination := namespace + "/" + name
+		if !readyDestinations[destination] {
+			return config_team.Team{}, invalidReference(fmt.Errorf("CanoDestination %s does not exist or is not ready", name))
+		}
+		team.Destinations = append(team.Destinations, destination)
+	}
+	return team, nil
+}
+
+func workflowFromResource(resource *unstructured.Unstructured) (config_workflow.WorkflowDefinition, error) {
+	var spec WorkflowSpec
+	if err := decodeSpec(resource, &spec); err != nil {
+		return config_workflow.WorkflowDefinition{}, err
+	}
+
+	namespace := resource.GetNamespace()
+	workflow := config_workflow.WorkflowDefinition{
+		Name:     qualifiedName(resource),
+		Triggers: spec.Triggers,
+		Actions:  spec.Actions,
+		Stop:     spec.Stop,
+	}
+	for i, trigger := range workflow.Triggers {
+		alertTrigger := trigger.OnAlertmanagerAlert
+		if alertTrigger == nil {
+			continue
+		}
+		if alertTrigger.Namespace != "" && alertTrigger.Namespace != namespace {
+			return config_workflow.WorkflowDefinition{}, invalidSpec(fmt.Errorf("trigger %d matches namespace %s, a CanoWorkflow only matches alerts from namespace %s", i, alertTrigger.Namespace, namespace))
+		}
+		alertTrigger.Namespace = namespace
+	}
+	if err := workflow.Validate(); err != nil {
+		return config_workflow.WorkflowDefinition{}, invalidSpec(err)
+	}
+	return workflow, nil
+}
+
+// decodeSpec decodes the spec of resource into out with the YAML field names of the
+// configuration files and rejects unknown fields
+func decodeSpec(resource *unstructured.Unstructured, out interface{}) error {
+	spec, found, err := unstructured.NestedMap(resource.Object, "spec")
+	if err != nil || !found {
+		return invalidSpec(errors.New("spec is required"))
+	}
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return invalidSpec(fmt.Errorf("failed to encode spec: %w", err))
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil {
+		return invalidSpec(fmt.Errorf("invalid spec: %w", err))
+	}
+	return nil
+}
+
+// qualifiedName names the configuration item of resource; names are unique across namespaces
+func qualifiedName(resource *unstructured.Unstructured) string {
+	return resource.GetNamespace() + "/" + resource.GetName()
+}
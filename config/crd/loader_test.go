@@ -0,0 +1,210 @@
+package config_crd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubecano/cano-collector/config"
+	config_team "github.com/kubecano/cano-collector/config/team"
+	"github.com/kubecano/cano-collector/mocks"
+)
+
+func resource(kind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": Group + "/" + Version,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"namespace":  namespace,
+			"name":       name,
+			"generation": int64(1),
+		},
+		"spec": spec,
+	}}
+}
+
+func slackDestination(namespace, name, secret string) *unstructured.Unstructured {
+	return resource("CanoDestination", namespace, name, map[string]interface{}{
+		"slack": map[string]interface{}{
+			"api_key_secret_ref": map[string]interface{}{"name": secret, "key": "token"},
+			"slack_channel":      "#alerts",
+		},
+	})
+}
+
+func secret(namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data:       map[string][]byte{"token": []byte("xoxb-test")},
+	}
+}
+
+type loaderTestDeps struct {
+	loader *Loader
+	client *dynamicfake.FakeDynamicClient
+}
+
+// setupLoaderTest starts a loader for objects without a base configuration
+func setupLoaderTest(t *testing.T, secrets []runtime.Object, objects ...runtime.Object) *loaderTestDeps {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		DestinationResource: "CanoDestinationList",
+		TeamResource:        "CanoTeamList",
+		WorkflowResource:    "CanoWorkflowList",
+	}, objects...)
+
+	loader, err := NewLoader(config.CRDConfig{SyncTimeout: 5 * time.Second}, nil, client, fake.NewClientset(secrets...), mockLogger)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	require.NoError(t, loader.Start(ctx))
+	return &loaderTestDeps{loader: loader, client: client}
+}
+
+// readyCondition returns the Ready condition written to the status of a resource
+func (d *loaderTestDeps) readyCondition(t *testing.T, gvr schema.GroupVersionResource, namespace, name string) map[string]interface{} {
+	t.Helper()
+	object, err := d.client.Resource(gvr).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	conditions, found, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+	require.NoError(t, err)
+	require.True(t, found, "status conditions were not written")
+	require.Len(t, conditions, 1)
+	return conditions[0].(map[string]interface{})
+}
+
+func TestLoader_LoadsNamespacedResources(t *testing.T) {
+	deps := setupLoaderTest(t,
+		[]runtime.Object{secret("payments", "slack")},
+		slackDestination("payments", "slack", "slack"),
+		resource("CanoTeam", "payments", "team", map[string]interface{}{"destinations": []interface{}{"slack"}}),
+		resource("CanoWorkflow", "payments", "enrich", map[string]interface{}{
+			"triggers": []interface{}{map[string]interface{}{"on_alertmanager_alert": map[string]interface{}{"alert_name": "KubePodCrashLooping"}}},
+			"actions":  []interface{}{map[string]interface{}{"action_type": "pod_logs"}},
+		}),
+	)
+
+	destinations, teams, workflows, err := deps.loader.Load()
+	require.NoError(t, err)
+
+	require.Len(t, destinations.Destinations.Slack, 1)
+	slack := destinations.Destinations.Slack[0]
+	assert.Equal(t, "payments/slack", slack.Name)
+	assert.Equal(t, "xoxb-test", slack.APIKey.Value())
+
+	assert.Equal(t, []config_team.Team{{
+		Name:         "payments/team",
+		Destinations: []string{"payments/slack"},
+		Namespaces:   []string{"payments"},
+	}}, teams.Teams)
+
+	require.Len(t, workflows.ActiveWorkflows, 1)
+	workflow := workflows.ActiveWorkflows[0]
+	assert.Equal(t, "payments/enrich", workflow.Name)
+	assert.Equal(t, "payments", workflow.Triggers[0].OnAlertmanagerAlert.Namespace)
+	assert.Equal(t, "pod_logs", workflow.Actions[0].RawData["action_type"])
+
+	condition := deps.readyCondition(t, TeamResource, "payments", "team")
+	assert.Equal(t, "True", condition["status"])
+	assert.Equal(t, ReasonValid, condition["reason"])
+}
+
+func TestLoader_InvalidSpecWritesStatus(t *testing.T) {
+	deps := setupLoaderTest(t, nil,
+		resource("CanoDestination", "payments", "slack", map[string]interface{}{
+			"slack": map[string]interface{}{"api_key": "xoxb-inline", "slack_channel": "#alerts"},
+		}),
+	)
+
+	destinations, _, _, err := deps.loader.Load()
+	require.NoError(t, err)
+	assert.Empty(t, destinations.Destinations.Slack)
+
+	condition := deps.readyCondition(t, DestinationResource, "payments", "slack")
+	assert.Equal(t, "False", condition["status"])
+	assert.Equal(t, ReasonInvalidSpec, condition["reason"])
+	assert.Contains(t, condition["message"], "api_key")
+	assert.NotContains(t, condition["message"], "xoxb-inline")
+}
+
+func TestLoader_MissingSecretInvalidatesTeam(t *testing.T) {
+	// the secret only exists in another namespace
+	deps := setupLoaderTest(t,
+		[]runtime.Object{secret("default", "slack")},
+		slackDestination("payments", "slack", "slack"),
+		resource("CanoTeam", "payments", "team", map[string]interface{}{"destinations": []interface{}{"slack"}}),
+	)
+
+	destinations, teams, _, err := deps.loader.Load()
+	require.NoError(t, err)
+	assert.Empty(t, destinations.Destinations.Slack)
+	assert.Empty(t, teams.Teams)
+
+	condition := deps.readyCondition(t, DestinationResource, "payments", "slack")
+	assert.Equal(t, ReasonInvalidReference, condition["reason"])
+	condition = deps.readyCondition(t, TeamResource, "payments", "team")
+	assert.Equal(t, ReasonInvalidReference, condition["reason"])
+}
+
+func TestLoader_RejectsCrossNamespaceReferences(t *testing.T) {
+	deps := setupLoaderTest(t,
+		[]runtime.Object{secret("default", "slack")},
+		slackDestination("default", "slack", "slack"),
+		resource("CanoTeam", "payments", "team", map[string]interface{}{"destinations": []interface{}{"default/slack"}}),
+		resource("CanoWorkflow", "payments", "enrich", map[string]interface{}{
+			"triggers": []interface{}{map[string]interface{}{"on_alertmanager_alert": map[string]interface{}{"namespace": "default"}}},
+			"actions":  []interface{}{map[string]interface{}{"action_type": "pod_logs"}},
+		}),
+	)
+
+	destinations, teams, workflows, err := deps.loader.Load()
+	require.NoError(t, err)
+	assert.Len(t, destinations.Destinations.Slack, 1)
+	assert.Empty(t, teams.Teams)
+	assert.Empty(t, workflows.ActiveWorkflows)
+
+	condition := deps.readyCondition(t, TeamResource, "payments", "team")
+	assert.Equal(t, ReasonInvalidSpec, condition["reason"])
+	condition = deps.readyCondition(t, WorkflowResource, "payments", "enrich")
+	assert.Equal(t, ReasonInvalidSpec, condition["reason"])
+	assert.Contains(t, condition["message"], "only matches alerts from namespace payments")
+}
+
+func TestLoader_NotifiesOnChange(t *testing.T) {
+	deps := setupLoaderTest(t, nil)
+	// drain notifications of the initial list
+	select {
+	case <-deps.loader.Changed():
+	default:
+	}
+
+	_, err := deps.client.Resource(TeamResource).Namespace("payments").Create(context.Background(),
+		resource("CanoTeam", "payments", "team", map[string]interface{}{}), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case <-deps.loader.Changed():
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notification after creating a resource")
+	}
+}
@@ -0,0 +1,90 @@
+package config_crd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resourceError is an error with the reason written to the Ready condition
+type resourceError struct {
+	reason string
+	err    error
+}
+
+func (e *resourceError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resourceError) Unwrap() error {
+	return e.err
+}
+
+func invalidSpec(err error) error {
+	return &resourceError{reason: ReasonInvalidSpec, err: err}
+}
+
+func invalidReference(err error) error {
+	return &resourceError{reason: ReasonInvalidReference, err: err}
+}
+
+// updateStatus writes the Ready condition of resource for the result of loading it. The status is
+// only written when the condition changed, so unchanged resources do not trigger watch events.
+func (l *Loader) updateStatus(ctx context.Context, gvr schema.GroupVersionResource, resource *unstructured.Unstructured, loadErr error) {
+	status, reason, message := string(metav1.ConditionTrue), ReasonValid, "Resource is part of the active configuration"
+	if loadErr != nil {
+		status, reason, message = string(metav1.ConditionFalse), ReasonInvalidSpec, loadErr.Error()
+		var resErr *resourceError
+		if errors.As(loadErr, &resErr) {
+			reason = resErr.reason
+		}
+		l.logger.Warn("Ignoring invalid custom resource",
+			zap.String("resource", gvr.Resource),
+			zap.String("name", qualifiedName(resource)),
+			zap.String("reason", reason),
+			zap.Error(loadErr))
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(resource.Object, "status", "conditions")
+	transitionTime := l.now().UTC().Format(time.RFC3339)
+	for _, existing := range conditions {
+		condition, ok := existing.(map[string]interface{})
+		if !ok || condition["type"] != ConditionReady {
+			continue
+		}
+		if condition["status"] == status && condition["reason"] == reason && condition["message"] == message &&
+			condition["observedGeneration"] == resource.GetGeneration() {
+			return
+		}
+		if condition["status"] == status {
+			if previous, ok := condition["lastTransitionTime"].(string); ok {
+				transitionTime = previous
+			}
+		}
+	}
+
+	updated := resource.DeepCopy()
+	ready := map[string]interface{}{
+		"type":               ConditionReady,
+		"status":             status,
+		"reason":             reason,
+		"message":            message,
+		"observedGeneration": resource.GetGeneration(),
+		"lastTransitionTime": transitionTime,
+	}
+	if err := unstructured.SetNestedSlice(updated.Object, []interface{}{ready}, "status", "conditions"); err != nil {
+		l.logger.Warn("Failed to set custom resource status", zap.String("name", qualifiedName(resource)), zap.Error(err))
+		return
+	}
+	if _, err := l.client.Resource(gvr).Namespace(resource.GetNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		l.logger.Warn("Failed to update custom resource status",
+			zap.String("resource", gvr.Resource),
+			zap.String("name", qualifiedName(resource)),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,63 @@
+package config_crd
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	config_destination "github.com/kubecano/cano-collector/config/destination"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+)
+
+// Group and version of the Cano custom resources
+const (
+	Group   = "cano.kubecano.io"
+	Version = "v1alpha1"
+)
+
+var (
+	DestinationResource = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "canodestinations"}
+	TeamResource        = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "canoteams"}
+	WorkflowResource    = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "canoworkflows"}
+)
+
+// Condition written to the status of every resource. It is True when the resource is part of the
+// active configuration and False with one of the reasons below otherwise.
+const (
+	ConditionReady = "Ready"
+
+	ReasonValid = "Valid"
+	// ReasonInvalidSpec is set when the spec cannot be decoded or fails validation
+	ReasonInvalidSpec = "InvalidSpec"
+	// ReasonInvalidReference is set when a referenced Secret or CanoDestination is missing or invalid
+	ReasonInvalidReference = "InvalidReference"
+)
+
+// DestinationSpec is the spec of a CanoDestination. Exactly one destination type must be set.
+// The destination is named <namespace>/<name> in the configuration.
+type DestinationSpec struct {
+	Slack *SlackDestinationSpec `yaml:"slack,omitempty"`
+}
+
+// SlackDestinationSpec configures a Slack destination like the destinations file, except that the
+// API key is read from a Secret in the namespace of the CanoDestination
+type SlackDestinationSpec struct {
+	APIKeySecretRef  *config_destination.SecretKeySelector      `yaml:"api_key_secret_ref"`
+	SlackChannel     string                                     `yaml:"slack_channel"`
+	GroupingInterval int                                        `yaml:"grouping_interval,omitempty"`
+	UnfurlLinks      *bool                                      `yaml:"unfurl_links,omitempty"`
+	Threading        *config_destination.SlackThreadingConfig   `yaml:"threading,omitempty"`
+	Enrichments      *config_destination.SlackEnrichmentsConfig `yaml:"enrichments,omitempty"`
+}
+
+// TeamSpec is the spec of a CanoTeam. Destinations are names of CanoDestinations in the same
+// namespace, and the team only handles alerts from its own namespace.
+type TeamSpec struct {
+	Destinations []string `yaml:"destinations"`
+}
+
+// WorkflowSpec is the spec of a CanoWorkflow. Triggers only match alerts from the namespace of
+// the CanoWorkflow.
+type WorkflowSpec struct {
+	Triggers []config_workflow.TriggerDefinition `yaml:"triggers"`
+	Actions  []config_workflow.ActionDefinition  `yaml:"actions"`
+	Stop     bool                                `yaml:"stop,omitempty"`
+}
@@ -12,21 +12,97 @@ import (
 
 type DestinationsConfig struct {
 	Destinations struct {
-		Slack []DestinationSlack `yaml:"slack"`
+		Slack      []DestinationSlack      `yaml:"slack"`
+		MSTeams    []DestinationMSTeams    `yaml:"msteams"`
+		PagerDuty  []DestinationPagerDuty  `yaml:"pagerduty"`
+		Opsgenie   []DestinationOpsgenie   `yaml:"opsgenie"`
+		Webhook    []DestinationWebhook    `yaml:"webhook"`
+		Jira       []DestinationJira       `yaml:"jira"`
+		ServiceNow []DestinationServiceNow `yaml:"servicenow"`
+		Discord    []DestinationDiscord    `yaml:"discord"`
+		Mattermost []DestinationMattermost `yaml:"mattermost"`
+		Telegram   []DestinationTelegram   `yaml:"telegram"`
+		GoogleChat []DestinationGoogleChat `yaml:"google_chat"`
 	} `yaml:"destinations"`
 }
 
+// Count returns the number of destinations of all types
+func (c DestinationsConfig) Count() int {
+	count := 0
+	for _, kind := range destinationKinds {
+		count += kind.count(&c)
+	}
+	return count
+}
+
+// Each calls fn with the type, the name and the configuration of every destination, in the order
+// of the types in DestinationKinds, and stops at the first error
+func (c *DestinationsConfig) Each(fn func(destinationType, name string, config interface{}) error) error {
+	for _, kind := range destinationKinds {
+		err := kind.each(c, func(name string, config interface{}) error {
+			return fn(kind.Type(), name, config)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// names returns the names of the destinations of all types
+func (c *DestinationsConfig) names() []string {
+	names := make([]string, 0, c.Count())
+	_ = c.Each(func(_, name string, _ interface{}) error {
+		names = append(names, name)
+		return nil
+	})
+	return names
+}
+
+// credentials returns the credentials of the destinations of all types
+func (c *DestinationsConfig) credentials() []credential {
+	var credentials []credential
+	for _, kind := range destinationKinds {
+		credentials = append(credentials, kind.credentials(c)...)
+	}
+	return credentials
+}
+
+// CredentialFiles returns the *_file paths the credentials of the destinations are read from
+func (c *DestinationsConfig) CredentialFiles() []string {
+	var files []string
+	for _, credential := range c.credentials() {
+		if credential.file != "" {
+			files = append(files, credential.file)
+		}
+	}
+	return files
+}
+
 // SlackDestination represents a Slack notification destination
 type DestinationSlack struct {
-	Name             string                  `yaml:"name"`
-	APIKey           string                  `yaml:"api_key"`
-	SlackChannel     string                  `yaml:"slack_channel"`
+	Name             string                  `yaml:"name" jsonschema:"required"`
+	APIKey           Secret                  `yaml:"api_key" jsonschema_description:"Slack bot token or ${ENV_VAR} placeholder"`
+	APIKeyFile       string                  `yaml:"api_key_file,omitempty" jsonschema_description:"File containing the Slack bot token"`
+	APIKeySecretRef  *SecretKeySelector      `yaml:"api_key_secret_ref,omitempty" jsonschema_description:"Secret key containing the Slack bot token"`
+	SlackChannel     string                  `yaml:"slack_channel" jsonschema:"required"`
 	GroupingInterval int                     `yaml:"grouping_interval,omitempty"`
 	UnfurlLinks      *bool                   `yaml:"unfurl_links,omitempty"`
 	Threading        *SlackThreadingConfig   `yaml:"threading,omitempty"`
 	Enrichments      *SlackEnrichmentsConfig `yaml:"enrichments,omitempty"`
 }
 
+func (d *DestinationSlack) name() string {
+	return d.Name
+}
+
+func (d *DestinationSlack) credentials() []credential {
+	owner := "slack destination " + d.Name
+	return []credential{
+		{owner: owner, field: "api_key", value: &d.APIKey, file: d.APIKeyFile, secretRef: d.APIKeySecretRef},
+	}
+}
+
 // SlackThreadingConfig represents thread management settings for Slack
 type SlackThreadingConfig struct {
 	Enabled               bool   `yaml:"enabled"`
@@ -38,11 +114,11 @@ type SlackThreadingConfig struct {
 
 // SlackEnrichmentsConfig represents enrichment display settings for Slack
 type SlackEnrichmentsConfig struct {
-	FormatAsBlocks      *bool  `yaml:"format_as_blocks,omitempty"`     // Use Slack blocks instead of plain text
-	ColorCoding         *bool  `yaml:"color_coding,omitempty"`         // Color-code enrichments by type
-	TableFormatting     string `yaml:"table_formatting,omitempty"`     // "simple", "enhanced", or "attachment"
-	MaxTableRows        int    `yaml:"max_table_rows,omitempty"`       // Convert large tables to files
-	AttachmentThreshold int    `yaml:"attachment_threshold,omitempty"` // Characters threshold for file conversion
+	FormatAsBlocks      *bool  `yaml:"format_as_blocks,omitempty"`                                              // Use Slack blocks instead of plain text
+	ColorCoding         *bool  `yaml:"color_coding,omitempty"`                                                  // Color-code enrichments by type
+	TableFormatting     string `yaml:"table_formatting,omitempty" jsonschema:"enum=simple|enhanced|attachment"` // "simple", "enhanced", or "attachment"
+	MaxTableRows        int    `yaml:"max_table_rows,omitempty"`                                                // Convert large tables to files
+	AttachmentThreshold int    `yaml:"attachment_threshold,omitempty"`                                          // Characters threshold for file conversion
 }
 
 //go:generate mockgen -destination=../../mocks/destinations_loader_mock.go -package=mocks github.com/kubecano/cano-collector/config/destination DestinationsLoader
@@ -53,6 +129,11 @@ type DestinationsLoader interface {
 // FileDestinationsLoader loads destinations from a file or secret (ConfigMap/Secret mount)
 type FileDestinationsLoader struct {
 	Path string
+	// Secrets resolves the *_secret_ref credentials; they are rejected when it is nil
+	Secrets SecretResolver
+	// SkipCredentials validates the credential sources without reading them, so a configuration
+	// can be validated without its environment variables, files and Secrets
+	SkipCredentials bool
 }
 
 func NewFileDestinationsLoader(path string) *FileDestinationsLoader {
@@ -68,41 +149,55 @@ func (f *FileDestinationsLoader) Load() (*DestinationsConfig, error) {
 		_ = file.Close()
 	}(file)
 
-	return parseDestinationsYAML(file)
+	return parseDestinationsYAML(file, f.Secrets, f.SkipCredentials)
 }
 
-func parseDestinationsYAML(r io.Reader) (*DestinationsConfig, error) {
+func parseDestinationsYAML(r io.Reader, secrets SecretResolver, skipCredentials bool) (*DestinationsConfig, error) {
 	var config DestinationsConfig
 	decoder := yaml.NewDecoder(r)
 	if err := decoder.Decode(&config); err != nil {
 		return nil, fmt.Errorf("failed to decode destinations YAML: %w", err)
 	}
 
-	// Replace environment variable placeholders and set defaults for Slack destinations
-	for i, d := range config.Destinations.Slack {
-		if strings.HasPrefix(d.APIKey, "${") && strings.HasSuffix(d.APIKey, "}") {
-			envVar := strings.TrimSuffix(strings.TrimPrefix(d.APIKey, "${"), "}")
-			val, ok := os.LookupEnv(envVar)
-			if !ok {
-				return nil, fmt.Errorf("missing required env %s for slack destination %s", envVar, d.Name)
-			}
-			config.Destinations.Slack[i].APIKey = val
+	// Resolve credentials from environment variables, files and Secrets
+	for _, c := range config.credentials() {
+		resolve := c.resolve
+		if skipCredentials {
+			resolve = c.skip
+		}
+		if err := resolve(secrets); err != nil {
+			return nil, err
 		}
+	}
 
-		// Set default values for new configuration options
-		config.Destinations.Slack[i] = setSlackDefaults(config.Destinations.Slack[i])
+	// Validate destinations after environment variables have been replaced and defaults set
+	for _, kind := range destinationKinds {
+		if err := kind.prepare(&config); err != nil {
+			return nil, err
+		}
 	}
 
-	// Validate Slack destinations after environment variables have been replaced and defaults set
-	for _, d := range config.Destinations.Slack {
-		if err := validateSlackDestination(d); err != nil {
-			return nil, fmt.Errorf("invalid Slack destination '%s': %w", d.Name, err)
+	// Teams and workflows refer to destinations by name, whatever their type
+	seen := make(map[string]bool)
+	for _, name := range config.names() {
+		if seen[name] {
+			return nil, fmt.Errorf("destination name '%s' is used more than once", name)
 		}
+		seen[name] = true
 	}
 
 	return &config, nil
 }
 
+// PrepareSlackDestination sets the defaults of a Slack destination and validates it
+func PrepareSlackDestination(d DestinationSlack) (DestinationSlack, error) {
+	d = setSlackDefaults(d)
+	if err := validateSlackDestination(d); err != nil {
+		return DestinationSlack{}, err
+	}
+	return d, nil
+}
+
 // setSlackDefaults sets default values for Slack destination configuration
 func setSlackDefaults(d DestinationSlack) DestinationSlack {
 	// Set threading defaults if threading config is present
@@ -162,7 +257,7 @@ func validateSlackDestination(d DestinationSlack) error {
 	}
 
 	// Skip validation for placeholder values that will be resolved at runtime
-	if strings.HasPrefix(d.APIKey, "${") && strings.HasSuffix(d.APIKey, "}") {
+	if isEnvPlaceholder(d.APIKey.Value()) {
 		return nil
 	}
 
@@ -0,0 +1,60 @@
+package config_destination
+
+import "fmt"
+
+// DestinationDiscord represents a Discord channel that receives embeds through a webhook
+type DestinationDiscord struct {
+	Name                string             `yaml:"name" jsonschema:"required"`
+	WebhookURL          Secret             `yaml:"webhook_url" jsonschema_description:"Webhook URL of the channel or ${ENV_VAR} placeholder"`
+	WebhookURLFile      string             `yaml:"webhook_url_file,omitempty" jsonschema_description:"File containing the webhook URL"`
+	WebhookURLSecretRef *SecretKeySelector `yaml:"webhook_url_secret_ref,omitempty" jsonschema_description:"Secret key containing the webhook URL"`
+	Username            string             `yaml:"username,omitempty" jsonschema_description:"Name the messages are posted as, instead of the name of the webhook"`
+	AvatarURL           string             `yaml:"avatar_url,omitempty" jsonschema_description:"Avatar the messages are posted with, instead of the avatar of the webhook"`
+}
+
+func (d *DestinationDiscord) name() string {
+	return d.Name
+}
+
+func (d *DestinationDiscord) credentials() []credential {
+	owner := "discord destination " + d.Name
+	return []credential{
+		{owner: owner, field: "webhook_url", value: &d.WebhookURL, file: d.WebhookURLFile, secretRef: d.WebhookURLSecretRef},
+	}
+}
+
+// PrepareDiscordDestination validates a Discord destination
+func PrepareDiscordDestination(d DestinationDiscord) (DestinationDiscord, error) {
+	if err := validateDiscordDestination(d); err != nil {
+		return DestinationDiscord{}, err
+	}
+	return d, nil
+}
+
+func validateDiscordDestination(d DestinationDiscord) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if d.AvatarURL != "" {
+		if err := validateURL("avatar_url", d.AvatarURL); err != nil {
+			return err
+		}
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.WebhookURL.Value()) {
+		return nil
+	}
+
+	if d.WebhookURL == "" {
+		return fmt.Errorf("webhook_url is required")
+	}
+
+	// Credentials validated without being read are no URLs
+	if d.WebhookURL == unresolvedCredential {
+		return nil
+	}
+
+	return validateURL("webhook_url", d.WebhookURL.Value())
+}
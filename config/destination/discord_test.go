@@ -0,0 +1,64 @@
+package config_destination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDestinationsYAML_Discord(t *testing.T) {
+	t.Setenv("DISCORD_WEBHOOK_URL_OPS", "https://discord.com/api/webhooks/2/env")
+	cfg, err := parseDestinationsYAML(strings.NewReader(`
+destinations:
+  discord:
+    - name: "alerts"
+      webhook_url: "https://discord.com/api/webhooks/1/inline"
+      username: "Cano"
+      avatar_url: "https://example.com/cano.png"
+    - name: "ops"
+      webhook_url: "${DISCORD_WEBHOOK_URL_OPS}"
+`), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Count())
+
+	require.Len(t, cfg.Destinations.Discord, 2)
+	assert.Equal(t, "https://discord.com/api/webhooks/1/inline", cfg.Destinations.Discord[0].WebhookURL.Value())
+	assert.Equal(t, "Cano", cfg.Destinations.Discord[0].Username)
+	assert.Equal(t, "https://example.com/cano.png", cfg.Destinations.Discord[0].AvatarURL)
+	assert.Equal(t, "https://discord.com/api/webhooks/2/env", cfg.Destinations.Discord[1].WebhookURL.Value())
+}
+
+func TestParseDestinationsYAML_DiscordErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "missing webhook URL",
+			yaml:   "  discord:\n    - name: \"alerts\"\n",
+			errMsg: "invalid Discord destination 'alerts': webhook_url is required",
+		},
+		{
+			name:   "invalid webhook URL",
+			yaml:   "  discord:\n    - name: \"alerts\"\n      webhook_url: \"discord.com/api/webhooks/secret-token\"\n",
+			errMsg: "invalid Discord destination 'alerts': webhook_url must be an http or https URL",
+		},
+		{
+			name:   "invalid avatar URL",
+			yaml:   "  discord:\n    - name: \"alerts\"\n      webhook_url: \"https://discord.com/api/webhooks/secret-token\"\n      avatar_url: \"cano.png\"\n",
+			errMsg: "invalid Discord destination 'alerts': avatar_url must be an http or https URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDestinationsYAML(strings.NewReader("destinations:\n"+tt.yaml), nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.NotContains(t, err.Error(), "secret-token")
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package config_destination
+
+import "fmt"
+
+// DestinationGoogleChat represents a Google Chat space that receives cards through an incoming webhook
+type DestinationGoogleChat struct {
+	Name         string             `yaml:"name" jsonschema:"required"`
+	URL          Secret             `yaml:"url" jsonschema_description:"Incoming webhook URL of the space or ${ENV_VAR} placeholder"`
+	URLFile      string             `yaml:"url_file,omitempty" jsonschema_description:"File containing the webhook URL"`
+	URLSecretRef *SecretKeySelector `yaml:"url_secret_ref,omitempty" jsonschema_description:"Secret key containing the webhook URL"`
+}
+
+func (d *DestinationGoogleChat) name() string {
+	return d.Name
+}
+
+func (d *DestinationGoogleChat) credentials() []credential {
+	owner := "google_chat destination " + d.Name
+	return []credential{
+		{owner: owner, field: "url", value: &d.URL, file: d.URLFile, secretRef: d.URLSecretRef},
+	}
+}
+
+// PrepareGoogleChatDestination validates a Google Chat destination
+func PrepareGoogleChatDestination(d DestinationGoogleChat) (DestinationGoogleChat, error) {
+	if err := validateGoogleChatDestination(d); err != nil {
+		return DestinationGoogleChat{}, err
+	}
+	return d, nil
+}
+
+func validateGoogleChatDestination(d DestinationGoogleChat) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.URL.Value()) {
+		return nil
+	}
+
+	if d.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	// Credentials validated without being read are no URLs
+	if d.URL == unresolvedCredential {
+		return nil
+	}
+
+	return validateURL("url", d.URL.Value())
+}
@@ -0,0 +1,55 @@
+package config_destination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDestinationsYAML_GoogleChat(t *testing.T) {
+	t.Setenv("GOOGLE_CHAT_URL_OPS", "https://chat.googleapis.com/v1/spaces/BBB/messages?key=k&token=env")
+	cfg, err := parseDestinationsYAML(strings.NewReader(`
+destinations:
+  google_chat:
+    - name: "alerts"
+      url: "https://chat.googleapis.com/v1/spaces/AAA/messages?key=k&token=inline"
+    - name: "ops"
+      url: "${GOOGLE_CHAT_URL_OPS}"
+`), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Count())
+
+	require.Len(t, cfg.Destinations.GoogleChat, 2)
+	assert.Equal(t, "https://chat.googleapis.com/v1/spaces/AAA/messages?key=k&token=inline", cfg.Destinations.GoogleChat[0].URL.Value())
+	assert.Equal(t, "https://chat.googleapis.com/v1/spaces/BBB/messages?key=k&token=env", cfg.Destinations.GoogleChat[1].URL.Value())
+}
+
+func TestParseDestinationsYAML_GoogleChatErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "missing URL",
+			yaml:   "  google_chat:\n    - name: \"alerts\"\n",
+			errMsg: "invalid Google Chat destination 'alerts': url is required",
+		},
+		{
+			name:   "invalid URL",
+			yaml:   "  google_chat:\n    - name: \"alerts\"\n      url: \"chat.googleapis.com/v1/spaces/AAA/messages?token=secret-token\"\n",
+			errMsg: "invalid Google Chat destination 'alerts': url must be an http or https URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDestinationsYAML(strings.NewReader("destinations:\n"+tt.yaml), nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.NotContains(t, err.Error(), "secret-token")
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package config_destination
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DestinationJira represents a Jira project in which issues are tracked as tickets
+type DestinationJira struct {
+	Name       string `yaml:"name" jsonschema:"required"`
+	URL        string `yaml:"url" jsonschema:"required" jsonschema_description:"Base URL of the Jira site, e.g. https://your-org.atlassian.net"`
+	APIVersion string `yaml:"api_version,omitempty" jsonschema:"enum=2|3" jsonschema_description:"REST API version: 3 for Jira Cloud (default), 2 for Jira Data Center"`
+	// Username selects basic authentication with the API token; without it the token is sent as
+	// a bearer token, like the personal access tokens of Jira Data Center
+	Username          string             `yaml:"username,omitempty" jsonschema_description:"Email of the Jira Cloud user the API token belongs to"`
+	APIToken          Secret             `yaml:"api_token" jsonschema_description:"API token or personal access token, or ${ENV_VAR} placeholder"`
+	APITokenFile      string             `yaml:"api_token_file,omitempty" jsonschema_description:"File containing the API token"`
+	APITokenSecretRef *SecretKeySelector `yaml:"api_token_secret_ref,omitempty" jsonschema_description:"Secret key containing the API token"`
+
+	ProjectKey      string                 `yaml:"project_key" jsonschema:"required" jsonschema_description:"Key of the project the tickets are created in"`
+	IssueType       string                 `yaml:"issue_type,omitempty" jsonschema_description:"Issue type of the tickets, defaults to Task"`
+	PriorityMapping map[string]string      `yaml:"priority_mapping,omitempty" jsonschema_description:"Jira priority of the issue severities high, low, info and debug"`
+	Components      []string               `yaml:"components,omitempty" jsonschema_description:"Components of the tickets"`
+	Labels          []string               `yaml:"labels,omitempty" jsonschema_description:"Labels of the tickets besides the fingerprint label"`
+	CustomFields    map[string]interface{} `yaml:"custom_fields,omitempty" jsonschema_description:"Further fields of the tickets by field ID, e.g. customfield_10010, in the format of the REST API"`
+	ResolveStatus   string                 `yaml:"resolve_status,omitempty" jsonschema_description:"Status the ticket is transitioned to when the issue is resolved, defaults to Done"`
+}
+
+func (d *DestinationJira) name() string {
+	return d.Name
+}
+
+func (d *DestinationJira) credentials() []credential {
+	owner := "jira destination " + d.Name
+	return []credential{
+		{owner: owner, field: "api_token", value: &d.APIToken, file: d.APITokenFile, secretRef: d.APITokenSecretRef},
+	}
+}
+
+// PrepareJiraDestination sets the defaults of a Jira destination and validates it
+func PrepareJiraDestination(d DestinationJira) (DestinationJira, error) {
+	if d.APIVersion == "" {
+		d.APIVersion = "3"
+	}
+	if d.IssueType == "" {
+		d.IssueType = "Task"
+	}
+	if d.ResolveStatus == "" {
+		d.ResolveStatus = "Done"
+	}
+	if err := validateJiraDestination(d); err != nil {
+		return DestinationJira{}, err
+	}
+	return d, nil
+}
+
+func validateJiraDestination(d DestinationJira) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if d.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if err := validateURL("url", d.URL); err != nil {
+		return err
+	}
+
+	if d.APIVersion != "2" && d.APIVersion != "3" {
+		return fmt.Errorf("api_version must be 2 or 3, got '%s'", d.APIVersion)
+	}
+
+	if d.ProjectKey == "" {
+		return fmt.Errorf("project_key is required")
+	}
+
+	for severity := range d.PriorityMapping {
+		switch strings.ToLower(severity) {
+		case "high", "low", "info", "debug":
+		default:
+			return fmt.Errorf("priority_mapping: unknown severity '%s', must be high, low, info or debug", severity)
+		}
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.APIToken.Value()) {
+		return nil
+	}
+
+	if d.APIToken == "" {
+		return fmt.Errorf("api_token is required")
+	}
+
+	return nil
+}
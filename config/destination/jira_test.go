@@ -0,0 +1,96 @@
+package config_destination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDestinationsYAML_Jira(t *testing.T) {
+	cfg, err := parseDestinationsYAML(strings.NewReader(`
+destinations:
+  jira:
+    - name: "ops"
+      url: "https://example.atlassian.net"
+      username: "bot@example.com"
+      api_token: "jira-api-token"
+      project_key: "OPS"
+    - name: "ops-dc"
+      url: "https://jira.internal"
+      api_version: "2"
+      api_token: "jira-api-token"
+      project_key: "OPS"
+      issue_type: "Incident"
+      priority_mapping:
+        high: "Highest"
+        low: "Low"
+      components: ["platform"]
+      labels: ["k8s"]
+      custom_fields:
+        customfield_10010: "cano"
+      resolve_status: "Resolved"
+`), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Count())
+
+	require.Len(t, cfg.Destinations.Jira, 2)
+	cloud := cfg.Destinations.Jira[0]
+	assert.Equal(t, "3", cloud.APIVersion)
+	assert.Equal(t, "Task", cloud.IssueType)
+	assert.Equal(t, "Done", cloud.ResolveStatus)
+	assert.Equal(t, "jira-api-token", cloud.APIToken.Value())
+
+	dc := cfg.Destinations.Jira[1]
+	assert.Equal(t, "2", dc.APIVersion)
+	assert.Equal(t, "Incident", dc.IssueType)
+	assert.Equal(t, map[string]string{"high": "Highest", "low": "Low"}, dc.PriorityMapping)
+	assert.Equal(t, []string{"platform"}, dc.Components)
+	assert.Equal(t, []string{"k8s"}, dc.Labels)
+	assert.Equal(t, map[string]interface{}{"customfield_10010": "cano"}, dc.CustomFields)
+	assert.Equal(t, "Resolved", dc.ResolveStatus)
+}
+
+func TestParseDestinationsYAML_JiraErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "missing API token",
+			yaml:   "  jira:\n    - name: \"ops\"\n      url: \"https://example.atlassian.net\"\n      project_key: \"OPS\"\n",
+			errMsg: "invalid Jira destination 'ops': api_token is required",
+		},
+		{
+			name:   "missing project key",
+			yaml:   "  jira:\n    - name: \"ops\"\n      url: \"https://example.atlassian.net\"\n      api_token: \"secret-token\"\n",
+			errMsg: "invalid Jira destination 'ops': project_key is required",
+		},
+		{
+			name:   "invalid URL",
+			yaml:   "  jira:\n    - name: \"ops\"\n      url: \"example.atlassian.net\"\n      api_token: \"secret-token\"\n      project_key: \"OPS\"\n",
+			errMsg: "invalid Jira destination 'ops': url must be an http or https URL",
+		},
+		{
+			name:   "unknown API version",
+			yaml:   "  jira:\n    - name: \"ops\"\n      url: \"https://example.atlassian.net\"\n      api_version: \"4\"\n      api_token: \"secret-token\"\n      project_key: \"OPS\"\n",
+			errMsg: "invalid Jira destination 'ops': api_version must be 2 or 3, got '4'",
+		},
+		{
+			name:   "unknown severity",
+			yaml:   "  jira:\n    - name: \"ops\"\n      url: \"https://example.atlassian.net\"\n      api_token: \"secret-token\"\n      project_key: \"OPS\"\n      priority_mapping:\n        critical: \"Highest\"\n",
+			errMsg: "invalid Jira destination 'ops': priority_mapping: unknown severity 'critical', must be high, low, info or debug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDestinationsYAML(strings.NewReader("destinations:\n"+tt.yaml), nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.NotContains(t, err.Error(), "secret-token")
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package config_destination
+
+import "fmt"
+
+// destinationKinds lists the types of destinations in the order they are loaded and registered.
+// Counting, resolving credentials, preparing and registering destinations all go through this
+// table, so a new type of destination only needs its field in DestinationsConfig and an entry
+// here.
+var destinationKinds = []DestinationKind{
+	newDestinationKind("slack", "Slack", func(c *DestinationsConfig) []DestinationSlack { return c.Destinations.Slack }, PrepareSlackDestination),
+	newDestinationKind("msteams", "MS Teams", func(c *DestinationsConfig) []DestinationMSTeams { return c.Destinations.MSTeams }, PrepareMSTeamsDestination),
+	newDestinationKind("pagerduty", "PagerDuty", func(c *DestinationsConfig) []DestinationPagerDuty { return c.Destinations.PagerDuty }, PreparePagerDutyDestination),
+	newDestinationKind("opsgenie", "Opsgenie", func(c *DestinationsConfig) []DestinationOpsgenie { return c.Destinations.Opsgenie }, PrepareOpsgenieDestination),
+	newDestinationKind("webhook", "webhook", func(c *DestinationsConfig) []DestinationWebhook { return c.Destinations.Webhook }, PrepareWebhookDestination),
+	newDestinationKind("jira", "Jira", func(c *DestinationsConfig) []DestinationJira { return c.Destinations.Jira }, PrepareJiraDestination),
+	newDestinationKind("servicenow", "ServiceNow", func(c *DestinationsConfig) []DestinationServiceNow { return c.Destinations.ServiceNow }, PrepareServiceNowDestination),
+	newDestinationKind("discord", "Discord", func(c *DestinationsConfig) []DestinationDiscord { return c.Destinations.Discord }, PrepareDiscordDestination),
+	newDestinationKind("mattermost", "Mattermost", func(c *DestinationsConfig) []DestinationMattermost { return c.Destinations.Mattermost }, PrepareMattermostDestination),
+	newDestinationKind("telegram", "Telegram", func(c *DestinationsConfig) []DestinationTelegram { return c.Destinations.Telegram }, PrepareTelegramDestination),
+	newDestinationKind("google_chat", "Google Chat", func(c *DestinationsConfig) []DestinationGoogleChat { return c.Destinations.GoogleChat }, PrepareGoogleChatDestination),
+}
+
+// DestinationKinds returns the types of destinations
+func DestinationKinds() []DestinationKind {
+	return destinationKinds
+}
+
+// DestinationKind is a type of destination, like Slack, and the destinations of that type in a
+// configuration
+type DestinationKind interface {
+	// Type is the key of the destinations in the configuration, like "slack"
+	Type() string
+	// New returns an empty destination of the type, as accepted by the destination factory
+	New() interface{}
+
+	count(c *DestinationsConfig) int
+	// each calls fn with the name and the configuration of every destination of the type
+	each(c *DestinationsConfig, fn func(name string, config interface{}) error) error
+	credentials(c *DestinationsConfig) []credential
+	// prepare sets the defaults of every destination of the type and validates it
+	prepare(c *DestinationsConfig) error
+}
+
+// destination is the pointer to a destination configuration, which knows its name and its
+// credentials
+type destination[D any] interface {
+	*D
+	name() string
+	credentials() []credential
+}
+
+type destinationKind[D any, P destination[D]] struct {
+	typ   string
+	label string
+	// list returns the destinations of the type; its elements are updated in place
+	list        func(c *DestinationsConfig) []D
+	prepareFunc func(d D) (D, error)
+}
+
+func newDestinationKind[D any, P destination[D]](typ, label string, list func(c *DestinationsConfig) []D, prepare func(d D) (D, error)) DestinationKind {
+	return destinationKind[D, P]{typ: typ, label: label, list: list, prepareFunc: prepare}
+}
+
+func (k destinationKind[D, P]) Type() string {
+	return k.typ
+}
+
+func (k destinationKind[D, P]) New() interface{} {
+	var d D
+	return d
+}
+
+func (k destinationKind[D, P]) count(c *DestinationsConfig) int {
+	return len(k.list(c))
+}
+
+func (k destinationKind[D, P]) each(c *DestinationsConfig, fn func(name string, config interface{}) error) error {
+	for _, d := range k.list(c) {
+		if err := fn(P(&d).name(), d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k destinationKind[D, P]) credentials(c *DestinationsConfig) []credential {
+	var credentials []credential
+	destinations := k.list(c)
+	for i := range destinations {
+		credentials = append(credentials, P(&destinations[i]).credentials()...)
+	}
+	return credentials
+}
+
+func (k destinationKind[D, P]) prepare(c *DestinationsConfig) error {
+	destinations := k.list(c)
+	for i, d := range destinations {
+		prepared, err := k.prepareFunc(d)
+		if err != nil {
+			return fmt.Errorf("invalid %s destination '%s': %w", k.label, P(&d).name(), err)
+		}
+		destinations[i] = prepared
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package config_destination
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestinationKinds_CoverConfig(t *testing.T) {
+	var config DestinationsConfig
+	fields := reflect.TypeOf(config.Destinations)
+
+	var keys []string
+	for i := 0; i < fields.NumField(); i++ {
+		keys = append(keys, fields.Field(i).Tag.Get("yaml"))
+	}
+	var types []string
+	for _, kind := range DestinationKinds() {
+		types = append(types, kind.Type())
+	}
+	assert.Equal(t, keys, types)
+}
+
+func TestDestinationsConfig_Each(t *testing.T) {
+	var config DestinationsConfig
+	config.Destinations.Slack = []DestinationSlack{{Name: "slack-1"}}
+	config.Destinations.Telegram = []DestinationTelegram{{Name: "telegram-1"}, {Name: "telegram-2"}}
+
+	var visited []string
+	err := config.Each(func(destinationType, name string, config interface{}) error {
+		visited = append(visited, destinationType+"/"+name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"slack/slack-1", "telegram/telegram-1", "telegram/telegram-2"}, visited)
+	assert.Equal(t, 3, config.Count())
+}
@@ -0,0 +1,81 @@
+package config_destination
+
+import (
+	"fmt"
+	"time"
+)
+
+// DestinationMattermost represents a Mattermost channel a bot posts to through the REST API
+type DestinationMattermost struct {
+	Name           string                     `yaml:"name" jsonschema:"required"`
+	URL            string                     `yaml:"url" jsonschema:"required" jsonschema_description:"Base URL of the Mattermost server, e.g. https://mattermost.example.com"`
+	Token          Secret                     `yaml:"token" jsonschema_description:"Access token of the bot or ${ENV_VAR} placeholder"`
+	TokenFile      string                     `yaml:"token_file,omitempty" jsonschema_description:"File containing the access token"`
+	TokenSecretRef *SecretKeySelector         `yaml:"token_secret_ref,omitempty" jsonschema_description:"Secret key containing the access token"`
+	ChannelID      string                     `yaml:"channel_id" jsonschema:"required" jsonschema_description:"ID of the channel, shown under View Info of the channel"`
+	Threading      *MattermostThreadingConfig `yaml:"threading,omitempty"`
+}
+
+// MattermostThreadingConfig posts resolved notifications as replies to the firing message
+type MattermostThreadingConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// CacheTTL is how long a firing message is remembered, so resolved notifications after it are posted in its thread
+	CacheTTL string `yaml:"cache_ttl,omitempty" jsonschema_description:"How long resolved notifications are posted in the thread of the firing message, defaults to 24h"`
+}
+
+func (d *DestinationMattermost) name() string {
+	return d.Name
+}
+
+func (d *DestinationMattermost) credentials() []credential {
+	owner := "mattermost destination " + d.Name
+	return []credential{
+		{owner: owner, field: "token", value: &d.Token, file: d.TokenFile, secretRef: d.TokenSecretRef},
+	}
+}
+
+// PrepareMattermostDestination sets the defaults of a Mattermost destination and validates it
+func PrepareMattermostDestination(d DestinationMattermost) (DestinationMattermost, error) {
+	if d.Threading != nil && d.Threading.CacheTTL == "" {
+		d.Threading.CacheTTL = "24h"
+	}
+	if err := validateMattermostDestination(d); err != nil {
+		return DestinationMattermost{}, err
+	}
+	return d, nil
+}
+
+func validateMattermostDestination(d DestinationMattermost) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if d.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if err := validateURL("url", d.URL); err != nil {
+		return err
+	}
+
+	if d.ChannelID == "" {
+		return fmt.Errorf("channel_id is required")
+	}
+
+	if d.Threading != nil {
+		ttl, err := time.ParseDuration(d.Threading.CacheTTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("threading.cache_ttl must be a positive duration, got '%s'", d.Threading.CacheTTL)
+		}
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.Token.Value()) {
+		return nil
+	}
+
+	if d.Token == "" {
+		return fmt.Errorf("token is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package config_destination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDestinationsYAML_Mattermost(t *testing.T) {
+	t.Setenv("MATTERMOST_TOKEN_OPS", "env-token")
+	cfg, err := parseDestinationsYAML(strings.NewReader(`
+destinations:
+  mattermost:
+    - name: "alerts"
+      url: "https://mattermost.example.com"
+      token: "inline-token"
+      channel_id: "4xp9fdt77pncbef59f4k1qe83o"
+      threading:
+        enabled: true
+    - name: "ops"
+      url: "https://mattermost.example.com"
+      token: "${MATTERMOST_TOKEN_OPS}"
+      channel_id: "9xp9fdt77pncbef59f4k1qe83o"
+`), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Count())
+
+	require.Len(t, cfg.Destinations.Mattermost, 2)
+	alerts := cfg.Destinations.Mattermost[0]
+	assert.Equal(t, "inline-token", alerts.Token.Value())
+	assert.Equal(t, "4xp9fdt77pncbef59f4k1qe83o", alerts.ChannelID)
+	require.NotNil(t, alerts.Threading)
+	assert.True(t, alerts.Threading.Enabled)
+	assert.Equal(t, "24h", alerts.Threading.CacheTTL)
+
+	ops := cfg.Destinations.Mattermost[1]
+	assert.Equal(t, "env-token", ops.Token.Value())
+	assert.Nil(t, ops.Threading)
+}
+
+func TestParseDestinationsYAML_MattermostErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "missing URL",
+			yaml:   "  mattermost:\n    - name: \"alerts\"\n      token: \"secret-token\"\n      channel_id: \"abc\"\n",
+			errMsg: "invalid Mattermost destination 'alerts': url is required",
+		},
+		{
+			name:   "invalid URL",
+			yaml:   "  mattermost:\n    - name: \"alerts\"\n      url: \"mattermost.example.com\"\n      token: \"secret-token\"\n      channel_id: \"abc\"\n",
+			errMsg: "invalid Mattermost destination 'alerts': url must be an http or https URL",
+		},
+		{
+			name:   "missing channel",
+			yaml:   "  mattermost:\n    - name: \"alerts\"\n      url: \"https://mattermost.example.com\"\n      token: \"secret-token\"\n",
+			errMsg: "invalid Mattermost destination 'alerts': channel_id is required",
+		},
+		{
+			name:   "missing token",
+			yaml:   "  mattermost:\n    - name: \"alerts\"\n      url: \"https://mattermost.example.com\"\n      channel_id: \"abc\"\n",
+			errMsg: "invalid Mattermost destination 'alerts': token is required",
+		},
+		{
+			name:   "invalid cache TTL",
+			yaml:   "  mattermost:\n    - name: \"alerts\"\n      url: \"https://mattermost.example.com\"\n      token: \"secret-token\"\n      channel_id: \"abc\"\n      threading:\n        enabled: true\n        cache_ttl: \"a day\"\n",
+			errMsg: "invalid Mattermost destination 'alerts': threading.cache_ttl must be a positive duration, got 'a day'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDestinationsYAML(strings.NewReader("destinations:\n"+tt.yaml), nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.NotContains(t, err.Error(), "secret-token")
+		})
+	}
+}
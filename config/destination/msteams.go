@@ -0,0 +1,67 @@
+package config_destination
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// DestinationMSTeams represents a Microsoft Teams destination that posts Adaptive Cards to an
+// incoming webhook or a Workflows URL
+type DestinationMSTeams struct {
+	Name                string             `yaml:"name" jsonschema:"required"`
+	WebhookURL          Secret             `yaml:"webhook_url" jsonschema_description:"Incoming webhook or Workflows URL of the channel or ${ENV_VAR} placeholder"`
+	WebhookURLFile      string             `yaml:"webhook_url_file,omitempty" jsonschema_description:"File containing the webhook URL"`
+	WebhookURLSecretRef *SecretKeySelector `yaml:"webhook_url_secret_ref,omitempty" jsonschema_description:"Secret key containing the webhook URL"`
+	WebhookOverride     string             `yaml:"webhook_override,omitempty" jsonschema_description:"Annotation of the issue subject whose value replaces the webhook URL"`
+}
+
+func (d *DestinationMSTeams) name() string {
+	return d.Name
+}
+
+func (d *DestinationMSTeams) credentials() []credential {
+	owner := "msteams destination " + d.Name
+	return []credential{
+		{owner: owner, field: "webhook_url", value: &d.WebhookURL, file: d.WebhookURLFile, secretRef: d.WebhookURLSecretRef},
+	}
+}
+
+// PrepareMSTeamsDestination validates a Microsoft Teams destination
+func PrepareMSTeamsDestination(d DestinationMSTeams) (DestinationMSTeams, error) {
+	if err := validateMSTeamsDestination(d); err != nil {
+		return DestinationMSTeams{}, err
+	}
+	return d, nil
+}
+
+func validateMSTeamsDestination(d DestinationMSTeams) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.WebhookURL.Value()) {
+		return nil
+	}
+
+	if d.WebhookURL == "" {
+		return fmt.Errorf("webhook_url is required")
+	}
+
+	// Credentials validated without being read are no URLs
+	if d.WebhookURL == unresolvedCredential {
+		return nil
+	}
+
+	return validateURL("webhook_url", d.WebhookURL.Value())
+}
+
+// validateURL checks that value is an http(s) URL. The URL is left out of the error, since
+// webhook URLs carry their own token.
+func validateURL(field, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("%s must be an http or https URL", field)
+	}
+	return nil
+}
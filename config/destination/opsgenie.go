@@ -0,0 +1,124 @@
+package config_destination
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// OpsgenieAPIURL is the API endpoint of the US service region
+	OpsgenieAPIURL = "https://api.opsgenie.com"
+	// OpsgenieEUAPIURL is the API endpoint of the EU service region
+	OpsgenieEUAPIURL = "https://api.eu.opsgenie.com"
+)
+
+// DestinationOpsgenie represents an Opsgenie account that receives alerts through the Alert API
+type DestinationOpsgenie struct {
+	Name            string              `yaml:"name" jsonschema:"required"`
+	APIKey          Secret              `yaml:"api_key" jsonschema_description:"Key of an Opsgenie API integration or ${ENV_VAR} placeholder"`
+	APIKeyFile      string              `yaml:"api_key_file,omitempty" jsonschema_description:"File containing the API key"`
+	APIKeySecretRef *SecretKeySelector  `yaml:"api_key_secret_ref,omitempty" jsonschema_description:"Secret key containing the API key"`
+	Region          string              `yaml:"region,omitempty" jsonschema:"enum=us|eu" jsonschema_description:"Service region of the Opsgenie account, defaults to us"`
+	Teams           []string            `yaml:"teams,omitempty" jsonschema_description:"Names of the teams that are responders of every alert"`
+	Responders      []OpsgenieResponder `yaml:"responders,omitempty" jsonschema_description:"Users, escalations and schedules that are responders of every alert"`
+	Tags            []string            `yaml:"tags,omitempty" jsonschema_description:"Tags added to every alert besides the labels of the subject"`
+	PriorityMapping map[string]string   `yaml:"priority_mapping,omitempty" jsonschema_description:"Opsgenie priority, P1 to P5, of critical alerts and of the issue severities high, low, info and debug"`
+}
+
+// OpsgeniePriorityDefaults is the priority of the severities without a mapping. Critical is a
+// high issue whose alert has the label severity=critical.
+var OpsgeniePriorityDefaults = map[string]string{
+	"critical": "P1",
+	"high":     "P2",
+	"low":      "P3",
+	"info":     "P4",
+	"debug":    "P5",
+}
+
+// OpsgenieResponder is a team, user, escalation or schedule notified of an alert
+type OpsgenieResponder struct {
+	Type string `yaml:"type" jsonschema:"required,enum=team|user|escalation|schedule"`
+	// Name is the name of the team, escalation or schedule, or the username of the user
+	Name string `yaml:"name" jsonschema:"required"`
+}
+
+// APIURL returns the API endpoint of the region of the destination
+func (d DestinationOpsgenie) APIURL() string {
+	if d.Region == "eu" {
+		return OpsgenieEUAPIURL
+	}
+	return OpsgenieAPIURL
+}
+
+func (d *DestinationOpsgenie) name() string {
+	return d.Name
+}
+
+func (d *DestinationOpsgenie) credentials() []credential {
+	owner := "opsgenie destination " + d.Name
+	return []credential{
+		{owner: owner, field: "api_key", value: &d.APIKey, file: d.APIKeyFile, secretRef: d.APIKeySecretRef},
+	}
+}
+
+// PrepareOpsgenieDestination sets the defaults of an Opsgenie destination and validates it
+func PrepareOpsgenieDestination(d DestinationOpsgenie) (DestinationOpsgenie, error) {
+	if d.Region == "" {
+		d.Region = "us"
+	}
+	priorities := make(map[string]string, len(OpsgeniePriorityDefaults))
+	for severity, priority := range OpsgeniePriorityDefaults {
+		priorities[severity] = priority
+	}
+	for severity, priority := range d.PriorityMapping {
+		priorities[strings.ToLower(severity)] = priority
+	}
+	d.PriorityMapping = priorities
+	if err := validateOpsgenieDestination(d); err != nil {
+		return DestinationOpsgenie{}, err
+	}
+	return d, nil
+}
+
+func validateOpsgenieDestination(d DestinationOpsgenie) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if d.Region != "us" && d.Region != "eu" {
+		return fmt.Errorf("region must be us or eu, got '%s'", d.Region)
+	}
+
+	for i, responder := range d.Responders {
+		switch responder.Type {
+		case "team", "user", "escalation", "schedule":
+		default:
+			return fmt.Errorf("responders[%d]: type must be team, user, escalation or schedule, got '%s'", i, responder.Type)
+		}
+		if responder.Name == "" {
+			return fmt.Errorf("responders[%d]: name is required", i)
+		}
+	}
+
+	for severity, priority := range d.PriorityMapping {
+		if _, ok := OpsgeniePriorityDefaults[severity]; !ok {
+			return fmt.Errorf("priority_mapping: unknown severity '%s', must be critical, high, low, info or debug", severity)
+		}
+		switch priority {
+		case "P1", "P2", "P3", "P4", "P5":
+		default:
+			return fmt.Errorf("priority_mapping.%s must be P1, P2, P3, P4 or P5, got '%s'", severity, priority)
+		}
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.APIKey.Value()) {
+		return nil
+	}
+
+	if d.APIKey == "" {
+		return fmt.Errorf("api_key is required")
+	}
+
+	return nil
+}
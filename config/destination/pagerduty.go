@@ -0,0 +1,59 @@
+package config_destination
+
+import "fmt"
+
+// DefaultPagerDutyEventsURL is the Events API v2 endpoint of the US service region
+const DefaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// DestinationPagerDuty represents a PagerDuty service that receives alert events through the
+// Events API v2
+type DestinationPagerDuty struct {
+	Name                string             `yaml:"name" jsonschema:"required"`
+	RoutingKey          Secret             `yaml:"routing_key" jsonschema_description:"Integration key of the PagerDuty service or ${ENV_VAR} placeholder"`
+	RoutingKeyFile      string             `yaml:"routing_key_file,omitempty" jsonschema_description:"File containing the integration key"`
+	RoutingKeySecretRef *SecretKeySelector `yaml:"routing_key_secret_ref,omitempty" jsonschema_description:"Secret key containing the integration key"`
+	EventsURL           string             `yaml:"events_url,omitempty" jsonschema_description:"Events API v2 endpoint, defaults to https://events.pagerduty.com/v2/enqueue; use https://events.eu.pagerduty.com/v2/enqueue for the EU service region"`
+}
+
+func (d *DestinationPagerDuty) name() string {
+	return d.Name
+}
+
+func (d *DestinationPagerDuty) credentials() []credential {
+	owner := "pagerduty destination " + d.Name
+	return []credential{
+		{owner: owner, field: "routing_key", value: &d.RoutingKey, file: d.RoutingKeyFile, secretRef: d.RoutingKeySecretRef},
+	}
+}
+
+// PreparePagerDutyDestination sets the defaults of a PagerDuty destination and validates it
+func PreparePagerDutyDestination(d DestinationPagerDuty) (DestinationPagerDuty, error) {
+	if d.EventsURL == "" {
+		d.EventsURL = DefaultPagerDutyEventsURL
+	}
+	if err := validatePagerDutyDestination(d); err != nil {
+		return DestinationPagerDuty{}, err
+	}
+	return d, nil
+}
+
+func validatePagerDutyDestination(d DestinationPagerDuty) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if err := validateURL("events_url", d.EventsURL); err != nil {
+		return err
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.RoutingKey.Value()) {
+		return nil
+	}
+
+	if d.RoutingKey == "" {
+		return fmt.Errorf("routing_key is required")
+	}
+
+	return nil
+}
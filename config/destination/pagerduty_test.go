@@ -0,0 +1,63 @@
+package config_destination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDestinationsYAML_PagerDuty(t *testing.T) {
+	t.Setenv("PAGERDUTY_ROUTING_KEY_DATABASES", "env-routing-key")
+	cfg, err := parseDestinationsYAML(strings.NewReader(`
+destinations:
+  pagerduty:
+    - name: "on-call"
+      routing_key: "inline-routing-key"
+    - name: "databases"
+      routing_key: "${PAGERDUTY_ROUTING_KEY_DATABASES}"
+      events_url: "https://events.eu.pagerduty.com/v2/enqueue"
+`), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Count())
+
+	require.Len(t, cfg.Destinations.PagerDuty, 2)
+	assert.Equal(t, "inline-routing-key", cfg.Destinations.PagerDuty[0].RoutingKey.Value())
+	assert.Equal(t, DefaultPagerDutyEventsURL, cfg.Destinations.PagerDuty[0].EventsURL)
+	assert.Equal(t, "env-routing-key", cfg.Destinations.PagerDuty[1].RoutingKey.Value())
+	assert.Equal(t, "https://events.eu.pagerduty.com/v2/enqueue", cfg.Destinations.PagerDuty[1].EventsURL)
+}
+
+func TestParseDestinationsYAML_PagerDutyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "missing routing key",
+			yaml:   "  pagerduty:\n    - name: \"on-call\"\n",
+			errMsg: "invalid PagerDuty destination 'on-call': routing_key is required",
+		},
+		{
+			name:   "events URL is not a URL",
+			yaml:   "  pagerduty:\n    - name: \"on-call\"\n      routing_key: \"secret-token\"\n      events_url: \"events.pagerduty.com\"\n",
+			errMsg: "invalid PagerDuty destination 'on-call': events_url must be an http or https URL",
+		},
+		{
+			name:   "missing name",
+			yaml:   "  pagerduty:\n    - routing_key: \"secret-token\"\n",
+			errMsg: "name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDestinationsYAML(strings.NewReader("destinations:\n"+tt.yaml), nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.NotContains(t, err.Error(), "secret-token")
+		})
+	}
+}
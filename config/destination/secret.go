@@ -0,0 +1,213 @@
+package config_destination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const redacted = "[REDACTED]"
+
+// unresolvedCredential replaces credentials that are validated without being read
+const unresolvedCredential Secret = "unresolved"
+
+// secretTimeout bounds reading one Secret from the Kubernetes API
+const secretTimeout = 10 * time.Second
+
+// Secret is a credential of a destination. It is redacted when formatted or encoded, so logging a
+// destination configuration never reveals it; Value returns the credential itself.
+type Secret string
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return fmt.Sprintf("%q", s.String())
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", s.String())), nil
+}
+
+func (s Secret) MarshalYAML() (interface{}, error) {
+	return s.String(), nil
+}
+
+// SecretKeySelector selects a key of a Secret
+type SecretKeySelector struct {
+	Name string `yaml:"name" jsonschema:"required"`
+	Key  string `yaml:"key" jsonschema:"required"`
+}
+
+// SecretResolver reads the values of Secrets referenced by destinations
+type SecretResolver interface {
+	ResolveSecret(ref SecretKeySelector) (string, error)
+}
+
+// KubernetesSecretResolver reads Secrets of one namespace through the Kubernetes API
+type KubernetesSecretResolver struct {
+	namespace string
+	newClient func() (kubernetes.Interface, error)
+
+	mu     sync.Mutex
+	client kubernetes.Interface
+}
+
+// NewKubernetesSecretResolver creates a resolver for the Secrets in namespace
+func NewKubernetesSecretResolver(client kubernetes.Interface, namespace string) *KubernetesSecretResolver {
+	return &KubernetesSecretResolver{namespace: namespace, client: client}
+}
+
+// NewInClusterSecretResolver creates a resolver for the Secrets in namespace that connects with the
+// service account of the pod on first use, so configurations without Secret references do not
+// need a cluster
+func NewInClusterSecretResolver(namespace string) *KubernetesSecretResolver {
+	return &KubernetesSecretResolver{
+		namespace: namespace,
+		newClient: func() (kubernetes.Interface, error) {
+			restConfig, err := rest.InClusterConfig()
+			if err != nil {
+				return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+			}
+			return kubernetes.NewForConfig(restConfig)
+		},
+	}
+}
+
+func (r *KubernetesSecretResolver) ResolveSecret(ref SecretKeySelector) (string, error) {
+	if ref.Name == "" || ref.Key == "" {
+		return "", errors.New("secret reference requires name and key")
+	}
+	client, err := r.kubeClient()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretTimeout)
+	defer cancel()
+	secret, err := client.CoreV1().Secrets(r.namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s: %w", ref.Name, err)
+	}
+	value := strings.TrimSpace(string(secret.Data[ref.Key]))
+	if value == "" {
+		return "", fmt.Errorf("secret %s has no value for key %s", ref.Name, ref.Key)
+	}
+	return value, nil
+}
+
+func (r *KubernetesSecretResolver) kubeClient() (kubernetes.Interface, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client == nil {
+		client, err := r.newClient()
+		if err != nil {
+			return nil, err
+		}
+		r.client = client
+	}
+	return r.client, nil
+}
+
+// credential is a secret field of a destination together with the alternative sources of its
+// value. Every destination type lists its credentials, so all of them support the same sources.
+type credential struct {
+	// owner describes the destination in errors, like "slack destination alerts"
+	owner     string
+	field     string
+	value     *Secret
+	file      string
+	secretRef *SecretKeySelector
+}
+
+// resolve sets the value of c from an environment placeholder, a file or a Secret. Files and
+// Secrets are read on every load, so rotated credentials are picked up by the next reload.
+// Errors name the source but never contain the value.
+func (c credential) resolve(resolver SecretResolver) error {
+	if isEnvPlaceholder(c.value.Value()) && c.file == "" && c.secretRef == nil {
+		envVar := strings.TrimSuffix(strings.TrimPrefix(c.value.Value(), "${"), "}")
+		value, ok := os.LookupEnv(envVar)
+		if !ok {
+			return fmt.Errorf("missing required env %s for %s", envVar, c.owner)
+		}
+		*c.value = Secret(value)
+		return nil
+	}
+	if err := c.resolveSource(resolver); err != nil {
+		return fmt.Errorf("%s: %w", c.owner, err)
+	}
+	return nil
+}
+
+func (c credential) resolveSource(resolver SecretResolver) error {
+	if err := c.checkSources(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.file != "":
+		data, err := os.ReadFile(c.file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s_file: %w", c.field, err)
+		}
+		value := strings.TrimSpace(string(data))
+		if value == "" {
+			return fmt.Errorf("%s_file %s is empty", c.field, c.file)
+		}
+		*c.value = Secret(value)
+	case c.secretRef != nil:
+		if resolver == nil {
+			return fmt.Errorf("%s_secret_ref requires access to the Kubernetes API", c.field)
+		}
+		value, err := resolver.ResolveSecret(*c.secretRef)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s_secret_ref: %w", c.field, err)
+		}
+		*c.value = Secret(value)
+	}
+	return nil
+}
+
+// skip checks the sources of c without reading them and replaces the value with a placeholder
+func (c credential) skip(SecretResolver) error {
+	if err := c.checkSources(); err != nil {
+		return fmt.Errorf("%s: %w", c.owner, err)
+	}
+	if c.file != "" || c.secretRef != nil || isEnvPlaceholder(c.value.Value()) {
+		*c.value = unresolvedCredential
+	}
+	return nil
+}
+
+func (c credential) checkSources() error {
+	sources := 0
+	for _, set := range []bool{*c.value != "", c.file != "", c.secretRef != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("only one of %[1]s, %[1]s_file and %[1]s_secret_ref may be set", c.field)
+	}
+	return nil
+}
+
+func isEnvPlaceholder(value string) bool {
+	return strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}")
+}
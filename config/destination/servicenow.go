@@ -0,0 +1,124 @@
+package config_destination
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DestinationServiceNow represents a ServiceNow instance in which issues are tracked as incidents
+type DestinationServiceNow struct {
+	Name              string             `yaml:"name" jsonschema:"required"`
+	URL               string             `yaml:"url" jsonschema:"required" jsonschema_description:"Base URL of the instance, e.g. https://your-org.service-now.com"`
+	Username          string             `yaml:"username" jsonschema:"required" jsonschema_description:"User the collector authenticates as"`
+	Password          Secret             `yaml:"password" jsonschema_description:"Password of the user or ${ENV_VAR} placeholder"`
+	PasswordFile      string             `yaml:"password_file,omitempty" jsonschema_description:"File containing the password"`
+	PasswordSecretRef *SecretKeySelector `yaml:"password_secret_ref,omitempty" jsonschema_description:"Secret key containing the password"`
+
+	Table           string                        `yaml:"table,omitempty" jsonschema_description:"Table of the incidents, defaults to incident"`
+	CallerID        string                        `yaml:"caller_id,omitempty" jsonschema_description:"Caller of the incidents, by sys_id or user name"`
+	AssignmentGroup string                        `yaml:"assignment_group,omitempty" jsonschema_description:"Group the incidents are assigned to, by sys_id or name"`
+	Category        string                        `yaml:"category,omitempty" jsonschema_description:"Category of the incidents"`
+	Subcategory     string                        `yaml:"subcategory,omitempty" jsonschema_description:"Subcategory of the incidents"`
+	SeverityMapping map[string]ServiceNowPriority `yaml:"severity_mapping,omitempty" jsonschema_description:"Impact and urgency of the issue severities high, low, info and debug"`
+	FieldMapping    ServiceNowFieldMapping        `yaml:"field_mapping,omitempty" jsonschema_description:"Fields of the incident that receive the cluster and the subject of the issue"`
+	CustomFields    map[string]string             `yaml:"custom_fields,omitempty" jsonschema_description:"Further fields of the incidents by column name"`
+	ResolveState    string                        `yaml:"resolve_state,omitempty" jsonschema_description:"State of resolved incidents, defaults to 6 (Resolved)"`
+	CloseCode       string                        `yaml:"close_code,omitempty" jsonschema_description:"Resolution code of resolved incidents, defaults to Resolved by caller"`
+}
+
+// ServiceNowPriority is the impact and urgency from which ServiceNow calculates the priority of
+// an incident; 1 is high, 2 medium and 3 low
+type ServiceNowPriority struct {
+	Impact  string `yaml:"impact" jsonschema:"required,enum=1|2|3"`
+	Urgency string `yaml:"urgency" jsonschema:"required,enum=1|2|3"`
+}
+
+// ServiceNowFieldMapping names the fields of an incident that receive the cluster and the
+// subject of the issue. Empty names leave the value out.
+type ServiceNowFieldMapping struct {
+	Cluster   string `yaml:"cluster,omitempty" jsonschema_description:"Field of the cluster name, e.g. cmdb_ci or u_cluster"`
+	Subject   string `yaml:"subject,omitempty" jsonschema_description:"Field of the subject name, e.g. cmdb_ci"`
+	Namespace string `yaml:"namespace,omitempty" jsonschema_description:"Field of the namespace of the subject"`
+}
+
+// ServiceNowSeverityDefaults is the impact and urgency of the severities without a mapping
+var ServiceNowSeverityDefaults = map[string]ServiceNowPriority{
+	"high":  {Impact: "1", Urgency: "1"},
+	"low":   {Impact: "2", Urgency: "2"},
+	"info":  {Impact: "3", Urgency: "3"},
+	"debug": {Impact: "3", Urgency: "3"},
+}
+
+func (d *DestinationServiceNow) name() string {
+	return d.Name
+}
+
+func (d *DestinationServiceNow) credentials() []credential {
+	owner := "servicenow destination " + d.Name
+	return []credential{
+		{owner: owner, field: "password", value: &d.Password, file: d.PasswordFile, secretRef: d.PasswordSecretRef},
+	}
+}
+
+// PrepareServiceNowDestination sets the defaults of a ServiceNow destination and validates it
+func PrepareServiceNowDestination(d DestinationServiceNow) (DestinationServiceNow, error) {
+	if d.Table == "" {
+		d.Table = "incident"
+	}
+	if d.ResolveState == "" {
+		d.ResolveState = "6"
+	}
+	if d.CloseCode == "" {
+		d.CloseCode = "Resolved by caller"
+	}
+	if err := validateServiceNowDestination(d); err != nil {
+		return DestinationServiceNow{}, err
+	}
+	return d, nil
+}
+
+func validateServiceNowDestination(d DestinationServiceNow) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if d.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if err := validateURL("url", d.URL); err != nil {
+		return err
+	}
+
+	if d.Username == "" {
+		return fmt.Errorf("username is required")
+	}
+
+	for severity, priority := range d.SeverityMapping {
+		switch strings.ToLower(severity) {
+		case "high", "low", "info", "debug":
+		default:
+			return fmt.Errorf("severity_mapping: unknown severity '%s', must be high, low, info or debug", severity)
+		}
+		if !isServiceNowLevel(priority.Impact) {
+			return fmt.Errorf("severity_mapping.%s: impact must be 1, 2 or 3, got '%s'", severity, priority.Impact)
+		}
+		if !isServiceNowLevel(priority.Urgency) {
+			return fmt.Errorf("severity_mapping.%s: urgency must be 1, 2 or 3, got '%s'", severity, priority.Urgency)
+		}
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.Password.Value()) {
+		return nil
+	}
+
+	if d.Password == "" {
+		return fmt.Errorf("password is required")
+	}
+
+	return nil
+}
+
+func isServiceNowLevel(value string) bool {
+	return value == "1" || value == "2" || value == "3"
+}
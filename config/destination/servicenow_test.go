@@ -0,0 +1,99 @@
+package config_destination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDestinationsYAML_ServiceNow(t *testing.T) {
+	cfg, err := parseDestinationsYAML(strings.NewReader(`
+destinations:
+  servicenow:
+    - name: "itsm"
+      url: "https://example.service-now.com"
+      username: "cano_bot"
+      password: "servicenow-password"
+    - name: "itsm-custom"
+      url: "https://example.service-now.com"
+      username: "cano_bot"
+      password: "servicenow-password"
+      caller_id: "cano_bot"
+      assignment_group: "Platform"
+      category: "Infrastructure"
+      subcategory: "Kubernetes"
+      severity_mapping:
+        high:
+          impact: "1"
+          urgency: "2"
+      field_mapping:
+        cluster: "u_cluster"
+        subject: "cmdb_ci"
+      custom_fields:
+        u_environment: "production"
+      resolve_state: "7"
+      close_code: "Solved (Permanently)"
+`), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Count())
+
+	require.Len(t, cfg.Destinations.ServiceNow, 2)
+	defaults := cfg.Destinations.ServiceNow[0]
+	assert.Equal(t, "incident", defaults.Table)
+	assert.Equal(t, "6", defaults.ResolveState)
+	assert.Equal(t, "Resolved by caller", defaults.CloseCode)
+	assert.Equal(t, "servicenow-password", defaults.Password.Value())
+
+	custom := cfg.Destinations.ServiceNow[1]
+	assert.Equal(t, "Platform", custom.AssignmentGroup)
+	assert.Equal(t, map[string]ServiceNowPriority{"high": {Impact: "1", Urgency: "2"}}, custom.SeverityMapping)
+	assert.Equal(t, ServiceNowFieldMapping{Cluster: "u_cluster", Subject: "cmdb_ci"}, custom.FieldMapping)
+	assert.Equal(t, map[string]string{"u_environment": "production"}, custom.CustomFields)
+	assert.Equal(t, "7", custom.ResolveState)
+	assert.Equal(t, "Solved (Permanently)", custom.CloseCode)
+}
+
+func TestParseDestinationsYAML_ServiceNowErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "missing password",
+			yaml:   "  servicenow:\n    - name: \"itsm\"\n      url: \"https://example.service-now.com\"\n      username: \"cano_bot\"\n",
+			errMsg: "invalid ServiceNow destination 'itsm': password is required",
+		},
+		{
+			name:   "missing username",
+			yaml:   "  servicenow:\n    - name: \"itsm\"\n      url: \"https://example.service-now.com\"\n      password: \"secret-password\"\n",
+			errMsg: "invalid ServiceNow destination 'itsm': username is required",
+		},
+		{
+			name:   "invalid URL",
+			yaml:   "  servicenow:\n    - name: \"itsm\"\n      url: \"example.service-now.com\"\n      username: \"cano_bot\"\n      password: \"secret-password\"\n",
+			errMsg: "invalid ServiceNow destination 'itsm': url must be an http or https URL",
+		},
+		{
+			name:   "unknown severity",
+			yaml:   "  servicenow:\n    - name: \"itsm\"\n      url: \"https://example.service-now.com\"\n      username: \"cano_bot\"\n      password: \"secret-password\"\n      severity_mapping:\n        critical:\n          impact: \"1\"\n          urgency: \"1\"\n",
+			errMsg: "invalid ServiceNow destination 'itsm': severity_mapping: unknown severity 'critical', must be high, low, info or debug",
+		},
+		{
+			name:   "invalid urgency",
+			yaml:   "  servicenow:\n    - name: \"itsm\"\n      url: \"https://example.service-now.com\"\n      username: \"cano_bot\"\n      password: \"secret-password\"\n      severity_mapping:\n        high:\n          impact: \"1\"\n          urgency: \"4\"\n",
+			errMsg: "invalid ServiceNow destination 'itsm': severity_mapping.high: urgency must be 1, 2 or 3, got '4'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDestinationsYAML(strings.NewReader("destinations:\n"+tt.yaml), nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.NotContains(t, err.Error(), "secret-password")
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package config_destination
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// telegramChatID matches the numeric ID of a chat or the @username of a public channel
+var telegramChatID = regexp.MustCompile(`^(-?[0-9]+|@[A-Za-z0-9_]{5,})$`)
+
+// DestinationTelegram represents a Telegram chat a bot sends messages to
+type DestinationTelegram struct {
+	Name              string             `yaml:"name" jsonschema:"required"`
+	BotToken          Secret             `yaml:"bot_token" jsonschema_description:"Token of the bot from BotFather or ${ENV_VAR} placeholder"`
+	BotTokenFile      string             `yaml:"bot_token_file,omitempty" jsonschema_description:"File containing the bot token"`
+	BotTokenSecretRef *SecretKeySelector `yaml:"bot_token_secret_ref,omitempty" jsonschema_description:"Secret key containing the bot token"`
+	ChatID            string             `yaml:"chat_id" jsonschema:"required" jsonschema_description:"Numeric ID of the chat, negative for groups and channels, or @username of a public channel"`
+	MessageThreadID   int                `yaml:"message_thread_id,omitempty" jsonschema_description:"Topic of a forum group the messages are sent to"`
+}
+
+func (d *DestinationTelegram) name() string {
+	return d.Name
+}
+
+func (d *DestinationTelegram) credentials() []credential {
+	owner := "telegram destination " + d.Name
+	return []credential{
+		{owner: owner, field: "bot_token", value: &d.BotToken, file: d.BotTokenFile, secretRef: d.BotTokenSecretRef},
+	}
+}
+
+// PrepareTelegramDestination validates a Telegram destination
+func PrepareTelegramDestination(d DestinationTelegram) (DestinationTelegram, error) {
+	if err := validateTelegramDestination(d); err != nil {
+		return DestinationTelegram{}, err
+	}
+	return d, nil
+}
+
+func validateTelegramDestination(d DestinationTelegram) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if d.ChatID == "" {
+		return fmt.Errorf("chat_id is required")
+	}
+	if !telegramChatID.MatchString(d.ChatID) {
+		return fmt.Errorf("chat_id must be a numeric chat ID or the @username of a channel, got '%s'", d.ChatID)
+	}
+
+	if d.MessageThreadID < 0 {
+		return fmt.Errorf("message_thread_id must not be negative")
+	}
+
+	// Skip validation for placeholder values that will be resolved at runtime
+	if isEnvPlaceholder(d.BotToken.Value()) {
+		return nil
+	}
+
+	if d.BotToken == "" {
+		return fmt.Errorf("bot_token is required")
+	}
+
+	return nil
+}
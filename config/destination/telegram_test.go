@@ -0,0 +1,71 @@
+package config_destination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDestinationsYAML_Telegram(t *testing.T) {
+	t.Setenv("TELEGRAM_BOT_TOKEN_OPS", "123456:env-token")
+	cfg, err := parseDestinationsYAML(strings.NewReader(`
+destinations:
+  telegram:
+    - name: "alerts"
+      bot_token: "123456:inline-token"
+      chat_id: "-1001234567890"
+      message_thread_id: 42
+    - name: "ops"
+      bot_token: "${TELEGRAM_BOT_TOKEN_OPS}"
+      chat_id: "@cano_alerts"
+`), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Count())
+
+	require.Len(t, cfg.Destinations.Telegram, 2)
+	assert.Equal(t, "123456:inline-token", cfg.Destinations.Telegram[0].BotToken.Value())
+	assert.Equal(t, "-1001234567890", cfg.Destinations.Telegram[0].ChatID)
+	assert.Equal(t, 42, cfg.Destinations.Telegram[0].MessageThreadID)
+	assert.Equal(t, "123456:env-token", cfg.Destinations.Telegram[1].BotToken.Value())
+	assert.Equal(t, "@cano_alerts", cfg.Destinations.Telegram[1].ChatID)
+}
+
+func TestParseDestinationsYAML_TelegramErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "missing chat ID",
+			yaml:   "  telegram:\n    - name: \"alerts\"\n      bot_token: \"secret-token\"\n",
+			errMsg: "invalid Telegram destination 'alerts': chat_id is required",
+		},
+		{
+			name:   "invalid chat ID",
+			yaml:   "  telegram:\n    - name: \"alerts\"\n      bot_token: \"secret-token\"\n      chat_id: \"alerts channel\"\n",
+			errMsg: "invalid Telegram destination 'alerts': chat_id must be a numeric chat ID or the @username of a channel, got 'alerts channel'",
+		},
+		{
+			name:   "negative topic",
+			yaml:   "  telegram:\n    - name: \"alerts\"\n      bot_token: \"secret-token\"\n      chat_id: \"-100123\"\n      message_thread_id: -1\n",
+			errMsg: "invalid Telegram destination 'alerts': message_thread_id must not be negative",
+		},
+		{
+			name:   "missing bot token",
+			yaml:   "  telegram:\n    - name: \"alerts\"\n      chat_id: \"-100123\"\n",
+			errMsg: "invalid Telegram destination 'alerts': bot_token is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDestinationsYAML(strings.NewReader("destinations:\n"+tt.yaml), nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.NotContains(t, err.Error(), "secret-token")
+		})
+	}
+}
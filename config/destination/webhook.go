@@ -0,0 +1,193 @@
+package config_destination
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+)
+
+// DestinationWebhook represents an HTTP endpoint that receives every issue, either as the JSON
+// encoding of the issue or as a body rendered by a template
+type DestinationWebhook struct {
+	Name    string             `yaml:"name" jsonschema:"required"`
+	URL     string             `yaml:"url" jsonschema:"required" jsonschema_description:"Endpoint that receives the issues"`
+	Method  string             `yaml:"method,omitempty" jsonschema:"enum=POST|PUT|PATCH" jsonschema_description:"HTTP method of the requests, defaults to POST"`
+	Headers map[string]*Secret `yaml:"headers,omitempty" jsonschema_description:"Headers of the requests; values may be ${ENV_VAR} placeholders"`
+	// HeadersFile and HeadersSecretRef are the alternative sources of header values, keyed by header
+	HeadersFile      map[string]string             `yaml:"headers_file,omitempty" jsonschema_description:"Headers of the requests read from files"`
+	HeadersSecretRef map[string]*SecretKeySelector `yaml:"headers_secret_ref,omitempty" jsonschema_description:"Headers of the requests read from Secret keys"`
+	// BodyTemplate is a Go template rendered with the issue; without it the body is the issue as JSON
+	BodyTemplate string `yaml:"body_template,omitempty" jsonschema_description:"Go template of the request body rendered with the issue, defaults to the issue as JSON"`
+	ContentType  string `yaml:"content_type,omitempty" jsonschema_description:"Content type of the request body, defaults to application/json"`
+
+	SigningSecret          Secret             `yaml:"signing_secret,omitempty" jsonschema_description:"Key that signs the request body with HMAC-SHA256 or ${ENV_VAR} placeholder"`
+	SigningSecretFile      string             `yaml:"signing_secret_file,omitempty" jsonschema_description:"File containing the signing key"`
+	SigningSecretSecretRef *SecretKeySelector `yaml:"signing_secret_secret_ref,omitempty" jsonschema_description:"Secret key containing the signing key"`
+
+	Retry *WebhookRetryConfig `yaml:"retry,omitempty"`
+	TLS   *WebhookTLSConfig   `yaml:"tls,omitempty"`
+}
+
+// WebhookRetryConfig configures how requests that fail with 429 or 5xx are retried
+type WebhookRetryConfig struct {
+	MaxAttempts int    `yaml:"max_attempts,omitempty" jsonschema_description:"Attempts including the first one, 1 disables retries; defaults to 3"`
+	Backoff     string `yaml:"backoff,omitempty" jsonschema_description:"Delay before the first retry, doubled for every further retry; defaults to 1s"`
+	MaxBackoff  string `yaml:"max_backoff,omitempty" jsonschema_description:"Longest delay between retries, defaults to 30s"`
+}
+
+// WebhookTLSConfig configures the TLS connections to the endpoint
+type WebhookTLSConfig struct {
+	CAFile             string `yaml:"ca_file,omitempty" jsonschema_description:"PEM file with the CA certificates that verify the endpoint"`
+	CertFile           string `yaml:"cert_file,omitempty" jsonschema_description:"PEM file with the client certificate for mutual TLS"`
+	KeyFile            string `yaml:"key_file,omitempty" jsonschema_description:"PEM file with the key of the client certificate"`
+	ServerName         string `yaml:"server_name,omitempty" jsonschema_description:"Name that the certificate of the endpoint is verified against"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty" jsonschema_description:"Skip the verification of the endpoint certificate; for testing only"`
+}
+
+func (d *DestinationWebhook) name() string {
+	return d.Name
+}
+
+func (d *DestinationWebhook) credentials() []credential {
+	owner := "webhook destination " + d.Name
+	credentials := []credential{
+		{owner: owner, field: "signing_secret", value: &d.SigningSecret, file: d.SigningSecretFile, secretRef: d.SigningSecretSecretRef},
+	}
+
+	// every header is a credential with its value in headers, so the resolved values end up there
+	seen := make(map[string]bool, len(d.Headers))
+	for name := range d.HeadersFile {
+		seen[name] = true
+	}
+	for name := range d.HeadersSecretRef {
+		seen[name] = true
+	}
+	for name := range d.Headers {
+		seen[name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) > 0 && d.Headers == nil {
+		d.Headers = make(map[string]*Secret, len(names))
+	}
+	for _, name := range names {
+		if d.Headers[name] == nil {
+			d.Headers[name] = new(Secret)
+		}
+		credentials = append(credentials, credential{
+			owner:     owner + " header " + name,
+			field:     "headers",
+			value:     d.Headers[name],
+			file:      d.HeadersFile[name],
+			secretRef: d.HeadersSecretRef[name],
+		})
+	}
+	return credentials
+}
+
+// PrepareWebhookDestination sets the defaults of a webhook destination and validates it
+func PrepareWebhookDestination(d DestinationWebhook) (DestinationWebhook, error) {
+	if d.Method == "" {
+		d.Method = http.MethodPost
+	}
+	if d.ContentType == "" {
+		d.ContentType = "application/json"
+	}
+	if d.Retry == nil {
+		d.Retry = &WebhookRetryConfig{}
+	}
+	if d.Retry.MaxAttempts == 0 {
+		d.Retry.MaxAttempts = 3
+	}
+	if d.Retry.Backoff == "" {
+		d.Retry.Backoff = "1s"
+	}
+	if d.Retry.MaxBackoff == "" {
+		d.Retry.MaxBackoff = "30s"
+	}
+	if err := validateWebhookDestination(d); err != nil {
+		return DestinationWebhook{}, err
+	}
+	return d, nil
+}
+
+func validateWebhookDestination(d DestinationWebhook) error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if d.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if err := validateURL("url", d.URL); err != nil {
+		return err
+	}
+
+	switch d.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return fmt.Errorf("method must be POST, PUT or PATCH, got '%s'", d.Method)
+	}
+
+	if d.Retry.MaxAttempts < 1 {
+		return fmt.Errorf("retry.max_attempts must be at least 1")
+	}
+	if _, err := time.ParseDuration(d.Retry.Backoff); err != nil {
+		return fmt.Errorf("retry.backoff must be a valid duration (e.g., '1s', '500ms'): %w", err)
+	}
+	if _, err := time.ParseDuration(d.Retry.MaxBackoff); err != nil {
+		return fmt.Errorf("retry.max_backoff must be a valid duration (e.g., '30s', '1m'): %w", err)
+	}
+
+	if d.TLS != nil && (d.TLS.CertFile == "") != (d.TLS.KeyFile == "") {
+		return fmt.Errorf("tls.cert_file and tls.key_file must be set together")
+	}
+
+	return nil
+}
+
+// RetryDurations returns the backoff and the maximum backoff of the retries
+func (c *WebhookRetryConfig) RetryDurations() (time.Duration, time.Duration) {
+	backoff, _ := time.ParseDuration(c.Backoff)
+	maxBackoff, _ := time.ParseDuration(c.MaxBackoff)
+	return backoff, maxBackoff
+}
+
+// Load reads the certificates and returns the TLS configuration of the connections
+func (c *WebhookTLSConfig) Load() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls.ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls.ca_file %s contains no PEM certificates", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls.cert_file and tls.key_file: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,170 @@
+package config_destination
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseDestinationsYAML_Webhook(t *testing.T) {
+	t.Setenv("WEBHOOK_TOKEN", "env-token")
+	cfg, err := parseDestinationsYAML(strings.NewReader(`
+destinations:
+  webhook:
+    - name: "incidents"
+      url: "https://hooks.internal/incidents"
+    - name: "ticketing"
+      url: "https://tickets.internal/api/alerts"
+      method: "PUT"
+      headers:
+        Authorization: "Bearer ${WEBHOOK_TOKEN}"
+        X-Api-Token: "${WEBHOOK_TOKEN}"
+      body_template: '{"title": {{ toJson .Title }}}'
+      signing_secret: "signing-key"
+      retry:
+        max_attempts: 5
+        backoff: "500ms"
+`), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Count())
+
+	require.Len(t, cfg.Destinations.Webhook, 2)
+	incidents := cfg.Destinations.Webhook[0]
+	assert.Equal(t, http.MethodPost, incidents.Method)
+	assert.Equal(t, "application/json", incidents.ContentType)
+	assert.Equal(t, &WebhookRetryConfig{MaxAttempts: 3, Backoff: "1s", MaxBackoff: "30s"}, incidents.Retry)
+
+	ticketing := cfg.Destinations.Webhook[1]
+	assert.Equal(t, http.MethodPut, ticketing.Method)
+	// only whole values are placeholders
+	assert.Equal(t, "Bearer ${WEBHOOK_TOKEN}", ticketing.Headers["Authorization"].Value())
+	assert.Equal(t, "env-token", ticketing.Headers["X-Api-Token"].Value())
+	assert.Equal(t, "signing-key", ticketing.SigningSecret.Value())
+	backoff, maxBackoff := ticketing.Retry.RetryDurations()
+	assert.Equal(t, 500*time.Millisecond, backoff)
+	assert.Equal(t, 30*time.Second, maxBackoff)
+	assert.Equal(t, 5, ticketing.Retry.MaxAttempts)
+}
+
+func TestParseDestinationsYAML_WebhookHeaderSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(t, os.WriteFile(path, []byte("file-key\n"), 0o600))
+	client := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cano", Name: "ticketing"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	})
+	yamlContent := fmt.Sprintf(`
+destinations:
+  webhook:
+    - name: "ticketing"
+      url: "https://tickets.internal/api/alerts"
+      headers:
+        X-Custom-Header: "value"
+      headers_file:
+        X-Api-Key: %q
+      headers_secret_ref:
+        Authorization:
+          name: "ticketing"
+          key: "token"
+`, path)
+
+	cfg, err := parseDestinationsYAML(strings.NewReader(yamlContent), NewKubernetesSecretResolver(client, "cano"), false)
+	require.NoError(t, err)
+	headers := cfg.Destinations.Webhook[0].Headers
+	require.Len(t, headers, 3)
+	assert.Equal(t, "value", headers["X-Custom-Header"].Value())
+	assert.Equal(t, "file-key", headers["X-Api-Key"].Value())
+	assert.Equal(t, "secret-token", headers["Authorization"].Value())
+	assert.Equal(t, []string{path}, cfg.CredentialFiles())
+
+	_, err = parseDestinationsYAML(strings.NewReader(strings.Replace(yamlContent, "X-Custom-Header", "X-Api-Key", 1)), NewKubernetesSecretResolver(client, "cano"), false)
+	assert.ErrorContains(t, err, "webhook destination ticketing header X-Api-Key: only one of headers, headers_file and headers_secret_ref may be set")
+}
+
+func TestParseDestinationsYAML_WebhookErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "missing URL",
+			yaml:   "  webhook:\n    - name: \"incidents\"\n",
+			errMsg: "invalid webhook destination 'incidents': url is required",
+		},
+		{
+			name:   "unsupported method",
+			yaml:   "  webhook:\n    - name: \"incidents\"\n      url: \"https://hooks.internal\"\n      method: \"GET\"\n",
+			errMsg: "invalid webhook destination 'incidents': method must be POST, PUT or PATCH, got 'GET'",
+		},
+		{
+			name:   "invalid backoff",
+			yaml:   "  webhook:\n    - name: \"incidents\"\n      url: \"https://hooks.internal\"\n      retry:\n        backoff: \"soon\"\n",
+			errMsg: "invalid webhook destination 'incidents': retry.backoff must be a valid duration",
+		},
+		{
+			name:   "client certificate without key",
+			yaml:   "  webhook:\n    - name: \"incidents\"\n      url: \"https://hooks.internal\"\n      tls:\n        cert_file: \"/etc/tls/tls.crt\"\n",
+			errMsg: "invalid webhook destination 'incidents': tls.cert_file and tls.key_file must be set together",
+		},
+		{
+			name:   "missing header environment variable",
+			yaml:   "  webhook:\n    - name: \"incidents\"\n      url: \"https://hooks.internal\"\n      headers:\n        Authorization: \"${WEBHOOK_TOKEN_UNSET}\"\n",
+			errMsg: "missing required env WEBHOOK_TOKEN_UNSET for webhook destination incidents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDestinationsYAML(strings.NewReader("destinations:\n"+tt.yaml), nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestWebhookTLSConfig_Load(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	tlsConfig, err := (&WebhookTLSConfig{CAFile: caFile}).Load()
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestWebhookTLSConfig_LoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	_, err := (&WebhookTLSConfig{CAFile: filepath.Join(dir, "missing.crt")}).Load()
+	require.ErrorContains(t, err, "failed to read tls.ca_file")
+
+	_, err = (&WebhookTLSConfig{CAFile: notPEM}).Load()
+	require.ErrorContains(t, err, "contains no PEM certificates")
+
+	_, err = (&WebhookTLSConfig{CertFile: notPEM, KeyFile: notPEM}).Load()
+	require.ErrorContains(t, err, "failed to load tls.cert_file and tls.key_file")
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+
+	config_destination "github.com/kubecano/cano-collector/config/destination"
+	config_team "github.com/kubecano/cano-collector/config/team"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+	"github.com/kubecano/cano-collector/pkg/schema"
+)
+
+// Schemas returns the JSON Schemas of the destinations, teams and workflows files, keyed by the file
+// name without extension. actionParameters holds the parameter schema of every action type, which
+// is used for the actions of the workflows.
+func Schemas(actionParameters map[string]*schema.Schema) map[string]*schema.Schema {
+	reflector := &schema.Reflector{
+		Overrides: map[reflect.Type]*schema.Schema{
+			reflect.TypeOf(config_workflow.ActionDefinition{}): actionSchema(actionParameters),
+		},
+	}
+
+	schemas := map[string]*schema.Schema{
+		"destinations": reflector.Reflect(config_destination.DestinationsConfig{}),
+		"teams":        reflector.Reflect(config_team.TeamsConfig{}),
+		"workflows":    reflector.Reflect(config_workflow.WorkflowConfig{}),
+	}
+	for name, s := range schemas {
+		s.Schema = schema.Draft
+		s.Title = "cano-collector " + name
+	}
+	return schemas
+}
+
+// actionSchema matches the action notations read by the workflow engine: the action type with
+// the parameters under data, the action type next to the parameters and the action type as key
+func actionSchema(actionParameters map[string]*schema.Schema) *schema.Schema {
+	actionTypes := make([]string, 0, len(actionParameters))
+	for actionType := range actionParameters {
+		actionTypes = append(actionTypes, actionType)
+	}
+	sort.Strings(actionTypes)
+
+	s := &schema.Schema{Type: "object", Description: "Workflow action"}
+	for _, actionType := range actionTypes {
+		parameters := actionParameters[actionType]
+		actionTypeSchema := &schema.Schema{Const: actionType}
+
+		inline := schema.Object(map[string]*schema.Schema{"action_type": actionTypeSchema}, "action_type")
+		for name, property := range parameters.Properties {
+			inline.Properties[name] = property
+		}
+		inline.Required = append(inline.Required, parameters.Required...)
+		// parameters under data take precedence, so they must not be checked as inline parameters
+		inline.Not = &schema.Schema{Required: []string{"data"}}
+
+		s.AnyOf = append(s.AnyOf,
+			schema.Object(map[string]*schema.Schema{"action_type": actionTypeSchema, "data": parameters}, "action_type"),
+			inline,
+			schema.Object(map[string]*schema.Schema{actionType: parameters}, actionType),
+		)
+	}
+	return s
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/pkg/schema"
+)
+
+func TestSchemas(t *testing.T) {
+	podLogs := schema.Object(map[string]*schema.Schema{"tail_lines": {Type: "integer"}})
+	router := schema.Object(map[string]*schema.Schema{"severity_mapping": schema.StringMap("")}, "severity_mapping")
+
+	schemas := Schemas(map[string]*schema.Schema{"severity_router": router, "pod_logs": podLogs})
+
+	require.Len(t, schemas, 3)
+	for name, s := range schemas {
+		assert.Equal(t, schema.Draft, s.Schema, name)
+		assert.Equal(t, "cano-collector "+name, s.Title)
+	}
+
+	slack := schemas["destinations"].Properties["destinations"].Properties["slack"].Items
+	assert.ElementsMatch(t, []string{"name", "slack_channel"}, slack.Required)
+	assert.Equal(t, "string", slack.Properties["api_key"].Type)
+	assert.ElementsMatch(t, []string{"name", "key"}, slack.Properties["api_key_secret_ref"].Required)
+
+	msteams := schemas["destinations"].Properties["destinations"].Properties["msteams"].Items
+	assert.ElementsMatch(t, []string{"name"}, msteams.Required)
+	assert.Equal(t, "string", msteams.Properties["webhook_url"].Type)
+	assert.ElementsMatch(t, []string{"name", "key"}, msteams.Properties["webhook_url_secret_ref"].Required)
+
+	pagerduty := schemas["destinations"].Properties["destinations"].Properties["pagerduty"].Items
+	assert.ElementsMatch(t, []string{"name"}, pagerduty.Required)
+	assert.Equal(t, "string", pagerduty.Properties["routing_key"].Type)
+
+	opsgenie := schemas["destinations"].Properties["destinations"].Properties["opsgenie"].Items
+	assert.Equal(t, []interface{}{"us", "eu"}, opsgenie.Properties["region"].Enum)
+	assert.ElementsMatch(t, []string{"type", "name"}, opsgenie.Properties["responders"].Items.Required)
+
+	webhook := schemas["destinations"].Properties["destinations"].Properties["webhook"].Items
+	assert.ElementsMatch(t, []string{"name", "url"}, webhook.Required)
+	assert.Equal(t, []interface{}{"POST", "PUT", "PATCH"}, webhook.Properties["method"].Enum)
+
+	jira := schemas["destinations"].Properties["destinations"].Properties["jira"].Items
+	assert.ElementsMatch(t, []string{"name", "url", "project_key"}, jira.Required)
+	assert.Equal(t, []interface{}{"2", "3"}, jira.Properties["api_version"].Enum)
+
+	servicenow := schemas["destinations"].Properties["destinations"].Properties["servicenow"].Items
+	assert.ElementsMatch(t, []string{"name", "url", "username"}, servicenow.Required)
+	assert.ElementsMatch(t, []string{"impact", "urgency"}, servicenow.Properties["severity_mapping"].AdditionalProperties.Required)
+
+	discord := schemas["destinations"].Properties["destinations"].Properties["discord"].Items
+	assert.ElementsMatch(t, []string{"name"}, discord.Required)
+	assert.Equal(t, "string", discord.Properties["webhook_url"].Type)
+
+	mattermost := schemas["destinations"].Properties["destinations"].Properties["mattermost"].Items
+	assert.ElementsMatch(t, []string{"name", "url", "channel_id"}, mattermost.Required)
+	assert.Equal(t, "string", mattermost.Properties["token"].Type)
+
+	telegram := schemas["destinations"].Properties["destinations"].Properties["telegram"].Items
+	assert.ElementsMatch(t, []string{"name", "chat_id"}, telegram.Required)
+	assert.Equal(t, "integer", telegram.Properties["message_thread_id"].Type)
+
+	googleChat := schemas["destinations"].Properties["destinations"].Properties["google_chat"].Items
+	assert.ElementsMatch(t, []string{"name"}, googleChat.Required)
+	assert.Equal(t, "string", googleChat.Properties["url"].Type)
+
+	team := schemas["teams"].Properties["teams"].Items
+	assert.ElementsMatch(t, []string{"name", "destinations"}, team.Required)
+
+	workflow := schemas["workflows"].Properties["active_workflows"].Items
+	assert.ElementsMatch(t, []string{"name", "triggers", "actions"}, workflow.Required)
+	trigger := workflow.Properties["triggers"].Items.Properties["on_alertmanager_alert"]
+	assert.Equal(t, []interface{}{"firing", "resolved", "all"}, trigger.Properties["status"].Enum)
+
+	// every action type may be written in three notations, ordered by action type
+	action := workflow.Properties["actions"].Items
+	require.Len(t, action.AnyOf, 6)
+
+	withData := action.AnyOf[0]
+	assert.Equal(t, "pod_logs", withData.Properties["action_type"].Const)
+	assert.Same(t, podLogs, withData.Properties["data"])
+
+	inline := action.AnyOf[4]
+	assert.Equal(t, "severity_router", inline.Properties["action_type"].Const)
+	assert.Same(t, router.Properties["severity_mapping"], inline.Properties["severity_mapping"])
+	assert.Equal(t, []string{"action_type", "severity_mapping"}, inline.Required)
+	assert.Equal(t, []string{"data"}, inline.Not.Required)
+
+	keyed := action.AnyOf[5]
+	assert.Same(t, router, keyed.Properties["severity_router"])
+	assert.Equal(t, []string{"severity_router"}, keyed.Required)
+}
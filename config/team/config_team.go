@@ -2,6 +2,7 @@ package config_team
 
 import (
 	"os"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
@@ -39,10 +40,17 @@ func (f *FileTeamsLoader) Load() (*TeamsConfig, error) {
 
 // TeamsConfig represents the top-level YAML structure
 type TeamsConfig struct {
-	Teams []Team `yaml:"teams"`
+	Teams []Team `yaml:"teams" jsonschema:"required"`
 }
 
 type Team struct {
-	Name         string   `yaml:"name"`
-	Destinations []string `yaml:"destinations"`
+	Name         string   `yaml:"name" jsonschema:"required"`
+	Destinations []string `yaml:"destinations" jsonschema:"required"`
+	// Namespaces restricts the team to alerts from these namespaces; empty matches every namespace
+	Namespaces []string `yaml:"namespaces,omitempty"`
+}
+
+// MatchesNamespace reports whether the team handles alerts from namespace
+func (t *Team) MatchesNamespace(namespace string) bool {
+	return len(t.Namespaces) == 0 || slices.Contains(t.Namespaces, namespace)
 }
@@ -0,0 +1,156 @@
+package config_webhook
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// webhookNamePattern restricts webhook names to values usable as a URL path segment
+var webhookNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// WebhooksConfig represents the top-level YAML structure of generic webhook endpoints
+type WebhooksConfig struct {
+	Webhooks []Webhook `yaml:"webhooks"`
+}
+
+// Webhook describes a single /api/webhooks/:name endpoint and how its JSON payload maps onto an Issue
+type Webhook struct {
+	Name      string       `yaml:"name"`
+	ItemsPath string       `yaml:"items_path,omitempty"` // Optional JSONPath to an array; every element becomes an issue
+	Mapping   FieldMapping `yaml:"mapping"`
+}
+
+// FieldMapping maps Issue fields to expressions evaluated against the payload.
+// An expression is a JSONPath starting with "$" (e.g. "$.alert.title"), a Go template
+// containing "{{" (e.g. "{{ .host }} is down") or a literal value.
+type FieldMapping struct {
+	Title          string            `yaml:"title"`
+	Description    string            `yaml:"description,omitempty"`
+	Severity       string            `yaml:"severity,omitempty"`
+	SeverityMap    map[string]string `yaml:"severity_map,omitempty"` // Payload value -> critical, warning, info, debug, ...
+	Status         string            `yaml:"status,omitempty"`
+	StatusMap      map[string]string `yaml:"status_map,omitempty"` // Payload value -> firing or resolved
+	Fingerprint    string            `yaml:"fingerprint,omitempty"`
+	AggregationKey string            `yaml:"aggregation_key,omitempty"`
+	Subject        SubjectMapping    `yaml:"subject,omitempty"`
+	Labels         map[string]string `yaml:"labels,omitempty"`
+	LabelsFrom     string            `yaml:"labels_from,omitempty"` // JSONPath to an object copied into subject labels
+	Links          []LinkMapping     `yaml:"links,omitempty"`
+}
+
+// SubjectMapping maps the Issue subject fields
+type SubjectMapping struct {
+	Kind      string `yaml:"kind,omitempty"`
+	Name      string `yaml:"name,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Node      string `yaml:"node,omitempty"`
+	Container string `yaml:"container,omitempty"`
+}
+
+// LinkMapping maps a single Issue link
+type LinkMapping struct {
+	Text string `yaml:"text"`
+	URL  string `yaml:"url"`
+	Type string `yaml:"type,omitempty"` // general, investigate, silence, runbook, prometheus_generator
+}
+
+// WebhooksLoader defines the interface for loading generic webhook configuration
+type WebhooksLoader interface {
+	Load() (*WebhooksConfig, error)
+}
+
+// FileWebhooksLoader loads webhook config from a YAML file
+type FileWebhooksLoader struct {
+	Path string
+}
+
+func NewFileWebhooksLoader(path string) *FileWebhooksLoader {
+	return &FileWebhooksLoader{Path: path}
+}
+
+// Load reads and validates the webhook configuration.
+// Generic webhooks are optional, so a missing file yields an empty configuration.
+func (f *FileWebhooksLoader) Load() (*WebhooksConfig, error) {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &WebhooksConfig{}, nil
+		}
+		return nil, fmt.Errorf("cannot open webhook config: %w", err)
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+
+	return parseWebhooksYAML(file)
+}
+
+func parseWebhooksYAML(r io.Reader) (*WebhooksConfig, error) {
+	var config WebhooksConfig
+	decoder := yaml.NewDecoder(r)
+	if err := decoder.Decode(&config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &config, nil
+		}
+		return nil, fmt.Errorf("failed to decode webhooks YAML: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Validate checks names, required mappings and value maps of all webhooks
+func (c *WebhooksConfig) Validate() error {
+	names := make(map[string]struct{}, len(c.Webhooks))
+	for i, webhook := range c.Webhooks {
+		if err := webhook.Validate(); err != nil {
+			return fmt.Errorf("webhook %d: %w", i, err)
+		}
+		if _, exists := names[webhook.Name]; exists {
+			return fmt.Errorf("duplicate webhook name: %s", webhook.Name)
+		}
+		names[webhook.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Validate checks a single webhook definition
+func (w *Webhook) Validate() error {
+	if w.Name == "" {
+		return errors.New("webhook name is required")
+	}
+	if !webhookNamePattern.MatchString(w.Name) {
+		return fmt.Errorf("webhook name '%s' may only contain letters, digits, '-' and '_'", w.Name)
+	}
+	if w.ItemsPath != "" && !strings.HasPrefix(w.ItemsPath, "$") {
+		return fmt.Errorf("webhook '%s': items_path must be a JSONPath starting with '$'", w.Name)
+	}
+	if w.Mapping.Title == "" {
+		return fmt.Errorf("webhook '%s': mapping.title is required", w.Name)
+	}
+	if w.Mapping.LabelsFrom != "" && !strings.HasPrefix(w.Mapping.LabelsFrom, "$") {
+		return fmt.Errorf("webhook '%s': mapping.labels_from must be a JSONPath starting with '$'", w.Name)
+	}
+	for value, status := range w.Mapping.StatusMap {
+		switch strings.ToLower(status) {
+		case "firing", "resolved":
+		default:
+			return fmt.Errorf("webhook '%s': status_map value for '%s' must be 'firing' or 'resolved', got '%s'", w.Name, value, status)
+		}
+	}
+	for i, link := range w.Mapping.Links {
+		if link.URL == "" {
+			return fmt.Errorf("webhook '%s': link %d url is required", w.Name, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package config_webhook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWebhooksLoader_Load_ValidConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `
+webhooks:
+  - name: ci-pipeline
+    items_path: "$.events"
+    mapping:
+      title: "$.title"
+      description: "{{ .message }}"
+      severity: "$.level"
+      severity_map:
+        P1: critical
+      status: "$.state"
+      status_map:
+        ok: resolved
+      fingerprint: "$.id"
+      subject:
+        kind: pod
+        name: "$.pod"
+        namespace: "$.namespace"
+      labels:
+        team: "$.team"
+      links:
+        - text: Pipeline
+          url: "$.url"
+          type: investigate
+`
+	configPath := filepath.Join(tempDir, "webhooks.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0o644))
+
+	cfg, err := NewFileWebhooksLoader(configPath).Load()
+
+	require.NoError(t, err)
+	require.Len(t, cfg.Webhooks, 1)
+	webhook := cfg.Webhooks[0]
+	assert.Equal(t, "ci-pipeline", webhook.Name)
+	assert.Equal(t, "$.events", webhook.ItemsPath)
+	assert.Equal(t, "$.title", webhook.Mapping.Title)
+	assert.Equal(t, "critical", webhook.Mapping.SeverityMap["P1"])
+	assert.Equal(t, "resolved", webhook.Mapping.StatusMap["ok"])
+	assert.Equal(t, "pod", webhook.Mapping.Subject.Kind)
+	assert.Equal(t, "$.team", webhook.Mapping.Labels["team"])
+	require.Len(t, webhook.Mapping.Links, 1)
+	assert.Equal(t, "investigate", webhook.Mapping.Links[0].Type)
+}
+
+func TestFileWebhooksLoader_Load_MissingFileIsEmpty(t *testing.T) {
+	cfg, err := NewFileWebhooksLoader(filepath.Join(t.TempDir(), "missing.yaml")).Load()
+
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Webhooks)
+}
+
+func TestFileWebhooksLoader_Load_EmptyFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "webhooks.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(""), 0o644))
+
+	cfg, err := NewFileWebhooksLoader(configPath).Load()
+
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Webhooks)
+}
+
+func TestFileWebhooksLoader_Load_InvalidYAML(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "webhooks.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("webhooks: [invalid yaml"), 0o644))
+
+	cfg, err := NewFileWebhooksLoader(configPath).Load()
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+}
+
+func TestParseWebhooksYAML_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "missing name",
+			yaml: `
+webhooks:
+  - mapping:
+      title: "$.title"
+`,
+			wantErr: "webhook name is required",
+		},
+		{
+			name: "invalid name",
+			yaml: `
+webhooks:
+  - name: "ci/pipeline"
+    mapping:
+      title: "$.title"
+`,
+			wantErr: "may only contain",
+		},
+		{
+			name: "duplicate name",
+			yaml: `
+webhooks:
+  - name: ci
+    mapping:
+      title: "$.title"
+  - name: ci
+    mapping:
+      title: "$.title"
+`,
+			wantErr: "duplicate webhook name: ci",
+		},
+		{
+			name: "missing title",
+			yaml: `
+webhooks:
+  - name: ci
+    mapping:
+      description: "$.message"
+`,
+			wantErr: "mapping.title is required",
+		},
+		{
+			name: "items_path not jsonpath",
+			yaml: `
+webhooks:
+  - name: ci
+    items_path: events
+    mapping:
+      title: "$.title"
+`,
+			wantErr: "items_path must be a JSONPath",
+		},
+		{
+			name: "invalid status map value",
+			yaml: `
+webhooks:
+  - name: ci
+    mapping:
+      title: "$.title"
+      status_map:
+        ok: closed
+`,
+			wantErr: "must be 'firing' or 'resolved'",
+		},
+		{
+			name: "link without url",
+			yaml: `
+webhooks:
+  - name: ci
+    mapping:
+      title: "$.title"
+      links:
+        - text: Pipeline
+`,
+			wantErr: "link 0 url is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseWebhooksYAML(strings.NewReader(tt.yaml))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Nil(t, cfg)
+		})
+	}
+}
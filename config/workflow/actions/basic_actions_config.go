@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"fmt"
+	"text/template"
+
+	actions_interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
+)
+
+// LabelFilterActionConfig defines the configuration for the label_filter workflow action
+type LabelFilterActionConfig struct {
+	actions_interfaces.ActionConfig `yaml:",inline" jsonschema:"-"`
+
+	// IncludeLabels labels the alert must have; an empty value matches any value
+	IncludeLabels map[string]string `yaml:"include_labels" json:"include_labels" jsonschema_description:"Labels the alert must have; an empty value matches any value"`
+
+	// ExcludeLabels labels the alert must not have; an empty value matches any value
+	ExcludeLabels map[string]string `yaml:"exclude_labels" json:"exclude_labels" jsonschema_description:"Labels the alert must not have; an empty value matches any value"`
+
+	// RequiredLabels names of labels the alert must have
+	RequiredLabels []string `yaml:"required_labels" json:"required_labels" jsonschema_description:"Names of labels the alert must have"`
+}
+
+// NewLabelFilterActionConfigWithDefaults creates a new LabelFilterActionConfig with default values
+func NewLabelFilterActionConfigWithDefaults(baseConfig actions_interfaces.ActionConfig) LabelFilterActionConfig {
+	return LabelFilterActionConfig{ActionConfig: baseConfig}
+}
+
+// UpdateFromParameters updates the configuration from workflow parameters
+func (c *LabelFilterActionConfig) UpdateFromParameters(params map[string]interface{}) error {
+	return DecodeParameters(params, c)
+}
+
+// Validate checks if the configuration is valid
+func (c *LabelFilterActionConfig) Validate() error {
+	if len(c.IncludeLabels) == 0 && len(c.ExcludeLabels) == 0 && len(c.RequiredLabels) == 0 {
+		return fmt.Errorf("label filter action must have at least one filter configured (include_labels, exclude_labels, or required_labels)")
+	}
+	return nil
+}
+
+// validSeverityMappingKeys are the severities and Prometheus severity labels a severity_mapping may route
+var validSeverityMappingKeys = map[string]bool{
+	"debug": true, "info": true, "low": true, "high": true, "default": true,
+	"DEBUG": true, "INFO": true, "LOW": true, "HIGH": true,
+	// Also accept common Prometheus label values
+	"critical": true, "warning": true, "CRITICAL": true, "WARNING": true,
+}
+
+// SeverityRouterActionConfig defines the configuration for the severity_router workflow action
+type SeverityRouterActionConfig struct {
+	actions_interfaces.ActionConfig `yaml:",inline" jsonschema:"-"`
+
+	// SeverityMapping maps severities to destinations
+	SeverityMapping map[string]string `yaml:"severity_mapping" json:"severity_mapping" jsonschema:"required" jsonschema_description:"Destination per severity: debug, info, low, high, critical, warning or default"`
+}
+
+// NewSeverityRouterActionConfigWithDefaults creates a new SeverityRouterActionConfig with default values
+func NewSeverityRouterActionConfigWithDefaults(baseConfig actions_interfaces.ActionConfig) SeverityRouterActionConfig {
+	return SeverityRouterActionConfig{ActionConfig: baseConfig}
+}
+
+// UpdateFromParameters updates the configuration from workflow parameters
+func (c *SeverityRouterActionConfig) UpdateFromParameters(params map[string]interface{}) error {
+	return DecodeParameters(params, c)
+}
+
+// Validate checks if the configuration is valid
+func (c *SeverityRouterActionConfig) Validate() error {
+	if len(c.SeverityMapping) == 0 {
+		return fmt.Errorf("severity router action must have severity_mapping parameter configured")
+	}
+
+	for severity := range c.SeverityMapping {
+		if !validSeverityMappingKeys[severity] {
+			return fmt.Errorf("invalid severity level in mapping: %s (valid: debug, info, low, high, default)", severity)
+		}
+	}
+
+	return nil
+}
+
+// IssueEnrichmentActionConfig defines the configuration for the issue_enrichment workflow action
+type IssueEnrichmentActionConfig struct {
+	actions_interfaces.ActionConfig `yaml:",inline" jsonschema:"-"`
+
+	// IncludeMetadata adds the receiver, status, alert count and severity
+	IncludeMetadata bool `yaml:"include_metadata" json:"include_metadata" jsonschema_description:"Add the receiver, status, alert count and severity, defaults to true"`
+
+	// CustomTitle is added to the issue as a Go template
+	CustomTitle string `yaml:"custom_title" json:"custom_title" jsonschema_description:"Title added to the issue; a Go template over the alert name, namespace, status, severity and labels"`
+
+	// IncludeLabels is accepted for configurations written before the converter added the alert labels
+	IncludeLabels bool `yaml:"include_labels" json:"include_labels" jsonschema_description:"Deprecated and ignored, the alert labels are always added to the issue"`
+}
+
+// NewIssueEnrichmentActionConfigWithDefaults creates a new IssueEnrichmentActionConfig with default values
+func NewIssueEnrichmentActionConfigWithDefaults(baseConfig actions_interfaces.ActionConfig) IssueEnrichmentActionConfig {
+	return IssueEnrichmentActionConfig{
+		ActionConfig:    baseConfig,
+		IncludeMetadata: true,
+	}
+}
+
+// UpdateFromParameters updates the configuration from workflow parameters
+func (c *IssueEnrichmentActionConfig) UpdateFromParameters(params map[string]interface{}) error {
+	return DecodeParameters(params, c)
+}
+
+// Validate checks if the configuration is valid
+func (c *IssueEnrichmentActionConfig) Validate() error {
+	if _, err := template.New("custom_title").Parse(c.CustomTitle); err != nil {
+		return fmt.Errorf("custom_title is not a valid template: %w", err)
+	}
+	return nil
+}
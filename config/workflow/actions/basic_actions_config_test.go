@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	actions_interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
+)
+
+func TestLabelFilterActionConfig_UpdateFromParameters(t *testing.T) {
+	config := NewLabelFilterActionConfigWithDefaults(actions_interfaces.ActionConfig{Name: "filter", Type: "label_filter"})
+
+	err := config.UpdateFromParameters(map[string]interface{}{
+		"include_labels":  map[string]interface{}{"team": "payments"},
+		"required_labels": []interface{}{"pod"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+
+	assert.Equal(t, map[string]string{"team": "payments"}, config.IncludeLabels)
+	assert.Empty(t, config.ExcludeLabels)
+	assert.Equal(t, []string{"pod"}, config.RequiredLabels)
+
+	err = config.UpdateFromParameters(map[string]interface{}{"include_label": map[string]interface{}{"team": "payments"}})
+	assert.EqualError(t, err, `unknown parameter "include_label"`)
+}
+
+func TestLabelFilterActionConfig_Validate_NoFilters(t *testing.T) {
+	config := NewLabelFilterActionConfigWithDefaults(actions_interfaces.ActionConfig{Name: "filter", Type: "label_filter"})
+
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one filter configured")
+}
+
+func TestSeverityRouterActionConfig_UpdateFromParameters(t *testing.T) {
+	config := NewSeverityRouterActionConfigWithDefaults(actions_interfaces.ActionConfig{Name: "router", Type: "severity_router"})
+
+	err := config.UpdateFromParameters(map[string]interface{}{})
+	assert.EqualError(t, err, `parameter "severity_mapping" is required`)
+
+	err = config.UpdateFromParameters(map[string]interface{}{
+		"severity_mapping": map[string]interface{}{"critical": "pagerduty", "default": "slack"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+	assert.Equal(t, map[string]string{"critical": "pagerduty", "default": "slack"}, config.SeverityMapping)
+}
+
+func TestSeverityRouterActionConfig_Validate_InvalidSeverity(t *testing.T) {
+	config := NewSeverityRouterActionConfigWithDefaults(actions_interfaces.ActionConfig{Name: "router", Type: "severity_router"})
+	config.SeverityMapping = map[string]string{"urgent": "pagerduty"}
+
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid severity level in mapping: urgent")
+}
+
+func TestIssueEnrichmentActionConfig_UpdateFromParameters(t *testing.T) {
+	config := NewIssueEnrichmentActionConfigWithDefaults(actions_interfaces.ActionConfig{Name: "enrich", Type: "issue_enrichment"})
+	assert.True(t, config.IncludeMetadata)
+
+	// include_labels is still accepted, as the default workflows of older releases set it
+	err := config.UpdateFromParameters(map[string]interface{}{
+		"include_metadata": false,
+		"include_labels":   true,
+		"custom_title":     "{{.alert_name}}",
+	})
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+
+	assert.False(t, config.IncludeMetadata)
+	assert.Equal(t, "{{.alert_name}}", config.CustomTitle)
+}
+
+func TestIssueEnrichmentActionConfig_Validate_InvalidTemplate(t *testing.T) {
+	config := NewIssueEnrichmentActionConfigWithDefaults(actions_interfaces.ActionConfig{Name: "enrich", Type: "issue_enrichment"})
+	config.CustomTitle = "{{.alert_name"
+
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "custom_title is not a valid template")
+}
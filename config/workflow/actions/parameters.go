@@ -0,0 +1,282 @@
+package actions
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// DecodeParameters decodes action parameters into target, a pointer to an action config struct.
+// Parameters are matched to fields by their yaml name, and fields without a parameter keep their
+// value, so defaults are set on target before decoding. Fields tagged jsonschema:"-" are not
+// parameters, and the "required" and "enum=a|b" options of the jsonschema tag are enforced the
+// same way as in the published schema. time.Duration fields take duration strings like "30s".
+// Unknown parameters and values of the wrong type are errors.
+func DecodeParameters(parameters map[string]interface{}, target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("parameters must be decoded into a pointer to a struct, got %T", target)
+	}
+	return decodeStruct(parameters, v.Elem(), "")
+}
+
+// parameterField is a struct field that is set by a parameter
+type parameterField struct {
+	value    reflect.Value
+	required bool
+	enum     []string
+}
+
+func decodeStruct(parameters map[string]interface{}, v reflect.Value, path string) error {
+	fields := make(map[string]parameterField)
+	collectFields(v, fields)
+
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("unknown parameter %q", path+name)
+		}
+		if err := decodeValue(parameters[name], field.value, path+name); err != nil {
+			return err
+		}
+		if value := reflect.Indirect(field.value); len(field.enum) > 0 && value.Kind() == reflect.String && !slices.Contains(field.enum, value.String()) {
+			return fmt.Errorf("parameter %q must be one of %s, got %q", path+name, strings.Join(field.enum, ", "), value.String())
+		}
+	}
+
+	var required []string
+	for name, field := range fields {
+		if _, ok := parameters[name]; field.required && !ok {
+			required = append(required, name)
+		}
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		return fmt.Errorf("parameter %q is required", path+required[0])
+	}
+
+	return nil
+}
+
+// collectFields adds the parameter fields of the struct v by their yaml name, including the
+// fields of inlined structs
+func collectFields(v reflect.Value, fields map[string]parameterField) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		options := strings.Split(field.Tag.Get("jsonschema"), ",")
+		if options[0] == "-" {
+			continue
+		}
+		name, flags, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(flags, "inline") && field.Type.Kind() == reflect.Struct {
+			collectFields(v.Field(i), fields)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		parameter := parameterField{value: v.Field(i)}
+		for _, option := range options {
+			switch {
+			case option == "required":
+				parameter.required = true
+			case strings.HasPrefix(option, "enum="):
+				parameter.enum = strings.Split(strings.TrimPrefix(option, "enum="), "|")
+			}
+		}
+		fields[name] = parameter
+	}
+}
+
+func decodeValue(raw interface{}, v reflect.Value, path string) error {
+	if raw == nil {
+		// an empty YAML value resets the field
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if v.Type() == durationType {
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("parameter %q: expected a duration like \"30s\", got %s", path, describe(raw))
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("parameter %q: invalid duration %q", path, s)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	rv := reflect.ValueOf(raw)
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeValue(raw, elem.Elem(), path); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.String:
+		if rv.Kind() != reflect.String {
+			return typeError(path, "a string", raw)
+		}
+		v.SetString(rv.String())
+	case reflect.Bool:
+		if rv.Kind() != reflect.Bool {
+			return typeError(path, "a boolean", raw)
+		}
+		v.SetBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := toInt(rv)
+		if !ok || v.OverflowInt(n) {
+			return typeError(path, "an integer", raw)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := toInt(rv)
+		if !ok || n < 0 || v.OverflowUint(uint64(n)) {
+			return typeError(path, "a non-negative integer", raw)
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat(rv)
+		if !ok {
+			return typeError(path, "a number", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return typeError(path, "a list", raw)
+		}
+		slice := reflect.MakeSlice(v.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if err := decodeValue(rv.Index(i).Interface(), slice.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Map:
+		entries, ok := toStringMap(rv)
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return typeError(path, "a map", raw)
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(entries))
+		for key, value := range entries {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeValue(value, elem, path+"."+key); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		entries, ok := toStringMap(rv)
+		if !ok {
+			return typeError(path, "a map", raw)
+		}
+		return decodeStruct(entries, v, path+".")
+	case reflect.Interface:
+		v.Set(rv)
+	default:
+		return fmt.Errorf("parameter %q: unsupported field type %s", path, v.Type())
+	}
+	return nil
+}
+
+// toInt converts integers and integral floats, since numbers decoded from JSON are float64
+func toInt(rv reflect.Value) (int64, bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	default:
+		return 0, false
+	}
+}
+
+func toFloat(rv reflect.Value) (float64, bool) {
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+// toStringMap returns the entries of a map with string keys, like the maps decoded from YAML or JSON
+func toStringMap(rv reflect.Value) (map[string]interface{}, bool) {
+	if rv.Kind() != reflect.Map {
+		return nil, false
+	}
+	entries := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		if key.Kind() == reflect.Interface {
+			key = key.Elem()
+		}
+		if key.Kind() != reflect.String {
+			return nil, false
+		}
+		entries[key.String()] = iter.Value().Interface()
+	}
+	return entries, true
+}
+
+func typeError(path, expected string, raw interface{}) error {
+	return fmt.Errorf("parameter %q: expected %s, got %s", path, expected, describe(raw))
+}
+
+// describe names the YAML type of a decoded value for error messages
+func describe(raw interface{}) string {
+	switch reflect.ValueOf(raw).Kind() {
+	case reflect.String:
+		return fmt.Sprintf("string %q", raw)
+	case reflect.Bool:
+		return fmt.Sprintf("boolean %v", raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("number %v", raw)
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	case reflect.Map:
+		return "a map"
+	default:
+		return fmt.Sprintf("%T", raw)
+	}
+}
@@ -0,0 +1,139 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	actions_interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
+)
+
+type testLimits struct {
+	Lines int `yaml:"lines"`
+}
+
+type testParameters struct {
+	actions_interfaces.ActionConfig `yaml:",inline" jsonschema:"-"`
+
+	Mode     string            `yaml:"mode" jsonschema:"required,enum=fast|slow"`
+	Count    int32             `yaml:"count"`
+	Ratio    float64           `yaml:"ratio"`
+	Enabled  bool              `yaml:"enabled"`
+	Interval time.Duration     `yaml:"interval"`
+	Labels   map[string]string `yaml:"labels"`
+	Names    []string          `yaml:"names"`
+	Limits   testLimits        `yaml:"limits"`
+	Optional *int              `yaml:"optional"`
+}
+
+func TestDecodeParameters(t *testing.T) {
+	params := testParameters{Count: 5, Ratio: 0.5}
+
+	err := DecodeParameters(map[string]interface{}{
+		"mode":     "fast",
+		"count":    float64(10), // numbers decoded from JSON
+		"enabled":  true,
+		"interval": "90s",
+		"labels":   map[string]interface{}{"team": "payments", "any": nil},
+		"names":    []interface{}{"api", "worker"},
+		"limits":   map[string]interface{}{"lines": 100},
+		"optional": 3,
+	}, &params)
+	require.NoError(t, err)
+
+	assert.Equal(t, "fast", params.Mode)
+	assert.Equal(t, int32(10), params.Count)
+	assert.Equal(t, 0.5, params.Ratio, "fields without a parameter keep their default")
+	assert.True(t, params.Enabled)
+	assert.Equal(t, 90*time.Second, params.Interval)
+	assert.Equal(t, map[string]string{"team": "payments", "any": ""}, params.Labels)
+	assert.Equal(t, []string{"api", "worker"}, params.Names)
+	assert.Equal(t, 100, params.Limits.Lines)
+	require.NotNil(t, params.Optional)
+	assert.Equal(t, 3, *params.Optional)
+}
+
+func TestDecodeParameters_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "unknown parameter",
+			params:  map[string]interface{}{"mode": "fast", "mod": "slow"},
+			wantErr: `unknown parameter "mod"`,
+		},
+		{
+			name:    "action config fields are not parameters",
+			params:  map[string]interface{}{"mode": "fast", "timeout": 10},
+			wantErr: `unknown parameter "timeout"`,
+		},
+		{
+			name:    "unknown nested parameter",
+			params:  map[string]interface{}{"mode": "fast", "limits": map[string]interface{}{"line": 1}},
+			wantErr: `unknown parameter "limits.line"`,
+		},
+		{
+			name:    "missing required parameter",
+			params:  map[string]interface{}{"count": 1},
+			wantErr: `parameter "mode" is required`,
+		},
+		{
+			name:    "value outside enum",
+			params:  map[string]interface{}{"mode": "medium"},
+			wantErr: `parameter "mode" must be one of fast, slow, got "medium"`,
+		},
+		{
+			name:    "fractional integer",
+			params:  map[string]interface{}{"mode": "fast", "count": 1.5},
+			wantErr: `parameter "count": expected an integer, got number 1.5`,
+		},
+		{
+			name:    "integer overflow",
+			params:  map[string]interface{}{"mode": "fast", "count": int64(1) << 40},
+			wantErr: `parameter "count": expected an integer, got number 1099511627776`,
+		},
+		{
+			name:    "duration number",
+			params:  map[string]interface{}{"mode": "fast", "interval": 30},
+			wantErr: `parameter "interval": expected a duration like "30s", got number 30`,
+		},
+		{
+			name:    "invalid duration",
+			params:  map[string]interface{}{"mode": "fast", "interval": "soon"},
+			wantErr: `parameter "interval": invalid duration "soon"`,
+		},
+		{
+			name:    "map value of wrong type",
+			params:  map[string]interface{}{"mode": "fast", "labels": map[string]interface{}{"team": 1}},
+			wantErr: `parameter "labels.team": expected a string, got number 1`,
+		},
+		{
+			name:    "list element of wrong type",
+			params:  map[string]interface{}{"mode": "fast", "names": []interface{}{"api", true}},
+			wantErr: `parameter "names[1]": expected a string, got boolean true`,
+		},
+		{
+			name:    "scalar instead of map",
+			params:  map[string]interface{}{"mode": "fast", "labels": "team=payments"},
+			wantErr: `parameter "labels": expected a map, got string "team=payments"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params testParameters
+			err := DecodeParameters(tt.params, &params)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDecodeParameters_InvalidTarget(t *testing.T) {
+	var params testParameters
+	err := DecodeParameters(map[string]interface{}{}, params)
+	assert.EqualError(t, err, "parameters must be decoded into a pointer to a struct, got actions.testParameters")
+}
@@ -8,7 +8,7 @@ import (
 
 // PodInfoActionConfig defines the configuration for the pod_info workflow action
 type PodInfoActionConfig struct {
-	actions_interfaces.ActionConfig `yaml:",inline"`
+	actions_interfaces.ActionConfig `yaml:",inline" jsonschema:"-"`
 
 	// IncludePreviousState includes information about the previous container state
 	IncludePreviousState bool `yaml:"include_previous_state" json:"include_previous_state"`
@@ -42,35 +42,7 @@ func (c *PodInfoActionConfig) Validate() error {
 
 // UpdateFromParameters updates the configuration from workflow parameters
 func (c *PodInfoActionConfig) UpdateFromParameters(params map[string]interface{}) error {
-	if params == nil {
-		return nil
-	}
-
-	if val, ok := params["include_previous_state"].(bool); ok {
-		c.IncludePreviousState = val
-	}
-
-	if val, ok := params["include_init_containers"].(bool); ok {
-		c.IncludeInitContainers = val
-	}
-
-	if val, ok := params["min_restart_count"].(int); ok {
-		c.MinRestartCount = int32(val)
-	}
-
-	if val, ok := params["min_restart_count"].(float64); ok {
-		c.MinRestartCount = int32(val)
-	}
-
-	if val, ok := params["pod_name"].(string); ok {
-		c.PodName = val
-	}
-
-	if val, ok := params["container"].(string); ok {
-		c.Container = val
-	}
-
-	return nil
+	return DecodeParameters(params, c)
 }
 
 // GetActionType returns the action type identifier
@@ -174,29 +174,40 @@ func TestPodInfoActionConfig_UpdateFromParameters_PartialParams(t *testing.T) {
 }
 
 func TestPodInfoActionConfig_UpdateFromParameters_WrongTypes(t *testing.T) {
-	config := PodInfoActionConfig{
-		ActionConfig: actions_interfaces.ActionConfig{
-			Name: "test-action",
-			Type: "pod_info",
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "include_previous_state not bool",
+			params:  map[string]interface{}{"include_previous_state": "invalid_bool"},
+			wantErr: `parameter "include_previous_state": expected a boolean, got string "invalid_bool"`,
+		},
+		{
+			name:    "min_restart_count not int",
+			params:  map[string]interface{}{"min_restart_count": "invalid_int"},
+			wantErr: `parameter "min_restart_count": expected an integer, got string "invalid_int"`,
+		},
+		{
+			name:    "pod_name not string",
+			params:  map[string]interface{}{"pod_name": 123},
+			wantErr: `parameter "pod_name": expected a string, got number 123`,
+		},
+		{
+			name:    "unknown parameter",
+			params:  map[string]interface{}{"min_restarts": 3},
+			wantErr: `unknown parameter "min_restarts"`,
 		},
 	}
 
-	// Parameters with wrong types should be ignored
-	params := map[string]interface{}{
-		"include_previous_state":  "invalid_bool",
-		"include_init_containers": 123,
-		"min_restart_count":       "invalid_int",
-		"pod_name":                123,
-		"container":               true,
-	}
-
-	err := config.UpdateFromParameters(params)
-	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := PodInfoActionConfig{}
 
-	// All fields should remain at their zero values
-	assert.False(t, config.IncludePreviousState)
-	assert.False(t, config.IncludeInitContainers)
-	assert.Equal(t, int32(0), config.MinRestartCount)
-	assert.Empty(t, config.PodName)
-	assert.Empty(t, config.Container)
+			err := config.UpdateFromParameters(tt.params)
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
 }
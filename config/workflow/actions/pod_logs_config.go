@@ -11,33 +11,36 @@ import (
 
 // PodLogsActionConfig contains configuration for PodLogsAction
 type PodLogsActionConfig struct {
-	actions_interfaces.ActionConfig `yaml:",inline"`
+	actions_interfaces.ActionConfig `yaml:",inline" jsonschema:"-"`
 
 	// MaxLines maximum number of lines to retrieve
-	MaxLines int `yaml:"max_lines" json:"max_lines"`
+	MaxLines int `yaml:"max_lines" json:"max_lines" jsonschema_description:"Maximum number of log lines to retrieve"`
 
 	// SinceTime retrieve logs since this time (RFC3339 format)
-	SinceTime string `yaml:"since_time" json:"since_time"`
+	SinceTime string `yaml:"since_time" json:"since_time" jsonschema_description:"Retrieve logs since this time (RFC3339)"`
 
 	// TailLines number of lines from the end of the logs to show
-	TailLines int `yaml:"tail_lines" json:"tail_lines"`
+	TailLines int `yaml:"tail_lines" json:"tail_lines" jsonschema_description:"Number of lines from the end of the logs"`
+
+	// PodName overrides the pod of the alert
+	PodName string `yaml:"pod_name" json:"pod_name" jsonschema_description:"Pod to read the logs of, defaults to the pod of the alert"`
 
 	// Container name to get logs from (empty means all containers)
-	Container string `yaml:"container" json:"container"`
+	Container string `yaml:"container" json:"container" jsonschema_description:"Container to read the logs of, defaults to the container of the alert"`
 
 	// Previous get logs from previous instance of the container
-	Previous bool `yaml:"previous" json:"previous"`
+	Previous bool `yaml:"previous" json:"previous" jsonschema_description:"Read the logs of the previous container instance"`
 
 	// Timestamps add timestamps to each log line
-	Timestamps bool `yaml:"timestamps" json:"timestamps"`
+	Timestamps bool `yaml:"timestamps" json:"timestamps" jsonschema_description:"Add timestamps to each log line"`
 
 	// Java-specific configuration
-	JavaSpecific bool `yaml:"java_specific" json:"java_specific"`
+	JavaSpecific bool `yaml:"java_specific" json:"java_specific" jsonschema_description:"Apply the defaults for Java containers"`
 
 	// File naming configuration
-	IncludeTimestamp bool   `yaml:"include_timestamp" json:"include_timestamp"`
-	IncludeContainer bool   `yaml:"include_container" json:"include_container"`
-	TimestampFormat  string `yaml:"timestamp_format" json:"timestamp_format"`
+	IncludeTimestamp bool   `yaml:"include_timestamp" json:"include_timestamp" jsonschema_description:"Include a timestamp in the file name"`
+	IncludeContainer bool   `yaml:"include_container" json:"include_container" jsonschema_description:"Include the container name in the file name"`
+	TimestampFormat  string `yaml:"timestamp_format" json:"timestamp_format" jsonschema_description:"Go time layout of the timestamp in the file name"`
 }
 
 // NewPodLogsActionConfigWithDefaults creates a new PodLogsActionConfig with defaults from environment variables
@@ -94,91 +97,13 @@ func (c *PodLogsActionConfig) Validate() error {
 
 // UpdateFromParameters updates configuration from action parameters
 func (c *PodLogsActionConfig) UpdateFromParameters(parameters map[string]interface{}) error {
-	if parameters == nil {
-		return nil
-	}
-
-	if maxLines, ok := parameters["max_lines"]; ok {
-		if maxLinesInt, ok := maxLines.(int); ok {
-			c.MaxLines = maxLinesInt
-		} else {
-			return fmt.Errorf("max_lines must be an integer")
-		}
+	if err := DecodeParameters(parameters, c); err != nil {
+		return err
 	}
 
-	if tailLines, ok := parameters["tail_lines"]; ok {
-		if tailLinesInt, ok := tailLines.(int); ok {
-			c.TailLines = tailLinesInt
-		} else {
-			return fmt.Errorf("tail_lines must be an integer")
-		}
-	}
-
-	if previous, ok := parameters["previous"]; ok {
-		if previousBool, ok := previous.(bool); ok {
-			c.Previous = previousBool
-		} else {
-			return fmt.Errorf("previous must be a boolean")
-		}
-	}
-
-	if timestamps, ok := parameters["timestamps"]; ok {
-		if timestampsBool, ok := timestamps.(bool); ok {
-			c.Timestamps = timestampsBool
-		} else {
-			return fmt.Errorf("timestamps must be a boolean")
-		}
-	}
-
-	if container, ok := parameters["container"]; ok {
-		if containerStr, ok := container.(string); ok {
-			c.Container = containerStr
-		} else {
-			return fmt.Errorf("container must be a string")
-		}
-	}
-
-	if sinceTime, ok := parameters["since_time"]; ok {
-		if sinceTimeStr, ok := sinceTime.(string); ok {
-			c.SinceTime = sinceTimeStr
-		} else {
-			return fmt.Errorf("since_time must be a string")
-		}
-	}
-
-	if javaSpecific, ok := parameters["java_specific"]; ok {
-		if javaSpecificBool, ok := javaSpecific.(bool); ok {
-			c.JavaSpecific = javaSpecificBool
-			if javaSpecificBool {
-				c.ApplyJavaDefaults()
-			}
-		} else {
-			return fmt.Errorf("java_specific must be a boolean")
-		}
-	}
-
-	if includeTimestamp, ok := parameters["include_timestamp"]; ok {
-		if includeTimestampBool, ok := includeTimestamp.(bool); ok {
-			c.IncludeTimestamp = includeTimestampBool
-		} else {
-			return fmt.Errorf("include_timestamp must be a boolean")
-		}
-	}
-
-	if includeContainer, ok := parameters["include_container"]; ok {
-		if includeContainerBool, ok := includeContainer.(bool); ok {
-			c.IncludeContainer = includeContainerBool
-		} else {
-			return fmt.Errorf("include_container must be a boolean")
-		}
-	}
-
-	if timestampFormat, ok := parameters["timestamp_format"]; ok {
-		if timestampFormatStr, ok := timestampFormat.(string); ok {
-			c.TimestampFormat = timestampFormatStr
-		} else {
-			return fmt.Errorf("timestamp_format must be a string")
-		}
+	// Java defaults take precedence over the configured line limits
+	if javaSpecific, ok := parameters["java_specific"].(bool); ok && javaSpecific {
+		c.ApplyJavaDefaults()
 	}
 
 	return nil
@@ -123,70 +123,77 @@ func TestPodLogsActionConfig_UpdateFromParameters_TypeErrors(t *testing.T) {
 			parameters: map[string]interface{}{
 				"max_lines": "not-int",
 			},
-			wantErr: "max_lines must be an integer",
+			wantErr: "parameter \"max_lines\": expected an integer, got string",
 		},
 		{
 			name: "tail_lines not int",
 			parameters: map[string]interface{}{
 				"tail_lines": 3.14,
 			},
-			wantErr: "tail_lines must be an integer",
+			wantErr: "parameter \"tail_lines\": expected an integer, got number 3.14",
 		},
 		{
 			name: "previous not bool",
 			parameters: map[string]interface{}{
 				"previous": "yes",
 			},
-			wantErr: "previous must be a boolean",
+			wantErr: "parameter \"previous\": expected a boolean",
 		},
 		{
 			name: "timestamps not bool",
 			parameters: map[string]interface{}{
 				"timestamps": 1,
 			},
-			wantErr: "timestamps must be a boolean",
+			wantErr: "parameter \"timestamps\": expected a boolean",
 		},
 		{
 			name: "container not string",
 			parameters: map[string]interface{}{
 				"container": 123,
 			},
-			wantErr: "container must be a string",
+			wantErr: "parameter \"container\": expected a string",
 		},
 		{
 			name: "since_time not string",
 			parameters: map[string]interface{}{
 				"since_time": 123456,
 			},
-			wantErr: "since_time must be a string",
+			wantErr: "parameter \"since_time\": expected a string",
 		},
 		{
 			name: "java_specific not bool",
 			parameters: map[string]interface{}{
 				"java_specific": "true",
 			},
-			wantErr: "java_specific must be a boolean",
+			wantErr: "parameter \"java_specific\": expected a boolean",
 		},
 		{
 			name: "include_timestamp not bool",
 			parameters: map[string]interface{}{
 				"include_timestamp": "false",
 			},
-			wantErr: "include_timestamp must be a boolean",
+			wantErr: "parameter \"include_timestamp\": expected a boolean",
 		},
 		{
 			name: "include_container not bool",
 			parameters: map[string]interface{}{
 				"include_container": 0,
 			},
-			wantErr: "include_container must be a boolean",
+			wantErr: "parameter \"include_container\": expected a boolean",
+		},
+		{
+			name: "misspelled parameter",
+			parameters: map[string]interface{}{
+				"max_line": 100,
+			},
+			wantErr: "unknown parameter \"max_line\"",
 		},
 		{
 			name: "timestamp_format not string",
 			parameters: map[string]interface{}{
 				"timestamp_format": []string{"format"},
 			},
-			wantErr: "timestamp_format must be a string",
+			wantErr: "parameter \"timestamp_format\": expected a string, got a list",
 		},
 	}
 
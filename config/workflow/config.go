@@ -10,7 +10,7 @@ import (
 
 // WorkflowConfig represents the complete workflow configuration structure
 type WorkflowConfig struct {
-	ActiveWorkflows []WorkflowDefinition `yaml:"active_workflows" json:"active_workflows"`
+	ActiveWorkflows []WorkflowDefinition `yaml:"active_workflows" json:"active_workflows" jsonschema:"required"`
 }
 
 // ConfigLoader handles loading workflow configuration from various sources
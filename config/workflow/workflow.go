@@ -7,9 +7,9 @@ import (
 
 // WorkflowDefinition represents a complete workflow configuration
 type WorkflowDefinition struct {
-	Name     string              `yaml:"name" json:"name"`
-	Triggers []TriggerDefinition `yaml:"triggers" json:"triggers"`
-	Actions  []ActionDefinition  `yaml:"actions" json:"actions"`
+	Name     string              `yaml:"name" json:"name" jsonschema:"required"`
+	Triggers []TriggerDefinition `yaml:"triggers" json:"triggers" jsonschema:"required"`
+	Actions  []ActionDefinition  `yaml:"actions" json:"actions" jsonschema:"required"`
 	Stop     bool                `yaml:"stop,omitempty" json:"stop,omitempty"`
 }
 
@@ -35,8 +35,8 @@ type ActionDefinition struct {
 // AlertmanagerAlertTrigger represents trigger conditions for Alertmanager alerts
 type AlertmanagerAlertTrigger struct {
 	AlertName string `yaml:"alert_name,omitempty" json:"alert_name,omitempty"`
-	Status    string `yaml:"status,omitempty" json:"status,omitempty"`       // firing, resolved, all
-	Severity  string `yaml:"severity,omitempty" json:"severity,omitempty"`   // critical, warning, info
+	Status    string `yaml:"status,omitempty" json:"status,omitempty" jsonschema:"enum=firing|resolved|all"`
+	Severity  string `yaml:"severity,omitempty" json:"severity,omitempty" jsonschema:"enum=critical|warning|info"`
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"` // kubernetes namespace
 	Instance  string `yaml:"instance,omitempty" json:"instance,omitempty"`   // prometheus instance
 	PodName   string `yaml:"pod_name,omitempty" json:"pod_name,omitempty"`   // pod name prefix
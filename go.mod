@@ -5,6 +5,7 @@ go 1.24.0
 toolchain go1.25.4
 
 require (
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/getsentry/sentry-go v0.36.2
 	github.com/getsentry/sentry-go/gin v0.36.2
 	github.com/gin-contrib/zap v1.1.5
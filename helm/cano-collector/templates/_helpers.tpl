@@ -55,17 +55,16 @@ Validate Slack destination configuration
 */}}
 {{- define "cano-collector.validateSlackDestination" -}}
 {{- $destination := . -}}
-{{- $hasApiKey := hasKey $destination "api_key" -}}
-{{- $hasApiKeyValueFrom := hasKey $destination "api_key_value_from" -}}
 {{- $authMethods := 0 -}}
-{{- if $hasApiKey }}{{ $authMethods = add $authMethods 1 }}{{ end -}}
-{{- if $hasApiKeyValueFrom }}{{ $authMethods = add $authMethods 1 }}{{ end -}}
+{{- range list "api_key" "api_key_value_from" "api_key_file" "api_key_secret_ref" -}}
+{{- if hasKey $destination . }}{{ $authMethods = add $authMethods 1 }}{{ end -}}
+{{- end -}}
 {{- if eq $authMethods 0 -}}
-{{- fail (printf "Slack destination '%s' must have exactly one of: api_key or api_key_value_from" $destination.name) -}}
+{{- fail (printf "Slack destination '%s' must have exactly one of: api_key, api_key_value_from, api_key_file or api_key_secret_ref" $destination.name) -}}
 {{- else if gt $authMethods 1 -}}
-{{- fail (printf "Slack destination '%s' cannot have multiple authentication methods. Use only one of: api_key or api_key_value_from" $destination.name) -}}
+{{- fail (printf "Slack destination '%s' cannot have multiple authentication methods. Use only one of: api_key, api_key_value_from, api_key_file or api_key_secret_ref" $destination.name) -}}
 {{- end -}}
-{{- if $hasApiKeyValueFrom -}}
+{{- if hasKey $destination "api_key_value_from" -}}
 {{- if not (hasKey $destination.api_key_value_from "secretName") -}}
 {{- fail (printf "Slack destination '%s' api_key_value_from must have secretName" $destination.name) -}}
 {{- end -}}
@@ -73,6 +72,58 @@ Validate Slack destination configuration
 {{- fail (printf "Slack destination '%s' api_key_value_from must have secretKey" $destination.name) -}}
 {{- end -}}
 {{- end -}}
+{{- if hasKey $destination "api_key_secret_ref" -}}
+{{- if not (and (hasKey $destination.api_key_secret_ref "name") (hasKey $destination.api_key_secret_ref "key")) -}}
+{{- fail (printf "Slack destination '%s' api_key_secret_ref must have name and key" $destination.name) -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
+
+{{/*
+Validate the credential of a destination, which must be set with exactly one of <field>,
+<field>_value_from, <field>_file or <field>_secret_ref, or at most one of them when "optional"
+is true.
+Usage: include "cano-collector.validateCredential" (dict "destination" . "type" "MS Teams" "field" "webhook_url")
+*/}}
+{{- define "cano-collector.validateCredential" -}}
+{{- $destination := .destination -}}
+{{- $field := .field -}}
+{{- $options := list $field (printf "%s_value_from" $field) (printf "%s_file" $field) (printf "%s_secret_ref" $field) -}}
+{{- $methods := 0 -}}
+{{- range $options -}}
+{{- if hasKey $destination . }}{{ $methods = add $methods 1 }}{{ end -}}
+{{- end -}}
+{{- if and .optional (gt $methods 1) -}}
+{{- fail (printf "%s destination '%s' must have at most one of: %s" .type $destination.name (join ", " $options)) -}}
+{{- else if and (not .optional) (ne $methods 1) -}}
+{{- fail (printf "%s destination '%s' must have exactly one of: %s" .type $destination.name (join ", " $options)) -}}
+{{- end -}}
+{{- $valueFrom := get $destination (printf "%s_value_from" $field) -}}
+{{- if and $valueFrom (not (and (hasKey $valueFrom "secretName") (hasKey $valueFrom "secretKey"))) -}}
+{{- fail (printf "%s destination '%s' %s_value_from must have secretName and secretKey" .type $destination.name $field) -}}
+{{- end -}}
+{{- $secretRef := get $destination (printf "%s_secret_ref" $field) -}}
+{{- if and $secretRef (not (and (hasKey $secretRef "name") (hasKey $secretRef "key"))) -}}
+{{- fail (printf "%s destination '%s' %s_secret_ref must have name and key" .type $destination.name $field) -}}
+{{- end -}}
+{{- end -}}
+
+{{/*
+Render a destination of the destinations file. A credential set with <field>_value_from is
+replaced by a placeholder of the environment variable <env>_<NAME>, all other settings are
+passed through.
+Usage: include "cano-collector.destination" (dict "destination" . "field" "webhook_url" "env" "MSTEAMS_WEBHOOK_URL")
+*/}}
+{{- define "cano-collector.destination" -}}
+{{- $valueFrom := printf "%s_value_from" .field -}}
+{{- $config := omit .destination "name" $valueFrom -}}
+{{- if hasKey .destination $valueFrom -}}
+{{- $_ := set $config .field (printf "${%s_%s}" .env (.destination.name | upper | replace "-" "_")) -}}
+{{- end -}}
+- name: {{ .destination.name | quote }}
+{{- with $config }}
+{{ toYaml . | indent 2 }}
+{{- end }}
 {{- end -}}
 
 {{/*
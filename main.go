@@ -2,21 +2,35 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/kubecano/cano-collector/config"
 	"github.com/kubecano/cano-collector/pkg/alert"
 	alert_interfaces "github.com/kubecano/cano-collector/pkg/alert/interfaces"
+	"github.com/kubecano/cano-collector/pkg/auth"
+	auth_interfaces "github.com/kubecano/cano-collector/pkg/auth/interfaces"
+	"github.com/kubecano/cano-collector/pkg/cli"
 	"github.com/kubecano/cano-collector/pkg/destination"
 	destination_interfaces "github.com/kubecano/cano-collector/pkg/destination/interfaces"
+	"github.com/kubecano/cano-collector/pkg/ha"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
 	"github.com/kubecano/cano-collector/pkg/health"
 	health_interfaces "github.com/kubecano/cano-collector/pkg/health/interfaces"
+	"github.com/kubecano/cano-collector/pkg/ingest"
+	ingest_interfaces "github.com/kubecano/cano-collector/pkg/ingest/interfaces"
 	"github.com/kubecano/cano-collector/pkg/logger"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	"github.com/kubecano/cano-collector/pkg/metric"
 	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	"github.com/kubecano/cano-collector/pkg/reload"
 	"github.com/kubecano/cano-collector/pkg/router"
 	router_interfaces "github.com/kubecano/cano-collector/pkg/router/interfaces"
+	"github.com/kubecano/cano-collector/pkg/schema"
+	schema_interfaces "github.com/kubecano/cano-collector/pkg/schema/interfaces"
+	"github.com/kubecano/cano-collector/pkg/shard"
+	shard_interfaces "github.com/kubecano/cano-collector/pkg/shard/interfaces"
 	"github.com/kubecano/cano-collector/pkg/tracer"
 	tracer_interfaces "github.com/kubecano/cano-collector/pkg/tracer/interfaces"
 	"github.com/kubecano/cano-collector/pkg/workflow"
@@ -25,32 +39,55 @@ import (
 	workflow_interfaces "github.com/kubecano/cano-collector/pkg/workflow/interfaces"
 
 	"github.com/getsentry/sentry-go"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
+	config_crd "github.com/kubecano/cano-collector/config/crd"
+	config_destination "github.com/kubecano/cano-collector/config/destination"
 	config_team "github.com/kubecano/cano-collector/config/team"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
 	"github.com/kubecano/cano-collector/pkg/util"
 )
 
 type AppDependencies struct {
+	// ConfigLoaderFactory creates the loader that reloads destinations, teams and workflows while running
+	ConfigLoaderFactory    func(cfg config.Config, log logger_interfaces.LoggerInterface) (config.FullConfigLoader, error)
 	LoggerFactory          func(level string, env string) logger_interfaces.LoggerInterface
 	HealthCheckerFactory   func(cfg config.Config, log logger_interfaces.LoggerInterface) health_interfaces.HealthInterface
 	TracerManagerFactory   func(cfg config.Config, log logger_interfaces.LoggerInterface) tracer_interfaces.TracerInterface
 	MetricsFactory         func(log logger_interfaces.LoggerInterface) metric_interfaces.MetricsInterface
-	DestinationFactory     func(log logger_interfaces.LoggerInterface) destination_interfaces.DestinationFactoryInterface
+	DestinationFactory     func(log logger_interfaces.LoggerInterface, store ha_interfaces.StateStoreInterface) destination_interfaces.DestinationFactoryInterface
 	DestinationRegistry    func(factory destination_interfaces.DestinationFactoryInterface, log logger_interfaces.LoggerInterface) destination_interfaces.DestinationRegistryInterface
 	TeamResolverFactory    func(teams config_team.TeamsConfig, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) alert_interfaces.TeamResolverInterface
 	AlertDispatcherFactory func(registry destination_interfaces.DestinationRegistryInterface, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) alert_interfaces.AlertDispatcherInterface
-	AlertHandlerFactory    func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, tr alert_interfaces.TeamResolverInterface, ad alert_interfaces.AlertDispatcherInterface, converter alert_interfaces.ConverterInterface, workflowEngine workflow_interfaces.WorkflowEngineInterface) alert_interfaces.AlertHandlerInterface
-	RouterManagerFactory   func(cfg config.Config, log logger_interfaces.LoggerInterface, t tracer_interfaces.TracerInterface, m metric_interfaces.MetricsInterface, h health_interfaces.HealthInterface, a alert_interfaces.AlertHandlerInterface) router_interfaces.RouterInterface
+	AlertHandlerFactory    func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, tr alert_interfaces.TeamResolverInterface, ad alert_interfaces.AlertDispatcherInterface, converter alert_interfaces.ConverterInterface, workflowEngine workflow_interfaces.WorkflowEngineInterface, sharder shard_interfaces.SharderInterface) alert_interfaces.AlertHandlerInterface
+	IngestHandlerFactory   func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, processor alert_interfaces.IssueProcessorInterface, converter alert_interfaces.ConverterInterface, store ha_interfaces.StateStoreInterface) (ingest_interfaces.IngestHandlerInterface, error)
+	RouterManagerFactory   func(cfg config.Config, log logger_interfaces.LoggerInterface, t tracer_interfaces.TracerInterface, m metric_interfaces.MetricsInterface, h health_interfaces.HealthInterface, a alert_interfaces.AlertHandlerInterface, i ingest_interfaces.IngestHandlerInterface, authn auth_interfaces.AuthenticatorInterface, coordinator ha_interfaces.CoordinatorInterface, sharder shard_interfaces.SharderInterface, schemas schema_interfaces.SchemaHandlerInterface) router_interfaces.RouterInterface
 	ConverterFactory       func(log logger_interfaces.LoggerInterface, cfg config.Config) alert_interfaces.ConverterInterface
+	AuthenticatorFactory   func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) (auth_interfaces.AuthenticatorInterface, error)
+	CoordinatorFactory     func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) (ha_interfaces.CoordinatorInterface, error)
+	SharderFactory         func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, processor alert_interfaces.IssueProcessorInterface) (shard_interfaces.SharderInterface, error)
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] != "serve" {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		panic("Error loading config: " + err.Error())
 	}
 
 	deps := AppDependencies{
+		ConfigLoaderFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface) (config.FullConfigLoader, error) {
+			loader := config.NewDefaultConfigLoader()
+			if !cfg.CRD.Enabled {
+				return loader, nil
+			}
+			return newCRDLoader(cfg.CRD, loader, log)
+		},
 		LoggerFactory: func(level, env string) logger_interfaces.LoggerInterface {
 			return logger.NewLogger(level, env)
 		},
@@ -61,8 +98,12 @@ func main() {
 			return tracer.NewTracerManager(cfg, log)
 		},
 		MetricsFactory: metric.NewMetricsCollector,
-		DestinationFactory: func(log logger_interfaces.LoggerInterface) destination_interfaces.DestinationFactoryInterface {
-			return destination.NewDestinationFactory(log, util.GetSharedHTTPClient())
+		DestinationFactory: func(log logger_interfaces.LoggerInterface, store ha_interfaces.StateStoreInterface) destination_interfaces.DestinationFactoryInterface {
+			factory := destination.NewDestinationFactory(log, util.GetSharedHTTPClient())
+			if store != nil {
+				factory.SetStateStore(store)
+			}
+			return factory
 		},
 		DestinationRegistry: func(factory destination_interfaces.DestinationFactoryInterface, log logger_interfaces.LoggerInterface) destination_interfaces.DestinationRegistryInterface {
 			return destination.NewDestinationRegistry(factory, log)
@@ -73,15 +114,52 @@ func main() {
 		AlertDispatcherFactory: func(registry destination_interfaces.DestinationRegistryInterface, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) alert_interfaces.AlertDispatcherInterface {
 			return alert.NewAlertDispatcher(registry, log, m)
 		},
-		AlertHandlerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, tr alert_interfaces.TeamResolverInterface, ad alert_interfaces.AlertDispatcherInterface, converter alert_interfaces.ConverterInterface, workflowEngine workflow_interfaces.WorkflowEngineInterface) alert_interfaces.AlertHandlerInterface {
-			return alert.NewAlertHandler(log, m, tr, ad, converter, workflowEngine)
+		AlertHandlerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, tr alert_interfaces.TeamResolverInterface, ad alert_interfaces.AlertDispatcherInterface, converter alert_interfaces.ConverterInterface, workflowEngine workflow_interfaces.WorkflowEngineInterface, sharder shard_interfaces.SharderInterface) alert_interfaces.AlertHandlerInterface {
+			handler := alert.NewAlertHandler(log, m, tr, ad, converter, workflowEngine)
+			if sharder.Enabled() {
+				handler.SetSharder(sharder)
+			}
+			return handler
 		},
-		RouterManagerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, t tracer_interfaces.TracerInterface, m metric_interfaces.MetricsInterface, h health_interfaces.HealthInterface, a alert_interfaces.AlertHandlerInterface) router_interfaces.RouterInterface {
-			return router.NewRouterManager(cfg, log, t, m, h, a)
+		IngestHandlerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, processor alert_interfaces.IssueProcessorInterface, converter alert_interfaces.ConverterInterface, store ha_interfaces.StateStoreInterface) (ingest_interfaces.IngestHandlerInterface, error) {
+			handler, err := ingest.NewIngestHandler(cfg.Webhooks, cfg.ClusterName, log, m, processor, converter)
+			if err != nil {
+				return nil, err
+			}
+			if store != nil {
+				handler.SetStateStore(store)
+			}
+			return handler, nil
+		},
+		RouterManagerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, t tracer_interfaces.TracerInterface, m metric_interfaces.MetricsInterface, h health_interfaces.HealthInterface, a alert_interfaces.AlertHandlerInterface, i ingest_interfaces.IngestHandlerInterface, authn auth_interfaces.AuthenticatorInterface, coordinator ha_interfaces.CoordinatorInterface, sharder shard_interfaces.SharderInterface, schemas schema_interfaces.SchemaHandlerInterface) router_interfaces.RouterInterface {
+			return router.NewRouterManager(cfg, log, t, m, h, a, i, authn, coordinator, sharder, schemas)
 		},
 		ConverterFactory: func(log logger_interfaces.LoggerInterface, cfg config.Config) alert_interfaces.ConverterInterface {
 			return alert.NewConverterWithConfig(log, cfg)
 		},
+		AuthenticatorFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) (auth_interfaces.AuthenticatorInterface, error) {
+			return auth.NewAuthenticator(cfg.Auth, log, m)
+		},
+		CoordinatorFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) (ha_interfaces.CoordinatorInterface, error) {
+			var client kubernetes.Interface
+			if cfg.HA.Enabled {
+				var err error
+				if client, err = newInClusterClient(); err != nil {
+					return nil, err
+				}
+			}
+			return ha.NewCoordinator(cfg.HA, client, util.GetSharedHTTPClient(), log, m)
+		},
+		SharderFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, processor alert_interfaces.IssueProcessorInterface) (shard_interfaces.SharderInterface, error) {
+			var client kubernetes.Interface
+			if cfg.Sharding.Enabled && cfg.Sharding.Discovery == "endpointslice" {
+				var err error
+				if client, err = newInClusterClient(); err != nil {
+					return nil, err
+				}
+			}
+			return shard.NewSharder(cfg.Sharding, client, processor, util.GetSharedHTTPClient(), log, m)
+		},
 	}
 
 	if err := run(cfg, deps); err != nil {
@@ -104,21 +182,11 @@ func run(cfg config.Config, deps AppDependencies) error {
 	tracerManager := deps.TracerManagerFactory(cfg, log)
 	metricsCollector := deps.MetricsFactory(log)
 
-	// Initialize destination components
-	destinationFactory := deps.DestinationFactory(log)
-	destinationRegistry := deps.DestinationRegistry(destinationFactory, log)
-
-	// Load destinations from config
-	if err := destinationRegistry.LoadFromConfig(cfg.Destinations); err != nil {
-		log.Fatalf("Failed to load destinations from config: %v", err)
+	coordinator, err := deps.CoordinatorFactory(cfg, log, metricsCollector)
+	if err != nil {
+		log.Fatalf("Failed to initialize HA mode: %v", err)
 		return err
 	}
-	log.Debug("Destinations loaded from config")
-
-	// Initialize alert processing components
-	teamResolver := deps.TeamResolverFactory(cfg.Teams, log, metricsCollector)
-	alertDispatcher := deps.AlertDispatcherFactory(destinationRegistry, log, metricsCollector)
-	converter := deps.ConverterFactory(log, cfg)
 
 	// Initialize workflow components
 	actionRegistry := actions.NewDefaultActionRegistry(log, metricsCollector)
@@ -130,18 +198,91 @@ func run(cfg config.Config, deps AppDependencies) error {
 	}
 
 	actionExecutor := actions.NewDefaultActionExecutor(actionRegistry, log, metricsCollector)
-	workflowEngine := workflow.NewWorkflowEngine(&cfg.Workflows, actionExecutor, log, metricsCollector)
+	schemaHandler := schema.NewHandler(config.Schemas(actionRegistry.ParameterSchemas()))
 
-	alertHandler := deps.AlertHandlerFactory(cfg, log, metricsCollector, teamResolver, alertDispatcher, converter, workflowEngine)
+	configLoader, err := deps.ConfigLoaderFactory(cfg, log)
+	if err != nil {
+		log.Fatalf("Failed to initialize configuration loader: %v", err)
+		return err
+	}
+	// Custom resources are only known to the loader, so they are added to the files loaded at startup
+	if cfg.CRD.Enabled {
+		if cfg.Destinations, cfg.Teams, cfg.Workflows, err = configLoader.Load(); err != nil {
+			log.Fatalf("Failed to load custom resources: %v", err)
+			return err
+		}
+	}
 
-	// Validate team destinations configuration
-	if err := teamResolver.ValidateTeamDestinations(destinationRegistry); err != nil {
-		log.Fatalf("Team destinations validation failed: %v", err)
+	destinationFactory := deps.DestinationFactory(log, coordinator.StateStore())
+	converter := deps.ConverterFactory(log, cfg)
+
+	// Handlers use views of the runtime, which take the components of one configuration per request,
+	// so reloaded components replace the active ones atomically
+	runtime := reload.NewRuntime(nil)
+
+	// The sharder processes the issues owned by this replica and forwards the others
+	sharder, err := deps.SharderFactory(cfg, log, metricsCollector, runtime.IssueProcessor())
+	if err != nil {
+		log.Fatalf("Failed to initialize fingerprint sharding: %v", err)
+		return err
+	}
+
+	// buildComponents creates the destinations, team resolver, workflow engine, workflow actions and the handlers
+	// using them; it runs at startup and whenever a changed configuration is reloaded
+	buildComponents := func(destinations config_destination.DestinationsConfig, teams config_team.TeamsConfig, workflows config_workflow.WorkflowConfig) (*reload.Components, error) {
+		registry := deps.DestinationRegistry(destinationFactory, log)
+		if err := registry.LoadFromConfig(destinations); err != nil {
+			return nil, fmt.Errorf("failed to load destinations from config: %w", err)
+		}
+		resolver := deps.TeamResolverFactory(teams, log, metricsCollector)
+		if err := resolver.ValidateTeamDestinations(registry); err != nil {
+			return nil, fmt.Errorf("team destinations validation failed: %w", err)
+		}
+		workflowEngine := workflow.NewWorkflowEngine(&workflows, actionExecutor, log, metricsCollector)
+		if err := workflowEngine.PrepareActions(); err != nil {
+			return nil, fmt.Errorf("failed to create workflow actions: %w", err)
+		}
+		alertDispatcher := deps.AlertDispatcherFactory(registry, log, metricsCollector)
+		return &reload.Components{
+			Registry:       registry,
+			TeamResolver:   resolver,
+			WorkflowEngine: workflowEngine,
+			// Issues of every source, local or forwarded by peers, share team routing, workflows and dispatching
+			IssueProcessor: alert.NewIssueProcessor(log, metricsCollector, resolver, alertDispatcher, workflowEngine),
+			AlertHandler:   deps.AlertHandlerFactory(cfg, log, metricsCollector, resolver, alertDispatcher, converter, workflowEngine, sharder),
+		}, nil
+	}
+
+	components, err := buildComponents(cfg.Destinations, cfg.Teams, cfg.Workflows)
+	if err != nil {
+		log.Fatalf("Failed to initialize destinations, teams and workflows: %v", err)
 		return err
 	}
-	log.Debug("Team destinations validation passed")
+	log.Debug("Destinations loaded and team destinations validated")
 
-	routerManager := deps.RouterManagerFactory(cfg, log, tracerManager, metricsCollector, healthChecker, alertHandler)
+	runtime.Swap(components)
+	reloader := reload.NewReloader(cfg.Reload, configLoader, buildComponents, runtime, cfg, log, metricsCollector)
+	if notifier, ok := configLoader.(reload.ChangeNotifier); ok {
+		reloader.SetChangeNotifier(notifier)
+	}
+
+	alertHandler := runtime.AlertHandler()
+	ingestHandler, err := deps.IngestHandlerFactory(cfg, log, metricsCollector, sharder, converter, coordinator.StateStore())
+	if err != nil {
+		log.Fatalf("Failed to initialize webhook ingestion: %v", err)
+		return err
+	}
+
+	authenticator, err := deps.AuthenticatorFactory(cfg, log, metricsCollector)
+	if err != nil {
+		log.Fatalf("Failed to initialize API authentication: %v", err)
+		return err
+	}
+	if !authenticator.Enabled() {
+		log.Warn("API authentication is disabled, any client can post alerts")
+	}
+
+	routerManager := deps.RouterManagerFactory(cfg, log, tracerManager, metricsCollector, healthChecker, alertHandler, ingestHandler, authenticator, coordinator, sharder, schemaHandler)
 
 	if cfg.SentryEnabled {
 		if err := initSentry(cfg.SentryDSN); err != nil {
@@ -169,6 +310,22 @@ func run(cfg config.Config, deps AppDependencies) error {
 
 	r := routerManager.SetupRouter()
 	log.Debug("Router setup complete")
+
+	if coordinator.Enabled() {
+		haCtx, cancelHA := context.WithCancel(ctx)
+		defer cancelHA()
+		go coordinator.Run(haCtx)
+	}
+	if reloader.Enabled() {
+		reloadCtx, cancelReload := context.WithCancel(ctx)
+		defer cancelReload()
+		go reloader.Run(reloadCtx)
+	}
+	if sharder.Enabled() {
+		shardCtx, cancelShard := context.WithCancel(ctx)
+		defer cancelShard()
+		go sharder.Run(shardCtx)
+	}
 	routerManager.StartServer(r)
 
 	return nil
@@ -186,32 +343,51 @@ func registerWorkflowActions(actionRegistry *actions.DefaultActionRegistry, log
 		kubeClient = realClient
 	}
 
-	// Register Pod Logs Action Factory
-	podLogsFactory := actions.NewPodLogsActionFactory(log, metrics, kubeClient)
-	if err := actionRegistry.Register("pod_logs", podLogsFactory); err != nil {
+	if err := actions.RegisterDefaultActions(actionRegistry, log, metrics, kubeClient); err != nil {
 		return err
 	}
 
-	// Register Label Filter Action Factory
-	labelFilterFactory := actions.NewLabelFilterActionFactory(log, metrics)
-	if err := actionRegistry.Register("label_filter", labelFilterFactory); err != nil {
-		return err
-	}
+	log.Info("Workflow actions registered successfully")
+	return nil
+}
 
-	// Register Severity Router Action Factory
-	severityRouterFactory := actions.NewSeverityRouterActionFactory(log, metrics)
-	if err := actionRegistry.Register("severity_router", severityRouterFactory); err != nil {
-		return err
+// newInClusterClient creates a clientset from the service account of the pod
+func newInClusterClient() (kubernetes.Interface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
 	}
+	return client, nil
+}
 
-	// Register Issue Enrichment Action Factory
-	issueEnrichmentFactory := actions.NewIssueEnrichmentActionFactory(log, metrics)
-	if err := actionRegistry.Register("issue_enrichment", issueEnrichmentFactory); err != nil {
-		return err
+// newCRDLoader watches the custom resources and adds them to the configuration of base
+func newCRDLoader(cfg config.CRDConfig, base config.FullConfigLoader, log logger_interfaces.LoggerInterface) (*config_crd.Loader, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
 	}
 
-	log.Info("Workflow actions registered successfully")
-	return nil
+	loader, err := config_crd.NewLoader(cfg, base, dynamicClient, client, log)
+	if err != nil {
+		return nil, err
+	}
+	// the watches run for the lifetime of the process
+	if err := loader.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	return loader, nil
 }
 
 func initSentry(sentryDSN string) error {
@@ -13,11 +13,16 @@ import (
 	config_team "github.com/kubecano/cano-collector/config/team"
 	"github.com/kubecano/cano-collector/mocks"
 	alert_interfaces "github.com/kubecano/cano-collector/pkg/alert/interfaces"
+	auth_interfaces "github.com/kubecano/cano-collector/pkg/auth/interfaces"
 	destination_interfaces "github.com/kubecano/cano-collector/pkg/destination/interfaces"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
 	health_interfaces "github.com/kubecano/cano-collector/pkg/health/interfaces"
+	ingest_interfaces "github.com/kubecano/cano-collector/pkg/ingest/interfaces"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
 	router_interfaces "github.com/kubecano/cano-collector/pkg/router/interfaces"
+	schema_interfaces "github.com/kubecano/cano-collector/pkg/schema/interfaces"
+	shard_interfaces "github.com/kubecano/cano-collector/pkg/shard/interfaces"
 	tracer_interfaces "github.com/kubecano/cano-collector/pkg/tracer/interfaces"
 	workflow_interfaces "github.com/kubecano/cano-collector/pkg/workflow/interfaces"
 )
@@ -57,12 +62,16 @@ func TestRun_WithMocks(t *testing.T) {
 	mockTracer := mocks.NewMockTracerInterface(ctrl)
 	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
 	mockAlerts := mocks.NewMockAlertHandlerInterface(ctrl)
+	mockIngest := mocks.NewMockIngestHandlerInterface(ctrl)
 	mockRouter := mocks.NewMockRouterInterface(ctrl)
 	mockDestinationFactory := mocks.NewMockDestinationFactoryInterface(ctrl)
 	mockDestinationRegistry := mocks.NewMockDestinationRegistryInterface(ctrl)
 	mockTeamResolver := mocks.NewMockTeamResolverInterface(ctrl)
 	mockAlertDispatcher := mocks.NewMockAlertDispatcherInterface(ctrl)
 	mockConverter := mocks.NewMockConverterInterface(ctrl)
+	mockAuthenticator := mocks.NewMockAuthenticatorInterface(ctrl)
+	mockCoordinator := mocks.NewMockCoordinatorInterface(ctrl)
+	mockSharder := mocks.NewMockSharderInterface(ctrl)
 
 	// Mock zachowania
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
@@ -71,6 +80,11 @@ func TestRun_WithMocks(t *testing.T) {
 	mockLogger.EXPECT().Fatalf(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
 
+	mockAuthenticator.EXPECT().Enabled().Return(true).Times(1)
+	mockCoordinator.EXPECT().StateStore().Return(nil).Times(2)
+	mockCoordinator.EXPECT().Enabled().Return(false).Times(1)
+	mockSharder.EXPECT().Enabled().Return(false).Times(1)
+
 	mockHealth.EXPECT().RegisterHealthChecks().Return(nil).Times(1)
 
 	mockTracer.EXPECT().InitTracer(gomock.Any()).Return(nil).Times(1)
@@ -88,6 +102,9 @@ func TestRun_WithMocks(t *testing.T) {
 	mockRouter.EXPECT().StartServer(g).Times(1)
 
 	deps := AppDependencies{
+		ConfigLoaderFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface) (config.FullConfigLoader, error) {
+			return mocks.NewMockFullConfigLoader(ctrl), nil
+		},
 		LoggerFactory: func(_, _ string) logger_interfaces.LoggerInterface { return mockLogger },
 		HealthCheckerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface) health_interfaces.HealthInterface {
 			return mockHealth
@@ -96,7 +113,7 @@ func TestRun_WithMocks(t *testing.T) {
 			return mockTracer
 		},
 		MetricsFactory: func(log logger_interfaces.LoggerInterface) metric_interfaces.MetricsInterface { return mockMetrics },
-		DestinationFactory: func(log logger_interfaces.LoggerInterface) destination_interfaces.DestinationFactoryInterface {
+		DestinationFactory: func(log logger_interfaces.LoggerInterface, store ha_interfaces.StateStoreInterface) destination_interfaces.DestinationFactoryInterface {
 			return mockDestinationFactory
 		},
 		DestinationRegistry: func(factory destination_interfaces.DestinationFactoryInterface, log logger_interfaces.LoggerInterface) destination_interfaces.DestinationRegistryInterface {
@@ -108,15 +125,27 @@ func TestRun_WithMocks(t *testing.T) {
 		AlertDispatcherFactory: func(registry destination_interfaces.DestinationRegistryInterface, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) alert_interfaces.AlertDispatcherInterface {
 			return mockAlertDispatcher
 		},
-		AlertHandlerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, tr alert_interfaces.TeamResolverInterface, ad alert_interfaces.AlertDispatcherInterface, converter alert_interfaces.ConverterInterface, workflowEngine workflow_interfaces.WorkflowEngineInterface) alert_interfaces.AlertHandlerInterface {
+		AlertHandlerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, tr alert_interfaces.TeamResolverInterface, ad alert_interfaces.AlertDispatcherInterface, converter alert_interfaces.ConverterInterface, workflowEngine workflow_interfaces.WorkflowEngineInterface, sharder shard_interfaces.SharderInterface) alert_interfaces.AlertHandlerInterface {
 			return mockAlerts
 		},
-		RouterManagerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, t tracer_interfaces.TracerInterface, m metric_interfaces.MetricsInterface, h health_interfaces.HealthInterface, a alert_interfaces.AlertHandlerInterface) router_interfaces.RouterInterface {
+		IngestHandlerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, processor alert_interfaces.IssueProcessorInterface, converter alert_interfaces.ConverterInterface, store ha_interfaces.StateStoreInterface) (ingest_interfaces.IngestHandlerInterface, error) {
+			return mockIngest, nil
+		},
+		RouterManagerFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, t tracer_interfaces.TracerInterface, m metric_interfaces.MetricsInterface, h health_interfaces.HealthInterface, a alert_interfaces.AlertHandlerInterface, i ingest_interfaces.IngestHandlerInterface, authn auth_interfaces.AuthenticatorInterface, coordinator ha_interfaces.CoordinatorInterface, sharder shard_interfaces.SharderInterface, schemas schema_interfaces.SchemaHandlerInterface) router_interfaces.RouterInterface {
 			return mockRouter
 		},
 		ConverterFactory: func(log logger_interfaces.LoggerInterface, cfg config.Config) alert_interfaces.ConverterInterface {
 			return mockConverter
 		},
+		AuthenticatorFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) (auth_interfaces.AuthenticatorInterface, error) {
+			return mockAuthenticator, nil
+		},
+		CoordinatorFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface) (ha_interfaces.CoordinatorInterface, error) {
+			return mockCoordinator, nil
+		},
+		SharderFactory: func(cfg config.Config, log logger_interfaces.LoggerInterface, m metric_interfaces.MetricsInterface, processor alert_interfaces.IssueProcessorInterface) (shard_interfaces.SharderInterface, error) {
+			return mockSharder, nil
+		},
 	}
 
 	cfg := config.Config{
@@ -49,3 +49,18 @@ func (mr *MockConverterInterfaceMockRecorder) ConvertAlertManagerEventToIssues(e
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertAlertManagerEventToIssues", reflect.TypeOf((*MockConverterInterface)(nil).ConvertAlertManagerEventToIssues), event)
 }
+
+// ConvertGrafanaEventToIssues mocks base method.
+func (m *MockConverterInterface) ConvertGrafanaEventToIssues(event *event.GrafanaEvent) ([]*issue.Issue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertGrafanaEventToIssues", event)
+	ret0, _ := ret[0].([]*issue.Issue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertGrafanaEventToIssues indicates an expected call of ConvertGrafanaEventToIssues.
+func (mr *MockConverterInterfaceMockRecorder) ConvertGrafanaEventToIssues(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertGrafanaEventToIssues", reflect.TypeOf((*MockConverterInterface)(nil).ConvertGrafanaEventToIssues), event)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthenticatorInterface is a mock of AuthenticatorInterface interface.
+type MockAuthenticatorInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticatorInterfaceMockRecorder
+}
+
+// MockAuthenticatorInterfaceMockRecorder is the mock recorder for MockAuthenticatorInterface.
+type MockAuthenticatorInterfaceMockRecorder struct {
+	mock *MockAuthenticatorInterface
+}
+
+// NewMockAuthenticatorInterface creates a new mock instance.
+func NewMockAuthenticatorInterface(ctrl *gomock.Controller) *MockAuthenticatorInterface {
+	mock := &MockAuthenticatorInterface{ctrl: ctrl}
+	mock.recorder = &MockAuthenticatorInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthenticatorInterface) EXPECT() *MockAuthenticatorInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockAuthenticatorInterface) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockAuthenticatorInterfaceMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockAuthenticatorInterface)(nil).Enabled))
+}
+
+// Middleware mocks base method.
+func (m *MockAuthenticatorInterface) Middleware() gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Middleware")
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// Middleware indicates an expected call of Middleware.
+func (mr *MockAuthenticatorInterfaceMockRecorder) Middleware() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Middleware", reflect.TypeOf((*MockAuthenticatorInterface)(nil).Middleware))
+}
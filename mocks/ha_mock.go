@@ -0,0 +1,207 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ha.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+)
+
+// MockCoordinatorInterface is a mock of CoordinatorInterface interface.
+type MockCoordinatorInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoordinatorInterfaceMockRecorder
+}
+
+// MockCoordinatorInterfaceMockRecorder is the mock recorder for MockCoordinatorInterface.
+type MockCoordinatorInterfaceMockRecorder struct {
+	mock *MockCoordinatorInterface
+}
+
+// NewMockCoordinatorInterface creates a new mock instance.
+func NewMockCoordinatorInterface(ctrl *gomock.Controller) *MockCoordinatorInterface {
+	mock := &MockCoordinatorInterface{ctrl: ctrl}
+	mock.recorder = &MockCoordinatorInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoordinatorInterface) EXPECT() *MockCoordinatorInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockCoordinatorInterface) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockCoordinatorInterfaceMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockCoordinatorInterface)(nil).Enabled))
+}
+
+// IsLeader mocks base method.
+func (m *MockCoordinatorInterface) IsLeader() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLeader")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsLeader indicates an expected call of IsLeader.
+func (mr *MockCoordinatorInterfaceMockRecorder) IsLeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLeader", reflect.TypeOf((*MockCoordinatorInterface)(nil).IsLeader))
+}
+
+// Middleware mocks base method.
+func (m *MockCoordinatorInterface) Middleware() gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Middleware")
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// Middleware indicates an expected call of Middleware.
+func (mr *MockCoordinatorInterfaceMockRecorder) Middleware() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Middleware", reflect.TypeOf((*MockCoordinatorInterface)(nil).Middleware))
+}
+
+// Run mocks base method.
+func (m *MockCoordinatorInterface) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockCoordinatorInterfaceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCoordinatorInterface)(nil).Run), ctx)
+}
+
+// SetHandler mocks base method.
+func (m *MockCoordinatorInterface) SetHandler(handler http.Handler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetHandler", handler)
+}
+
+// SetHandler indicates an expected call of SetHandler.
+func (mr *MockCoordinatorInterfaceMockRecorder) SetHandler(handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHandler", reflect.TypeOf((*MockCoordinatorInterface)(nil).SetHandler), handler)
+}
+
+// StateStore mocks base method.
+func (m *MockCoordinatorInterface) StateStore() interfaces.StateStoreInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateStore")
+	ret0, _ := ret[0].(interfaces.StateStoreInterface)
+	return ret0
+}
+
+// StateStore indicates an expected call of StateStore.
+func (mr *MockCoordinatorInterfaceMockRecorder) StateStore() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateStore", reflect.TypeOf((*MockCoordinatorInterface)(nil).StateStore))
+}
+
+// Status mocks base method.
+func (m *MockCoordinatorInterface) Status() interfaces.LeadershipStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(interfaces.LeadershipStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockCoordinatorInterfaceMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockCoordinatorInterface)(nil).Status))
+}
+
+// Stop mocks base method.
+func (m *MockCoordinatorInterface) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockCoordinatorInterfaceMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockCoordinatorInterface)(nil).Stop))
+}
+
+// MockStateStoreInterface is a mock of StateStoreInterface interface.
+type MockStateStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStateStoreInterfaceMockRecorder
+}
+
+// MockStateStoreInterfaceMockRecorder is the mock recorder for MockStateStoreInterface.
+type MockStateStoreInterfaceMockRecorder struct {
+	mock *MockStateStoreInterface
+}
+
+// NewMockStateStoreInterface creates a new mock instance.
+func NewMockStateStoreInterface(ctrl *gomock.Controller) *MockStateStoreInterface {
+	mock := &MockStateStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockStateStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStateStoreInterface) EXPECT() *MockStateStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockStateStoreInterface) Delete(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", key)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStateStoreInterfaceMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStateStoreInterface)(nil).Delete), key)
+}
+
+// Get mocks base method.
+func (m *MockStateStoreInterface) Get(key string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStateStoreInterfaceMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStateStoreInterface)(nil).Get), key)
+}
+
+// Set mocks base method.
+func (m *MockStateStoreInterface) Set(key, value string, ttl time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", key, value, ttl)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockStateStoreInterfaceMockRecorder) Set(key, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStateStoreInterface)(nil).Set), key, value, ttl)
+}
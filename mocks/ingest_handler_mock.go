@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ingest.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIngestHandlerInterface is a mock of IngestHandlerInterface interface.
+type MockIngestHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngestHandlerInterfaceMockRecorder
+}
+
+// MockIngestHandlerInterfaceMockRecorder is the mock recorder for MockIngestHandlerInterface.
+type MockIngestHandlerInterfaceMockRecorder struct {
+	mock *MockIngestHandlerInterface
+}
+
+// NewMockIngestHandlerInterface creates a new mock instance.
+func NewMockIngestHandlerInterface(ctrl *gomock.Controller) *MockIngestHandlerInterface {
+	mock := &MockIngestHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockIngestHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngestHandlerInterface) EXPECT() *MockIngestHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateIssue mocks base method.
+func (m *MockIngestHandlerInterface) CreateIssue(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateIssue", c)
+}
+
+// CreateIssue indicates an expected call of CreateIssue.
+func (mr *MockIngestHandlerInterfaceMockRecorder) CreateIssue(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssue", reflect.TypeOf((*MockIngestHandlerInterface)(nil).CreateIssue), c)
+}
+
+// HandleFalco mocks base method.
+func (m *MockIngestHandlerInterface) HandleFalco(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleFalco", c)
+}
+
+// HandleFalco indicates an expected call of HandleFalco.
+func (mr *MockIngestHandlerInterfaceMockRecorder) HandleFalco(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleFalco", reflect.TypeOf((*MockIngestHandlerInterface)(nil).HandleFalco), c)
+}
+
+// HandleGrafana mocks base method.
+func (m *MockIngestHandlerInterface) HandleGrafana(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleGrafana", c)
+}
+
+// HandleGrafana indicates an expected call of HandleGrafana.
+func (mr *MockIngestHandlerInterfaceMockRecorder) HandleGrafana(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleGrafana", reflect.TypeOf((*MockIngestHandlerInterface)(nil).HandleGrafana), c)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockIngestHandlerInterface) HandleWebhook(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleWebhook", c)
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockIngestHandlerInterfaceMockRecorder) HandleWebhook(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockIngestHandlerInterface)(nil).HandleWebhook), c)
+}
+
+// ResolveIssue mocks base method.
+func (m *MockIngestHandlerInterface) ResolveIssue(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResolveIssue", c)
+}
+
+// ResolveIssue indicates an expected call of ResolveIssue.
+func (mr *MockIngestHandlerInterfaceMockRecorder) ResolveIssue(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveIssue", reflect.TypeOf((*MockIngestHandlerInterface)(nil).ResolveIssue), c)
+}
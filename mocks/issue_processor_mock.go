@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: issue_processor.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	issue "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// MockIssueProcessorInterface is a mock of IssueProcessorInterface interface.
+type MockIssueProcessorInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueProcessorInterfaceMockRecorder
+}
+
+// MockIssueProcessorInterfaceMockRecorder is the mock recorder for MockIssueProcessorInterface.
+type MockIssueProcessorInterfaceMockRecorder struct {
+	mock *MockIssueProcessorInterface
+}
+
+// NewMockIssueProcessorInterface creates a new mock instance.
+func NewMockIssueProcessorInterface(ctrl *gomock.Controller) *MockIssueProcessorInterface {
+	mock := &MockIssueProcessorInterface{ctrl: ctrl}
+	mock.recorder = &MockIssueProcessorInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueProcessorInterface) EXPECT() *MockIssueProcessorInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ProcessIssues mocks base method.
+func (m *MockIssueProcessorInterface) ProcessIssues(ctx context.Context, issues []*issue.Issue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessIssues", ctx, issues)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessIssues indicates an expected call of ProcessIssues.
+func (mr *MockIssueProcessorInterfaceMockRecorder) ProcessIssues(ctx, issues interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessIssues", reflect.TypeOf((*MockIssueProcessorInterface)(nil).ProcessIssues), ctx, issues)
+}
@@ -71,6 +71,30 @@ func (mr *MockMetricsInterfaceMockRecorder) IncAlertsProcessed(alertName, severi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncAlertsProcessed", reflect.TypeOf((*MockMetricsInterface)(nil).IncAlertsProcessed), alertName, severity, source)
 }
 
+// IncAuthRejections mocks base method.
+func (m *MockMetricsInterface) IncAuthRejections(path, reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncAuthRejections", path, reason)
+}
+
+// IncAuthRejections indicates an expected call of IncAuthRejections.
+func (mr *MockMetricsInterfaceMockRecorder) IncAuthRejections(path, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncAuthRejections", reflect.TypeOf((*MockMetricsInterface)(nil).IncAuthRejections), path, reason)
+}
+
+// IncConfigReloads mocks base method.
+func (m *MockMetricsInterface) IncConfigReloads(result string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncConfigReloads", result)
+}
+
+// IncConfigReloads indicates an expected call of IncConfigReloads.
+func (mr *MockMetricsInterfaceMockRecorder) IncConfigReloads(result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncConfigReloads", reflect.TypeOf((*MockMetricsInterface)(nil).IncConfigReloads), result)
+}
+
 // IncDestinationErrors mocks base method.
 func (m *MockMetricsInterface) IncDestinationErrors(destinationName, destinationType, errorType string) {
 	m.ctrl.T.Helper()
@@ -95,6 +119,18 @@ func (mr *MockMetricsInterfaceMockRecorder) IncDestinationMessagesSent(destinati
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncDestinationMessagesSent", reflect.TypeOf((*MockMetricsInterface)(nil).IncDestinationMessagesSent), destinationName, destinationType, status)
 }
 
+// IncHARequests mocks base method.
+func (m *MockMetricsInterface) IncHARequests(outcome string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncHARequests", outcome)
+}
+
+// IncHARequests indicates an expected call of IncHARequests.
+func (mr *MockMetricsInterfaceMockRecorder) IncHARequests(outcome interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncHARequests", reflect.TypeOf((*MockMetricsInterface)(nil).IncHARequests), outcome)
+}
+
 // IncRoutingDecisions mocks base method.
 func (m *MockMetricsInterface) IncRoutingDecisions(teamName, destinationType, decision string) {
 	m.ctrl.T.Helper()
@@ -107,6 +143,18 @@ func (mr *MockMetricsInterfaceMockRecorder) IncRoutingDecisions(teamName, destin
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncRoutingDecisions", reflect.TypeOf((*MockMetricsInterface)(nil).IncRoutingDecisions), teamName, destinationType, decision)
 }
 
+// IncShardIssues mocks base method.
+func (m *MockMetricsInterface) IncShardIssues(outcome string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncShardIssues", outcome)
+}
+
+// IncShardIssues indicates an expected call of IncShardIssues.
+func (mr *MockMetricsInterfaceMockRecorder) IncShardIssues(outcome interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncShardIssues", reflect.TypeOf((*MockMetricsInterface)(nil).IncShardIssues), outcome)
+}
+
 // IncTeamsMatched mocks base method.
 func (m *MockMetricsInterface) IncTeamsMatched(teamName, alertName string) {
 	m.ctrl.T.Helper()
@@ -216,3 +264,39 @@ func (mr *MockMetricsInterfaceMockRecorder) PrometheusMiddleware() *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrometheusMiddleware", reflect.TypeOf((*MockMetricsInterface)(nil).PrometheusMiddleware))
 }
+
+// SetConfigLastReloadSuccessful mocks base method.
+func (m *MockMetricsInterface) SetConfigLastReloadSuccessful(successful bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConfigLastReloadSuccessful", successful)
+}
+
+// SetConfigLastReloadSuccessful indicates an expected call of SetConfigLastReloadSuccessful.
+func (mr *MockMetricsInterfaceMockRecorder) SetConfigLastReloadSuccessful(successful interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConfigLastReloadSuccessful", reflect.TypeOf((*MockMetricsInterface)(nil).SetConfigLastReloadSuccessful), successful)
+}
+
+// SetHALeader mocks base method.
+func (m *MockMetricsInterface) SetHALeader(leader bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetHALeader", leader)
+}
+
+// SetHALeader indicates an expected call of SetHALeader.
+func (mr *MockMetricsInterfaceMockRecorder) SetHALeader(leader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHALeader", reflect.TypeOf((*MockMetricsInterface)(nil).SetHALeader), leader)
+}
+
+// SetShardPeers mocks base method.
+func (m *MockMetricsInterface) SetShardPeers(count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetShardPeers", count)
+}
+
+// SetShardPeers indicates an expected call of SetShardPeers.
+func (mr *MockMetricsInterfaceMockRecorder) SetShardPeers(count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShardPeers", reflect.TypeOf((*MockMetricsInterface)(nil).SetShardPeers), count)
+}
@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: schema.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSchemaHandlerInterface is a mock of SchemaHandlerInterface interface.
+type MockSchemaHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaHandlerInterfaceMockRecorder
+}
+
+// MockSchemaHandlerInterfaceMockRecorder is the mock recorder for MockSchemaHandlerInterface.
+type MockSchemaHandlerInterfaceMockRecorder struct {
+	mock *MockSchemaHandlerInterface
+}
+
+// NewMockSchemaHandlerInterface creates a new mock instance.
+func NewMockSchemaHandlerInterface(ctrl *gomock.Controller) *MockSchemaHandlerInterface {
+	mock := &MockSchemaHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockSchemaHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaHandlerInterface) EXPECT() *MockSchemaHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// HandleIndex mocks base method.
+func (m *MockSchemaHandlerInterface) HandleIndex(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleIndex", c)
+}
+
+// HandleIndex indicates an expected call of HandleIndex.
+func (mr *MockSchemaHandlerInterfaceMockRecorder) HandleIndex(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIndex", reflect.TypeOf((*MockSchemaHandlerInterface)(nil).HandleIndex), c)
+}
+
+// HandleSchema mocks base method.
+func (m *MockSchemaHandlerInterface) HandleSchema(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleSchema", c)
+}
+
+// HandleSchema indicates an expected call of HandleSchema.
+func (mr *MockSchemaHandlerInterfaceMockRecorder) HandleSchema(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSchema", reflect.TypeOf((*MockSchemaHandlerInterface)(nil).HandleSchema), c)
+}
@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shard.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	issue "github.com/kubecano/cano-collector/pkg/core/issue"
+	interfaces "github.com/kubecano/cano-collector/pkg/shard/interfaces"
+)
+
+// MockSharderInterface is a mock of SharderInterface interface.
+type MockSharderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSharderInterfaceMockRecorder
+}
+
+// MockSharderInterfaceMockRecorder is the mock recorder for MockSharderInterface.
+type MockSharderInterfaceMockRecorder struct {
+	mock *MockSharderInterface
+}
+
+// NewMockSharderInterface creates a new mock instance.
+func NewMockSharderInterface(ctrl *gomock.Controller) *MockSharderInterface {
+	mock := &MockSharderInterface{ctrl: ctrl}
+	mock.recorder = &MockSharderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSharderInterface) EXPECT() *MockSharderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Distribute mocks base method.
+func (m *MockSharderInterface) Distribute(ctx context.Context, issues []*issue.Issue) ([]*issue.Issue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distribute", ctx, issues)
+	ret0, _ := ret[0].([]*issue.Issue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Distribute indicates an expected call of Distribute.
+func (mr *MockSharderInterfaceMockRecorder) Distribute(ctx, issues interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distribute", reflect.TypeOf((*MockSharderInterface)(nil).Distribute), ctx, issues)
+}
+
+// Enabled mocks base method.
+func (m *MockSharderInterface) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockSharderInterfaceMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockSharderInterface)(nil).Enabled))
+}
+
+// HandleForwardedIssues mocks base method.
+func (m *MockSharderInterface) HandleForwardedIssues(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleForwardedIssues", c)
+}
+
+// HandleForwardedIssues indicates an expected call of HandleForwardedIssues.
+func (mr *MockSharderInterfaceMockRecorder) HandleForwardedIssues(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleForwardedIssues", reflect.TypeOf((*MockSharderInterface)(nil).HandleForwardedIssues), c)
+}
+
+// ProcessIssues mocks base method.
+func (m *MockSharderInterface) ProcessIssues(ctx context.Context, issues []*issue.Issue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessIssues", ctx, issues)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessIssues indicates an expected call of ProcessIssues.
+func (mr *MockSharderInterfaceMockRecorder) ProcessIssues(ctx, issues interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessIssues", reflect.TypeOf((*MockSharderInterface)(nil).ProcessIssues), ctx, issues)
+}
+
+// Run mocks base method.
+func (m *MockSharderInterface) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockSharderInterfaceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockSharderInterface)(nil).Run), ctx)
+}
+
+// Status mocks base method.
+func (m *MockSharderInterface) Status() interfaces.ShardStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(interfaces.ShardStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockSharderInterfaceMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockSharderInterface)(nil).Status))
+}
@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	config_team "github.com/kubecano/cano-collector/config/team"
 	event "github.com/kubecano/cano-collector/pkg/core/event"
+	issue "github.com/kubecano/cano-collector/pkg/core/issue"
 	interfaces "github.com/kubecano/cano-collector/pkg/destination/interfaces"
 )
 
@@ -51,6 +52,21 @@ func (mr *MockTeamResolverInterfaceMockRecorder) ResolveTeam(alert interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTeam", reflect.TypeOf((*MockTeamResolverInterface)(nil).ResolveTeam), alert)
 }
 
+// ResolveTeamForIssue mocks base method.
+func (m *MockTeamResolverInterface) ResolveTeamForIssue(issue *issue.Issue) (*config_team.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveTeamForIssue", issue)
+	ret0, _ := ret[0].(*config_team.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveTeamForIssue indicates an expected call of ResolveTeamForIssue.
+func (mr *MockTeamResolverInterfaceMockRecorder) ResolveTeamForIssue(issue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTeamForIssue", reflect.TypeOf((*MockTeamResolverInterface)(nil).ResolveTeamForIssue), issue)
+}
+
 // ValidateTeamDestinations mocks base method.
 func (m *MockTeamResolverInterface) ValidateTeamDestinations(registry interfaces.DestinationRegistryInterface) error {
 	m.ctrl.T.Helper()
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	event "github.com/kubecano/cano-collector/pkg/core/event"
+	schema "github.com/kubecano/cano-collector/pkg/schema"
 	interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
 )
 
@@ -294,6 +295,20 @@ func (mr *MockActionFactoryMockRecorder) GetActionType() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionType", reflect.TypeOf((*MockActionFactory)(nil).GetActionType))
 }
 
+// ParameterSchema mocks base method.
+func (m *MockActionFactory) ParameterSchema() *schema.Schema {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParameterSchema")
+	ret0, _ := ret[0].(*schema.Schema)
+	return ret0
+}
+
+// ParameterSchema indicates an expected call of ParameterSchema.
+func (mr *MockActionFactoryMockRecorder) ParameterSchema() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParameterSchema", reflect.TypeOf((*MockActionFactory)(nil).ParameterSchema))
+}
+
 // ValidateConfig mocks base method.
 func (m *MockActionFactory) ValidateConfig(config interfaces.ActionConfig) error {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package alert
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"net/http"
 	"time"
@@ -14,17 +15,19 @@ import (
 	"github.com/kubecano/cano-collector/pkg/core/event"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	shard_interfaces "github.com/kubecano/cano-collector/pkg/shard/interfaces"
 	workflow_interfaces "github.com/kubecano/cano-collector/pkg/workflow/interfaces"
 )
 
 // AlertHandler handles incoming alerts from Alertmanager
 type AlertHandler struct {
-	logger          logger_interfaces.LoggerInterface
-	metrics         metric_interfaces.MetricsInterface
-	teamResolver    alert_interfaces.TeamResolverInterface
-	alertDispatcher alert_interfaces.AlertDispatcherInterface
-	converter       alert_interfaces.ConverterInterface
-	workflowEngine  workflow_interfaces.WorkflowEngineInterface
+	logger    logger_interfaces.LoggerInterface
+	metrics   metric_interfaces.MetricsInterface
+	converter alert_interfaces.ConverterInterface
+	// processor routes, enriches and dispatches the issues like those forwarded by other replicas
+	processor *IssueProcessor
+	// sharder forwards issues owned by other replicas; nil processes every issue locally
+	sharder shard_interfaces.SharderInterface
 }
 
 // NewAlertHandler creates a new alert handler
@@ -37,15 +40,18 @@ func NewAlertHandler(
 	workflowEngine workflow_interfaces.WorkflowEngineInterface,
 ) *AlertHandler {
 	return &AlertHandler{
-		logger:          logger,
-		metrics:         metrics,
-		teamResolver:    teamResolver,
-		alertDispatcher: alertDispatcher,
-		converter:       converter,
-		workflowEngine:  workflowEngine,
+		logger:    logger,
+		metrics:   metrics,
+		converter: converter,
+		processor: NewIssueProcessor(logger, metrics, teamResolver, alertDispatcher, workflowEngine),
 	}
 }
 
+// SetSharder forwards issues owned by other replicas to them instead of processing them locally
+func (h *AlertHandler) SetSharder(sharder shard_interfaces.SharderInterface) {
+	h.sharder = sharder
+}
+
 // HandleAlert processes alerts
 func (h *AlertHandler) HandleAlert(c *gin.Context) {
 	start := time.Now()
@@ -54,6 +60,11 @@ func (h *AlertHandler) HandleAlert(c *gin.Context) {
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
 		h.logger.Error("Failed to read request body", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
 		return
 	}
@@ -77,7 +88,11 @@ func (h *AlertHandler) HandleAlert(c *gin.Context) {
 	// Convert template.Data to AlertManagerEvent
 	alertEvent := event.NewAlertManagerEvent(templateData)
 	if err := alertEvent.Validate(); err != nil {
-		h.logger.Error("Invalid alert structure", zap.Error(err), zap.Any("alert", templateData))
+		// the payload is not logged, annotations and labels may carry credentials
+		h.logger.Error("Invalid alert structure", zap.Error(err),
+			zap.String("receiver", templateData.Receiver),
+			zap.String("status", templateData.Status),
+			zap.Int("alerts", len(templateData.Alerts)))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert format: " + err.Error()})
 		return
 	}
@@ -85,15 +100,6 @@ func (h *AlertHandler) HandleAlert(c *gin.Context) {
 	// Register received alert metric
 	h.metrics.ObserveAlert(alertEvent.Receiver, alertEvent.Status)
 
-	// Resolve which team should handle this alert
-	team, err := h.teamResolver.ResolveTeam(alertEvent)
-	if err != nil {
-		h.logger.Error("Failed to resolve team for alert", zap.Error(err))
-		h.metrics.IncAlertErrors(alertEvent.GetAlertName(), "team_resolution_failed")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve team"})
-		return
-	}
-
 	// Convert AlertManagerEvent to Issues FIRST
 	issues, err := h.converter.ConvertAlertManagerEventToIssues(alertEvent)
 	if err != nil {
@@ -103,101 +109,40 @@ func (h *AlertHandler) HandleAlert(c *gin.Context) {
 		return
 	}
 
-	// Process workflows per issue if workflow engine is available
-	if h.workflowEngine != nil {
-		for i, issueItem := range issues {
-			// Create issue-specific workflow event from the corresponding alert
-			if i < len(alertEvent.Alerts) {
-				// Create a single-alert event for this specific issue
-				singleAlertEvent := &event.AlertManagerEvent{
-					BaseEvent: event.BaseEvent{
-						ID:        alertEvent.ID,
-						Timestamp: alertEvent.Timestamp,
-						Source:    alertEvent.Source,
-						Type:      alertEvent.Type,
-					},
-					Receiver:          alertEvent.Receiver,
-					Status:            alertEvent.Status,
-					Alerts:            []event.PrometheusAlert{alertEvent.Alerts[i]},
-					GroupLabels:       alertEvent.GroupLabels,
-					CommonLabels:      alertEvent.CommonLabels,
-					CommonAnnotations: alertEvent.CommonAnnotations,
-					ExternalURL:       alertEvent.ExternalURL,
-				}
-
-				workflowEvent := event.NewAlertManagerWorkflowEvent(singleAlertEvent)
-
-				matchingWorkflows := h.workflowEngine.SelectWorkflows(workflowEvent)
-				h.logger.Info("Workflow processing for issue",
-					zap.String("alert_name", issueItem.AggregationKey),
-					zap.String("pod", issueItem.Subject.Name),
-					zap.Int("matching_workflows", len(matchingWorkflows)))
-
-				// Execute workflows and collect enrichments for THIS specific issue
-				enrichments, err := h.workflowEngine.ExecuteWorkflowsWithEnrichments(c.Request.Context(), matchingWorkflows, workflowEvent)
-				if err != nil {
-					h.logger.Error("Failed to execute workflows with enrichments for issue",
-						zap.Error(err),
-						zap.String("alert_name", issueItem.AggregationKey),
-						zap.String("pod", issueItem.Subject.Name))
-					// Continue processing even if workflows fail for this issue
-				} else {
-					// Add enrichments to THIS specific issue only
-					for _, enrichment := range enrichments {
-						issueItem.AddEnrichment(enrichment)
-					}
-					h.logger.Info("Applied workflow enrichments to issue",
-						zap.String("alert_name", issueItem.AggregationKey),
-						zap.String("pod", issueItem.Subject.Name),
-						zap.Int("enrichments_count", len(enrichments)))
-				}
-			}
+	// Issues owned by other replicas are processed there
+	var forwardErr error
+	if h.sharder != nil {
+		issues, forwardErr = h.sharder.Distribute(c.Request.Context(), issues)
+		if len(issues) == 0 && forwardErr == nil {
+			h.logger.Info("Alert forwarded to owning replicas",
+				zap.String("receiver", alertEvent.Receiver),
+				zap.Int("alerts_count", len(alertEvent.Alerts)))
+			c.JSON(http.StatusOK, gin.H{"status": "alert forwarded"})
+			return
 		}
 	}
 
-	// Dispatch issues to team destinations
-	ctx := c.Request.Context()
-	dispatchErr := h.alertDispatcher.DispatchIssues(ctx, issues, team)
-	if dispatchErr != nil {
-		h.logger.Error("Failed to dispatch issues", zap.Error(dispatchErr))
-		h.metrics.IncAlertErrors(alertEvent.GetAlertName(), "dispatch_failed")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to dispatch issues"})
+	// Resolve the team of each issue, alerts of one group may belong to different namespaces, and
+	// enrich the issues exactly like the issues forwarded by other replicas
+	if err := h.processor.ProcessIssues(c.Request.Context(), issues); err != nil {
+		h.logger.Error("Failed to process issues", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process issues"})
 		return
 	}
 
-	// Record processing metrics
-	processingDuration := time.Since(start)
-	workflowCount := 0
-	if team != nil {
-		workflowCount = len(team.Destinations)
+	if forwardErr != nil {
+		h.logger.Error("Failed to process issues on owning replica", zap.Error(forwardErr))
+		h.metrics.IncAlertErrors(alertEvent.GetAlertName(), "forward_failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process issues on owning replica"})
+		return
 	}
 
-	h.metrics.ObserveAlertProcessingDuration(alertEvent.GetAlertName(), workflowCount, processingDuration)
-
-	if team == nil {
-		h.logger.Warn("Alert received but no team resolved - alert not processed",
-			zap.String("receiver", alertEvent.Receiver),
-			zap.String("status", alertEvent.Status),
-			zap.Int("alerts_count", len(alertEvent.Alerts)),
-			zap.Int("issues_count", len(issues)))
-		h.metrics.IncAlertsProcessed(alertEvent.GetAlertName(), alertEvent.GetSeverity(), "no_team_resolved")
-	} else if len(team.Destinations) == 0 {
-		h.logger.Warn("Alert received for team, but team has no destinations - alert not processed",
-			zap.String("receiver", alertEvent.Receiver),
-			zap.String("status", alertEvent.Status),
-			zap.Int("alerts_count", len(alertEvent.Alerts)),
-			zap.Int("issues_count", len(issues)),
-			zap.String("team", team.Name))
-		h.metrics.IncAlertsProcessed(alertEvent.GetAlertName(), alertEvent.GetSeverity(), "no_destinations")
-	} else {
-		h.logger.Info("Alert processed successfully",
-			zap.String("receiver", alertEvent.Receiver),
-			zap.String("status", alertEvent.Status),
-			zap.Int("alerts_count", len(alertEvent.Alerts)),
-			zap.Int("issues_count", len(issues)),
-			zap.String("team", team.Name))
-		h.metrics.IncAlertsProcessed(alertEvent.GetAlertName(), alertEvent.GetSeverity(), "processed")
-	}
+	h.logger.Info("Alert processed",
+		zap.String("receiver", alertEvent.Receiver),
+		zap.String("status", alertEvent.Status),
+		zap.Int("alerts_count", len(alertEvent.Alerts)),
+		zap.Int("issues_count", len(issues)),
+		zap.Duration("duration", time.Since(start)))
 
 	// Log only essential alert information to avoid memory issues with large alerts
 	h.logger.Debug("Alert details",
@@ -17,6 +17,7 @@ import (
 	config_team "github.com/kubecano/cano-collector/config/team"
 	"github.com/kubecano/cano-collector/config/workflow"
 	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/core/event"
 	"github.com/kubecano/cano-collector/pkg/core/issue"
 	"github.com/kubecano/cano-collector/pkg/metric"
 )
@@ -70,7 +71,7 @@ func setupTestRouter(t *testing.T) alertHandlerTestDeps {
 		Name:         "test-team",
 		Destinations: []string{"test-destination"},
 	}
-	mockTeamResolver.EXPECT().ResolveTeam(gomock.Any()).Return(mockTeam, nil).AnyTimes()
+	mockTeamResolver.EXPECT().ResolveTeamForIssue(gomock.Any()).Return(mockTeam, nil).AnyTimes()
 	mockAlertDispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockWorkflowEngine.EXPECT().SelectWorkflows(gomock.Any()).Return([]*workflow.WorkflowDefinition{}).AnyTimes()
 	mockWorkflowEngine.EXPECT().ExecuteWorkflowsWithEnrichments(gomock.Any(), gomock.Any(), gomock.Any()).Return([]issue.Enrichment{}, nil).AnyTimes()
@@ -204,6 +205,24 @@ func TestAlertHandler_EmptyBody(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "empty JSON body")
 }
 
+func TestAlertHandler_ChunkedBodyTooLarge(t *testing.T) {
+	deps := setupTestRouter(t)
+	defer deps.ctrl.Finish()
+
+	alert := `{"receiver": "test-receiver", "status": "firing", "alerts": []}`
+	req, _ := http.NewRequest(http.MethodPost, "/alert", bytes.NewBufferString(alert))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = -1
+
+	w := httptest.NewRecorder()
+	// the limit of the server, enforced while reading a body of unknown length
+	req.Body = http.MaxBytesReader(w, req.Body, 16)
+	deps.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "request body too large")
+}
+
 func TestAlertHandler_AdditionalFields(t *testing.T) {
 	deps := setupTestRouter(t)
 	defer deps.ctrl.Finish()
@@ -286,7 +305,7 @@ func TestAlertHandler_NoTeamResolved(t *testing.T) {
 	mockMetrics := metric.NewMetricsCollector(mockLogger)
 
 	// Edge case - no team resolved
-	mockTeamResolver.EXPECT().ResolveTeam(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockTeamResolver.EXPECT().ResolveTeamForIssue(gomock.Any()).Return(nil, nil).AnyTimes()
 	mockAlertDispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockWorkflowEngine.EXPECT().SelectWorkflows(gomock.Any()).Return([]*workflow.WorkflowDefinition{}).AnyTimes()
 	mockWorkflowEngine.EXPECT().ExecuteWorkflowsWithEnrichments(gomock.Any(), gomock.Any(), gomock.Any()).Return([]issue.Enrichment{}, nil).AnyTimes()
@@ -354,7 +373,7 @@ func TestAlertHandler_TeamResolutionFailed(t *testing.T) {
 	mockMetrics := metric.NewMetricsCollector(mockLogger)
 
 	// Edge case - team resolution failed
-	mockTeamResolver.EXPECT().ResolveTeam(gomock.Any()).Return(nil, errors.New("team resolution failed"))
+	mockTeamResolver.EXPECT().ResolveTeamForIssue(gomock.Any()).Return(nil, errors.New("team resolution failed"))
 	mockAlertDispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockWorkflowEngine.EXPECT().SelectWorkflows(gomock.Any()).Return([]*workflow.WorkflowDefinition{}).AnyTimes()
 	mockWorkflowEngine.EXPECT().ExecuteWorkflowsWithEnrichments(gomock.Any(), gomock.Any(), gomock.Any()).Return([]issue.Enrichment{}, nil).AnyTimes()
@@ -384,5 +403,141 @@ func TestAlertHandler_TeamResolutionFailed(t *testing.T) {
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Contains(t, w.Body.String(), "failed to resolve team")
+	assert.Contains(t, w.Body.String(), "failed to process issues")
+}
+
+// newShardedAlertHandler serves an alert handler whose sharder keeps the issues accepted by owned
+func newShardedAlertHandler(t *testing.T, owned func(*issue.Issue) bool, forwardErr error) (*gin.Engine, *mocks.MockAlertDispatcherInterface, *mocks.MockWorkflowEngineInterface) {
+	t.Helper()
+	deps := setupTestRouter(t)
+	t.Cleanup(deps.ctrl.Finish)
+
+	dispatcher := mocks.NewMockAlertDispatcherInterface(deps.ctrl)
+	workflowEngine := mocks.NewMockWorkflowEngineInterface(deps.ctrl)
+	sharder := mocks.NewMockSharderInterface(deps.ctrl)
+	sharder.EXPECT().Distribute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, issues []*issue.Issue) ([]*issue.Issue, error) {
+			var local []*issue.Issue
+			for _, issueItem := range issues {
+				if owned(issueItem) {
+					local = append(local, issueItem)
+				}
+			}
+			return local, forwardErr
+		}).Times(1)
+
+	handler := NewAlertHandler(deps.logger, metric.NewMetricsCollector(deps.logger), deps.teamResolver, dispatcher, NewConverter(deps.logger), workflowEngine)
+	handler.SetSharder(sharder)
+
+	r := gin.New()
+	r.POST("/alert", handler.HandleAlert)
+	return r, dispatcher, workflowEngine
+}
+
+func postTwoAlerts(r *gin.Engine) *httptest.ResponseRecorder {
+	alert := template.Data{
+		Receiver: "test-receiver",
+		Status:   "firing",
+		Alerts: []template.Alert{
+			{Status: "firing", Labels: map[string]string{"alertname": "HighCPUUsage"}, StartsAt: time.Now()},
+			{Status: "firing", Labels: map[string]string{"alertname": "HighMemoryUsage"}, StartsAt: time.Now()},
+		},
+	}
+	jsonAlert, _ := json.Marshal(alert)
+	req, _ := http.NewRequest(http.MethodPost, "/alert", bytes.NewBuffer(jsonAlert))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAlertHandler_Sharding_ProcessesOwnedIssues(t *testing.T) {
+	r, dispatcher, workflowEngine := newShardedAlertHandler(t, func(iss *issue.Issue) bool {
+		return iss.AggregationKey == "HighMemoryUsage"
+	}, nil)
+
+	// workflows run with the alert of the remaining issue and none of them matches
+	workflowEngine.EXPECT().SelectWorkflows(gomock.Any()).DoAndReturn(func(e event.WorkflowEvent) []*workflow.WorkflowDefinition {
+		assert.Equal(t, "HighMemoryUsage", e.GetAlertName())
+		return nil
+	}).Times(1)
+	dispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, issues []*issue.Issue, _ *config_team.Team) error {
+			assert.Len(t, issues, 1)
+			assert.Equal(t, "HighMemoryUsage", issues[0].AggregationKey)
+			return nil
+		}).Times(1)
+
+	w := postTwoAlerts(r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "alert processed")
+}
+
+func TestAlertHandler_Sharding_AllIssuesForwarded(t *testing.T) {
+	r, _, _ := newShardedAlertHandler(t, func(*issue.Issue) bool { return false }, nil)
+
+	w := postTwoAlerts(r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "alert forwarded")
+}
+
+func TestAlertHandler_Sharding_OwnerFailed(t *testing.T) {
+	r, dispatcher, workflowEngine := newShardedAlertHandler(t, func(iss *issue.Issue) bool {
+		return iss.AggregationKey == "HighMemoryUsage"
+	}, errors.New("replica 10.0.0.2 failed to process 1 issues, status 500"))
+
+	workflowEngine.EXPECT().SelectWorkflows(gomock.Any()).Return(nil).Times(1)
+	dispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	w := postTwoAlerts(r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "owning replica")
+}
+
+func TestAlertHandler_ResolvesTeamPerIssue(t *testing.T) {
+	deps := setupTestRouter(t)
+	defer deps.ctrl.Finish()
+
+	teams := config_team.TeamsConfig{Teams: []config_team.Team{
+		{Name: "payments", Destinations: []string{"payments-slack"}, Namespaces: []string{"payments"}},
+		{Name: "platform", Destinations: []string{"platform-slack"}},
+	}}
+	resolver := NewTeamResolver(teams, deps.logger, metric.NewMetricsCollector(deps.logger))
+	dispatcher := mocks.NewMockAlertDispatcherInterface(deps.ctrl)
+	dispatched := make(map[string][]string)
+	dispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, issues []*issue.Issue, team *config_team.Team) error {
+			for _, issueItem := range issues {
+				dispatched[team.Name] = append(dispatched[team.Name], issueItem.Subject.Namespace)
+			}
+			return nil
+		}).Times(2)
+
+	handler := NewAlertHandler(deps.logger, metric.NewMetricsCollector(deps.logger), resolver, dispatcher, NewConverter(deps.logger), nil)
+	r := gin.New()
+	r.POST("/alert", handler.HandleAlert)
+
+	// one Alertmanager group with alerts from two namespaces
+	alert := template.Data{
+		Receiver: "test-receiver",
+		Status:   "firing",
+		Alerts: []template.Alert{
+			{Status: "firing", Labels: map[string]string{"alertname": "KubePodCrashLooping", "namespace": "payments"}, StartsAt: time.Now()},
+			{Status: "firing", Labels: map[string]string{"alertname": "KubePodCrashLooping", "namespace": "billing"}, StartsAt: time.Now()},
+			{Status: "firing", Labels: map[string]string{"alertname": "KubePodCrashLooping", "namespace": "payments"}, StartsAt: time.Now()},
+		},
+	}
+	jsonAlert, _ := json.Marshal(alert)
+	req, _ := http.NewRequest(http.MethodPost, "/alert", bytes.NewBuffer(jsonAlert))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, map[string][]string{
+		"payments": {"payments", "payments"},
+		"platform": {"billing"},
+	}, dispatched)
 }
@@ -0,0 +1,95 @@
+package alert
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/kubecano/cano-collector/pkg/core/event"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// ConvertGrafanaEventToIssues converts a Grafana unified alerting webhook payload to a slice of Issues
+func (c *Converter) ConvertGrafanaEventToIssues(grafanaEvent *event.GrafanaEvent) ([]*issue.Issue, error) {
+	if grafanaEvent == nil {
+		return nil, fmt.Errorf("grafana event is nil")
+	}
+
+	if len(grafanaEvent.Alerts) == 0 {
+		return nil, fmt.Errorf("no alerts in grafana event")
+	}
+
+	var issues []*issue.Issue
+	for _, alert := range grafanaEvent.Alerts {
+		iss, err := c.convertGrafanaAlertToIssue(alert)
+		if err != nil {
+			c.logger.Error("Failed to convert grafana alert to issue",
+				zap.Error(err),
+				zap.String("fingerprint", alert.Fingerprint),
+				zap.String("alertname", alert.Labels["alertname"]),
+			)
+			continue
+		}
+		issues = append(issues, iss)
+	}
+
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("no issues created from grafana event")
+	}
+
+	return issues, nil
+}
+
+// convertGrafanaAlertToIssue converts a single GrafanaAlert to Issue.
+// The Alertmanager-compatible part is converted like a Prometheus alert,
+// then Grafana links, query values and the rendered panel image are added.
+func (c *Converter) convertGrafanaAlertToIssue(alert event.GrafanaAlert) (*issue.Issue, error) {
+	iss, err := c.convertPrometheusAlertToIssue(alert.PrometheusAlert)
+	if err != nil {
+		return nil, err
+	}
+
+	iss.Source = issue.SourceGrafana
+
+	if alert.DashboardURL != "" {
+		iss.AddLink(*issue.NewLink("Dashboard", alert.DashboardURL, issue.LinkTypeDashboard))
+	}
+	if alert.PanelURL != "" {
+		iss.AddLink(*issue.NewLink("Panel", alert.PanelURL, issue.LinkTypePanel))
+	}
+	if alert.SilenceURL != "" {
+		iss.AddLink(*issue.NewLink("Silence", alert.SilenceURL, issue.LinkTypeSilence))
+	}
+
+	if len(alert.Values) > 0 {
+		iss.AddEnrichment(*c.createGrafanaValuesEnrichment(alert.Values))
+	}
+
+	if alert.ImageURL != "" {
+		panelImage := issue.NewEnrichmentWithType(issue.EnrichmentTypeGraph, "Panel")
+		panelImage.AddBlock(issue.NewImageBlock(alert.ImageURL, iss.Title))
+		iss.AddEnrichment(*panelImage)
+	}
+
+	return iss, nil
+}
+
+// createGrafanaValuesEnrichment creates a table with the query and expression values that triggered the alert
+func (c *Converter) createGrafanaValuesEnrichment(values map[string]float64) *issue.Enrichment {
+	refIDs := make([]string, 0, len(values))
+	for refID := range values {
+		refIDs = append(refIDs, refID)
+	}
+	sort.Strings(refIDs)
+
+	rows := make([][]string, 0, len(refIDs))
+	for _, refID := range refIDs {
+		rows = append(rows, []string{refID, strconv.FormatFloat(values[refID], 'f', -1, 64)})
+	}
+
+	enrichment := issue.NewEnrichmentWithType(issue.EnrichmentTypeAlertMetadata, "Values")
+	enrichment.AddBlock(issue.NewTableBlock([]string{"query", "value"}, rows, "values", issue.TableBlockFormatVertical))
+	return enrichment
+}
@@ -0,0 +1,128 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/pkg/core/event"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/logger"
+)
+
+func newGrafanaAlert() event.GrafanaAlert {
+	return event.GrafanaAlert{
+		PrometheusAlert: event.PrometheusAlert{
+			Status:       "firing",
+			Fingerprint:  "57c6d9296de2ad39",
+			StartsAt:     time.Now(),
+			GeneratorURL: "https://grafana.example.com/alerting/grafana/abc/view",
+			Labels: map[string]string{
+				"alertname":      "HighCPU",
+				"severity":       "critical",
+				"grafana_folder": "Infra",
+				"pod":            "api-0",
+				"namespace":      "prod",
+			},
+			Annotations: map[string]string{
+				"summary": "CPU above 90%",
+			},
+		},
+		SilenceURL:   "https://grafana.example.com/alerting/silence/new",
+		DashboardURL: "https://grafana.example.com/d/dash",
+		PanelURL:     "https://grafana.example.com/d/dash?viewPanel=2",
+		ImageURL:     "https://grafana.example.com/render/panel.png",
+		Values:       map[string]float64{"C": 1, "B": 93.5},
+	}
+}
+
+func findEnrichment(iss *issue.Issue, enrichmentType issue.EnrichmentType) *issue.Enrichment {
+	for i := range iss.Enrichments {
+		if iss.Enrichments[i].Type == enrichmentType {
+			return &iss.Enrichments[i]
+		}
+	}
+	return nil
+}
+
+func TestConverter_ConvertGrafanaEventToIssues(t *testing.T) {
+	converter := NewConverter(logger.NewLogger("info", "test"))
+
+	issues, err := converter.ConvertGrafanaEventToIssues(&event.GrafanaEvent{
+		Receiver: "cano-collector",
+		Status:   "firing",
+		Alerts:   []event.GrafanaAlert{newGrafanaAlert()},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+
+	iss := issues[0]
+	assert.Equal(t, "CPU above 90%", iss.Title)
+	assert.Equal(t, "HighCPU", iss.AggregationKey)
+	assert.Equal(t, issue.SourceGrafana, iss.Source)
+	assert.Equal(t, issue.SeverityHigh, iss.Severity)
+	assert.Equal(t, "57c6d9296de2ad39", iss.Fingerprint)
+	assert.Equal(t, issue.SubjectTypePod, iss.Subject.SubjectType)
+	assert.Equal(t, "api-0", iss.Subject.Name)
+
+	linkTypes := make(map[issue.LinkType]string)
+	for _, link := range iss.Links {
+		linkTypes[link.Type] = link.URL
+	}
+	assert.Equal(t, "https://grafana.example.com/d/dash", linkTypes[issue.LinkTypeDashboard])
+	assert.Equal(t, "https://grafana.example.com/d/dash?viewPanel=2", linkTypes[issue.LinkTypePanel])
+	assert.Equal(t, "https://grafana.example.com/alerting/silence/new", linkTypes[issue.LinkTypeSilence])
+	assert.Equal(t, "https://grafana.example.com/alerting/grafana/abc/view", linkTypes[issue.LinkTypePrometheusGenerator])
+
+	panel := findEnrichment(iss, issue.EnrichmentTypeGraph)
+	require.NotNil(t, panel)
+	require.Len(t, panel.Blocks, 1)
+	image, ok := panel.Blocks[0].(*issue.ImageBlock)
+	require.True(t, ok)
+	assert.Equal(t, "https://grafana.example.com/render/panel.png", image.URL)
+	assert.Equal(t, "CPU above 90%", image.AltText)
+
+	values := findEnrichment(iss, issue.EnrichmentTypeAlertMetadata)
+	require.NotNil(t, values)
+	table, ok := values.Blocks[0].(*issue.TableBlock)
+	require.True(t, ok)
+	assert.Equal(t, [][]string{{"B", "93.5"}, {"C", "1"}}, table.Rows)
+}
+
+func TestConverter_ConvertGrafanaEventToIssues_WithoutGrafanaExtras(t *testing.T) {
+	converter := NewConverter(logger.NewLogger("info", "test"))
+
+	alert := newGrafanaAlert()
+	alert.SilenceURL = ""
+	alert.DashboardURL = ""
+	alert.PanelURL = ""
+	alert.ImageURL = ""
+	alert.Values = nil
+
+	issues, err := converter.ConvertGrafanaEventToIssues(&event.GrafanaEvent{Alerts: []event.GrafanaAlert{alert}})
+
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Len(t, issues[0].Links, 1)
+	assert.Nil(t, findEnrichment(issues[0], issue.EnrichmentTypeGraph))
+	assert.Nil(t, findEnrichment(issues[0], issue.EnrichmentTypeAlertMetadata))
+}
+
+func TestConverter_ConvertGrafanaEventToIssues_Errors(t *testing.T) {
+	converter := NewConverter(logger.NewLogger("info", "test"))
+
+	_, err := converter.ConvertGrafanaEventToIssues(nil)
+	require.Error(t, err)
+
+	_, err = converter.ConvertGrafanaEventToIssues(&event.GrafanaEvent{})
+	require.Error(t, err)
+
+	alert := newGrafanaAlert()
+	delete(alert.Labels, "alertname")
+	_, err = converter.ConvertGrafanaEventToIssues(&event.GrafanaEvent{Alerts: []event.GrafanaAlert{alert}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no issues created")
+}
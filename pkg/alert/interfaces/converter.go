@@ -8,4 +8,5 @@ import (
 //go:generate mockgen -source=converter.go -destination=../../../mocks/alert_converter_mock.go -package=mocks
 type ConverterInterface interface {
 	ConvertAlertManagerEventToIssues(event *event.AlertManagerEvent) ([]*issuepkg.Issue, error)
+	ConvertGrafanaEventToIssues(event *event.GrafanaEvent) ([]*issuepkg.Issue, error)
 }
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// IssueProcessorInterface defines the interface for routing ready-made issues
+// through team resolution, workflows and dispatching.
+//
+//go:generate mockgen -source=issue_processor.go -destination=../../../mocks/issue_processor_mock.go -package=mocks
+type IssueProcessorInterface interface {
+	ProcessIssues(ctx context.Context, issues []*issuepkg.Issue) error
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	config_team "github.com/kubecano/cano-collector/config/team"
 	"github.com/kubecano/cano-collector/pkg/core/event"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
 	destination_interfaces "github.com/kubecano/cano-collector/pkg/destination/interfaces"
 )
 
@@ -11,5 +12,6 @@ import (
 //go:generate mockgen -source=team_resolver.go -destination=../../../mocks/team_resolver_mock.go -package=mocks
 type TeamResolverInterface interface {
 	ResolveTeam(alert *event.AlertManagerEvent) (*config_team.Team, error)
+	ResolveTeamForIssue(issue *issuepkg.Issue) (*config_team.Team, error)
 	ValidateTeamDestinations(registry destination_interfaces.DestinationRegistryInterface) error
 }
@@ -0,0 +1,177 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	config_team "github.com/kubecano/cano-collector/config/team"
+	alert_interfaces "github.com/kubecano/cano-collector/pkg/alert/interfaces"
+	"github.com/kubecano/cano-collector/pkg/core/event"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	workflow_interfaces "github.com/kubecano/cano-collector/pkg/workflow/interfaces"
+)
+
+// IssueProcessor runs issues through team routing, workflow enrichment and
+// dispatching, grouping the issues of each team into one dispatch
+type IssueProcessor struct {
+	logger          logger_interfaces.LoggerInterface
+	metrics         metric_interfaces.MetricsInterface
+	teamResolver    alert_interfaces.TeamResolverInterface
+	alertDispatcher alert_interfaces.AlertDispatcherInterface
+	workflowEngine  workflow_interfaces.WorkflowEngineInterface
+}
+
+// NewIssueProcessor creates a new issue processor
+func NewIssueProcessor(
+	logger logger_interfaces.LoggerInterface,
+	metrics metric_interfaces.MetricsInterface,
+	teamResolver alert_interfaces.TeamResolverInterface,
+	alertDispatcher alert_interfaces.AlertDispatcherInterface,
+	workflowEngine workflow_interfaces.WorkflowEngineInterface,
+) *IssueProcessor {
+	return &IssueProcessor{
+		logger:          logger,
+		metrics:         metrics,
+		teamResolver:    teamResolver,
+		alertDispatcher: alertDispatcher,
+		workflowEngine:  workflowEngine,
+	}
+}
+
+// teamIssues groups issues resolved to the same team
+type teamIssues struct {
+	team   *config_team.Team
+	issues []*issuepkg.Issue
+}
+
+// ProcessIssues resolves a team for each issue, applies workflow enrichments
+// and dispatches the issues to the team destinations
+func (p *IssueProcessor) ProcessIssues(ctx context.Context, issues []*issuepkg.Issue) error {
+	var errs []error
+	var groups []*teamIssues
+	groupByTeam := make(map[string]*teamIssues)
+
+	for _, issueItem := range issues {
+		start := time.Now()
+
+		team, err := p.teamResolver.ResolveTeamForIssue(issueItem)
+		if err != nil {
+			p.logger.Error("Failed to resolve team for issue",
+				zap.Error(err),
+				zap.String("aggregation_key", issueItem.AggregationKey))
+			p.metrics.IncAlertErrors(issueItem.AggregationKey, "team_resolution_failed")
+			errs = append(errs, fmt.Errorf("failed to resolve team for issue '%s': %w", issueItem.AggregationKey, err))
+			continue
+		}
+
+		p.applyWorkflows(ctx, issueItem)
+
+		workflowCount := 0
+		if team != nil {
+			workflowCount = len(team.Destinations)
+		}
+		p.metrics.ObserveAlertProcessingDuration(issueItem.AggregationKey, workflowCount, time.Since(start))
+
+		if team == nil {
+			p.logger.Warn("Issue received but no team resolved - issue not processed",
+				zap.String("aggregation_key", issueItem.AggregationKey),
+				zap.String("source", issueItem.Source.String()))
+			p.metrics.IncAlertsProcessed(issueItem.AggregationKey, issueItem.Severity.String(), "no_team_resolved")
+			continue
+		}
+
+		group, exists := groupByTeam[team.Name]
+		if !exists {
+			group = &teamIssues{team: team}
+			groupByTeam[team.Name] = group
+			groups = append(groups, group)
+		}
+		group.issues = append(group.issues, issueItem)
+	}
+
+	for _, group := range groups {
+		if err := p.alertDispatcher.DispatchIssues(ctx, group.issues, group.team); err != nil {
+			p.logger.Error("Failed to dispatch issues",
+				zap.Error(err),
+				zap.String("team", group.team.Name),
+				zap.Int("issues_count", len(group.issues)))
+			for _, issueItem := range group.issues {
+				p.metrics.IncAlertErrors(issueItem.AggregationKey, "dispatch_failed")
+			}
+			errs = append(errs, fmt.Errorf("failed to dispatch issues to team '%s': %w", group.team.Name, err))
+			continue
+		}
+
+		outcome := "processed"
+		if len(group.team.Destinations) == 0 {
+			outcome = "no_destinations"
+		}
+		for _, issueItem := range group.issues {
+			p.metrics.IncAlertsProcessed(issueItem.AggregationKey, issueItem.Severity.String(), outcome)
+		}
+
+		p.logger.Info("Issues processed successfully",
+			zap.String("team", group.team.Name),
+			zap.Int("issues_count", len(group.issues)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyWorkflows executes matching workflows for a single issue and attaches the resulting enrichments
+func (p *IssueProcessor) applyWorkflows(ctx context.Context, issueItem *issuepkg.Issue) {
+	if p.workflowEngine == nil {
+		return
+	}
+	applyIssueWorkflows(ctx, p.workflowEngine, issueItem, p.logger)
+}
+
+// ApplyWorkflows runs the workflows matching each issue and adds their enrichments to it. Failing
+// workflows leave the issue unchanged.
+func ApplyWorkflows(
+	ctx context.Context,
+	engine workflow_interfaces.WorkflowEngineInterface,
+	issues []*issuepkg.Issue,
+	logger logger_interfaces.LoggerInterface,
+) {
+	for _, issueItem := range issues {
+		applyIssueWorkflows(ctx, engine, issueItem, logger)
+	}
+}
+
+func applyIssueWorkflows(
+	ctx context.Context,
+	engine workflow_interfaces.WorkflowEngineInterface,
+	issueItem *issuepkg.Issue,
+	logger logger_interfaces.LoggerInterface,
+) {
+	workflowEvent := event.NewWorkflowEventForIssue(issueItem)
+	matchingWorkflows := engine.SelectWorkflows(workflowEvent)
+	if len(matchingWorkflows) == 0 {
+		return
+	}
+
+	logger.Info("Workflow processing for issue",
+		zap.String("aggregation_key", issueItem.AggregationKey),
+		zap.String("source", issueItem.Source.String()),
+		zap.Int("matching_workflows", len(matchingWorkflows)))
+
+	enrichments, err := engine.ExecuteWorkflowsWithEnrichments(ctx, matchingWorkflows, workflowEvent)
+	if err != nil {
+		// Continue processing even if workflows fail for this issue
+		logger.Error("Failed to execute workflows with enrichments for issue",
+			zap.Error(err),
+			zap.String("aggregation_key", issueItem.AggregationKey))
+		return
+	}
+
+	for _, enrichment := range enrichments {
+		issueItem.AddEnrichment(enrichment)
+	}
+}
@@ -0,0 +1,142 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config_team "github.com/kubecano/cano-collector/config/team"
+	"github.com/kubecano/cano-collector/config/workflow"
+	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/core/event"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+type issueProcessorTestDeps struct {
+	ctrl            *gomock.Controller
+	teamResolver    *mocks.MockTeamResolverInterface
+	alertDispatcher *mocks.MockAlertDispatcherInterface
+	workflowEngine  *mocks.MockWorkflowEngineInterface
+	processor       *IssueProcessor
+}
+
+func setupIssueProcessorTest(t *testing.T) issueProcessorTestDeps {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+	mockMetrics.EXPECT().IncAlertErrors(gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().IncAlertsProcessed(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().ObserveAlertProcessingDuration(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockTeamResolver := mocks.NewMockTeamResolverInterface(ctrl)
+	mockAlertDispatcher := mocks.NewMockAlertDispatcherInterface(ctrl)
+	mockWorkflowEngine := mocks.NewMockWorkflowEngineInterface(ctrl)
+
+	processor := NewIssueProcessor(mockLogger, mockMetrics, mockTeamResolver, mockAlertDispatcher, mockWorkflowEngine)
+
+	return issueProcessorTestDeps{
+		ctrl:            ctrl,
+		teamResolver:    mockTeamResolver,
+		alertDispatcher: mockAlertDispatcher,
+		workflowEngine:  mockWorkflowEngine,
+		processor:       processor,
+	}
+}
+
+func TestIssueProcessor_ProcessIssues_AppliesWorkflowsAndDispatches(t *testing.T) {
+	deps := setupIssueProcessorTest(t)
+	defer deps.ctrl.Finish()
+
+	team := &config_team.Team{Name: "ops", Destinations: []string{"slack-ops"}}
+	iss := issuepkg.NewIssue("Build failed", "BuildFailed")
+	iss.Source = issuepkg.SourceWebhook
+
+	workflows := []*workflow.WorkflowDefinition{{Name: "enrich"}}
+	enrichment := *issuepkg.NewEnrichmentWithType(issuepkg.EnrichmentTypeAlertLabels, "Labels")
+
+	deps.teamResolver.EXPECT().ResolveTeamForIssue(iss).Return(team, nil)
+	deps.workflowEngine.EXPECT().SelectWorkflows(gomock.AssignableToTypeOf(&event.IssueWorkflowEvent{})).Return(workflows)
+	deps.workflowEngine.EXPECT().ExecuteWorkflowsWithEnrichments(gomock.Any(), workflows, gomock.Any()).Return([]issuepkg.Enrichment{enrichment}, nil)
+	deps.alertDispatcher.EXPECT().DispatchIssues(gomock.Any(), []*issuepkg.Issue{iss}, team).Return(nil)
+
+	err := deps.processor.ProcessIssues(context.Background(), []*issuepkg.Issue{iss})
+
+	require.NoError(t, err)
+	require.Len(t, iss.Enrichments, 1)
+	assert.Equal(t, "Labels", iss.Enrichments[0].Title)
+}
+
+func TestIssueProcessor_ProcessIssues_NoTeamSkipsDispatch(t *testing.T) {
+	deps := setupIssueProcessorTest(t)
+	defer deps.ctrl.Finish()
+
+	iss := issuepkg.NewIssue("Build failed", "BuildFailed")
+
+	deps.teamResolver.EXPECT().ResolveTeamForIssue(iss).Return(nil, nil)
+	deps.workflowEngine.EXPECT().SelectWorkflows(gomock.Any()).Return(nil)
+	deps.alertDispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := deps.processor.ProcessIssues(context.Background(), []*issuepkg.Issue{iss})
+
+	require.NoError(t, err)
+}
+
+func TestIssueProcessor_ProcessIssues_GroupsIssuesByTeam(t *testing.T) {
+	deps := setupIssueProcessorTest(t)
+	defer deps.ctrl.Finish()
+
+	team := &config_team.Team{Name: "ops", Destinations: []string{"slack-ops"}}
+	first := issuepkg.NewIssue("First", "First")
+	second := issuepkg.NewIssue("Second", "Second")
+
+	deps.teamResolver.EXPECT().ResolveTeamForIssue(gomock.Any()).Return(team, nil).Times(2)
+	deps.workflowEngine.EXPECT().SelectWorkflows(gomock.Any()).Return(nil).Times(2)
+	deps.alertDispatcher.EXPECT().DispatchIssues(gomock.Any(), []*issuepkg.Issue{first, second}, team).Return(nil)
+
+	err := deps.processor.ProcessIssues(context.Background(), []*issuepkg.Issue{first, second})
+
+	require.NoError(t, err)
+}
+
+func TestIssueProcessor_ProcessIssues_Errors(t *testing.T) {
+	t.Run("team resolution failure", func(t *testing.T) {
+		deps := setupIssueProcessorTest(t)
+		defer deps.ctrl.Finish()
+
+		deps.teamResolver.EXPECT().ResolveTeamForIssue(gomock.Any()).Return(nil, errors.New("boom"))
+		deps.alertDispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := deps.processor.ProcessIssues(context.Background(), []*issuepkg.Issue{issuepkg.NewIssue("t", "k")})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to resolve team")
+	})
+
+	t.Run("dispatch failure", func(t *testing.T) {
+		deps := setupIssueProcessorTest(t)
+		defer deps.ctrl.Finish()
+
+		team := &config_team.Team{Name: "ops", Destinations: []string{"slack-ops"}}
+		deps.teamResolver.EXPECT().ResolveTeamForIssue(gomock.Any()).Return(team, nil)
+		deps.workflowEngine.EXPECT().SelectWorkflows(gomock.Any()).Return(nil)
+		deps.alertDispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), team).Return(errors.New("slack down"))
+
+		err := deps.processor.ProcessIssues(context.Background(), []*issuepkg.Issue{issuepkg.NewIssue("t", "k")})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "slack down")
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	config_team "github.com/kubecano/cano-collector/config/team"
 	"github.com/kubecano/cano-collector/pkg/core/event"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
 	destination_interfaces "github.com/kubecano/cano-collector/pkg/destination/interfaces"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
@@ -40,29 +41,86 @@ func (r *TeamResolver) ValidateTeamDestinations(registry destination_interfaces.
 	return nil
 }
 
-// ResolveTeam determines which team should handle the alert
-// For now, returns the first team (default team) as specified in requirements
+// selectTeam returns the first team restricted to namespace or, when there is none, the first
+// team without a namespace restriction
+func (r *TeamResolver) selectTeam(namespace string) *config_team.Team {
+	var fallback *config_team.Team
+	for i := range r.teams.Teams {
+		team := &r.teams.Teams[i]
+		if len(team.Namespaces) == 0 {
+			if fallback == nil {
+				fallback = team
+			}
+			continue
+		}
+		if namespace != "" && team.MatchesNamespace(namespace) {
+			return team
+		}
+	}
+	return fallback
+}
+
+// ResolveTeam determines which team should handle the alert.
+// Teams restricted to the namespace of the alert take precedence over the first unrestricted team.
 func (r *TeamResolver) ResolveTeam(alertEvent *event.AlertManagerEvent) (*config_team.Team, error) {
 	if len(r.teams.Teams) == 0 {
 		r.metrics.IncRoutingDecisions("no_team", "none", "no_teams_configured")
 		return nil, nil // No teams configured
 	}
 
-	// TODO: Implement proper routing logic based on namespace, pod names, etc.
-	// For now, return the first team as the default team
-	defaultTeam := r.teams.Teams[0]
+	// TODO: Implement proper routing logic based on pod names, etc.
+	selected := r.selectTeam(alertEvent.GetNamespace())
+	if selected == nil {
+		r.metrics.IncRoutingDecisions("no_team", "none", "no_matching_team")
+		return nil, nil
+	}
+	team := *selected
 	r.logger.Info("Resolved team for alert",
-		zap.String("team", defaultTeam.Name),
-		zap.Strings("destinations", defaultTeam.Destinations),
+		zap.String("team", team.Name),
+		zap.Strings("destinations", team.Destinations),
 		zap.String("alert_name", alertEvent.GetAlertName()))
 
 	// Record team matching metrics
-	r.metrics.IncTeamsMatched(defaultTeam.Name, alertEvent.GetAlertName())
+	r.metrics.IncTeamsMatched(team.Name, alertEvent.GetAlertName())
 
 	// Record routing decision metrics based on team's destinations
-	for range defaultTeam.Destinations {
-		r.metrics.IncRoutingDecisions(defaultTeam.Name, "unknown", "routed") // TODO: Get actual destination type
+	for range team.Destinations {
+		r.metrics.IncRoutingDecisions(team.Name, "unknown", "routed") // TODO: Get actual destination type
+	}
+
+	return &team, nil
+}
+
+// ResolveTeamForIssue determines which team should handle an issue. Uses the same
+// routing as ResolveTeam with the namespace of the issue subject, so each alert of
+// an Alertmanager group is routed by its own namespace.
+func (r *TeamResolver) ResolveTeamForIssue(iss *issuepkg.Issue) (*config_team.Team, error) {
+	if len(r.teams.Teams) == 0 {
+		r.metrics.IncRoutingDecisions("no_team", "none", "no_teams_configured")
+		return nil, nil // No teams configured
+	}
+
+	namespace := ""
+	if iss.Subject != nil {
+		namespace = iss.Subject.Namespace
+	}
+	selected := r.selectTeam(namespace)
+	if selected == nil {
+		r.metrics.IncRoutingDecisions("no_team", "none", "no_matching_team")
+		return nil, nil
+	}
+	team := *selected
+	r.logger.Info("Resolved team for issue",
+		zap.String("team", team.Name),
+		zap.Strings("destinations", team.Destinations),
+		zap.String("aggregation_key", iss.AggregationKey),
+		zap.String("source", iss.Source.String()))
+
+	r.metrics.IncTeamsMatched(team.Name, iss.AggregationKey)
+
+	for range team.Destinations {
+		r.metrics.IncRoutingDecisions(team.Name, "unknown", "routed")
 	}
 
-	return &defaultTeam, nil
+	return &team, nil
 }
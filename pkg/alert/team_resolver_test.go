@@ -11,6 +11,7 @@ import (
 	config_team "github.com/kubecano/cano-collector/config/team"
 	"github.com/kubecano/cano-collector/mocks"
 	"github.com/kubecano/cano-collector/pkg/core/event"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
 )
 
 type teamResolverTestDeps struct {
@@ -255,3 +256,78 @@ func TestTeamResolver_ResolveTeam_LoggingVerification(t *testing.T) {
 	assert.NotNil(t, team)
 	assert.Equal(t, "default-team", team.Name)
 }
+
+func TestTeamResolver_ResolveTeamForIssue_DefaultTeam(t *testing.T) {
+	teams := config_team.TeamsConfig{
+		Teams: []config_team.Team{
+			{Name: "team-1", Destinations: []string{"slack-1"}},
+			{Name: "team-2", Destinations: []string{"slack-2"}},
+		},
+	}
+	deps := setupTeamResolverTest(t, teams)
+	defer deps.ctrl.Finish()
+
+	iss := issuepkg.NewIssue("Build failed", "BuildFailed")
+	iss.Source = issuepkg.SourceWebhook
+
+	team, err := deps.resolver.ResolveTeamForIssue(iss)
+	require.NoError(t, err)
+	require.NotNil(t, team)
+	assert.Equal(t, "team-1", team.Name)
+	assert.Equal(t, []string{"slack-1"}, team.Destinations)
+}
+
+func TestTeamResolver_ResolveTeamForIssue_NoTeams(t *testing.T) {
+	deps := setupTeamResolverTest(t, config_team.TeamsConfig{})
+	defer deps.ctrl.Finish()
+
+	team, err := deps.resolver.ResolveTeamForIssue(issuepkg.NewIssue("Build failed", "BuildFailed"))
+	require.NoError(t, err)
+	assert.Nil(t, team)
+}
+
+func TestTeamResolver_NamespacedTeams(t *testing.T) {
+	teams := config_team.TeamsConfig{
+		Teams: []config_team.Team{
+			{Name: "platform", Destinations: []string{"slack-platform"}},
+			{Name: "payments/oncall", Destinations: []string{"payments/slack"}, Namespaces: []string{"payments"}},
+		},
+	}
+	deps := setupTeamResolverTest(t, teams)
+	defer deps.ctrl.Finish()
+
+	alert := createTestAlertManagerEventForTeamResolver()
+	alert.Alerts[0].Labels["namespace"] = "payments"
+	team, err := deps.resolver.ResolveTeam(alert)
+	require.NoError(t, err)
+	require.NotNil(t, team)
+	assert.Equal(t, "payments/oncall", team.Name)
+
+	// alerts from other namespaces never reach a namespaced team
+	alert.Alerts[0].Labels["namespace"] = "checkout"
+	team, err = deps.resolver.ResolveTeam(alert)
+	require.NoError(t, err)
+	require.NotNil(t, team)
+	assert.Equal(t, "platform", team.Name)
+
+	iss := issuepkg.NewIssue("Build failed", "BuildFailed")
+	iss.Subject = &issuepkg.Subject{Name: "api", Namespace: "payments"}
+	team, err = deps.resolver.ResolveTeamForIssue(iss)
+	require.NoError(t, err)
+	require.NotNil(t, team)
+	assert.Equal(t, "payments/oncall", team.Name)
+}
+
+func TestTeamResolver_OnlyNamespacedTeams(t *testing.T) {
+	teams := config_team.TeamsConfig{
+		Teams: []config_team.Team{
+			{Name: "payments/oncall", Destinations: []string{"payments/slack"}, Namespaces: []string{"payments"}},
+		},
+	}
+	deps := setupTeamResolverTest(t, teams)
+	defer deps.ctrl.Finish()
+
+	team, err := deps.resolver.ResolveTeamForIssue(issuepkg.NewIssue("Build failed", "BuildFailed"))
+	require.NoError(t, err)
+	assert.Nil(t, team)
+}
@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/kubecano/cano-collector/config"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+)
+
+// Rejection reasons reported in the cano_auth_rejections_total metric
+const (
+	reasonMissingCredentials = "missing_credentials"
+	reasonInvalidBearerToken = "invalid_bearer_token"
+	reasonInvalidBasicAuth   = "invalid_basic_auth"
+	reasonInvalidSignature   = "invalid_signature"
+	reasonInvalidClientCert  = "invalid_client_certificate"
+)
+
+const hmacSignaturePrefix = "sha256="
+
+// Authenticator authenticates requests with any of the configured methods:
+// static bearer tokens, basic auth, HMAC-SHA256 body signatures and TLS client certificates.
+// A request is accepted as soon as one configured method succeeds.
+type Authenticator struct {
+	logger             logger_interfaces.LoggerInterface
+	metrics            metric_interfaces.MetricsInterface
+	bearerTokens       [][]byte
+	basicAuthUsers     map[string][]byte
+	hmacSecret         []byte
+	hmacHeader         string
+	clientCAs          *x509.CertPool
+	allowedClientNames []string
+}
+
+// NewAuthenticator loads the credentials referenced by the auth configuration
+func NewAuthenticator(
+	cfg config.AuthConfig,
+	logger logger_interfaces.LoggerInterface,
+	metrics metric_interfaces.MetricsInterface,
+) (*Authenticator, error) {
+	a := &Authenticator{
+		logger:             logger,
+		metrics:            metrics,
+		hmacHeader:         cfg.HMACHeader,
+		allowedClientNames: cfg.AllowedClientNames,
+	}
+
+	var err error
+	if cfg.BearerTokensFile != "" {
+		if a.bearerTokens, err = loadBearerTokens(cfg.BearerTokensFile); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.BasicAuthFile != "" {
+		if a.basicAuthUsers, err = loadBasicAuthUsers(cfg.BasicAuthFile); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.HMACSecretFile != "" {
+		if a.hmacSecret, err = loadHMACSecret(cfg.HMACSecretFile); err != nil {
+			return nil, err
+		}
+		if a.hmacHeader == "" {
+			a.hmacHeader = "X-Cano-Signature"
+		}
+	}
+	if cfg.ClientCAFile != "" {
+		if a.clientCAs, err = loadClientCAs(cfg.ClientCAFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+// Enabled reports whether at least one authentication method is configured
+func (a *Authenticator) Enabled() bool {
+	return len(a.bearerTokens) > 0 || len(a.basicAuthUsers) > 0 || len(a.hmacSecret) > 0 || a.clientCAs != nil
+}
+
+// Methods returns the names of the configured authentication methods
+func (a *Authenticator) Methods() []string {
+	var methods []string
+	if len(a.bearerTokens) > 0 {
+		methods = append(methods, "bearer")
+	}
+	if len(a.basicAuthUsers) > 0 {
+		methods = append(methods, "basic")
+	}
+	if len(a.hmacSecret) > 0 {
+		methods = append(methods, "hmac")
+	}
+	if a.clientCAs != nil {
+		methods = append(methods, "mtls")
+	}
+	return methods
+}
+
+// Middleware returns a gin middleware rejecting unauthenticated requests with 401
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.Enabled() {
+			c.Next()
+			return
+		}
+
+		if ok, reason := a.authenticate(c); !ok {
+			a.reject(c, reason)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authenticate checks the request against every configured method.
+// The returned reason describes the first failure when no method succeeded.
+func (a *Authenticator) authenticate(c *gin.Context) (bool, string) {
+	reason := reasonMissingCredentials
+
+	if a.clientCAs != nil && c.Request.TLS != nil && len(c.Request.TLS.PeerCertificates) > 0 {
+		if a.verifyClientCertificate(c.Request) {
+			return true, ""
+		}
+		// A presented but untrusted certificate is never overridden by other credentials
+		return false, reasonInvalidClientCert
+	}
+
+	if authorization := c.GetHeader("Authorization"); authorization != "" {
+		scheme, credentials, _ := strings.Cut(authorization, " ")
+		switch {
+		case strings.EqualFold(scheme, "Bearer") && len(a.bearerTokens) > 0:
+			if a.verifyBearerToken(strings.TrimSpace(credentials)) {
+				return true, ""
+			}
+			reason = reasonInvalidBearerToken
+		case strings.EqualFold(scheme, "Basic") && len(a.basicAuthUsers) > 0:
+			if a.verifyBasicAuth(c.Request) {
+				return true, ""
+			}
+			reason = reasonInvalidBasicAuth
+		}
+	}
+
+	if len(a.hmacSecret) > 0 {
+		if signature := c.GetHeader(a.hmacHeader); signature != "" {
+			if a.verifySignature(c, signature) {
+				return true, ""
+			}
+			if reason == reasonMissingCredentials {
+				reason = reasonInvalidSignature
+			}
+		}
+	}
+
+	return false, reason
+}
+
+func (a *Authenticator) verifyBearerToken(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	matched := 0
+	for _, expected := range a.bearerTokens {
+		matched |= subtle.ConstantTimeCompare([]byte(token), expected)
+	}
+	return matched == 1
+}
+
+func (a *Authenticator) verifyBasicAuth(r *http.Request) bool {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	expected, exists := a.basicAuthUsers[username]
+	if !exists {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(password), expected) == 1
+}
+
+// verifySignature checks an HMAC-SHA256 signature of the raw request body, hex encoded
+// and optionally prefixed with "sha256=". The body is restored for the handlers.
+func (a *Authenticator) verifySignature(c *gin.Context, signature string) bool {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return false
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	provided, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), hmacSignaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, a.hmacSecret)
+	mac.Write(body)
+	return hmac.Equal(provided, mac.Sum(nil))
+}
+
+func (a *Authenticator) verifyClientCertificate(r *http.Request) bool {
+	certs := r.TLS.PeerCertificates
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         a.clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return false
+	}
+
+	if len(a.allowedClientNames) == 0 {
+		return true
+	}
+	if slices.Contains(a.allowedClientNames, certs[0].Subject.CommonName) {
+		return true
+	}
+	for _, name := range certs[0].DNSNames {
+		if slices.Contains(a.allowedClientNames, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Authenticator) reject(c *gin.Context, reason string) {
+	path := c.FullPath()
+	if path == "" {
+		path = c.Request.URL.Path
+	}
+
+	a.logger.Warn("Rejected unauthenticated request",
+		zap.String("path", path),
+		zap.String("reason", reason),
+		zap.String("client_ip", c.ClientIP()))
+	a.metrics.IncAuthRejections(path, reason)
+
+	if len(a.bearerTokens) > 0 {
+		c.Header("WWW-Authenticate", `Bearer realm="cano-collector"`)
+	} else if len(a.basicAuthUsers) > 0 {
+		c.Header("WWW-Authenticate", `Basic realm="cano-collector"`)
+	}
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+}
@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/config"
+	"github.com/kubecano/cano-collector/mocks"
+)
+
+type authTestDeps struct {
+	metrics *mocks.MockMetricsInterface
+	router  *gin.Engine
+}
+
+func setupAuthTest(t *testing.T, cfg config.AuthConfig) authTestDeps {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+
+	authenticator, err := NewAuthenticator(cfg, mockLogger, mockMetrics)
+	require.NoError(t, err)
+
+	r := gin.New()
+	api := r.Group("/api")
+	api.Use(authenticator.Middleware())
+	api.POST("/alerts", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+
+	return authTestDeps{metrics: mockMetrics, router: r}
+}
+
+func postAlert(router *gin.Engine, body string, modify func(r *http.Request)) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/alerts", bytes.NewBufferString(body))
+	if modify != nil {
+		modify(req)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthenticator_Disabled(t *testing.T) {
+	deps := setupAuthTest(t, config.AuthConfig{})
+
+	w := postAlert(deps.router, "payload", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthenticator_BearerToken(t *testing.T) {
+	deps := setupAuthTest(t, config.AuthConfig{
+		BearerTokensFile: writeSecretFile(t, "tokens", "token-a\ntoken-b\n"),
+	})
+
+	w := postAlert(deps.router, "payload", func(r *http.Request) { r.Header.Set("Authorization", "Bearer token-b") })
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "payload", w.Body.String())
+
+	deps.metrics.EXPECT().IncAuthRejections("/api/alerts", "invalid_bearer_token")
+	w = postAlert(deps.router, "payload", func(r *http.Request) { r.Header.Set("Authorization", "Bearer wrong") })
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="cano-collector"`, w.Header().Get("WWW-Authenticate"))
+
+	deps.metrics.EXPECT().IncAuthRejections("/api/alerts", "missing_credentials")
+	w = postAlert(deps.router, "payload", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthenticator_BasicAuth(t *testing.T) {
+	deps := setupAuthTest(t, config.AuthConfig{
+		BasicAuthFile: writeSecretFile(t, "users", "alertmanager:s3cret\n"),
+	})
+
+	w := postAlert(deps.router, "payload", func(r *http.Request) { r.SetBasicAuth("alertmanager", "s3cret") })
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	deps.metrics.EXPECT().IncAuthRejections("/api/alerts", "invalid_basic_auth").Times(2)
+	w = postAlert(deps.router, "payload", func(r *http.Request) { r.SetBasicAuth("alertmanager", "wrong") })
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Basic realm="cano-collector"`, w.Header().Get("WWW-Authenticate"))
+
+	w = postAlert(deps.router, "payload", func(r *http.Request) { r.SetBasicAuth("unknown", "s3cret") })
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthenticator_HMACSignature(t *testing.T) {
+	deps := setupAuthTest(t, config.AuthConfig{
+		HMACSecretFile: writeSecretFile(t, "hmac", "shared-secret"),
+		HMACHeader:     "X-Cano-Signature",
+	})
+
+	body := `{"title": "Backup failed"}`
+	mac := hmac.New(sha256.New, []byte("shared-secret"))
+	mac.Write([]byte(body))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	w := postAlert(deps.router, body, func(r *http.Request) { r.Header.Set("X-Cano-Signature", "sha256="+signature) })
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, body, w.Body.String(), "body must be available to the handler after verification")
+
+	w = postAlert(deps.router, body, func(r *http.Request) { r.Header.Set("X-Cano-Signature", signature) })
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	deps.metrics.EXPECT().IncAuthRejections("/api/alerts", "invalid_signature").Times(2)
+	w = postAlert(deps.router, body+" ", func(r *http.Request) { r.Header.Set("X-Cano-Signature", "sha256="+signature) })
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = postAlert(deps.router, body, func(r *http.Request) { r.Header.Set("X-Cano-Signature", "not-hex") })
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthenticator_AnyConfiguredMethodIsAccepted(t *testing.T) {
+	deps := setupAuthTest(t, config.AuthConfig{
+		BearerTokensFile: writeSecretFile(t, "tokens", "token-a"),
+		BasicAuthFile:    writeSecretFile(t, "users", "grafana:pass"),
+	})
+
+	w := postAlert(deps.router, "payload", func(r *http.Request) { r.Header.Set("Authorization", "Bearer token-a") })
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = postAlert(deps.router, "payload", func(r *http.Request) { r.SetBasicAuth("grafana", "pass") })
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNewAuthenticator_InvalidSecretFile(t *testing.T) {
+	_, err := NewAuthenticator(config.AuthConfig{BearerTokensFile: "/nonexistent/tokens"}, nil, nil)
+	require.Error(t, err)
+}
+
+// testCA is a self-signed CA issuing client certificates for mTLS tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (ca *testCA) issueClientCert(t *testing.T, commonName string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestAuthenticator_ClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+
+	deps := setupAuthTest(t, config.AuthConfig{
+		ClientCAFile:       writeSecretFile(t, "ca.crt", string(ca.pem)),
+		AllowedClientNames: []string{"alertmanager"},
+	})
+
+	withCert := func(cert *x509.Certificate) func(r *http.Request) {
+		return func(r *http.Request) {
+			r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		}
+	}
+
+	w := postAlert(deps.router, "payload", withCert(ca.issueClientCert(t, "alertmanager")))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	deps.metrics.EXPECT().IncAuthRejections("/api/alerts", "invalid_client_certificate").Times(2)
+	w = postAlert(deps.router, "payload", withCert(ca.issueClientCert(t, "someone-else")))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = postAlert(deps.router, "payload", withCert(otherCA.issueClientCert(t, "alertmanager")))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	deps.metrics.EXPECT().IncAuthRejections("/api/alerts", "missing_credentials")
+	w = postAlert(deps.router, "payload", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// AuthenticatorInterface authenticates requests to the ingestion endpoints.
+//
+//go:generate mockgen -source=auth.go -destination=../../../mocks/authenticator_mock.go -package=mocks
+type AuthenticatorInterface interface {
+	// Middleware returns a gin middleware rejecting unauthenticated requests
+	Middleware() gin.HandlerFunc
+	// Enabled reports whether at least one authentication method is configured
+	Enabled() bool
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// readSecretLines reads a mounted secret file, skipping blank lines and # comments
+func readSecretLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret file %s: %w", path, err)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read secret file %s: %w", path, err)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("secret file %s is empty", path)
+	}
+
+	return lines, nil
+}
+
+// loadBearerTokens loads one token per line
+func loadBearerTokens(path string) ([][]byte, error) {
+	lines, err := readSecretLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([][]byte, 0, len(lines))
+	for _, line := range lines {
+		tokens = append(tokens, []byte(line))
+	}
+	return tokens, nil
+}
+
+// loadBasicAuthUsers loads one "username:password" pair per line
+func loadBasicAuthUsers(path string) (map[string][]byte, error) {
+	lines, err := readSecretLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string][]byte, len(lines))
+	for i, line := range lines {
+		username, password, found := strings.Cut(line, ":")
+		if !found || username == "" || password == "" {
+			// Never include the line itself, it contains the password
+			return nil, fmt.Errorf("secret file %s: entry %d is not in username:password format", path, i+1)
+		}
+		users[username] = []byte(password)
+	}
+	return users, nil
+}
+
+// loadHMACSecret loads the shared signing secret
+func loadHMACSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret file %s: %w", path, err)
+	}
+
+	secret := bytes.TrimSpace(data)
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("secret file %s is empty", path)
+	}
+	return secret, nil
+}
+
+// loadClientCAs loads the PEM bundle used to verify client certificates
+func loadClientCAs(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file %s: %w", path, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("client CA file %s contains no PEM certificates", path)
+	}
+	return pool, nil
+}
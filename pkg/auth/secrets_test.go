@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSecretFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadBearerTokens(t *testing.T) {
+	path := writeSecretFile(t, "tokens", "# alertmanager\ntoken-a\n\n  token-b  \n")
+
+	tokens, err := loadBearerTokens(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("token-a"), []byte("token-b")}, tokens)
+}
+
+func TestLoadBearerTokens_Errors(t *testing.T) {
+	_, err := loadBearerTokens(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	_, err = loadBearerTokens(writeSecretFile(t, "tokens", "# only a comment\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is empty")
+}
+
+func TestLoadBasicAuthUsers(t *testing.T) {
+	users, err := loadBasicAuthUsers(writeSecretFile(t, "users", "alertmanager:s3cr:et\ngrafana:pass\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("s3cr:et"), users["alertmanager"])
+	assert.Equal(t, []byte("pass"), users["grafana"])
+}
+
+func TestLoadBasicAuthUsers_InvalidEntryDoesNotLeakPassword(t *testing.T) {
+	_, err := loadBasicAuthUsers(writeSecretFile(t, "users", "alertmanager:ok\nsupersecretwithoutuser\n"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "entry 2")
+	assert.NotContains(t, err.Error(), "supersecret")
+}
+
+func TestLoadHMACSecret(t *testing.T) {
+	secret, err := loadHMACSecret(writeSecretFile(t, "hmac", "shared-secret\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("shared-secret"), secret)
+
+	_, err = loadHMACSecret(writeSecretFile(t, "hmac", "\n"))
+	require.Error(t, err)
+}
+
+func TestLoadClientCAs(t *testing.T) {
+	_, err := loadClientCAs(writeSecretFile(t, "ca.crt", "not a certificate"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no PEM certificates")
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/kubecano/cano-collector/pkg/logger"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/metric"
+	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+)
+
+// Exit codes of the subcommands
+const (
+	ExitOK = 0
+	// ExitFailure is returned when the configuration is invalid or a command fails
+	ExitFailure = 1
+	// ExitUsage is returned for unknown commands and invalid flags
+	ExitUsage = 2
+)
+
+const usage = `Usage: cano-collector [command] [flags]
+
+Commands:
+  serve      Run the collector (default)
+  validate   Validate destinations, teams and workflows
+  render     Render the issues and Slack messages of an Alertmanager webhook payload
+  schema     Print the JSON Schemas of the configuration files
+
+Run 'cano-collector <command> -h' for the flags of a command.
+`
+
+// Run runs the subcommand named by args[0] and returns its exit code
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return ExitUsage
+	}
+
+	switch args[0] {
+	case "validate":
+		return runValidate(args[1:], stdout, stderr)
+	case "render":
+		return runRender(args[1:], stdout, stderr)
+	case "schema":
+		return runSchema(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return ExitOK
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return ExitUsage
+	}
+}
+
+// newLogger logs warnings and errors to stderr, so stdout only contains the command output
+func newLogger() logger_interfaces.LoggerInterface {
+	return logger.NewStderrLogger("warn", "production")
+}
+
+var (
+	metricsOnce sync.Once
+	metrics     metric_interfaces.MetricsInterface
+)
+
+// newMetrics returns the metrics of the process; the collectors register globally, so they are
+// created only once
+func newMetrics(log logger_interfaces.LoggerInterface) metric_interfaces.MetricsInterface {
+	metricsOnce.Do(func() {
+		metrics = metric.NewMetricsCollector(log)
+	})
+	return metrics
+}
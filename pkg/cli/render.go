@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prometheus/alertmanager/template"
+	slackapi "github.com/slack-go/slack"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubecano/cano-collector/config"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+	"github.com/kubecano/cano-collector/pkg/alert"
+	"github.com/kubecano/cano-collector/pkg/core/event"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	slacksender "github.com/kubecano/cano-collector/pkg/sender/slack"
+	"github.com/kubecano/cano-collector/pkg/workflow"
+	"github.com/kubecano/cano-collector/pkg/workflow/actions"
+)
+
+type renderOptions struct {
+	alertPath     string
+	workflowsPath string
+	clusterName   string
+	slackChannel  string
+}
+
+// renderedIssue is the output for one alert of the payload
+type renderedIssue struct {
+	Issue *issuepkg.Issue `json:"issue"`
+	Slack slackapi.Msg    `json:"slack"`
+}
+
+func runRender(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var opts renderOptions
+	flags.StringVar(&opts.alertPath, "alert", "", "path of an Alertmanager webhook payload (required)")
+	flags.StringVar(&opts.workflowsPath, "workflows", "", "path of a workflows file to run against a fake cluster")
+	flags.StringVar(&opts.clusterName, "cluster-name", "render", "cluster name of the rendered issues")
+	flags.StringVar(&opts.slackChannel, "slack-channel", "#alerts", "channel of the rendered Slack messages")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+	if opts.alertPath == "" {
+		fmt.Fprintln(stderr, "render: -alert is required")
+		flags.Usage()
+		return ExitUsage
+	}
+
+	rendered, err := render(context.Background(), opts, newLogger())
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+
+	if err := encodeJSON(stdout, rendered); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// render converts the alerts of the payload to issues, runs the workflows against a fake cluster
+// that contains the pods named by the alerts and builds the Slack message of every issue.
+// Nothing is sent.
+func render(ctx context.Context, opts renderOptions, log logger_interfaces.LoggerInterface) ([]renderedIssue, error) {
+	data, err := os.ReadFile(opts.alertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert: %w", err)
+	}
+	var payload template.Data
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse alert: %w", err)
+	}
+	alertEvent := event.NewAlertManagerEvent(payload)
+	if err := alertEvent.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid alert: %w", err)
+	}
+
+	converter := alert.NewConverterWithConfig(log, config.Config{
+		ClusterName: opts.clusterName,
+		Enrichment:  config.LoadEnrichmentConfig(),
+	})
+	issues, err := converter.ConvertAlertManagerEventToIssues(alertEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert alert: %w", err)
+	}
+
+	if opts.workflowsPath != "" {
+		workflows, err := config_workflow.LoadWorkflowConfig(opts.workflowsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflows: %w", err)
+		}
+		metrics := newMetrics(log)
+		registry := actions.NewDefaultActionRegistry(log, metrics)
+		kubeClient := actions.NewKubernetesClient(fake.NewClientset(podsOf(alertEvent.Alerts)...), log)
+		if err := actions.RegisterDefaultActions(registry, log, metrics, kubeClient); err != nil {
+			return nil, fmt.Errorf("failed to register workflow actions: %w", err)
+		}
+		engine := workflow.NewWorkflowEngine(workflows, actions.NewDefaultActionExecutor(registry, log, metrics), log, metrics)
+		if err := engine.PrepareActions(); err != nil {
+			return nil, fmt.Errorf("failed to create workflow actions: %w", err)
+		}
+		alert.ApplyWorkflows(ctx, engine, issues, log)
+	}
+
+	sender := slacksender.NewSenderSlack("", opts.slackChannel, true, log, nil)
+	rendered := make([]renderedIssue, 0, len(issues))
+	for _, issue := range issues {
+		rendered = append(rendered, renderedIssue{Issue: issue, Slack: sender.BuildMessage(issue)})
+	}
+	return rendered, nil
+}
+
+// podsOf returns a running pod for every namespace and pod label of alerts, so actions that read
+// pods or their logs have something to read
+func podsOf(alerts []event.PrometheusAlert) []runtime.Object {
+	seen := make(map[string]bool)
+	var pods []runtime.Object
+	for _, a := range alerts {
+		namespace, name := a.Labels["namespace"], a.Labels["pod"]
+		if namespace == "" || name == "" || seen[namespace+"/"+name] {
+			continue
+		}
+		seen[namespace+"/"+name] = true
+
+		container := a.Labels["container"]
+		if container == "" {
+			container = name
+		}
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: container}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  container,
+					Ready: true,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}},
+			},
+		})
+	}
+	return pods
+}
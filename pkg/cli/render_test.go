@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type renderOutput struct {
+	Issue struct {
+		Title       string `json:"title"`
+		ClusterName string `json:"cluster_name"`
+		Enrichments []struct {
+			Title string `json:"title"`
+		} `json:"enrichments"`
+	} `json:"issue"`
+	Slack struct {
+		Channel string            `json:"channel"`
+		Blocks  []json.RawMessage `json:"blocks"`
+	} `json:"slack"`
+}
+
+func TestRun_Render(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"render", "-alert", "testdata/alert.json", "-cluster-name", "prod", "-slack-channel", "#oncall"}, &stdout, &stderr)
+
+	require.Equal(t, ExitOK, code, stderr.String())
+	var out []renderOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out, 1)
+	assert.Equal(t, "prod", out[0].Issue.ClusterName)
+	assert.Equal(t, "#oncall", out[0].Slack.Channel)
+	assert.NotEmpty(t, out[0].Slack.Blocks)
+	for _, enrichment := range out[0].Issue.Enrichments {
+		assert.NotContains(t, enrichment.Title, "Pod Logs")
+	}
+}
+
+func TestRun_RenderWithWorkflows(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"render", "-alert", "testdata/alert.json", "-workflows", "testdata/workflows.yaml"}, &stdout, &stderr)
+
+	require.Equal(t, ExitOK, code, stderr.String())
+	var out []renderOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out, 1)
+	// the pod logs action reads the logs of the pod from the fake cluster
+	titles := make([]string, 0, len(out[0].Issue.Enrichments))
+	for _, enrichment := range out[0].Issue.Enrichments {
+		titles = append(titles, enrichment.Title)
+	}
+	assert.Contains(t, titles, "Pod Logs: default/api-7d9f8b6c5-x2k4q (api)")
+}
+
+func TestRun_RenderInvalidAlert(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"render", "-alert", writeFile(t, `{"receiver": "cano-collector", "status": "firing", "alerts": []}`)}, &stdout, &stderr)
+
+	assert.Equal(t, ExitFailure, code)
+	assert.True(t, strings.HasPrefix(stderr.String(), "error: invalid alert"), stderr.String())
+	assert.Empty(t, stdout.String())
+}
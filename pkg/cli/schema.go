@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kubecano/cano-collector/config"
+	"github.com/kubecano/cano-collector/pkg/schema"
+	"github.com/kubecano/cano-collector/pkg/workflow/actions"
+)
+
+func runSchema(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	outDir := flags.String("out", "", "directory to write every schema to as <name>.schema.json")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: cano-collector schema [-out dir] [destinations|teams|workflows]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	schemas, err := configSchemas()
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+
+	if *outDir != "" {
+		for name, s := range schemas {
+			path := filepath.Join(*outDir, name+".schema.json")
+			if err := writeSchema(path, s); err != nil {
+				fmt.Fprintf(stderr, "error: %v\n", err)
+				return ExitFailure
+			}
+			fmt.Fprintln(stdout, path)
+		}
+		return ExitOK
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return ExitUsage
+	}
+	s, ok := schemas[flags.Arg(0)]
+	if !ok {
+		names := make([]string, 0, len(schemas))
+		for name := range schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(stderr, "unknown schema %q, must be one of: %s\n", flags.Arg(0), strings.Join(names, ", "))
+		return ExitUsage
+	}
+	if err := encodeJSON(stdout, s); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// configSchemas returns the schemas served by the collector, including the parameters of the
+// built-in actions
+func configSchemas() (map[string]*schema.Schema, error) {
+	log := newLogger()
+	metrics := newMetrics(log)
+	registry := actions.NewDefaultActionRegistry(log, metrics)
+	if err := actions.RegisterDefaultActions(registry, log, metrics, actions.NewPlaceholderKubernetesClient(log)); err != nil {
+		return nil, fmt.Errorf("failed to register workflow actions: %w", err)
+	}
+	return config.Schemas(registry.ParameterSchemas()), nil
+}
+
+func writeSchema(path string, s *schema.Schema) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create schema file: %w", err)
+	}
+	if err := encodeJSON(file, s); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func encodeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Schema(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"schema", "workflows"}, &stdout, &stderr)
+
+	require.Equal(t, ExitOK, code, stderr.String())
+	var out struct {
+		Title string `json:"title"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, "cano-collector workflows", out.Title)
+	// the parameters of the built-in actions are part of the workflows schema
+	assert.Contains(t, stdout.String(), `"const": "pod_logs"`)
+	assert.Contains(t, stdout.String(), `"tail_lines"`)
+}
+
+func TestRun_SchemaOut(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	dir := t.TempDir()
+
+	code := Run([]string{"schema", "-out", dir}, &stdout, &stderr)
+
+	require.Equal(t, ExitOK, code, stderr.String())
+	for _, name := range []string{"destinations", "teams", "workflows"} {
+		data, err := os.ReadFile(filepath.Join(dir, name+".schema.json"))
+		require.NoError(t, err)
+		assert.True(t, json.Valid(data), name)
+	}
+}
+
+func TestRun_SchemaUnknown(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run([]string{"schema", "alerts"}, &stdout, &stderr)
+
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr.String(), `unknown schema "alerts", must be one of: destinations, teams, workflows`)
+}
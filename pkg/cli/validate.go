@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kubecano/cano-collector/config"
+	config_destination "github.com/kubecano/cano-collector/config/destination"
+	config_team "github.com/kubecano/cano-collector/config/team"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+	"github.com/kubecano/cano-collector/pkg/alert"
+	"github.com/kubecano/cano-collector/pkg/destination"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/workflow"
+	"github.com/kubecano/cano-collector/pkg/workflow/actions"
+)
+
+type validateOptions struct {
+	destinationsPath   string
+	teamsPath          string
+	workflowsPath      string
+	resolveCredentials bool
+}
+
+// validationResult holds the loaded configuration and every problem found in it
+type validationResult struct {
+	destinations *config_destination.DestinationsConfig
+	teams        *config_team.TeamsConfig
+	workflows    *config_workflow.WorkflowConfig
+	errs         []error
+}
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var opts validateOptions
+	flags.StringVar(&opts.destinationsPath, "destinations", config.DestinationsPath, "path of the destinations file")
+	flags.StringVar(&opts.teamsPath, "teams", config.TeamsPath, "path of the teams file")
+	flags.StringVar(&opts.workflowsPath, "workflows", config.WorkflowsPath, "path of the workflows file")
+	flags.BoolVar(&opts.resolveCredentials, "resolve-credentials", false,
+		"read credentials from environment variables, files and Secrets instead of only checking their sources")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	result := validate(opts, newLogger())
+	if len(result.errs) > 0 {
+		for _, err := range result.errs {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+		}
+		fmt.Fprintf(stderr, "configuration is invalid: %d error(s)\n", len(result.errs))
+		return ExitFailure
+	}
+
+	fmt.Fprintf(stdout, "configuration is valid: %d destination(s), %d team(s), %d workflow(s)\n",
+		result.destinations.Count(), len(result.teams.Teams), len(result.workflows.ActiveWorkflows))
+	return ExitOK
+}
+
+// validate runs the checks the collector runs at startup and additionally validates the
+// parameters of every workflow action with its factory
+func validate(opts validateOptions, log logger_interfaces.LoggerInterface) validationResult {
+	var result validationResult
+	metrics := newMetrics(log)
+
+	destinationsLoader := config_destination.NewFileDestinationsLoader(opts.destinationsPath)
+	destinationsLoader.SkipCredentials = !opts.resolveCredentials
+	if opts.resolveCredentials {
+		destinationsLoader.Secrets = config_destination.NewInClusterSecretResolver(os.Getenv("INSTALLATION_NAMESPACE"))
+	}
+	destinations, err := destinationsLoader.Load()
+	if err != nil {
+		result.errs = append(result.errs, fmt.Errorf("destinations: %w", err))
+	}
+	result.destinations = destinations
+
+	teams, err := config_team.NewFileTeamsLoader(opts.teamsPath).Load()
+	if err != nil {
+		result.errs = append(result.errs, fmt.Errorf("teams: %w", err))
+	}
+	result.teams = teams
+
+	if destinations != nil && teams != nil {
+		registry := destination.NewDestinationRegistry(destination.NewDestinationFactory(log, nil), log)
+		if err := registry.LoadFromConfig(*destinations); err != nil {
+			result.errs = append(result.errs, fmt.Errorf("destinations: %w", err))
+		} else if err := alert.NewTeamResolver(*teams, log, metrics).ValidateTeamDestinations(registry); err != nil {
+			result.errs = append(result.errs, fmt.Errorf("teams: %w", err))
+		}
+	}
+
+	workflows, err := config_workflow.LoadWorkflowConfig(opts.workflowsPath)
+	if err != nil {
+		result.errs = append(result.errs, fmt.Errorf("workflows: %w", err))
+		return result
+	}
+	result.workflows = workflows
+
+	actionRegistry := actions.NewDefaultActionRegistry(log, metrics)
+	if err := actions.RegisterDefaultActions(actionRegistry, log, metrics, actions.NewPlaceholderKubernetesClient(log)); err != nil {
+		result.errs = append(result.errs, fmt.Errorf("workflows: %w", err))
+		return result
+	}
+	for i := range workflows.ActiveWorkflows {
+		wf := &workflows.ActiveWorkflows[i]
+		actionConfigs, err := workflow.BuildActionConfigs(wf)
+		if err != nil {
+			result.errs = append(result.errs, fmt.Errorf("workflows: %w", err))
+			continue
+		}
+		for j, actionConfig := range actionConfigs {
+			if err := actionRegistry.ValidateConfig(actionConfig); err != nil {
+				result.errs = append(result.errs, fmt.Errorf("workflows: action %d in workflow '%s': %w", j, wf.Name, err))
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func validateArgs(destinations, teams, workflows string) []string {
+	return []string{"validate", "-destinations", destinations, "-teams", teams, "-workflows", workflows}
+}
+
+func TestRun_ValidateValidConfig(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	// credentials are not resolved by default, so the env placeholder needs no variable
+	code := Run(validateArgs("testdata/destinations.yaml", "testdata/teams.yaml", "testdata/workflows.yaml"), &stdout, &stderr)
+
+	assert.Equal(t, ExitOK, code, stderr.String())
+	assert.Equal(t, "configuration is valid: 1 destination(s), 1 team(s), 1 workflow(s)\n", stdout.String())
+}
+
+func TestRun_ValidateInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		destinations string
+		teams        string
+		workflows    string
+		errMsg       string
+	}{
+		{
+			name:         "missing file",
+			destinations: "testdata/nonexistent.yaml",
+			teams:        "testdata/teams.yaml",
+			workflows:    "testdata/workflows.yaml",
+			errMsg:       "destinations: cannot open destination config",
+		},
+		{
+			name:         "unknown team destination",
+			destinations: "testdata/destinations.yaml",
+			teams:        writeFile(t, "teams:\n  - name: devops\n    destinations:\n      - missing\n"),
+			workflows:    "testdata/workflows.yaml",
+			errMsg:       "teams: ",
+		},
+		{
+			name:         "unknown action type",
+			destinations: "testdata/destinations.yaml",
+			teams:        "testdata/teams.yaml",
+			workflows: writeFile(t, `
+active_workflows:
+  - name: "unknown"
+    triggers:
+      - on_alertmanager_alert:
+          status: "firing"
+    actions:
+      - action_type: "unknown_action"
+`),
+			errMsg: "action 0 in workflow 'unknown': no factory registered for action type 'unknown_action'",
+		},
+		{
+			name:         "invalid action parameters",
+			destinations: "testdata/destinations.yaml",
+			teams:        "testdata/teams.yaml",
+			workflows: writeFile(t, `
+active_workflows:
+  - name: "pod-logs"
+    triggers:
+      - on_alertmanager_alert:
+          status: "firing"
+    actions:
+      - action_type: "pod_logs"
+        data:
+          max_lines: "many"
+`),
+			errMsg: `parameter "max_lines": expected an integer`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := Run(validateArgs(tt.destinations, tt.teams, tt.workflows), &stdout, &stderr)
+
+			assert.Equal(t, ExitFailure, code)
+			assert.Contains(t, stderr.String(), tt.errMsg)
+			assert.Contains(t, stderr.String(), "configuration is invalid")
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	assert.Equal(t, ExitUsage, Run([]string{"unknown"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), `unknown command "unknown"`)
+
+	assert.Equal(t, ExitUsage, Run([]string{"validate", "-unknown"}, &stdout, &stderr))
+	assert.Equal(t, ExitUsage, Run([]string{"render"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "-alert is required")
+
+	assert.Equal(t, ExitOK, Run([]string{"help"}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "Usage: cano-collector")
+}
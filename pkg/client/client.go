@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+// Result values returned by the issue API
+const (
+	ResultCreated   = "created"
+	ResultResolved  = "resolved"
+	ResultDuplicate = "duplicate"
+)
+
+// IssueResponse is the body returned by the issue API
+type IssueResponse struct {
+	ID          string `json:"id"`
+	Fingerprint string `json:"fingerprint"`
+	Result      string `json:"result"`
+}
+
+// APIError is returned when cano-collector responds with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("cano-collector API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient overrides the HTTP client used to talk to cano-collector
+func WithHTTPClient(httpClient util.HTTPClient) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHeader adds a header sent with every request
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Set(key, value)
+	}
+}
+
+// WithBearerToken sends the token in the Authorization header
+func WithBearerToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// Client pushes issues to the cano-collector issue API (/api/v1/issues)
+type Client struct {
+	baseURL    *url.URL
+	httpClient util.HTTPClient
+	headers    http.Header
+}
+
+// NewClient creates a client for the cano-collector instance at baseURL,
+// e.g. http://cano-collector.monitoring.svc:8080
+func NewClient(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    parsed,
+		httpClient: util.GetSharedHTTPClient(),
+		headers:    make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// CreateIssue submits an issue. Source defaults to issue.SourceCustom on the server side.
+// Sending an issue whose fingerprint and status were already accepted returns ResultDuplicate.
+func (c *Client) CreateIssue(ctx context.Context, iss *issue.Issue) (*IssueResponse, error) {
+	if iss == nil {
+		return nil, errors.New("issue is nil")
+	}
+
+	body, err := json.Marshal(iss)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal issue: %w", err)
+	}
+
+	return c.do(ctx, http.MethodPost, "/api/v1/issues", body)
+}
+
+// ResolveIssue marks the issue with the given fingerprint as resolved
+func (c *Client) ResolveIssue(ctx context.Context, fingerprint string) (*IssueResponse, error) {
+	if fingerprint == "" {
+		return nil, errors.New("fingerprint is required")
+	}
+
+	return c.do(ctx, http.MethodPatch, "/api/v1/issues/"+url.PathEscape(fingerprint), []byte(`{"status":"resolved"}`))
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte) (*IssueResponse, error) {
+	endpoint := c.baseURL.String() + path
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: errorMessage(respBody)}
+	}
+
+	var result IssueResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// errorMessage extracts the "error" field of an API error response, falling back to the raw body
+func errorMessage(body []byte) string {
+	var payload struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Error != "" {
+		return payload.Error
+	}
+	return strings.TrimSpace(string(body))
+}
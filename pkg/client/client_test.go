@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+func TestNewClient_InvalidBaseURL(t *testing.T) {
+	_, err := NewClient("cano-collector:8080")
+	require.Error(t, err)
+
+	_, err = NewClient("://bad")
+	require.Error(t, err)
+}
+
+func TestClient_CreateIssue(t *testing.T) {
+	var received issue.Issue
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/issues", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &received))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"123","fingerprint":"backup-nightly","result":"created"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL+"/", WithBearerToken("secret"))
+	require.NoError(t, err)
+
+	iss := issue.NewIssue("Backup job failed", "backup")
+	iss.Source = issue.SourceOperator
+	iss.Fingerprint = "backup-nightly"
+	iss.AddEnrichment(*issue.NewEnrichmentWithType(issue.EnrichmentTypeLogs, "Job output"))
+	iss.Enrichments[0].Blocks = []issue.BaseBlock{issue.NewMarkdownBlock("*exit code 1*")}
+
+	resp, err := c.CreateIssue(context.Background(), iss)
+	require.NoError(t, err)
+	assert.Equal(t, &IssueResponse{ID: "123", Fingerprint: "backup-nightly", Result: ResultCreated}, resp)
+
+	assert.Equal(t, "Backup job failed", received.Title)
+	assert.Equal(t, issue.SourceOperator, received.Source)
+	require.Len(t, received.Enrichments, 1)
+	require.Len(t, received.Enrichments[0].Blocks, 1)
+	assert.IsType(t, &issue.MarkdownBlock{}, received.Enrichments[0].Blocks[0])
+}
+
+func TestClient_ResolveIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/v1/issues/fp%2F1", r.URL.EscapedPath())
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"status":"resolved"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"id":"123","fingerprint":"fp/1","result":"resolved"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	resp, err := c.ResolveIssue(context.Background(), "fp/1")
+	require.NoError(t, err)
+	assert.Equal(t, ResultResolved, resp.Result)
+
+	_, err = c.ResolveIssue(context.Background(), "")
+	require.Error(t, err)
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"issue not found"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = c.ResolveIssue(context.Background(), "unknown")
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "issue not found", apiErr.Message)
+}
+
+func TestClient_WithHTTPClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mocks.NewMockHTTPClient(ctrl)
+	mockHTTP.EXPECT().Do(gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	c, err := NewClient("http://cano-collector:8080", WithHTTPClient(mockHTTP))
+	require.NoError(t, err)
+
+	_, err = c.CreateIssue(context.Background(), issue.NewIssue("title", "key"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
@@ -0,0 +1,32 @@
+package event
+
+import (
+	"errors"
+	"time"
+)
+
+// FalcoEvent represents a Falco runtime security event as forwarded by falcosidekick's webhook output
+type FalcoEvent struct {
+	UUID         string                 `json:"uuid,omitempty"`
+	Output       string                 `json:"output"`
+	Priority     string                 `json:"priority"`
+	Rule         string                 `json:"rule"`
+	Time         time.Time              `json:"time"`
+	OutputFields map[string]interface{} `json:"output_fields,omitempty"`
+	Source       string                 `json:"source,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	Hostname     string                 `json:"hostname,omitempty"`
+}
+
+// Validate checks if the Falco event is valid
+func (f *FalcoEvent) Validate() error {
+	if f.Rule == "" {
+		return errors.New("missing rule field")
+	}
+
+	if f.Priority == "" {
+		return errors.New("missing priority field")
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFalcoEvent_Unmarshal(t *testing.T) {
+	payload := `{
+		"uuid": "b7e3c3a2-2b8f-4b8e-9a3c-1f2d3e4f5a6b",
+		"output": "Shell spawned in a container (user=root pod=api-0)",
+		"priority": "Notice",
+		"rule": "Terminal shell in container",
+		"time": "2024-01-15T10:30:00.123456789Z",
+		"output_fields": {"k8s.pod.name": "api-0", "k8s.ns.name": "prod", "proc.pid": 4242},
+		"source": "syscall",
+		"tags": ["container", "shell"],
+		"hostname": "worker-1"
+	}`
+
+	var falcoEvent FalcoEvent
+	require.NoError(t, json.Unmarshal([]byte(payload), &falcoEvent))
+
+	require.NoError(t, falcoEvent.Validate())
+	assert.Equal(t, "Terminal shell in container", falcoEvent.Rule)
+	assert.Equal(t, "Notice", falcoEvent.Priority)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 123456789, time.UTC), falcoEvent.Time)
+	assert.Equal(t, "api-0", falcoEvent.OutputFields["k8s.pod.name"])
+	assert.Equal(t, []string{"container", "shell"}, falcoEvent.Tags)
+	assert.Equal(t, "worker-1", falcoEvent.Hostname)
+}
+
+func TestFalcoEvent_Validate(t *testing.T) {
+	falcoEvent := FalcoEvent{Priority: "Warning"}
+	require.Error(t, falcoEvent.Validate())
+
+	falcoEvent = FalcoEvent{Rule: "Write below etc"}
+	require.Error(t, falcoEvent.Validate())
+}
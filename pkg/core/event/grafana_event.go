@@ -0,0 +1,58 @@
+package event
+
+import "fmt"
+
+// GrafanaAlert represents a single alert from Grafana unified alerting.
+// It extends the Alertmanager alert with Grafana-specific URLs and query values.
+type GrafanaAlert struct {
+	PrometheusAlert
+	SilenceURL   string             `json:"silenceURL,omitempty"`
+	DashboardURL string             `json:"dashboardURL,omitempty"`
+	PanelURL     string             `json:"panelURL,omitempty"`
+	ImageURL     string             `json:"imageURL,omitempty"`
+	Values       map[string]float64 `json:"values,omitempty"`
+	ValueString  string             `json:"valueString,omitempty"`
+}
+
+// GrafanaEvent represents the webhook payload sent by a Grafana contact point
+type GrafanaEvent struct {
+	Receiver          string            `json:"receiver"`
+	Status            string            `json:"status"`
+	OrgID             int64             `json:"orgId"`
+	Alerts            []GrafanaAlert    `json:"alerts"`
+	GroupLabels       map[string]string `json:"groupLabels,omitempty"`
+	CommonLabels      map[string]string `json:"commonLabels,omitempty"`
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+	ExternalURL       string            `json:"externalURL"`
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Title             string            `json:"title,omitempty"`
+	State             string            `json:"state,omitempty"`
+	Message           string            `json:"message,omitempty"`
+}
+
+// Validate checks if the Grafana event is valid
+func (g *GrafanaEvent) Validate() error {
+	if len(g.Alerts) == 0 {
+		return ErrMissingAlerts
+	}
+
+	for i, alert := range g.Alerts {
+		if err := validateAlert(alert.PrometheusAlert); err != nil {
+			return fmt.Errorf("alert at index %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// GetAlertName returns the alert name from the first alert
+func (g *GrafanaEvent) GetAlertName() string {
+	if len(g.Alerts) > 0 {
+		if name, exists := g.Alerts[0].Labels["alertname"]; exists {
+			return name
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,72 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const grafanaPayload = `{
+  "receiver": "cano-collector",
+  "status": "firing",
+  "orgId": 1,
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"alertname": "HighCPU", "grafana_folder": "Infra", "namespace": "prod"},
+      "annotations": {"summary": "CPU above 90%"},
+      "startsAt": "2024-01-15T10:30:00Z",
+      "endsAt": "0001-01-01T00:00:00Z",
+      "generatorURL": "https://grafana.example.com/alerting/grafana/abc/view",
+      "fingerprint": "57c6d9296de2ad39",
+      "silenceURL": "https://grafana.example.com/alerting/silence/new?alertmanager=grafana",
+      "dashboardURL": "https://grafana.example.com/d/dash",
+      "panelURL": "https://grafana.example.com/d/dash?viewPanel=2",
+      "imageURL": "https://grafana.example.com/render/panel.png",
+      "values": {"B": 93.5, "C": 1},
+      "valueString": "[ var='B' labels={} value=93.5 ]"
+    }
+  ],
+  "groupLabels": {"alertname": "HighCPU"},
+  "externalURL": "https://grafana.example.com/",
+  "version": "1",
+  "groupKey": "{}:{alertname=\"HighCPU\"}",
+  "truncatedAlerts": 0,
+  "title": "[FIRING:1] HighCPU",
+  "state": "alerting",
+  "message": "CPU above 90%"
+}`
+
+func TestGrafanaEvent_Unmarshal(t *testing.T) {
+	var grafanaEvent GrafanaEvent
+	require.NoError(t, json.Unmarshal([]byte(grafanaPayload), &grafanaEvent))
+
+	require.NoError(t, grafanaEvent.Validate())
+	assert.Equal(t, int64(1), grafanaEvent.OrgID)
+	assert.Equal(t, "alerting", grafanaEvent.State)
+	assert.Equal(t, "HighCPU", grafanaEvent.GetAlertName())
+
+	require.Len(t, grafanaEvent.Alerts, 1)
+	alert := grafanaEvent.Alerts[0]
+	assert.Equal(t, "firing", alert.Status)
+	assert.Equal(t, "57c6d9296de2ad39", alert.Fingerprint)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), alert.StartsAt)
+	assert.Equal(t, "https://grafana.example.com/d/dash", alert.DashboardURL)
+	assert.Equal(t, "https://grafana.example.com/d/dash?viewPanel=2", alert.PanelURL)
+	assert.Equal(t, "https://grafana.example.com/render/panel.png", alert.ImageURL)
+	assert.InDelta(t, 93.5, alert.Values["B"], 0.001)
+}
+
+func TestGrafanaEvent_Validate(t *testing.T) {
+	grafanaEvent := GrafanaEvent{}
+	assert.ErrorIs(t, grafanaEvent.Validate(), ErrMissingAlerts)
+	assert.Equal(t, "unknown", grafanaEvent.GetAlertName())
+
+	grafanaEvent.Alerts = []GrafanaAlert{{PrometheusAlert: PrometheusAlert{Status: "alerting"}}}
+	err := grafanaEvent.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "alert at index 0")
+}
@@ -0,0 +1,146 @@
+package event
+
+import (
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// EventTypeIssue marks events built from issues that did not come from Alertmanager
+const EventTypeIssue EventType = "issue"
+
+// IssueWorkflowEvent wraps an Issue produced by a non-Alertmanager source
+// (generic webhooks, API clients, ...) so it can be processed by workflows
+type IssueWorkflowEvent struct {
+	BaseEvent
+	Issue *issue.Issue
+}
+
+// NewIssueWorkflowEvent creates a new IssueWorkflowEvent
+func NewIssueWorkflowEvent(iss *issue.Issue) *IssueWorkflowEvent {
+	return &IssueWorkflowEvent{
+		BaseEvent: BaseEvent{
+			ID:        iss.ID,
+			Timestamp: time.Now(),
+			Source:    strings.ToLower(iss.Source.String()),
+			Type:      EventTypeIssue,
+		},
+		Issue: iss,
+	}
+}
+
+// NewWorkflowEventForIssue creates the workflow event of an issue. An issue converted from a
+// Prometheus alert gets an AlertManagerWorkflowEvent with the alert rebuilt from the issue, so
+// workflows see the same alert whether this replica converted the issue or a peer forwarded it.
+// Fields of the Alertmanager group, like the receiver, are not part of an issue and stay empty.
+func NewWorkflowEventForIssue(iss *issue.Issue) WorkflowEvent {
+	if iss.Source != issue.SourcePrometheus {
+		return NewIssueWorkflowEvent(iss)
+	}
+
+	alert := PrometheusAlert{
+		StartsAt:    iss.StartsAt,
+		Fingerprint: iss.Fingerprint,
+		Status:      strings.ToLower(iss.Status.String()),
+		Labels:      map[string]string{"alertname": iss.AggregationKey},
+	}
+	if iss.EndsAt != nil {
+		alert.EndsAt = *iss.EndsAt
+	}
+	if iss.Subject != nil {
+		maps.Copy(alert.Labels, iss.Subject.Labels)
+		alert.Annotations = maps.Clone(iss.Subject.Annotations)
+	}
+	for _, link := range iss.Links {
+		if link.Type == issue.LinkTypePrometheusGenerator {
+			alert.GeneratorURL = link.URL
+			break
+		}
+	}
+
+	return NewAlertManagerWorkflowEvent(&AlertManagerEvent{
+		BaseEvent: BaseEvent{
+			ID:        iss.ID,
+			Timestamp: time.Now(),
+			Source:    "alertmanager",
+			Type:      EventTypeAlertManager,
+		},
+		Alerts: []PrometheusAlert{alert},
+		Status: alert.Status,
+	})
+}
+
+// GetID returns the event ID
+func (e *IssueWorkflowEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+// GetTimestamp returns the event timestamp
+func (e *IssueWorkflowEvent) GetTimestamp() time.Time {
+	return e.Timestamp
+}
+
+// GetSource returns the event source
+func (e *IssueWorkflowEvent) GetSource() string {
+	return e.Source
+}
+
+// GetType returns the event type
+func (e *IssueWorkflowEvent) GetType() EventType {
+	return e.Type
+}
+
+// GetEventData returns the underlying Issue
+func (e *IssueWorkflowEvent) GetEventData() interface{} {
+	return e.Issue
+}
+
+// GetIssue returns the underlying Issue for internal use
+func (e *IssueWorkflowEvent) GetIssue() *issue.Issue {
+	return e.Issue
+}
+
+// GetAlertName returns the issue aggregation key, which plays the role of the alert name
+func (e *IssueWorkflowEvent) GetAlertName() string {
+	return e.Issue.AggregationKey
+}
+
+// GetStatus returns the issue status in Alertmanager notation (firing, resolved)
+func (e *IssueWorkflowEvent) GetStatus() string {
+	return strings.ToLower(e.Issue.Status.String())
+}
+
+// GetSeverity returns the issue severity in Prometheus label notation.
+// An explicit "severity" subject label takes precedence over the mapped value.
+func (e *IssueWorkflowEvent) GetSeverity() string {
+	if e.Issue.Subject != nil {
+		if severity, exists := e.Issue.Subject.Labels["severity"]; exists && severity != "" {
+			return severity
+		}
+	}
+
+	switch e.Issue.Severity {
+	case issue.SeverityHigh:
+		return "critical"
+	case issue.SeverityLow:
+		return "warning"
+	case issue.SeverityInfo:
+		return "info"
+	case issue.SeverityDebug:
+		return "debug"
+	default:
+		return "unknown"
+	}
+}
+
+// GetNamespace returns the namespace of the issue subject
+func (e *IssueWorkflowEvent) GetNamespace() string {
+	if e.Issue.Subject == nil {
+		return ""
+	}
+	return e.Issue.Subject.Namespace
+}
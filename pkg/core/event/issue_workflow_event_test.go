@@ -0,0 +1,114 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+func TestNewIssueWorkflowEvent(t *testing.T) {
+	iss := issue.NewIssue("Build failed", "BuildFailed")
+	iss.Source = issue.SourceWebhook
+	iss.Severity = issue.SeverityHigh
+	iss.Status = issue.StatusResolved
+	subject := issue.NewSubject("api-7d9f", issue.SubjectTypePod)
+	subject.Namespace = "production"
+	iss.SetSubject(subject)
+
+	workflowEvent := NewIssueWorkflowEvent(iss)
+
+	assert.Equal(t, iss.ID, workflowEvent.GetID())
+	assert.NotZero(t, workflowEvent.GetTimestamp())
+	assert.Equal(t, "webhook", workflowEvent.GetSource())
+	assert.Equal(t, EventTypeIssue, workflowEvent.GetType())
+	assert.Same(t, iss, workflowEvent.GetEventData())
+	assert.Same(t, iss, workflowEvent.GetIssue())
+	assert.Equal(t, "BuildFailed", workflowEvent.GetAlertName())
+	assert.Equal(t, "resolved", workflowEvent.GetStatus())
+	assert.Equal(t, "critical", workflowEvent.GetSeverity())
+	assert.Equal(t, "production", workflowEvent.GetNamespace())
+}
+
+func TestIssueWorkflowEvent_GetSeverity(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity issue.Severity
+		labels   map[string]string
+		expected string
+	}{
+		{name: "high", severity: issue.SeverityHigh, expected: "critical"},
+		{name: "low", severity: issue.SeverityLow, expected: "warning"},
+		{name: "info", severity: issue.SeverityInfo, expected: "info"},
+		{name: "debug", severity: issue.SeverityDebug, expected: "debug"},
+		{name: "label overrides mapping", severity: issue.SeverityHigh, labels: map[string]string{"severity": "page"}, expected: "page"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iss := issue.NewIssue("title", "key")
+			iss.Severity = tt.severity
+			for k, v := range tt.labels {
+				iss.Subject.Labels[k] = v
+			}
+
+			assert.Equal(t, tt.expected, NewIssueWorkflowEvent(iss).GetSeverity())
+		})
+	}
+}
+
+func TestIssueWorkflowEvent_GetNamespace_NilSubject(t *testing.T) {
+	iss := issue.NewIssue("title", "key")
+	iss.Subject = nil
+
+	assert.Empty(t, NewIssueWorkflowEvent(iss).GetNamespace())
+	assert.Equal(t, "info", NewIssueWorkflowEvent(iss).GetSeverity())
+}
+
+func TestNewWorkflowEventForIssue_Prometheus(t *testing.T) {
+	startsAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	endsAt := startsAt.Add(time.Hour)
+	iss := issue.NewIssue("Pod is crash looping", "KubePodCrashLooping")
+	iss.Source = issue.SourcePrometheus
+	iss.Status = issue.StatusResolved
+	iss.StartsAt = startsAt
+	iss.EndsAt = &endsAt
+	subject := issue.NewSubject("api-0", issue.SubjectTypePod)
+	subject.Namespace = "payments"
+	subject.Labels = map[string]string{"alertname": "KubePodCrashLooping", "namespace": "payments", "pod": "api-0", "severity": "critical"}
+	subject.Annotations = map[string]string{"summary": "Pod is crash looping"}
+	iss.SetSubject(subject)
+	iss.SetFingerprint("fingerprint-1")
+	iss.AddLink(*issue.NewLink("Generator URL", "http://prometheus/graph", issue.LinkTypePrometheusGenerator))
+
+	workflowEvent, ok := NewWorkflowEventForIssue(iss).(*AlertManagerWorkflowEvent)
+	require.True(t, ok)
+
+	assert.Equal(t, iss.ID, workflowEvent.GetID())
+	assert.Equal(t, EventTypeAlertManager, workflowEvent.GetType())
+	assert.Equal(t, "KubePodCrashLooping", workflowEvent.GetAlertName())
+	assert.Equal(t, "resolved", workflowEvent.GetStatus())
+	assert.Equal(t, "critical", workflowEvent.GetSeverity())
+	assert.Equal(t, "payments", workflowEvent.GetNamespace())
+	assert.Equal(t, []PrometheusAlert{{
+		StartsAt:     startsAt,
+		EndsAt:       endsAt,
+		GeneratorURL: "http://prometheus/graph",
+		Fingerprint:  "fingerprint-1",
+		Status:       "resolved",
+		Labels:       subject.Labels,
+		Annotations:  subject.Annotations,
+	}}, workflowEvent.GetAlertManagerEvent().Alerts)
+}
+
+func TestNewWorkflowEventForIssue_OtherSources(t *testing.T) {
+	iss := issue.NewIssue("Build failed", "BuildFailed")
+	iss.Source = issue.SourceWebhook
+
+	workflowEvent, ok := NewWorkflowEventForIssue(iss).(*IssueWorkflowEvent)
+	require.True(t, ok)
+	assert.Same(t, iss, workflowEvent.GetIssue())
+}
@@ -316,6 +316,25 @@ func (fb *FileBlock) GetSizeKB() float64 {
 	return float64(fb.Size) / 1024.0
 }
 
+// ImageBlock represents an image referenced by URL, e.g. a rendered dashboard panel
+type ImageBlock struct {
+	URL     string `json:"url"`
+	AltText string `json:"alt_text,omitempty"`
+}
+
+// BlockType returns the type of this block
+func (ib *ImageBlock) BlockType() string {
+	return "image"
+}
+
+// NewImageBlock creates a new ImageBlock
+func NewImageBlock(url, altText string) *ImageBlock {
+	return &ImageBlock{
+		URL:     url,
+		AltText: altText,
+	}
+}
+
 // DividerBlock represents a visual separator
 type DividerBlock struct{}
 
@@ -337,6 +337,14 @@ func TestFileBlock_GetSizeKB(t *testing.T) {
 	assert.InDelta(t, 2.0, sizeKB, 0.01)
 }
 
+func TestImageBlock_NewImageBlock(t *testing.T) {
+	imageBlock := NewImageBlock("https://grafana.example.com/render/panel.png", "CPU usage")
+
+	assert.Equal(t, "https://grafana.example.com/render/panel.png", imageBlock.URL)
+	assert.Equal(t, "CPU usage", imageBlock.AltText)
+	assert.Equal(t, "image", imageBlock.BlockType())
+}
+
 func TestDividerBlock_NewDividerBlock(t *testing.T) {
 	dividerBlock := NewDividerBlock()
 
@@ -1,5 +1,10 @@
 package issue
 
+import (
+	"fmt"
+	"strings"
+)
+
 // LinkType represents the type of link
 type LinkType int
 
@@ -9,6 +14,8 @@ const (
 	LinkTypeInvestigate
 	LinkTypeSilence
 	LinkTypeRunbook
+	LinkTypeDashboard
+	LinkTypePanel
 )
 
 // String returns the string representation of the link type
@@ -24,11 +31,37 @@ func (lt LinkType) String() string {
 		return "SILENCE"
 	case LinkTypeRunbook:
 		return "RUNBOOK"
+	case LinkTypeDashboard:
+		return "DASHBOARD"
+	case LinkTypePanel:
+		return "PANEL"
 	default:
 		return "UNKNOWN"
 	}
 }
 
+// LinkTypeFromString converts a string to LinkType
+func LinkTypeFromString(s string) (LinkType, error) {
+	switch strings.ToUpper(s) {
+	case "GENERAL":
+		return LinkTypeGeneral, nil
+	case "PROMETHEUS_GENERATOR":
+		return LinkTypePrometheusGenerator, nil
+	case "INVESTIGATE":
+		return LinkTypeInvestigate, nil
+	case "SILENCE":
+		return LinkTypeSilence, nil
+	case "RUNBOOK":
+		return LinkTypeRunbook, nil
+	case "DASHBOARD":
+		return LinkTypeDashboard, nil
+	case "PANEL":
+		return LinkTypePanel, nil
+	default:
+		return LinkTypeGeneral, fmt.Errorf("unknown link type: %s", s)
+	}
+}
+
 // Link represents a URL relevant to the issue
 type Link struct {
 	Text string   `json:"text"`
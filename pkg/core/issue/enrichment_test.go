@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewEnrichment(t *testing.T) {
@@ -115,6 +116,8 @@ func TestLinkType_String(t *testing.T) {
 		{LinkTypeInvestigate, "INVESTIGATE"},
 		{LinkTypeSilence, "SILENCE"},
 		{LinkTypeRunbook, "RUNBOOK"},
+		{LinkTypeDashboard, "DASHBOARD"},
+		{LinkTypePanel, "PANEL"},
 		{LinkType(999), "UNKNOWN"}, // default case
 	}
 
@@ -122,3 +125,28 @@ func TestLinkType_String(t *testing.T) {
 		assert.Equal(t, test.expected, test.linkType.String())
 	}
 }
+
+func TestLinkTypeFromString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected LinkType
+	}{
+		{"general", LinkTypeGeneral},
+		{"PROMETHEUS_GENERATOR", LinkTypePrometheusGenerator},
+		{"Investigate", LinkTypeInvestigate},
+		{"silence", LinkTypeSilence},
+		{"runbook", LinkTypeRunbook},
+		{"Dashboard", LinkTypeDashboard},
+		{"panel", LinkTypePanel},
+	}
+
+	for _, test := range tests {
+		linkType, err := LinkTypeFromString(test.input)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, linkType)
+	}
+
+	linkType, err := LinkTypeFromString("dashboard-ish")
+	require.Error(t, err)
+	assert.Equal(t, LinkTypeGeneral, linkType)
+}
@@ -1,5 +1,7 @@
 package issue
 
+import "fmt"
+
 // EnrichmentType represents the type of enrichment to categorize different kinds of contextual data
 type EnrichmentType int
 
@@ -55,3 +57,13 @@ func (et EnrichmentType) String() string {
 		return "unknown"
 	}
 }
+
+// EnrichmentTypeFromString converts a string to EnrichmentType
+func EnrichmentTypeFromString(s string) (EnrichmentType, error) {
+	for et := EnrichmentTypeAlertLabels; et <= EnrichmentTypeLogs; et++ {
+		if et.String() == s {
+			return et, nil
+		}
+	}
+	return EnrichmentTypeAlertMetadata, fmt.Errorf("unknown enrichment type: %s", s)
+}
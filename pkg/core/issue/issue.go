@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -78,6 +80,11 @@ func (i *Issue) generateFingerprint() string {
 	return hex.EncodeToString(hash[:])
 }
 
+// RefreshFingerprint regenerates the fingerprint from the current issue attributes
+func (i *Issue) RefreshFingerprint() {
+	i.Fingerprint = i.generateFingerprint()
+}
+
 // SetFingerprint sets a custom fingerprint (e.g., from Prometheus/Alertmanager)
 func (i *Issue) SetFingerprint(fingerprint string) {
 	if fingerprint != "" {
@@ -120,6 +127,36 @@ func (i *Issue) SetSubject(subject *Subject) {
 	i.Fingerprint = i.generateFingerprint()
 }
 
+// Clone returns a copy of the issue that shares no slices, maps or pointers with it, so either
+// can be enriched without affecting the other. Blocks are shared, they are not changed once added.
+func (i *Issue) Clone() *Issue {
+	clone := *i
+	if i.Subject != nil {
+		subject := *i.Subject
+		subject.Labels = maps.Clone(i.Subject.Labels)
+		subject.Annotations = maps.Clone(i.Subject.Annotations)
+		clone.Subject = &subject
+	}
+	if i.Enrichments != nil {
+		clone.Enrichments = make([]Enrichment, len(i.Enrichments))
+		for j, enrichment := range i.Enrichments {
+			enrichment.Blocks = slices.Clone(enrichment.Blocks)
+			enrichment.Annotations = maps.Clone(enrichment.Annotations)
+			if enrichment.FileInfo != nil {
+				fileInfo := *enrichment.FileInfo
+				enrichment.FileInfo = &fileInfo
+			}
+			clone.Enrichments[j] = enrichment
+		}
+	}
+	clone.Links = slices.Clone(i.Links)
+	if i.EndsAt != nil {
+		endsAt := *i.EndsAt
+		clone.EndsAt = &endsAt
+	}
+	return &clone
+}
+
 // IsResolved returns true if the issue is resolved
 func (i *Issue) IsResolved() bool {
 	return i.Status == StatusResolved
@@ -2,6 +2,7 @@ package issue
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -238,3 +239,27 @@ func TestIssue_AddEnrichmentWithType_MultipleEnrichments(t *testing.T) {
 	assert.Equal(t, "Graph", issue.Enrichments[1].Title)
 	assert.Equal(t, block2, issue.Enrichments[1].Blocks[0])
 }
+
+func TestIssue_Clone(t *testing.T) {
+	original := NewIssue("Test Issue", "test-key")
+	original.Subject.Labels["app"] = "api"
+	endsAt := time.Now()
+	original.EndsAt = &endsAt
+	original.AddLink(Link{Text: "Runbook", URL: "https://runbooks/test"})
+	original.AddEnrichmentWithType([]BaseBlock{NewMarkdownBlock("first")}, EnrichmentTypeLogs, "Logs")
+
+	clone := original.Clone()
+	assert.Equal(t, original, clone)
+
+	clone.Subject.Labels["app"] = "worker"
+	*clone.EndsAt = endsAt.Add(time.Hour)
+	clone.Links[0].URL = "https://changed"
+	clone.Enrichments[0].Blocks = append(clone.Enrichments[0].Blocks, NewMarkdownBlock("second"))
+	clone.AddEnrichmentBlocks([]BaseBlock{NewMarkdownBlock("workflow")})
+
+	assert.Equal(t, "api", original.Subject.Labels["app"])
+	assert.Equal(t, endsAt, *original.EndsAt)
+	assert.Equal(t, "https://runbooks/test", original.Links[0].URL)
+	assert.Len(t, original.Enrichments, 1)
+	assert.Len(t, original.Enrichments[0].Blocks, 1)
+}
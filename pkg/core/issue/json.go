@@ -0,0 +1,208 @@
+package issue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// unmarshalEnum decodes an enum given either as its integer value or as its string name.
+// Integers must lie between zero and last, the highest value of the enum.
+func unmarshalEnum[T ~int](data []byte, fromString func(string) (T, error), last T) (T, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return 0, err
+		}
+		// Allow quoted integers as well
+		if n, err := strconv.Atoi(s); err == nil {
+			return enumValue(n, last)
+		}
+		return fromString(s)
+	}
+
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return 0, fmt.Errorf("enum value must be a string or an integer: %w", err)
+	}
+	return enumValue(n, last)
+}
+
+func enumValue[T ~int](n int, last T) (T, error) {
+	if n < 0 || n > int(last) {
+		return 0, fmt.Errorf("enum value %d is out of range 0-%d", n, last)
+	}
+	return T(n), nil
+}
+
+// UnmarshalJSON accepts the severity as an integer or a name such as "HIGH"
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, SeverityFromString, SeverityHigh)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// UnmarshalJSON accepts the status as an integer or a name such as "RESOLVED"
+func (s *Status) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, StatusFromString, StatusResolved)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// UnmarshalJSON accepts the source as an integer or a name such as "OPERATOR"
+func (s *Source) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, SourceFromString, SourceFalco)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// UnmarshalJSON accepts the subject type as an integer or a name such as "POD"
+func (st *SubjectType) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, SubjectTypeFromString, SubjectTypeHPA)
+	if err != nil {
+		return err
+	}
+	*st = value
+	return nil
+}
+
+// UnmarshalJSON accepts the link type as an integer or a name such as "RUNBOOK"
+func (lt *LinkType) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, LinkTypeFromString, LinkTypePanel)
+	if err != nil {
+		return err
+	}
+	*lt = value
+	return nil
+}
+
+// UnmarshalJSON accepts the enrichment type as an integer or a name such as "logs"
+func (et *EnrichmentType) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, func(s string) (EnrichmentType, error) {
+		return EnrichmentTypeFromString(strings.ToLower(s))
+	}, EnrichmentTypeLogs)
+	if err != nil {
+		return err
+	}
+	*et = value
+	return nil
+}
+
+// enrichmentAlias has the Enrichment fields without its JSON methods
+type enrichmentAlias Enrichment
+
+// MarshalJSON encodes blocks with a "type" discriminator so they can be decoded again
+func (e Enrichment) MarshalJSON() ([]byte, error) {
+	blocks := make([]json.RawMessage, 0, len(e.Blocks))
+	for _, block := range e.Blocks {
+		encoded, err := MarshalBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, encoded)
+	}
+
+	return json.Marshal(struct {
+		enrichmentAlias
+		Blocks []json.RawMessage `json:"blocks,omitempty"`
+	}{
+		enrichmentAlias: enrichmentAlias(e),
+		Blocks:          blocks,
+	})
+}
+
+// UnmarshalJSON decodes an enrichment including its typed blocks
+func (e *Enrichment) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		enrichmentAlias
+		Blocks []json.RawMessage `json:"blocks,omitempty"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*e = Enrichment(decoded.enrichmentAlias)
+	e.Blocks = make([]BaseBlock, 0, len(decoded.Blocks))
+	for i, raw := range decoded.Blocks {
+		block, err := UnmarshalBlock(raw)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", i, err)
+		}
+		e.Blocks = append(e.Blocks, block)
+	}
+
+	return nil
+}
+
+// MarshalBlock encodes a block as a JSON object with its fields and a "type" discriminator
+func MarshalBlock(block BaseBlock) (json.RawMessage, error) {
+	encoded, err := json.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s block: %w", block.BlockType(), err)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, fmt.Errorf("failed to encode %s block: %w", block.BlockType(), err)
+	}
+	blockType, _ := json.Marshal(block.BlockType())
+	fields["type"] = blockType
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalBlock decodes a block using its "type" discriminator
+func UnmarshalBlock(data []byte) (BaseBlock, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	var block BaseBlock
+	switch header.Type {
+	case "markdown":
+		block = &MarkdownBlock{}
+	case "table":
+		block = &TableBlock{}
+	case "json":
+		block = &JsonBlock{}
+	case "header":
+		block = &HeaderBlock{}
+	case "list":
+		block = &ListBlock{}
+	case "links":
+		block = &LinksBlock{}
+	case "file":
+		block = &FileBlock{}
+	case "image":
+		block = &ImageBlock{}
+	case "divider":
+		return &DividerBlock{}, nil
+	case "":
+		return nil, fmt.Errorf("block type is required")
+	default:
+		return nil, fmt.Errorf("unknown block type: %s", header.Type)
+	}
+
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, fmt.Errorf("failed to decode %s block: %w", header.Type, err)
+	}
+	if fileBlock, ok := block.(*FileBlock); ok && fileBlock.Size == 0 {
+		fileBlock.Size = int64(len(fileBlock.Contents))
+	}
+	return block, nil
+}
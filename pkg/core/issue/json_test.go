@@ -0,0 +1,155 @@
+package issue
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumUnmarshalJSON(t *testing.T) {
+	var decoded struct {
+		Severity       Severity       `json:"severity"`
+		Status         Status         `json:"status"`
+		Source         Source         `json:"source"`
+		SubjectType    SubjectType    `json:"subject_type"`
+		LinkType       LinkType       `json:"link_type"`
+		EnrichmentType EnrichmentType `json:"enrichment_type"`
+	}
+
+	err := json.Unmarshal([]byte(`{
+		"severity": "high",
+		"status": "RESOLVED",
+		"source": "operator",
+		"subject_type": "Pod",
+		"link_type": "runbook",
+		"enrichment_type": "LOGS"
+	}`), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, SeverityHigh, decoded.Severity)
+	assert.Equal(t, StatusResolved, decoded.Status)
+	assert.Equal(t, SourceOperator, decoded.Source)
+	assert.Equal(t, SubjectTypePod, decoded.SubjectType)
+	assert.Equal(t, LinkTypeRunbook, decoded.LinkType)
+	assert.Equal(t, EnrichmentTypeLogs, decoded.EnrichmentType)
+
+	err = json.Unmarshal([]byte(`{"severity": 2, "status": "1", "source": 3}`), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, SeverityLow, decoded.Severity)
+	assert.Equal(t, StatusResolved, decoded.Status)
+	assert.Equal(t, SourceCustom, decoded.Source)
+}
+
+func TestEnumUnmarshalJSON_Errors(t *testing.T) {
+	var severity Severity
+	require.Error(t, json.Unmarshal([]byte(`"catastrophic"`), &severity))
+	require.Error(t, json.Unmarshal([]byte(`true`), &severity))
+	require.Error(t, json.Unmarshal([]byte(`7`), &severity))
+	require.Error(t, json.Unmarshal([]byte(`"-1"`), &severity))
+
+	var status Status
+	require.Error(t, json.Unmarshal([]byte(`2`), &status))
+	require.NoError(t, json.Unmarshal([]byte(`1`), &status))
+	assert.Equal(t, StatusResolved, status)
+
+	var enrichmentType EnrichmentType
+	require.Error(t, json.Unmarshal([]byte(`"unknown_type"`), &enrichmentType))
+}
+
+func TestEnrichment_JSONRoundTrip(t *testing.T) {
+	enrichment := NewEnrichmentWithType(EnrichmentTypeLogs, "Pod logs")
+	enrichment.AddBlock(NewHeaderBlock("Logs"))
+	enrichment.AddBlock(NewMarkdownBlock("*restarted*"))
+	enrichment.AddBlock(NewTableBlock([]string{"k", "v"}, [][]string{{"a", "1"}}, "labels", TableBlockFormatVertical))
+	enrichment.AddBlock(NewJsonBlock(map[string]interface{}{"key": "value"}))
+	enrichment.AddBlock(NewListBlock([]string{"one", "two"}, true, "steps"))
+	enrichment.AddBlock(NewLinksBlock([]Link{{Text: "Runbook", URL: "https://example.com", Type: LinkTypeRunbook}}, "links"))
+	enrichment.AddBlock(NewFileBlock("app.log", []byte("line 1\nline 2"), "text/plain"))
+	enrichment.AddBlock(NewImageBlock("https://grafana.example.com/render/panel.png", "CPU usage"))
+	enrichment.AddBlock(NewDividerBlock())
+	enrichment.AddAnnotation("source", "test")
+
+	encoded, err := json.Marshal(enrichment)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"type":"markdown"`)
+	assert.Contains(t, string(encoded), `"type":"divider"`)
+
+	var decoded Enrichment
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+
+	assert.Equal(t, *enrichment, decoded)
+}
+
+func TestEnrichment_UnmarshalJSON_FileBlockSize(t *testing.T) {
+	var decoded Enrichment
+	err := json.Unmarshal([]byte(`{"type": "logs", "blocks": [{"type": "file", "filename": "a.log", "contents": "aGVsbG8="}]}`), &decoded)
+
+	require.NoError(t, err)
+	require.Len(t, decoded.Blocks, 1)
+	fileBlock, ok := decoded.Blocks[0].(*FileBlock)
+	require.True(t, ok)
+	assert.Equal(t, []byte("hello"), fileBlock.Contents)
+	assert.Equal(t, int64(5), fileBlock.Size)
+}
+
+func TestUnmarshalBlock_Errors(t *testing.T) {
+	_, err := UnmarshalBlock([]byte(`{"text": "no type"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "block type is required")
+
+	_, err = UnmarshalBlock([]byte(`{"type": "chart"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown block type: chart")
+
+	_, err = UnmarshalBlock([]byte(`{"type": "list", "items": "not-a-list"}`))
+	require.Error(t, err)
+}
+
+func TestIssue_JSONRoundTrip(t *testing.T) {
+	original := NewIssue("Disk almost full", "DiskAlmostFull")
+	original.Severity = SeverityHigh
+	original.Source = SourceOperator
+	subject := NewSubject("db-0", SubjectTypePod)
+	subject.Namespace = "data"
+	original.SetSubject(subject)
+	original.AddLink(*NewLink("Runbook", "https://example.com/runbook", LinkTypeRunbook))
+	original.AddEnrichmentBlocks([]BaseBlock{NewMarkdownBlock("90% used")})
+
+	encoded, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded Issue
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+
+	assert.Equal(t, original.ID, decoded.ID)
+	assert.Equal(t, original.Fingerprint, decoded.Fingerprint)
+	require.NotNil(t, decoded.Subject)
+	assert.Equal(t, "db-0", decoded.Subject.Name)
+	assert.Equal(t, "data", decoded.Subject.Namespace)
+	assert.Equal(t, SubjectTypePod, decoded.Subject.SubjectType)
+	assert.Equal(t, SeverityHigh, decoded.Severity)
+	assert.Equal(t, SourceOperator, decoded.Source)
+	assert.Equal(t, original.Links, decoded.Links)
+	require.Len(t, decoded.Enrichments, 1)
+	assert.Equal(t, original.Enrichments[0].Blocks, decoded.Enrichments[0].Blocks)
+	assert.True(t, original.StartsAt.Equal(decoded.StartsAt))
+}
+
+func TestEnrichmentTypeFromString(t *testing.T) {
+	enrichmentType, err := EnrichmentTypeFromString("crash_info")
+	require.NoError(t, err)
+	assert.Equal(t, EnrichmentTypeCrashInfo, enrichmentType)
+
+	_, err = EnrichmentTypeFromString("nope")
+	require.Error(t, err)
+}
+
+func TestIssue_RefreshFingerprint(t *testing.T) {
+	iss := NewIssue("title", "key")
+	iss.SetFingerprint("custom")
+	iss.RefreshFingerprint()
+
+	assert.Equal(t, iss.generateFingerprint(), iss.Fingerprint)
+	assert.NotEqual(t, "custom", iss.Fingerprint)
+}
@@ -84,3 +84,19 @@ func SeverityFromPrometheusLabel(label string) Severity {
 		return SeverityInfo
 	}
 }
+
+// SeverityFromFalcoPriority maps Falco rule priorities to Issue severity
+func SeverityFromFalcoPriority(priority string) Severity {
+	switch strings.ToLower(priority) {
+	case "emergency", "alert", "critical", "error":
+		return SeverityHigh
+	case "warning":
+		return SeverityLow
+	case "notice", "informational", "info":
+		return SeverityInfo
+	case "debug":
+		return SeverityDebug
+	default:
+		return SeverityInfo
+	}
+}
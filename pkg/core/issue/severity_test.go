@@ -22,3 +22,24 @@ func TestSeverity_ToEmoji(t *testing.T) {
 		assert.Equal(t, test.expected, emoji, "Incorrect emoji for severity %s", test.severity.String())
 	}
 }
+
+func TestSeverityFromFalcoPriority(t *testing.T) {
+	tests := []struct {
+		priority string
+		expected Severity
+	}{
+		{"Emergency", SeverityHigh},
+		{"Alert", SeverityHigh},
+		{"Critical", SeverityHigh},
+		{"Error", SeverityHigh},
+		{"Warning", SeverityLow},
+		{"Notice", SeverityInfo},
+		{"Informational", SeverityInfo},
+		{"Debug", SeverityDebug},
+		{"", SeverityInfo},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, SeverityFromFalcoPriority(test.priority), "Incorrect severity for priority %q", test.priority)
+	}
+}
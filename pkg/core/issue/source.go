@@ -16,6 +16,8 @@ const (
 	SourceWebhook
 	SourceManual
 	SourceOperator
+	SourceGrafana
+	SourceFalco
 )
 
 // String returns the string representation of the source
@@ -35,6 +37,10 @@ func (s Source) String() string {
 		return "MANUAL"
 	case SourceOperator:
 		return "OPERATOR"
+	case SourceGrafana:
+		return "GRAFANA"
+	case SourceFalco:
+		return "FALCO"
 	default:
 		return "UNKNOWN"
 	}
@@ -57,6 +63,10 @@ func SourceFromString(s string) (Source, error) {
 		return SourceManual, nil
 	case "OPERATOR":
 		return SourceOperator, nil
+	case "GRAFANA":
+		return SourceGrafana, nil
+	case "FALCO":
+		return SourceFalco, nil
 	default:
 		return SourceUnknown, fmt.Errorf("unknown source: %s", s)
 	}
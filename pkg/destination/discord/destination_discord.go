@@ -0,0 +1,40 @@
+package destdiscord
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	discordsender "github.com/kubecano/cano-collector/pkg/sender/discord"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationDiscordConfig struct {
+	Name       string
+	WebhookURL string
+	Username   string
+	AvatarURL  string
+}
+
+type DestinationDiscord struct {
+	sender *discordsender.SenderDiscord
+	cfg    *DestinationDiscordConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationDiscord(cfg *DestinationDiscordConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationDiscord {
+	return &DestinationDiscord{
+		sender: discordsender.NewSenderDiscord(cfg.WebhookURL, cfg.Username, cfg.AvatarURL, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Send implements the destination interface
+func (d *DestinationDiscord) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to Discord destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
@@ -2,16 +2,31 @@ package destination
 
 import (
 	"fmt"
+	"strings"
 
 	config_destination "github.com/kubecano/cano-collector/config/destination"
+	destdiscord "github.com/kubecano/cano-collector/pkg/destination/discord"
+	destgooglechat "github.com/kubecano/cano-collector/pkg/destination/googlechat"
+	destjira "github.com/kubecano/cano-collector/pkg/destination/jira"
+	destmattermost "github.com/kubecano/cano-collector/pkg/destination/mattermost"
+	destmsteams "github.com/kubecano/cano-collector/pkg/destination/msteams"
+	destopsgenie "github.com/kubecano/cano-collector/pkg/destination/opsgenie"
+	destpagerduty "github.com/kubecano/cano-collector/pkg/destination/pagerduty"
+	destservicenow "github.com/kubecano/cano-collector/pkg/destination/servicenow"
 	destslack "github.com/kubecano/cano-collector/pkg/destination/slack"
+	desttelegram "github.com/kubecano/cano-collector/pkg/destination/telegram"
+	destwebhook "github.com/kubecano/cano-collector/pkg/destination/webhook"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	opsgeniesender "github.com/kubecano/cano-collector/pkg/sender/opsgenie"
+	servicenowsender "github.com/kubecano/cano-collector/pkg/sender/servicenow"
 	"github.com/kubecano/cano-collector/pkg/util"
 )
 
 type DestinationFactory struct {
 	logger     logger_interfaces.LoggerInterface
 	httpClient util.HTTPClient
+	stateStore ha_interfaces.StateStoreInterface
 }
 
 func NewDestinationFactory(logger logger_interfaces.LoggerInterface, httpClient util.HTTPClient) *DestinationFactory {
@@ -24,11 +39,36 @@ func NewDestinationFactory(logger logger_interfaces.LoggerInterface, httpClient
 	}
 }
 
+// SetStateStore sets the state shared between HA replicas, e.g. Slack thread relationships
+func (f *DestinationFactory) SetStateStore(store ha_interfaces.StateStoreInterface) {
+	f.stateStore = store
+}
+
 // config: can be e.g. config_destination.SlackDestination
 func (f *DestinationFactory) CreateDestination(config interface{}) (interface{}, error) {
 	switch d := config.(type) {
 	case config_destination.DestinationSlack:
 		return f.createSlackDestination(&d)
+	case config_destination.DestinationMSTeams:
+		return f.createMSTeamsDestination(&d)
+	case config_destination.DestinationPagerDuty:
+		return f.createPagerDutyDestination(&d)
+	case config_destination.DestinationOpsgenie:
+		return f.createOpsgenieDestination(&d)
+	case config_destination.DestinationWebhook:
+		return f.createWebhookDestination(&d)
+	case config_destination.DestinationJira:
+		return f.createJiraDestination(&d)
+	case config_destination.DestinationServiceNow:
+		return f.createServiceNowDestination(&d)
+	case config_destination.DestinationDiscord:
+		return f.createDiscordDestination(&d)
+	case config_destination.DestinationMattermost:
+		return f.createMattermostDestination(&d)
+	case config_destination.DestinationTelegram:
+		return f.createTelegramDestination(&d)
+	case config_destination.DestinationGoogleChat:
+		return f.createGoogleChatDestination(&d)
 	default:
 		return nil, fmt.Errorf("unsupported destination type: %T", config)
 	}
@@ -40,7 +80,7 @@ func (f *DestinationFactory) createSlackDestination(d *config_destination.Destin
 	}
 	cfg := &destslack.DestinationSlackConfig{
 		Name:             d.Name,
-		APIKey:           d.APIKey,
+		APIKey:           d.APIKey.Value(),
 		SlackChannel:     d.SlackChannel,
 		GroupingInterval: d.GroupingInterval,
 		UnfurlLinks:      d.UnfurlLinks == nil || *d.UnfurlLinks,
@@ -68,5 +108,224 @@ func (f *DestinationFactory) createSlackDestination(d *config_destination.Destin
 		}
 	}
 
-	return destslack.NewDestinationSlack(cfg, f.logger, f.httpClient), nil
+	destination := destslack.NewDestinationSlack(cfg, f.logger, f.httpClient)
+	if f.stateStore != nil {
+		destination.SetStateStore(f.stateStore)
+	}
+	return destination, nil
+}
+
+func (f *DestinationFactory) createMSTeamsDestination(d *config_destination.DestinationMSTeams) (*destmsteams.DestinationMSTeams, error) {
+	if d.WebhookURL == "" {
+		return nil, fmt.Errorf("msteams destination '%s' must have webhook_url", d.Name)
+	}
+	cfg := &destmsteams.DestinationMSTeamsConfig{
+		Name:            d.Name,
+		WebhookURL:      d.WebhookURL.Value(),
+		WebhookOverride: d.WebhookOverride,
+	}
+	return destmsteams.NewDestinationMSTeams(cfg, f.logger, f.httpClient), nil
+}
+
+func (f *DestinationFactory) createPagerDutyDestination(d *config_destination.DestinationPagerDuty) (*destpagerduty.DestinationPagerDuty, error) {
+	if d.RoutingKey == "" {
+		return nil, fmt.Errorf("pagerduty destination '%s' must have routing_key", d.Name)
+	}
+	eventsURL := d.EventsURL
+	if eventsURL == "" {
+		eventsURL = config_destination.DefaultPagerDutyEventsURL
+	}
+	cfg := &destpagerduty.DestinationPagerDutyConfig{
+		Name:       d.Name,
+		RoutingKey: d.RoutingKey.Value(),
+		EventsURL:  eventsURL,
+	}
+	return destpagerduty.NewDestinationPagerDuty(cfg, f.logger, f.httpClient), nil
+}
+
+func (f *DestinationFactory) createOpsgenieDestination(d *config_destination.DestinationOpsgenie) (*destopsgenie.DestinationOpsgenie, error) {
+	if d.APIKey == "" {
+		return nil, fmt.Errorf("opsgenie destination '%s' must have api_key", d.Name)
+	}
+	responders := make([]opsgeniesender.Responder, 0, len(d.Teams)+len(d.Responders))
+	for _, team := range d.Teams {
+		responders = append(responders, opsgeniesender.NewResponder("team", team))
+	}
+	for _, responder := range d.Responders {
+		responders = append(responders, opsgeniesender.NewResponder(responder.Type, responder.Name))
+	}
+	cfg := &destopsgenie.DestinationOpsgenieConfig{
+		Name:       d.Name,
+		APIKey:     d.APIKey.Value(),
+		APIURL:     d.APIURL(),
+		Responders: responders,
+		Tags:       d.Tags,
+		Priorities: d.PriorityMapping,
+	}
+	return destopsgenie.NewDestinationOpsgenie(cfg, f.logger, f.httpClient), nil
+}
+
+func (f *DestinationFactory) createWebhookDestination(d *config_destination.DestinationWebhook) (*destwebhook.DestinationWebhook, error) {
+	if d.URL == "" {
+		return nil, fmt.Errorf("webhook destination '%s' must have url", d.Name)
+	}
+	headers := make(map[string]string, len(d.Headers))
+	for name, value := range d.Headers {
+		if value != nil {
+			headers[name] = value.Value()
+		}
+	}
+	cfg := &destwebhook.DestinationWebhookConfig{
+		Name:          d.Name,
+		URL:           d.URL,
+		Method:        d.Method,
+		Headers:       headers,
+		ContentType:   d.ContentType,
+		BodyTemplate:  d.BodyTemplate,
+		SigningSecret: d.SigningSecret.Value(),
+	}
+	if d.Retry != nil {
+		cfg.Retry.MaxAttempts = d.Retry.MaxAttempts
+		cfg.Retry.Backoff, cfg.Retry.MaxBackoff = d.Retry.RetryDurations()
+	}
+
+	client := f.httpClient
+	if d.TLS != nil {
+		tlsConfig, err := d.TLS.Load()
+		if err != nil {
+			return nil, fmt.Errorf("webhook destination '%s': %w", d.Name, err)
+		}
+		client = util.NewHTTPClientWithTLS(tlsConfig)
+	}
+
+	destination, err := destwebhook.NewDestinationWebhook(cfg, f.logger, client)
+	if err != nil {
+		return nil, fmt.Errorf("webhook destination '%s': %w", d.Name, err)
+	}
+	return destination, nil
+}
+
+func (f *DestinationFactory) createJiraDestination(d *config_destination.DestinationJira) (*destjira.DestinationJira, error) {
+	if d.APIToken == "" {
+		return nil, fmt.Errorf("jira destination '%s' must have api_token", d.Name)
+	}
+	priorities := make(map[string]string, len(d.PriorityMapping))
+	for severity, priority := range d.PriorityMapping {
+		priorities[strings.ToLower(severity)] = priority
+	}
+	cfg := &destjira.DestinationJiraConfig{
+		Name:          d.Name,
+		URL:           d.URL,
+		APIVersion:    d.APIVersion,
+		Username:      d.Username,
+		APIToken:      d.APIToken.Value(),
+		ProjectKey:    d.ProjectKey,
+		IssueType:     d.IssueType,
+		Priorities:    priorities,
+		Components:    d.Components,
+		Labels:        d.Labels,
+		CustomFields:  d.CustomFields,
+		ResolveStatus: d.ResolveStatus,
+	}
+	return destjira.NewDestinationJira(cfg, f.logger, f.httpClient), nil
+}
+
+func (f *DestinationFactory) createServiceNowDestination(d *config_destination.DestinationServiceNow) (*destservicenow.DestinationServiceNow, error) {
+	if d.Password == "" {
+		return nil, fmt.Errorf("servicenow destination '%s' must have password", d.Name)
+	}
+	priorities := make(map[string]servicenowsender.Priority, len(config_destination.ServiceNowSeverityDefaults))
+	for severity, priority := range config_destination.ServiceNowSeverityDefaults {
+		priorities[severity] = servicenowsender.Priority{Impact: priority.Impact, Urgency: priority.Urgency}
+	}
+	for severity, priority := range d.SeverityMapping {
+		priorities[strings.ToLower(severity)] = servicenowsender.Priority{Impact: priority.Impact, Urgency: priority.Urgency}
+	}
+	cfg := &destservicenow.DestinationServiceNowConfig{
+		Name:       d.Name,
+		URL:        d.URL,
+		Username:   d.Username,
+		Password:   d.Password.Value(),
+		Table:      d.Table,
+		Priorities: priorities,
+		FieldMapping: servicenowsender.FieldMapping{
+			Cluster:   d.FieldMapping.Cluster,
+			Subject:   d.FieldMapping.Subject,
+			Namespace: d.FieldMapping.Namespace,
+		},
+		CallerID:        d.CallerID,
+		AssignmentGroup: d.AssignmentGroup,
+		Category:        d.Category,
+		Subcategory:     d.Subcategory,
+		CustomFields:    d.CustomFields,
+		ResolveState:    d.ResolveState,
+		CloseCode:       d.CloseCode,
+	}
+	return destservicenow.NewDestinationServiceNow(cfg, f.logger, f.httpClient), nil
+}
+
+func (f *DestinationFactory) createDiscordDestination(d *config_destination.DestinationDiscord) (*destdiscord.DestinationDiscord, error) {
+	if d.WebhookURL == "" {
+		return nil, fmt.Errorf("discord destination '%s' must have webhook_url", d.Name)
+	}
+	cfg := &destdiscord.DestinationDiscordConfig{
+		Name:       d.Name,
+		WebhookURL: d.WebhookURL.Value(),
+		Username:   d.Username,
+		AvatarURL:  d.AvatarURL,
+	}
+	return destdiscord.NewDestinationDiscord(cfg, f.logger, f.httpClient), nil
+}
+
+func (f *DestinationFactory) createMattermostDestination(d *config_destination.DestinationMattermost) (*destmattermost.DestinationMattermost, error) {
+	if d.Token == "" {
+		return nil, fmt.Errorf("mattermost destination '%s' must have token", d.Name)
+	}
+	cfg := &destmattermost.DestinationMattermostConfig{
+		Name:      d.Name,
+		URL:       d.URL,
+		Token:     d.Token.Value(),
+		ChannelID: d.ChannelID,
+	}
+	if d.Threading != nil {
+		cfg.Threading = &destmattermost.MattermostThreadingConfig{
+			Enabled:  d.Threading.Enabled,
+			CacheTTL: d.Threading.CacheTTL,
+		}
+	}
+
+	destination := destmattermost.NewDestinationMattermost(cfg, f.logger, f.httpClient)
+	if f.stateStore != nil {
+		destination.SetStateStore(f.stateStore)
+	}
+	return destination, nil
+}
+
+func (f *DestinationFactory) createTelegramDestination(d *config_destination.DestinationTelegram) (*desttelegram.DestinationTelegram, error) {
+	if d.BotToken == "" {
+		return nil, fmt.Errorf("telegram destination '%s' must have bot_token", d.Name)
+	}
+	cfg := &desttelegram.DestinationTelegramConfig{
+		Name:            d.Name,
+		BotToken:        d.BotToken.Value(),
+		ChatID:          d.ChatID,
+		MessageThreadID: d.MessageThreadID,
+	}
+
+	destination := desttelegram.NewDestinationTelegram(cfg, f.logger, f.httpClient)
+	if f.stateStore != nil {
+		destination.SetStateStore(f.stateStore)
+	}
+	return destination, nil
+}
+
+func (f *DestinationFactory) createGoogleChatDestination(d *config_destination.DestinationGoogleChat) (*destgooglechat.DestinationGoogleChat, error) {
+	if d.URL == "" {
+		return nil, fmt.Errorf("google_chat destination '%s' must have url", d.Name)
+	}
+	cfg := &destgooglechat.DestinationGoogleChatConfig{
+		Name: d.Name,
+		URL:  d.URL.Value(),
+	}
+	return destgooglechat.NewDestinationGoogleChat(cfg, f.logger, f.httpClient), nil
 }
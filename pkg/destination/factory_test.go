@@ -71,6 +71,229 @@ func TestDestinationFactory_CreateDestinationSlack_MissingAPIKey(t *testing.T) {
 	assert.Contains(t, err.Error(), "must have api_key")
 }
 
+func TestDestinationFactory_CreateDestinationMSTeams(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationMSTeams{
+		Name:       "test-msteams",
+		WebhookURL: "https://example.webhook.office.com/webhookb2/test",
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationMSTeams{Name: "test-msteams"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have webhook_url")
+}
+
+func TestDestinationFactory_CreateDestinationPagerDuty(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationPagerDuty{
+		Name:       "test-pagerduty",
+		RoutingKey: "routing-key",
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationPagerDuty{Name: "test-pagerduty"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have routing_key")
+}
+
+func TestDestinationFactory_CreateDestinationOpsgenie(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationOpsgenie{
+		Name:       "test-opsgenie",
+		APIKey:     "api-key",
+		Region:     "eu",
+		Teams:      []string{"sre"},
+		Responders: []destination_config.OpsgenieResponder{{Type: "user", Name: "jane@example.com"}},
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationOpsgenie{Name: "test-opsgenie"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have api_key")
+}
+
+func TestDestinationFactory_CreateDestinationWebhook(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	token := destination_config.Secret("token")
+	dest := destination_config.DestinationWebhook{
+		Name:         "test-webhook",
+		URL:          "https://hooks.internal/cano",
+		Headers:      map[string]*destination_config.Secret{"Authorization": &token},
+		BodyTemplate: `{"title": {{ toJson .Title }}}`,
+		Retry:        &destination_config.WebhookRetryConfig{MaxAttempts: 2, Backoff: "1s", MaxBackoff: "5s"},
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationWebhook{Name: "test-webhook"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have url")
+
+	dest.BodyTemplate = `{{ .Title `
+	d, err = factory.CreateDestination(dest)
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "webhook destination 'test-webhook': invalid body_template")
+
+	dest.BodyTemplate = ""
+	dest.TLS = &destination_config.WebhookTLSConfig{CAFile: "/nonexistent/ca.crt"}
+	d, err = factory.CreateDestination(dest)
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "failed to read tls.ca_file")
+}
+
+func TestDestinationFactory_CreateDestinationJira(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationJira{
+		Name:            "test-jira",
+		URL:             "https://example.atlassian.net",
+		Username:        "bot@example.com",
+		APIToken:        "api-token",
+		ProjectKey:      "OPS",
+		PriorityMapping: map[string]string{"High": "Highest"},
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationJira{Name: "test-jira"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have api_token")
+}
+
+func TestDestinationFactory_CreateDestinationServiceNow(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationServiceNow{
+		Name:            "test-servicenow",
+		URL:             "https://example.service-now.com",
+		Username:        "cano_bot",
+		Password:        "password",
+		SeverityMapping: map[string]destination_config.ServiceNowPriority{"High": {Impact: "1", Urgency: "2"}},
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationServiceNow{Name: "test-servicenow"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have password")
+}
+
+func TestDestinationFactory_CreateDestinationDiscord(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationDiscord{
+		Name:       "test-discord",
+		WebhookURL: "https://discord.com/api/webhooks/1/token",
+		Username:   "Cano",
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationDiscord{Name: "test-discord"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have webhook_url")
+}
+
+func TestDestinationFactory_CreateDestinationMattermost(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationMattermost{
+		Name:      "test-mattermost",
+		URL:       "https://mattermost.example.com",
+		Token:     "bot-token",
+		ChannelID: "channel-1",
+		Threading: &destination_config.MattermostThreadingConfig{Enabled: true, CacheTTL: "24h"},
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationMattermost{Name: "test-mattermost", ChannelID: "channel-1"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have token")
+}
+
+func TestDestinationFactory_CreateDestinationTelegram(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationTelegram{
+		Name:            "test-telegram",
+		BotToken:        "123456:bot-token",
+		ChatID:          "-1001234567890",
+		MessageThreadID: 42,
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationTelegram{Name: "test-telegram", ChatID: "-1001234567890"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have bot_token")
+}
+
+func TestDestinationFactory_CreateDestinationGoogleChat(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	dest := destination_config.DestinationGoogleChat{
+		Name: "test-google-chat",
+		URL:  "https://chat.googleapis.com/v1/spaces/AAA/messages?key=key&token=token",
+	}
+
+	d, err := factory.CreateDestination(dest)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	d, err = factory.CreateDestination(destination_config.DestinationGoogleChat{Name: "test-google-chat"})
+	require.Error(t, err)
+	assert.Nil(t, d)
+	assert.Contains(t, err.Error(), "must have url")
+}
+
 func TestDestinationFactory_CreateDestination_UnsupportedType(t *testing.T) {
 	factory, ctrl := setupTest(t)
 	defer ctrl.Finish()
@@ -83,6 +306,19 @@ func TestDestinationFactory_CreateDestination_UnsupportedType(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported destination type")
 }
 
+func TestDestinationFactory_CreateDestination_EveryKind(t *testing.T) {
+	factory, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	// Empty destinations fail validation, but never as an unsupported type
+	for _, kind := range destination_config.DestinationKinds() {
+		_, err := factory.CreateDestination(kind.New())
+		if err != nil {
+			assert.NotContains(t, err.Error(), "unsupported destination type", kind.Type())
+		}
+	}
+}
+
 func TestDestinationFactory_CreateDestinationSlack_WithThreadingAndEnrichments(t *testing.T) {
 	factory, ctrl := setupTest(t)
 	defer ctrl.Finish()
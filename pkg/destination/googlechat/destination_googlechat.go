@@ -0,0 +1,38 @@
+package destgooglechat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	googlechatsender "github.com/kubecano/cano-collector/pkg/sender/googlechat"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationGoogleChatConfig struct {
+	Name string
+	URL  string
+}
+
+type DestinationGoogleChat struct {
+	sender *googlechatsender.SenderGoogleChat
+	cfg    *DestinationGoogleChatConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationGoogleChat(cfg *DestinationGoogleChatConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationGoogleChat {
+	return &DestinationGoogleChat{
+		sender: googlechatsender.NewSenderGoogleChat(cfg.URL, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Send implements the destination interface
+func (d *DestinationGoogleChat) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to Google Chat destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
@@ -0,0 +1,61 @@
+package destjira
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	jirasender "github.com/kubecano/cano-collector/pkg/sender/jira"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationJiraConfig struct {
+	Name          string
+	URL           string
+	APIVersion    string
+	Username      string
+	APIToken      string
+	ProjectKey    string
+	IssueType     string
+	Priorities    map[string]string
+	Components    []string
+	Labels        []string
+	CustomFields  map[string]interface{}
+	ResolveStatus string
+}
+
+type DestinationJira struct {
+	sender *jirasender.SenderJira
+	cfg    *DestinationJiraConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationJira(cfg *DestinationJiraConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationJira {
+	senderConfig := jirasender.Config{
+		URL:           cfg.URL,
+		APIVersion:    cfg.APIVersion,
+		Username:      cfg.Username,
+		APIToken:      cfg.APIToken,
+		ProjectKey:    cfg.ProjectKey,
+		IssueType:     cfg.IssueType,
+		Priorities:    cfg.Priorities,
+		Components:    cfg.Components,
+		Labels:        cfg.Labels,
+		CustomFields:  cfg.CustomFields,
+		ResolveStatus: cfg.ResolveStatus,
+	}
+	return &DestinationJira{
+		sender: jirasender.NewSenderJira(senderConfig, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Send implements the destination interface
+func (d *DestinationJira) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to Jira destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
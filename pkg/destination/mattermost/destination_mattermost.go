@@ -0,0 +1,68 @@
+package destmattermost
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	mattermostsender "github.com/kubecano/cano-collector/pkg/sender/mattermost"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationMattermostConfig struct {
+	Name      string
+	URL       string
+	Token     string
+	ChannelID string
+	// Threading configuration
+	Threading *MattermostThreadingConfig
+}
+
+// MattermostThreadingConfig contains threading-specific configuration
+type MattermostThreadingConfig struct {
+	Enabled  bool
+	CacheTTL string
+}
+
+type DestinationMattermost struct {
+	sender *mattermostsender.SenderMattermost
+	cfg    *DestinationMattermostConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationMattermost(cfg *DestinationMattermostConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationMattermost {
+	destination := &DestinationMattermost{
+		sender: mattermostsender.NewSenderMattermost(cfg.URL, cfg.Token, cfg.ChannelID, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+
+	if cfg.Threading != nil && cfg.Threading.Enabled {
+		cacheTTL, err := time.ParseDuration(cfg.Threading.CacheTTL)
+		if err != nil {
+			logger.Warn("Invalid cache TTL, using default",
+				zap.String("cacheTTL", cfg.Threading.CacheTTL),
+				zap.Error(err))
+			cacheTTL = 24 * time.Hour
+		}
+		destination.sender.EnableThreading(cacheTTL)
+	}
+
+	return destination
+}
+
+// SetStateStore shares Mattermost thread relationships between HA replicas
+func (d *DestinationMattermost) SetStateStore(store ha_interfaces.StateStoreInterface) {
+	d.sender.SetThreadStateStore(store)
+}
+
+// Send implements the destination interface
+func (d *DestinationMattermost) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to Mattermost destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
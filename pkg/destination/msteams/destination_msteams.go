@@ -0,0 +1,61 @@
+package destmsteams
+
+import (
+	"context"
+	"net/url"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	msteamssender "github.com/kubecano/cano-collector/pkg/sender/msteams"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationMSTeamsConfig struct {
+	Name       string
+	WebhookURL string
+	// WebhookOverride is an annotation of the issue subject that replaces the webhook URL
+	WebhookOverride string
+}
+
+type DestinationMSTeams struct {
+	sender *msteamssender.SenderMSTeams
+	cfg    *DestinationMSTeamsConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationMSTeams(cfg *DestinationMSTeamsConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationMSTeams {
+	return &DestinationMSTeams{
+		sender: msteamssender.NewSenderMSTeams(cfg.WebhookURL, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Send implements the destination interface
+func (d *DestinationMSTeams) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to MS Teams destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.SendTo(ctx, d.webhookURL(issue), issue)
+}
+
+// webhookURL returns the URL in the override annotation of the issue subject, if there is a valid
+// one, and the configured URL otherwise
+func (d *DestinationMSTeams) webhookURL(issue *issuepkg.Issue) string {
+	if d.cfg.WebhookOverride == "" || issue.Subject == nil {
+		return d.cfg.WebhookURL
+	}
+	override, ok := issue.Subject.Annotations[d.cfg.WebhookOverride]
+	if !ok || override == "" {
+		return d.cfg.WebhookURL
+	}
+	if u, err := url.Parse(override); err != nil || u.Scheme != "https" || u.Host == "" {
+		d.logger.Warn("Ignoring invalid MS Teams webhook override",
+			zap.String("destination", d.cfg.Name),
+			zap.String("annotation", d.cfg.WebhookOverride),
+		)
+		return d.cfg.WebhookURL
+	}
+	return override
+}
@@ -0,0 +1,63 @@
+package destmsteams
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+func TestDestinationMSTeams_Send_WebhookOverride(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectedURL string
+	}{
+		{
+			name:        "no annotation",
+			expectedURL: "https://example.webhook.office.com/default",
+		},
+		{
+			name:        "annotation overrides the webhook URL",
+			annotations: map[string]string{"msteams.webhook/infra": "https://example.webhook.office.com/infra"},
+			expectedURL: "https://example.webhook.office.com/infra",
+		},
+		{
+			name:        "invalid annotation is ignored",
+			annotations: map[string]string{"msteams.webhook/infra": "not a url"},
+			expectedURL: "https://example.webhook.office.com/default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockLogger := mocks.NewMockLoggerInterface(ctrl)
+			mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			mockClient := mocks.NewMockHTTPClient(ctrl)
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, tt.expectedURL, req.URL.String())
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			})
+
+			d := NewDestinationMSTeams(&DestinationMSTeamsConfig{
+				Name:            "infra",
+				WebhookURL:      "https://example.webhook.office.com/default",
+				WebhookOverride: "msteams.webhook/infra",
+			}, mockLogger, mockClient)
+
+			issue := issuepkg.NewIssue("Test Issue", "test-key")
+			issue.Subject.Annotations = tt.annotations
+			require.NoError(t, d.Send(context.Background(), issue))
+		})
+	}
+}
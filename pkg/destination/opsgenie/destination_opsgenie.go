@@ -0,0 +1,42 @@
+package destopsgenie
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	opsgeniesender "github.com/kubecano/cano-collector/pkg/sender/opsgenie"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationOpsgenieConfig struct {
+	Name       string
+	APIKey     string
+	APIURL     string
+	Responders []opsgeniesender.Responder
+	Tags       []string
+	Priorities map[string]string
+}
+
+type DestinationOpsgenie struct {
+	sender *opsgeniesender.SenderOpsgenie
+	cfg    *DestinationOpsgenieConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationOpsgenie(cfg *DestinationOpsgenieConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationOpsgenie {
+	return &DestinationOpsgenie{
+		sender: opsgeniesender.NewSenderOpsgenie(cfg.APIKey, cfg.APIURL, cfg.Responders, cfg.Tags, cfg.Priorities, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Send implements the destination interface
+func (d *DestinationOpsgenie) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to Opsgenie destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
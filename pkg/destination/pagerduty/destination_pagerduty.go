@@ -0,0 +1,39 @@
+package destpagerduty
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	pagerdutysender "github.com/kubecano/cano-collector/pkg/sender/pagerduty"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationPagerDutyConfig struct {
+	Name       string
+	RoutingKey string
+	EventsURL  string
+}
+
+type DestinationPagerDuty struct {
+	sender *pagerdutysender.SenderPagerDuty
+	cfg    *DestinationPagerDutyConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationPagerDuty(cfg *DestinationPagerDutyConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationPagerDuty {
+	return &DestinationPagerDuty{
+		sender: pagerdutysender.NewSenderPagerDuty(cfg.RoutingKey, cfg.EventsURL, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Send implements the destination interface
+func (d *DestinationPagerDuty) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to PagerDuty destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
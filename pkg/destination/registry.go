@@ -33,24 +33,25 @@ func (r *DestinationRegistry) LoadFromConfig(config config_destination.Destinati
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Load Slack destinations
-	for _, slackConfig := range config.Destinations.Slack {
-		destination, err := r.factory.CreateDestination(slackConfig)
-		if err != nil {
-			return fmt.Errorf("failed to create slack destination '%s': %w", slackConfig.Name, err)
-		}
+	return config.Each(r.register)
+}
 
-		if dest, ok := destination.(interfaces.DestinationInterface); ok {
-			r.destinations[slackConfig.Name] = dest
-			r.logger.Info("Registered destination",
-				zap.String("name", slackConfig.Name),
-				zap.String("type", "slack"),
-			)
-		} else {
-			return fmt.Errorf("destination '%s' does not implement DestinationInterface", slackConfig.Name)
-		}
+// register creates a destination from its configuration; the caller holds the lock
+func (r *DestinationRegistry) register(destinationType, name string, config interface{}) error {
+	destination, err := r.factory.CreateDestination(config)
+	if err != nil {
+		return fmt.Errorf("failed to create %s destination '%s': %w", destinationType, name, err)
 	}
 
+	dest, ok := destination.(interfaces.DestinationInterface)
+	if !ok {
+		return fmt.Errorf("destination '%s' does not implement DestinationInterface", name)
+	}
+	r.destinations[name] = dest
+	r.logger.Info("Registered destination",
+		zap.String("name", name),
+		zap.String("type", destinationType),
+	)
 	return nil
 }
 
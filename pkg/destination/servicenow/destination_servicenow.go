@@ -0,0 +1,65 @@
+package destservicenow
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	servicenowsender "github.com/kubecano/cano-collector/pkg/sender/servicenow"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationServiceNowConfig struct {
+	Name            string
+	URL             string
+	Username        string
+	Password        string
+	Table           string
+	Priorities      map[string]servicenowsender.Priority
+	FieldMapping    servicenowsender.FieldMapping
+	CallerID        string
+	AssignmentGroup string
+	Category        string
+	Subcategory     string
+	CustomFields    map[string]string
+	ResolveState    string
+	CloseCode       string
+}
+
+type DestinationServiceNow struct {
+	sender *servicenowsender.SenderServiceNow
+	cfg    *DestinationServiceNowConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationServiceNow(cfg *DestinationServiceNowConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationServiceNow {
+	senderConfig := servicenowsender.Config{
+		URL:             cfg.URL,
+		Username:        cfg.Username,
+		Password:        cfg.Password,
+		Table:           cfg.Table,
+		Priorities:      cfg.Priorities,
+		FieldMapping:    cfg.FieldMapping,
+		CallerID:        cfg.CallerID,
+		AssignmentGroup: cfg.AssignmentGroup,
+		Category:        cfg.Category,
+		Subcategory:     cfg.Subcategory,
+		CustomFields:    cfg.CustomFields,
+		ResolveState:    cfg.ResolveState,
+		CloseCode:       cfg.CloseCode,
+	}
+	return &DestinationServiceNow{
+		sender: servicenowsender.NewSenderServiceNow(senderConfig, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Send implements the destination interface
+func (d *DestinationServiceNow) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to ServiceNow destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
@@ -7,6 +7,7 @@ import (
 	"go.uber.org/zap"
 
 	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	slacksender "github.com/kubecano/cano-collector/pkg/sender/slack"
 	"github.com/kubecano/cano-collector/pkg/util"
@@ -96,6 +97,11 @@ func (d *DestinationSlack) enableThreading() {
 	d.sender.EnableThreading(cacheTTL, threadingConfig.SearchLimit, searchWindow)
 }
 
+// SetStateStore shares Slack thread relationships between HA replicas
+func (d *DestinationSlack) SetStateStore(store ha_interfaces.StateStoreInterface) {
+	d.sender.SetThreadStateStore(store)
+}
+
 // configureEnrichments sets up enrichments formatting by passing parameters to sender
 func (d *DestinationSlack) configureEnrichments() {
 	enrichmentsConfig := d.cfg.Enrichments
@@ -0,0 +1,46 @@
+package desttelegram
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	telegramsender "github.com/kubecano/cano-collector/pkg/sender/telegram"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationTelegramConfig struct {
+	Name            string
+	BotToken        string
+	ChatID          string
+	MessageThreadID int
+}
+
+type DestinationTelegram struct {
+	sender *telegramsender.SenderTelegram
+	cfg    *DestinationTelegramConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+func NewDestinationTelegram(cfg *DestinationTelegramConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *DestinationTelegram {
+	return &DestinationTelegram{
+		sender: telegramsender.NewSenderTelegram(cfg.BotToken, cfg.ChatID, cfg.MessageThreadID, logger, client),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// SetStateStore shares the messages resolved issues reply to between HA replicas
+func (d *DestinationTelegram) SetStateStore(store ha_interfaces.StateStoreInterface) {
+	d.sender.SetMessageStateStore(store)
+}
+
+// Send implements the destination interface
+func (d *DestinationTelegram) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to Telegram destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
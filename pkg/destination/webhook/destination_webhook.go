@@ -0,0 +1,75 @@
+package destwebhook
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	webhooksender "github.com/kubecano/cano-collector/pkg/sender/webhook"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+type DestinationWebhookConfig struct {
+	Name          string
+	URL           string
+	Method        string
+	Headers       map[string]string
+	ContentType   string
+	BodyTemplate  string
+	SigningSecret string
+	Retry         WebhookRetryConfig
+}
+
+// WebhookRetryConfig configures how requests that fail with 429 or 5xx are retried
+type WebhookRetryConfig struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+type DestinationWebhook struct {
+	sender *webhooksender.SenderWebhook
+	cfg    *DestinationWebhookConfig
+	logger logger_interfaces.LoggerInterface
+}
+
+// NewDestinationWebhook returns the destination, or an error when the body template is invalid
+func NewDestinationWebhook(cfg *DestinationWebhookConfig, logger logger_interfaces.LoggerInterface, client util.HTTPClient) (*DestinationWebhook, error) {
+	s := webhooksender.NewSenderWebhook(cfg.URL, cfg.Method, logger, client)
+	s.SetHeaders(cfg.Headers)
+	if cfg.ContentType != "" {
+		s.SetContentType(cfg.ContentType)
+	}
+	if cfg.BodyTemplate != "" {
+		tmpl, err := webhooksender.ParseBodyTemplate(cfg.BodyTemplate)
+		if err != nil {
+			return nil, err
+		}
+		s.SetBodyTemplate(tmpl)
+	}
+	s.SetSigningSecret(cfg.SigningSecret)
+	if cfg.Retry.MaxAttempts > 0 {
+		s.SetRetryPolicy(sender.RetryPolicy{
+			MaxAttempts: cfg.Retry.MaxAttempts,
+			Backoff:     cfg.Retry.Backoff,
+			MaxBackoff:  cfg.Retry.MaxBackoff,
+		})
+	}
+
+	return &DestinationWebhook{
+		sender: s,
+		cfg:    cfg,
+		logger: logger,
+	}, nil
+}
+
+// Send implements the destination interface
+func (d *DestinationWebhook) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	d.logger.Info("Sending to webhook destination", zap.String("destination", d.cfg.Name))
+
+	return d.sender.Send(ctx, issue)
+}
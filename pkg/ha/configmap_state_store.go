@@ -0,0 +1,99 @@
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// stateConfigMapKey is the ConfigMap data key holding the JSON encoded state
+const stateConfigMapKey = "state.json"
+
+// ConfigMapStateStore keeps state in memory and persists it to a ConfigMap, so a new
+// leader continues with the thread and deduplication state of the previous one
+type ConfigMapStateStore struct {
+	*MemoryStateStore
+
+	client    kubernetes.Interface
+	namespace string
+	name      string
+
+	flushMu        sync.Mutex
+	flushedVersion uint64
+}
+
+// NewConfigMapStateStore creates a state store persisted in the namespace/name ConfigMap
+func NewConfigMapStateStore(client kubernetes.Interface, namespace, name string) *ConfigMapStateStore {
+	return &ConfigMapStateStore{
+		MemoryStateStore: NewMemoryStateStore(),
+		client:           client,
+		namespace:        namespace,
+		name:             name,
+	}
+}
+
+// Load merges the persisted state into memory; a missing ConfigMap is an empty state
+func (s *ConfigMapStateStore) Load(ctx context.Context) error {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get state configmap %s/%s: %w", s.namespace, s.name, err)
+	}
+
+	data, exists := cm.Data[stateConfigMapKey]
+	if !exists || data == "" {
+		return nil
+	}
+	var entries map[string]stateEntry
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return fmt.Errorf("failed to decode state configmap %s/%s: %w", s.namespace, s.name, err)
+	}
+	s.merge(entries)
+	return nil
+}
+
+// Flush writes the state to the ConfigMap if it changed since the last flush
+func (s *ConfigMapStateStore) Flush(ctx context.Context) error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	entries, version := s.snapshot()
+	if version == s.flushedVersion {
+		return nil
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+	cm, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace},
+			Data:       map[string]string{stateConfigMapKey: string(data)},
+		}
+		_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+	case err == nil:
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[stateConfigMapKey] = string(data)
+		_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write state configmap %s/%s: %w", s.namespace, s.name, err)
+	}
+
+	s.flushedVersion = version
+	return nil
+}
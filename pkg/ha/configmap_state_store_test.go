@@ -0,0 +1,72 @@
+package ha
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapStateStore_FlushAndLoad(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+
+	leader := NewConfigMapStateStore(client, "monitoring", "cano-collector-state")
+	leader.Set("slack-thread/#alerts/abc", "1700000000.000100", time.Hour)
+	require.NoError(t, leader.Flush(ctx))
+
+	cm, err := client.CoreV1().ConfigMaps("monitoring").Get(ctx, "cano-collector-state", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data[stateConfigMapKey], "1700000000.000100")
+
+	// a new leader continues with the state of the previous one
+	next := NewConfigMapStateStore(client, "monitoring", "cano-collector-state")
+	require.NoError(t, next.Load(ctx))
+	value, found := next.Get("slack-thread/#alerts/abc")
+	assert.True(t, found)
+	assert.Equal(t, "1700000000.000100", value)
+
+	// updates go to the existing ConfigMap
+	next.Delete("slack-thread/#alerts/abc")
+	require.NoError(t, next.Flush(ctx))
+	cm, err = client.CoreV1().ConfigMaps("monitoring").Get(ctx, "cano-collector-state", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, cm.Data[stateConfigMapKey], "1700000000.000100")
+}
+
+func TestConfigMapStateStore_FlushSkipsUnchangedState(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	store := NewConfigMapStateStore(client, "monitoring", "cano-collector-state")
+
+	require.NoError(t, store.Flush(ctx))
+	_, err := client.CoreV1().ConfigMaps("monitoring").Get(ctx, "cano-collector-state", metav1.GetOptions{})
+	assert.Error(t, err, "an unchanged empty state is not written")
+
+	store.Set("key", "value", time.Hour)
+	require.NoError(t, store.Flush(ctx))
+	actions := len(client.Actions())
+
+	require.NoError(t, store.Flush(ctx))
+	assert.Len(t, client.Actions(), actions, "flushing again without changes does not call the API")
+}
+
+func TestConfigMapStateStore_LoadMissingConfigMap(t *testing.T) {
+	store := NewConfigMapStateStore(fake.NewClientset(), "monitoring", "cano-collector-state")
+	require.NoError(t, store.Load(context.Background()))
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestConfigMapStateStore_LoadInvalidData(t *testing.T) {
+	client := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cano-collector-state", Namespace: "monitoring"},
+		Data:       map[string]string{stateConfigMapKey: "not json"},
+	})
+	store := NewConfigMapStateStore(client, "monitoring", "cano-collector-state")
+	assert.Error(t, store.Load(context.Background()))
+}
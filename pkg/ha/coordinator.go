@@ -0,0 +1,516 @@
+package ha
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/kubecano/cano-collector/config"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+const (
+	// ForwardedByHeader marks requests forwarded by a follower; they are never forwarded again
+	ForwardedByHeader = "X-Cano-Forwarded-By"
+
+	// stateTimeout bounds loading and flushing the shared state
+	stateTimeout = 5 * time.Second
+)
+
+// Outcomes reported in the cano_ha_requests_total metric
+const (
+	outcomeForwarded = "forwarded"
+	outcomeBuffered  = "buffered"
+	outcomeReplayed  = "replayed"
+	outcomeDuplicate = "duplicate"
+	outcomeDropped   = "dropped"
+)
+
+// Coordinator elects a leader among collector replicas with a Lease. The leader processes
+// ingestion requests; followers forward them to the leader or buffer them until one is reachable.
+type Coordinator struct {
+	cfg        config.HAConfig
+	client     kubernetes.Interface
+	httpClient util.HTTPClient
+	logger     logger_interfaces.LoggerInterface
+	metrics    metric_interfaces.MetricsInterface
+	store      *ConfigMapStateStore
+	buffer     *requestBuffer
+
+	mu           sync.RWMutex
+	leader       bool
+	leaderHolder string
+	handler      http.Handler
+	cancel       context.CancelFunc
+	stopped      bool
+
+	// flushMu serializes delivering buffered requests
+	flushMu sync.Mutex
+}
+
+// NewCoordinator creates a coordinator; client is only used when HA mode is enabled
+func NewCoordinator(
+	cfg config.HAConfig,
+	client kubernetes.Interface,
+	httpClient util.HTTPClient,
+	logger logger_interfaces.LoggerInterface,
+	metrics metric_interfaces.MetricsInterface,
+) (*Coordinator, error) {
+	c := &Coordinator{
+		cfg:     cfg,
+		logger:  logger,
+		metrics: metrics,
+	}
+	if !cfg.Enabled {
+		return c, nil
+	}
+
+	if client == nil {
+		return nil, errors.New("HA mode requires a Kubernetes client")
+	}
+	if cfg.Identity == "" {
+		return nil, errors.New("HA mode requires an identity, set POD_NAME")
+	}
+	if strings.Contains(cfg.Identity, "@") {
+		return nil, fmt.Errorf("HA identity %q must not contain '@'", cfg.Identity)
+	}
+	advertise, err := url.Parse(cfg.AdvertiseAddress)
+	if err != nil || (advertise.Scheme != "http" && advertise.Scheme != "https") || advertise.Host == "" {
+		return nil, fmt.Errorf("HA mode requires an http(s) advertise address, got %q", cfg.AdvertiseAddress)
+	}
+	if cfg.BufferSize <= 0 {
+		return nil, fmt.Errorf("HA buffer size must be positive, got %d", cfg.BufferSize)
+	}
+	if httpClient == nil {
+		httpClient = util.DefaultHTTPClient()
+	}
+
+	c.client = client
+	c.httpClient = httpClient
+	c.store = NewConfigMapStateStore(client, cfg.Namespace, cfg.StateConfigMap)
+	c.buffer = newRequestBuffer(cfg.BufferSize)
+
+	// validates the lease timings before the election starts
+	if _, err := leaderelection.NewLeaderElector(c.electionConfig()); err != nil {
+		return nil, fmt.Errorf("invalid leader election configuration: %w", err)
+	}
+	return c, nil
+}
+
+func (c *Coordinator) Enabled() bool {
+	return c.cfg.Enabled
+}
+
+// StateStore returns the state shared between leaders, or nil when HA mode is disabled
+func (c *Coordinator) StateStore() ha_interfaces.StateStoreInterface {
+	if !c.cfg.Enabled {
+		return nil
+	}
+	return c.store
+}
+
+func (c *Coordinator) SetHandler(handler http.Handler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handler = handler
+}
+
+// IsLeader reports whether this replica dispatches issues; it is always true when HA mode is disabled
+func (c *Coordinator) IsLeader() bool {
+	if !c.cfg.Enabled {
+		return true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.leader
+}
+
+func (c *Coordinator) Status() ha_interfaces.LeadershipStatus {
+	if !c.cfg.Enabled {
+		return ha_interfaces.LeadershipStatus{Enabled: false, Leader: true}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	leaderIdentity, _ := parseHolderIdentity(c.leaderHolder)
+	return ha_interfaces.LeadershipStatus{
+		Enabled:          true,
+		Leader:           c.leader,
+		Identity:         c.cfg.Identity,
+		LeaderIdentity:   leaderIdentity,
+		BufferedRequests: c.buffer.len(),
+	}
+}
+
+// Run takes part in leader election until ctx is cancelled or Stop is called. A replica
+// that loses the lease campaigns again, so it can take over when the new leader goes away.
+func (c *Coordinator) Run(ctx context.Context) {
+	if !c.cfg.Enabled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return
+	}
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	go c.retryBufferedRequests(ctx)
+
+	c.logger.Info("Starting leader election",
+		zap.String("lease", c.cfg.Namespace+"/"+c.cfg.LeaseName),
+		zap.String("identity", c.cfg.Identity))
+	for ctx.Err() == nil {
+		elector, err := leaderelection.NewLeaderElector(c.electionConfig())
+		if err != nil {
+			c.logger.Error("Failed to create leader elector", zap.Error(err))
+			return
+		}
+		elector.Run(ctx)
+	}
+}
+
+// Stop releases the lease, so another replica takes over without waiting for it to expire
+func (c *Coordinator) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+func (c *Coordinator) electionConfig() leaderelection.LeaderElectionConfig {
+	return leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{Name: c.cfg.LeaseName, Namespace: c.cfg.Namespace},
+			Client:    c.client.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{
+				Identity: holderIdentity(c.cfg.Identity, c.cfg.AdvertiseAddress),
+			},
+		},
+		Name:            c.cfg.LeaseName,
+		LeaseDuration:   c.cfg.LeaseDuration,
+		RenewDeadline:   c.cfg.RenewDeadline,
+		RetryPeriod:     c.cfg.RetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: c.onStartedLeading,
+			OnStoppedLeading: c.onStoppedLeading,
+			OnNewLeader:      c.onNewLeader,
+		},
+	}
+}
+
+// onStartedLeading loads the state of the previous leader, replays buffered requests and
+// keeps the shared state in sync until leadership is lost
+func (c *Coordinator) onStartedLeading(ctx context.Context) {
+	loadCtx, cancel := context.WithTimeout(ctx, stateTimeout)
+	if err := c.store.Load(loadCtx); err != nil {
+		c.logger.Error("Failed to load shared state, continuing with local state", zap.Error(err))
+	}
+	cancel()
+
+	c.setLeader(true)
+	c.logger.Info("Became HA leader", zap.String("identity", c.cfg.Identity))
+	go c.flushBuffer(ctx)
+
+	ticker := time.NewTicker(c.cfg.StateSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// ctx is already cancelled, so the final flush gets its own deadline
+			flushCtx, cancel := context.WithTimeout(context.Background(), stateTimeout)
+			c.flushState(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			c.flushState(ctx)
+		}
+	}
+}
+
+func (c *Coordinator) onStoppedLeading() {
+	if c.IsLeader() {
+		c.logger.Info("Stopped being HA leader", zap.String("identity", c.cfg.Identity))
+	}
+	c.setLeader(false)
+}
+
+func (c *Coordinator) onNewLeader(holder string) {
+	c.mu.Lock()
+	c.leaderHolder = holder
+	c.mu.Unlock()
+
+	leaderIdentity, _ := parseHolderIdentity(holder)
+	if leaderIdentity == c.cfg.Identity {
+		return
+	}
+	c.logger.Info("New HA leader elected", zap.String("leader", leaderIdentity))
+	go c.flushBuffer(context.Background())
+}
+
+func (c *Coordinator) setLeader(leader bool) {
+	c.mu.Lock()
+	c.leader = leader
+	c.mu.Unlock()
+	c.metrics.SetHALeader(leader)
+}
+
+func (c *Coordinator) flushState(ctx context.Context) {
+	if err := c.store.Flush(ctx); err != nil {
+		c.logger.Error("Failed to persist shared state", zap.Error(err))
+	}
+}
+
+// leaderAddress returns the advertise address of another replica holding the lease
+func (c *Coordinator) leaderAddress() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	identity, address := parseHolderIdentity(c.leaderHolder)
+	if identity == c.cfg.Identity {
+		return ""
+	}
+	return address
+}
+
+// Middleware lets the leader process requests, ignoring duplicates within the dedup window.
+// Followers forward requests to the leader and buffer them when it is unreachable.
+func (c *Coordinator) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !c.cfg.Enabled {
+			ctx.Next()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if c.IsLeader() {
+			c.processAsLeader(ctx, body)
+			return
+		}
+
+		req := newBufferedRequest(ctx.Request, body)
+		if ctx.GetHeader(ForwardedByHeader) == "" {
+			if address := c.leaderAddress(); address != "" {
+				status, header, respBody, err := c.forward(ctx.Request.Context(), address, req)
+				if err == nil {
+					c.metrics.IncHARequests(outcomeForwarded)
+					ctx.Abort()
+					ctx.Data(status, header.Get("Content-Type"), respBody)
+					return
+				}
+				c.logger.Warn("Failed to forward request to HA leader, buffering it",
+					zap.String("path", ctx.Request.URL.Path), zap.Error(err))
+			}
+		}
+
+		if !c.buffer.push(req) {
+			c.metrics.IncHARequests(outcomeDropped)
+			c.logger.Error("HA request buffer is full, rejecting request", zap.String("path", ctx.Request.URL.Path))
+			ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "no leader available"})
+			return
+		}
+		c.metrics.IncHARequests(outcomeBuffered)
+		ctx.AbortWithStatusJSON(http.StatusAccepted, gin.H{"status": "accepted, waiting for leader"})
+	}
+}
+
+// processAsLeader ignores a request identical to one received within the dedup window, e.g. the
+// same notification delivered to several replicas, and forgets failed requests so retries succeed
+func (c *Coordinator) processAsLeader(ctx *gin.Context, body []byte) {
+	if c.cfg.DedupWindow <= 0 {
+		ctx.Next()
+		return
+	}
+
+	key := dedupKey(ctx.Request, body)
+	if !c.store.SetIfAbsent(key, c.cfg.Identity, c.cfg.DedupWindow) {
+		c.metrics.IncHARequests(outcomeDuplicate)
+		c.logger.Info("Ignoring duplicate request", zap.String("path", ctx.Request.URL.Path))
+		ctx.AbortWithStatusJSON(http.StatusOK, gin.H{"status": "duplicate request ignored"})
+		return
+	}
+
+	ctx.Next()
+	if ctx.Writer.Status() >= http.StatusInternalServerError {
+		c.store.Delete(key)
+	}
+}
+
+// forward sends the request to the leader and returns its response
+func (c *Coordinator) forward(ctx context.Context, address string, req *bufferedRequest) (int, http.Header, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.ForwardTimeout)
+	defer cancel()
+
+	httpReq, err := req.toHTTPRequest(ctx, strings.TrimSuffix(address, "/"))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to build forwarded request: %w", err)
+	}
+	httpReq.Header.Set(ForwardedByHeader, c.cfg.Identity)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to forward request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read forwarded response: %w", err)
+	}
+	return resp.StatusCode, resp.Header, respBody, nil
+}
+
+// flushBuffer delivers buffered requests to the current leader, replaying them locally when
+// this replica leads. Requests that cannot be delivered stay buffered for the next attempt.
+func (c *Coordinator) flushBuffer(ctx context.Context) {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	requests := c.buffer.takeAll()
+	for i, req := range requests {
+		if err := c.deliver(ctx, req); err != nil {
+			if dropped := c.buffer.requeue(requests[i:]); dropped > 0 {
+				c.logger.Error("HA request buffer overflowed, dropping requests", zap.Int("dropped", dropped))
+				for range dropped {
+					c.metrics.IncHARequests(outcomeDropped)
+				}
+			}
+			c.logger.Warn("Failed to deliver buffered requests, will retry",
+				zap.Int("remaining", len(requests)-i), zap.Error(err))
+			return
+		}
+	}
+	if len(requests) > 0 {
+		c.logger.Info("Delivered buffered requests", zap.Int("count", len(requests)))
+	}
+}
+
+func (c *Coordinator) deliver(ctx context.Context, req *bufferedRequest) error {
+	if c.IsLeader() {
+		c.mu.RLock()
+		handler := c.handler
+		c.mu.RUnlock()
+		if handler == nil {
+			return errors.New("no handler to replay requests")
+		}
+
+		httpReq, err := req.toHTTPRequest(ctx, "")
+		if err != nil {
+			return fmt.Errorf("failed to build replayed request: %w", err)
+		}
+		w := newReplayResponseWriter()
+		handler.ServeHTTP(w, httpReq)
+		if w.status >= http.StatusInternalServerError {
+			c.logger.Warn("Replayed request failed", zap.String("path", httpReq.URL.Path), zap.Int("status", w.status))
+		}
+		c.metrics.IncHARequests(outcomeReplayed)
+		return nil
+	}
+
+	address := c.leaderAddress()
+	if address == "" {
+		return errors.New("no leader elected")
+	}
+	status, _, _, err := c.forward(ctx, address, req)
+	if err != nil {
+		return err
+	}
+	if status >= http.StatusInternalServerError {
+		c.logger.Warn("HA leader failed to process buffered request", zap.Int("status", status))
+	}
+	c.metrics.IncHARequests(outcomeForwarded)
+	return nil
+}
+
+// retryBufferedRequests periodically retries delivering buffered requests
+func (c *Coordinator) retryBufferedRequests(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.RetryPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.buffer.len() > 0 {
+				c.flushBuffer(ctx)
+			}
+		}
+	}
+}
+
+// holderIdentity encodes the replica identity and the address followers forward requests to
+func holderIdentity(identity, address string) string {
+	return identity + "@" + address
+}
+
+func parseHolderIdentity(holder string) (string, string) {
+	identity, address, _ := strings.Cut(holder, "@")
+	return identity, address
+}
+
+// dedupKey identifies a request by method, path and body
+func dedupKey(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return "request/" + hex.EncodeToString(h.Sum(nil))
+}
+
+// replayResponseWriter discards the response of a replayed request and keeps its status
+type replayResponseWriter struct {
+	header http.Header
+	status int
+}
+
+func newReplayResponseWriter() *replayResponseWriter {
+	return &replayResponseWriter{header: make(http.Header), status: http.StatusOK}
+}
+
+func (w *replayResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *replayResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *replayResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
@@ -0,0 +1,302 @@
+package ha
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubecano/cano-collector/config"
+	"github.com/kubecano/cano-collector/mocks"
+)
+
+func testHAConfig() config.HAConfig {
+	return config.HAConfig{
+		Enabled:           true,
+		Namespace:         "monitoring",
+		LeaseName:         "cano-collector-leader",
+		Identity:          "cano-collector-0",
+		AdvertiseAddress:  "http://10.0.0.1:8080",
+		LeaseDuration:     2 * time.Second,
+		RenewDeadline:     time.Second,
+		RetryPeriod:       100 * time.Millisecond,
+		StateConfigMap:    "cano-collector-state",
+		StateSyncInterval: 100 * time.Millisecond,
+		DedupWindow:       time.Minute,
+		ForwardTimeout:    time.Second,
+		BufferSize:        2,
+	}
+}
+
+type coordinatorTestDeps struct {
+	coordinator *Coordinator
+	metrics     *mocks.MockMetricsInterface
+	client      *fake.Clientset
+}
+
+func setupCoordinatorTest(t *testing.T, cfg config.HAConfig) coordinatorTestDeps {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+	mockMetrics.EXPECT().SetHALeader(gomock.Any()).AnyTimes()
+
+	client := fake.NewClientset()
+	coordinator, err := NewCoordinator(cfg, client, nil, mockLogger, mockMetrics)
+	require.NoError(t, err)
+
+	return coordinatorTestDeps{coordinator: coordinator, metrics: mockMetrics, client: client}
+}
+
+// newCoordinatorRouter serves /api/alerts behind the coordinator middleware and counts processed requests
+func newCoordinatorRouter(c *Coordinator, status *atomic.Int32, processed *atomic.Int32) *gin.Engine {
+	r := gin.New()
+	api := r.Group("/api", c.Middleware())
+	api.POST("/alerts", func(ctx *gin.Context) {
+		processed.Add(1)
+		ctx.JSON(int(status.Load()), gin.H{"status": "alert processed"})
+	})
+	c.SetHandler(r)
+	return r
+}
+
+func postAlert(router http.Handler, body string, header http.Header) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/alerts", strings.NewReader(body))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCoordinator_Disabled(t *testing.T) {
+	cfg := testHAConfig()
+	cfg.Enabled = false
+	deps := setupCoordinatorTest(t, cfg)
+	c := deps.coordinator
+
+	assert.False(t, c.Enabled())
+	assert.True(t, c.IsLeader())
+	assert.Nil(t, c.StateStore())
+	assert.Equal(t, false, c.Status().Enabled)
+
+	var status, processed atomic.Int32
+	status.Store(http.StatusOK)
+	router := newCoordinatorRouter(c, &status, &processed)
+	postAlert(router, `{"alerts":[]}`, nil)
+	postAlert(router, `{"alerts":[]}`, nil)
+	assert.Equal(t, int32(2), processed.Load(), "duplicates are only ignored in HA mode")
+}
+
+func TestNewCoordinator_InvalidConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+	client := fake.NewClientset()
+
+	_, err := NewCoordinator(testHAConfig(), nil, nil, mockLogger, mockMetrics)
+	assert.ErrorContains(t, err, "Kubernetes client")
+
+	cfg := testHAConfig()
+	cfg.Identity = ""
+	_, err = NewCoordinator(cfg, client, nil, mockLogger, mockMetrics)
+	assert.ErrorContains(t, err, "identity")
+
+	cfg = testHAConfig()
+	cfg.Identity = "pod@node"
+	_, err = NewCoordinator(cfg, client, nil, mockLogger, mockMetrics)
+	assert.ErrorContains(t, err, "must not contain")
+
+	cfg = testHAConfig()
+	cfg.AdvertiseAddress = "10.0.0.1:8080"
+	_, err = NewCoordinator(cfg, client, nil, mockLogger, mockMetrics)
+	assert.ErrorContains(t, err, "advertise address")
+
+	cfg = testHAConfig()
+	cfg.RenewDeadline = 3 * time.Second
+	_, err = NewCoordinator(cfg, client, nil, mockLogger, mockMetrics)
+	assert.ErrorContains(t, err, "leader election")
+}
+
+func TestCoordinator_LeaderIgnoresDuplicates(t *testing.T) {
+	deps := setupCoordinatorTest(t, testHAConfig())
+	c := deps.coordinator
+	c.leader = true
+	deps.metrics.EXPECT().IncHARequests(outcomeDuplicate).Times(1)
+
+	var status, processed atomic.Int32
+	status.Store(http.StatusOK)
+	router := newCoordinatorRouter(c, &status, &processed)
+
+	assert.Equal(t, http.StatusOK, postAlert(router, `{"alerts":[1]}`, nil).Code)
+	w := postAlert(router, `{"alerts":[1]}`, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "duplicate")
+	assert.Equal(t, http.StatusOK, postAlert(router, `{"alerts":[2]}`, nil).Code)
+
+	assert.Equal(t, int32(2), processed.Load())
+}
+
+func TestCoordinator_LeaderProcessesRetryOfFailedRequest(t *testing.T) {
+	deps := setupCoordinatorTest(t, testHAConfig())
+	c := deps.coordinator
+	c.leader = true
+
+	var status, processed atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	router := newCoordinatorRouter(c, &status, &processed)
+
+	assert.Equal(t, http.StatusInternalServerError, postAlert(router, `{"alerts":[1]}`, nil).Code)
+	status.Store(http.StatusOK)
+	assert.Equal(t, http.StatusOK, postAlert(router, `{"alerts":[1]}`, nil).Code)
+	assert.Equal(t, int32(2), processed.Load())
+}
+
+func TestCoordinator_FollowerForwardsToLeader(t *testing.T) {
+	deps := setupCoordinatorTest(t, testHAConfig())
+	c := deps.coordinator
+	deps.metrics.EXPECT().IncHARequests(outcomeForwarded).Times(1)
+
+	var forwardedBy, forwardedBody, authorization string
+	leader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwardedBy = r.Header.Get(ForwardedByHeader)
+		authorization = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		forwardedBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"alert processed"}`))
+	}))
+	defer leader.Close()
+	c.leaderHolder = holderIdentity("cano-collector-1", leader.URL)
+
+	var status, processed atomic.Int32
+	router := newCoordinatorRouter(c, &status, &processed)
+	w := postAlert(router, `{"alerts":[1]}`, http.Header{"Authorization": {"Bearer token"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"alert processed"}`, w.Body.String())
+	assert.Equal(t, "cano-collector-0", forwardedBy)
+	assert.Equal(t, "Bearer token", authorization)
+	assert.Equal(t, `{"alerts":[1]}`, forwardedBody)
+	assert.Equal(t, int32(0), processed.Load(), "followers do not process requests")
+	assert.Equal(t, "cano-collector-1", c.Status().LeaderIdentity)
+}
+
+func TestCoordinator_FollowerBuffersAndReplaysAsLeader(t *testing.T) {
+	deps := setupCoordinatorTest(t, testHAConfig())
+	c := deps.coordinator
+	deps.metrics.EXPECT().IncHARequests(outcomeBuffered).Times(2)
+	deps.metrics.EXPECT().IncHARequests(outcomeDropped).Times(1)
+	deps.metrics.EXPECT().IncHARequests(outcomeReplayed).Times(2)
+
+	var status, processed atomic.Int32
+	status.Store(http.StatusOK)
+	router := newCoordinatorRouter(c, &status, &processed)
+
+	assert.Equal(t, http.StatusAccepted, postAlert(router, `{"alerts":[1]}`, nil).Code)
+	assert.Equal(t, http.StatusAccepted, postAlert(router, `{"alerts":[2]}`, nil).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, postAlert(router, `{"alerts":[3]}`, nil).Code, "buffer is full")
+	assert.Equal(t, 2, c.Status().BufferedRequests)
+
+	c.leader = true
+	c.flushBuffer(context.Background())
+
+	assert.Equal(t, int32(2), processed.Load())
+	assert.Equal(t, 0, c.Status().BufferedRequests)
+}
+
+func TestCoordinator_ForwardedRequestIsNotForwardedAgain(t *testing.T) {
+	deps := setupCoordinatorTest(t, testHAConfig())
+	c := deps.coordinator
+	deps.metrics.EXPECT().IncHARequests(outcomeBuffered).Times(1)
+
+	var calls atomic.Int32
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer other.Close()
+	c.leaderHolder = holderIdentity("cano-collector-1", other.URL)
+
+	var status, processed atomic.Int32
+	router := newCoordinatorRouter(c, &status, &processed)
+	w := postAlert(router, `{"alerts":[1]}`, http.Header{ForwardedByHeader: {"cano-collector-2"}})
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, int32(0), calls.Load())
+}
+
+func TestCoordinator_LeaderElection(t *testing.T) {
+	deps := setupCoordinatorTest(t, testHAConfig())
+	c := deps.coordinator
+	deps.metrics.EXPECT().IncHARequests(gomock.Any()).AnyTimes()
+
+	done := make(chan struct{})
+	go func() {
+		c.Run(context.Background())
+		close(done)
+	}()
+
+	require.Eventually(t, c.IsLeader, 5*time.Second, 20*time.Millisecond)
+	lease, err := deps.client.CoordinationV1().Leases("monitoring").Get(context.Background(), "cano-collector-leader", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "cano-collector-0@http://10.0.0.1:8080", *lease.Spec.HolderIdentity)
+	require.Eventually(t, func() bool { return c.Status().LeaderIdentity == "cano-collector-0" }, 5*time.Second, 20*time.Millisecond)
+
+	c.StateStore().Set("slack-thread/#alerts/abc", "1700000000.000100", time.Hour)
+
+	c.Stop()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("coordinator did not stop")
+	}
+	assert.False(t, c.IsLeader())
+
+	lease, err = deps.client.CoordinationV1().Leases("monitoring").Get(context.Background(), "cano-collector-leader", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, *lease.Spec.HolderIdentity, "the lease is released for a fast failover")
+
+	require.Eventually(t, func() bool {
+		cm, err := deps.client.CoreV1().ConfigMaps("monitoring").Get(context.Background(), "cano-collector-state", metav1.GetOptions{})
+		return err == nil && strings.Contains(cm.Data[stateConfigMapKey], "1700000000.000100")
+	}, 5*time.Second, 20*time.Millisecond, "the shared state is persisted when leadership ends")
+}
+
+func TestRequestBuffer_RequeueKeepsOrder(t *testing.T) {
+	buffer := newRequestBuffer(3)
+	first := &bufferedRequest{requestURI: "/1"}
+	second := &bufferedRequest{requestURI: "/2"}
+	third := &bufferedRequest{requestURI: "/3"}
+	fourth := &bufferedRequest{requestURI: "/4"}
+
+	assert.True(t, buffer.push(first))
+	assert.True(t, buffer.push(second))
+	taken := buffer.takeAll()
+	assert.True(t, buffer.push(third))
+	assert.True(t, buffer.push(fourth))
+
+	assert.Equal(t, 1, buffer.requeue(taken))
+	assert.Equal(t, []*bufferedRequest{first, second, third}, buffer.takeAll())
+}
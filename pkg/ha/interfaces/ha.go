@@ -0,0 +1,46 @@
+package interfaces
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LeadershipStatus describes the leader election state of this replica
+type LeadershipStatus struct {
+	Enabled          bool   `json:"enabled"`
+	Leader           bool   `json:"leader"`
+	Identity         string `json:"identity,omitempty"`
+	LeaderIdentity   string `json:"leader_identity,omitempty"`
+	BufferedRequests int    `json:"buffered_requests"`
+}
+
+// CoordinatorInterface coordinates several collector replicas so only the leader dispatches issues.
+//
+//go:generate mockgen -source=ha.go -destination=../../../mocks/ha_mock.go -package=mocks
+type CoordinatorInterface interface {
+	// Enabled reports whether HA mode is configured
+	Enabled() bool
+	// Run takes part in leader election until ctx is cancelled or Stop is called
+	Run(ctx context.Context)
+	// Stop gives up leadership so another replica can take over immediately
+	Stop()
+	IsLeader() bool
+	Status() LeadershipStatus
+	// Middleware passes requests through on the leader and forwards or buffers them on followers
+	Middleware() gin.HandlerFunc
+	// SetHandler sets the handler buffered requests are replayed against once this replica leads
+	SetHandler(handler http.Handler)
+	// StateStore returns the state shared between leaders, or nil when HA mode is disabled
+	StateStore() StateStoreInterface
+}
+
+// StateStoreInterface stores small string values, such as Slack thread timestamps, that must
+// survive a leader failover
+type StateStoreInterface interface {
+	Get(key string) (string, bool)
+	Set(key, value string, ttl time.Duration)
+	Delete(key string)
+}
@@ -0,0 +1,91 @@
+package ha
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+)
+
+// bufferedRequest is an ingestion request kept by a follower until a leader can process it
+type bufferedRequest struct {
+	method     string
+	requestURI string
+	header     http.Header
+	body       []byte
+}
+
+func newBufferedRequest(r *http.Request, body []byte) *bufferedRequest {
+	return &bufferedRequest{
+		method:     r.Method,
+		requestURI: r.URL.RequestURI(),
+		header:     r.Header.Clone(),
+		body:       body,
+	}
+}
+
+// toHTTPRequest builds a request for baseURL; an empty baseURL builds a server-side request for local replay
+func (b *bufferedRequest) toHTTPRequest(ctx context.Context, baseURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, b.method, baseURL+b.requestURI, bytes.NewReader(b.body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = b.header.Clone()
+	return req, nil
+}
+
+// requestBuffer is a bounded FIFO of buffered requests
+type requestBuffer struct {
+	mu       sync.Mutex
+	requests []*bufferedRequest
+	size     int
+}
+
+func newRequestBuffer(size int) *requestBuffer {
+	return &requestBuffer{size: size}
+}
+
+// push appends the request and reports false when the buffer is full
+func (b *requestBuffer) push(req *bufferedRequest) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.requests) >= b.size {
+		return false
+	}
+	b.requests = append(b.requests, req)
+	return true
+}
+
+// takeAll empties the buffer and returns its requests in arrival order
+func (b *requestBuffer) takeAll() []*bufferedRequest {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	requests := b.requests
+	b.requests = nil
+	return requests
+}
+
+// requeue puts back requests that could not be delivered ahead of newer ones, dropping the
+// newest when the buffer overflows; it returns the number of dropped requests
+func (b *requestBuffer) requeue(requests []*bufferedRequest) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	combined := append(requests, b.requests...)
+	dropped := 0
+	if len(combined) > b.size {
+		dropped = len(combined) - b.size
+		combined = combined[:b.size]
+	}
+	b.requests = combined
+	return dropped
+}
+
+func (b *requestBuffer) len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.requests)
+}
@@ -0,0 +1,122 @@
+package ha
+
+import (
+	"sync"
+	"time"
+)
+
+// stateEntry is a stored value together with its expiry
+type stateEntry struct {
+	Value     string    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MemoryStateStore is an in-memory state store with per-key expiry
+type MemoryStateStore struct {
+	mu      sync.Mutex
+	entries map[string]stateEntry
+	// version changes on every write so persistent stores know when to flush
+	version uint64
+	now     func() time.Time
+}
+
+// NewMemoryStateStore creates an empty in-memory state store
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		entries: make(map[string]stateEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value of a non-expired key
+func (s *MemoryStateStore) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.entries[key]
+	if !exists {
+		return "", false
+	}
+	if !s.now().Before(entry.ExpiresAt) {
+		delete(s.entries, key)
+		return "", false
+	}
+	return entry.Value, true
+}
+
+// Set stores the value for ttl
+func (s *MemoryStateStore) Set(key, value string, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = stateEntry{Value: value, ExpiresAt: s.now().Add(ttl)}
+	s.version++
+}
+
+// SetIfAbsent stores the value for ttl unless the key holds a non-expired value and reports whether it was stored
+func (s *MemoryStateStore) SetIfAbsent(key, value string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if entry, exists := s.entries[key]; exists && now.Before(entry.ExpiresAt) {
+		return false
+	}
+	s.entries[key] = stateEntry{Value: value, ExpiresAt: now.Add(ttl)}
+	s.version++
+	return true
+}
+
+// Delete removes the key
+func (s *MemoryStateStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[key]; !exists {
+		return
+	}
+	delete(s.entries, key)
+	s.version++
+}
+
+// snapshot drops expired entries and returns a copy of the remaining ones with the current version
+func (s *MemoryStateStore) snapshot() (map[string]stateEntry, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	entries := make(map[string]stateEntry, len(s.entries))
+	for key, entry := range s.entries {
+		if !now.Before(entry.ExpiresAt) {
+			delete(s.entries, key)
+			continue
+		}
+		entries[key] = entry
+	}
+	return entries, s.version
+}
+
+// merge adds entries that are not known locally or expire later than the local ones
+func (s *MemoryStateStore) merge(entries map[string]stateEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, entry := range entries {
+		if !now.Before(entry.ExpiresAt) {
+			continue
+		}
+		if local, exists := s.entries[key]; exists && !entry.ExpiresAt.After(local.ExpiresAt) {
+			continue
+		}
+		s.entries[key] = entry
+	}
+}
+
+// Len returns the number of stored entries, including expired ones not yet removed
+func (s *MemoryStateStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.entries)
+}
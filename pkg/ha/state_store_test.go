@@ -0,0 +1,86 @@
+package ha
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStateStore_SetGetDelete(t *testing.T) {
+	store := NewMemoryStateStore()
+
+	_, found := store.Get("key")
+	assert.False(t, found)
+
+	store.Set("key", "value", time.Minute)
+	value, found := store.Get("key")
+	assert.True(t, found)
+	assert.Equal(t, "value", value)
+
+	store.Delete("key")
+	_, found = store.Get("key")
+	assert.False(t, found)
+}
+
+func TestMemoryStateStore_Expiry(t *testing.T) {
+	store := NewMemoryStateStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	store.Set("key", "value", time.Minute)
+	now = now.Add(time.Minute)
+
+	_, found := store.Get("key")
+	assert.False(t, found)
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestMemoryStateStore_SetIfAbsent(t *testing.T) {
+	store := NewMemoryStateStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	assert.True(t, store.SetIfAbsent("key", "first", time.Minute))
+	assert.False(t, store.SetIfAbsent("key", "second", time.Minute))
+
+	value, _ := store.Get("key")
+	assert.Equal(t, "first", value)
+
+	now = now.Add(time.Minute)
+	assert.True(t, store.SetIfAbsent("key", "third", time.Minute), "expired keys can be set again")
+}
+
+func TestMemoryStateStore_VersionChangesOnWrite(t *testing.T) {
+	store := NewMemoryStateStore()
+	_, initial := store.snapshot()
+
+	store.Delete("missing")
+	_, version := store.snapshot()
+	assert.Equal(t, initial, version, "deleting a missing key is not a change")
+
+	store.Set("key", "value", time.Minute)
+	_, version = store.snapshot()
+	assert.NotEqual(t, initial, version)
+}
+
+func TestMemoryStateStore_MergeKeepsLaterExpiry(t *testing.T) {
+	store := NewMemoryStateStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	store.Set("local", "newer", 2*time.Minute)
+	store.merge(map[string]stateEntry{
+		"local":   {Value: "older", ExpiresAt: now.Add(time.Minute)},
+		"remote":  {Value: "remote", ExpiresAt: now.Add(time.Minute)},
+		"expired": {Value: "expired", ExpiresAt: now.Add(-time.Second)},
+	})
+
+	value, _ := store.Get("local")
+	assert.Equal(t, "newer", value)
+	value, found := store.Get("remote")
+	assert.True(t, found)
+	assert.Equal(t, "remote", value)
+	_, found = store.Get("expired")
+	assert.False(t, found)
+}
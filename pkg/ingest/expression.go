@@ -0,0 +1,190 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// pathSegment is a single step of a JSONPath: an object key or an array index
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// jsonPath is a parsed subset of JSONPath supporting dot notation ($.a.b),
+// bracket notation ($['a.b']) and array indexes ($.items[0], $.items[-1])
+type jsonPath []pathSegment
+
+// parseJSONPath parses a JSONPath expression starting with "$"
+func parseJSONPath(expr string) (jsonPath, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("JSONPath must start with '$': %s", expr)
+	}
+
+	var path jsonPath
+	rest := expr[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("empty key in JSONPath: %s", expr)
+			}
+			path = append(path, pathSegment{key: key})
+			rest = rest[end+1:]
+		case '[':
+			closing := strings.Index(rest, "]")
+			if closing == -1 {
+				return nil, fmt.Errorf("unterminated '[' in JSONPath: %s", expr)
+			}
+			inner := rest[1:closing]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				path = append(path, pathSegment{key: inner[1 : len(inner)-1]})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index '%s' in JSONPath: %s", inner, expr)
+				}
+				path = append(path, pathSegment{index: index, isIndex: true})
+			}
+			rest = rest[closing+1:]
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' in JSONPath: %s", rest[0], expr)
+		}
+	}
+
+	return path, nil
+}
+
+// evaluate walks the decoded JSON document and returns the selected value
+func (p jsonPath) evaluate(data interface{}) (interface{}, bool) {
+	current := data
+	for _, segment := range p {
+		if segment.isIndex {
+			items, ok := current.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			index := segment.index
+			if index < 0 {
+				index += len(items)
+			}
+			if index < 0 || index >= len(items) {
+				return nil, false
+			}
+			current = items[index]
+			continue
+		}
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[segment.key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// expression is a compiled field mapping: a JSONPath, a Go template or a literal
+type expression struct {
+	raw  string
+	path jsonPath
+	tmpl *template.Template
+}
+
+// templateFuncs are the helper functions available to mapping templates
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"default": func(fallback string, value interface{}) string {
+		if s := stringifyValue(value); s != "" {
+			return s
+		}
+		return fallback
+	},
+}
+
+// compileExpression compiles a mapping expression; an empty expression compiles to nil
+func compileExpression(field, raw string) (*expression, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	expr := &expression{raw: raw}
+	switch {
+	case strings.HasPrefix(raw, "$"):
+		path, err := parseJSONPath(raw)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", field, err)
+		}
+		expr.path = path
+	case strings.Contains(raw, "{{"):
+		tmpl, err := template.New(field).Funcs(templateFuncs).Option("missingkey=zero").Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': invalid template: %w", field, err)
+		}
+		expr.tmpl = tmpl
+	}
+
+	return expr, nil
+}
+
+// evaluate resolves the expression against the payload and returns a string value
+func (e *expression) evaluate(data interface{}) (string, error) {
+	if e == nil {
+		return "", nil
+	}
+
+	switch {
+	case e.path != nil:
+		value, found := e.path.evaluate(data)
+		if !found {
+			return "", nil
+		}
+		return stringifyValue(value), nil
+	case e.tmpl != nil:
+		var buf bytes.Buffer
+		if err := e.tmpl.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("failed to render template '%s': %w", e.raw, err)
+		}
+		// Missing map keys render as "<no value>" even with missingkey=zero
+		return strings.TrimSpace(strings.ReplaceAll(buf.String(), "<no value>", "")), nil
+	default:
+		return e.raw, nil
+	}
+}
+
+// stringifyValue converts a decoded JSON value into its string form
+func stringifyValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,111 @@
+package ingest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodePayload(t *testing.T, raw string) interface{} {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+	var payload interface{}
+	require.NoError(t, decoder.Decode(&payload))
+	return payload
+}
+
+func TestParseJSONPath_Errors(t *testing.T) {
+	tests := []string{
+		"title",
+		"$..title",
+		"$.items[",
+		"$.items[abc]",
+		"$title",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := parseJSONPath(expr)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestJSONPath_Evaluate(t *testing.T) {
+	payload := decodePayload(t, `{
+		"alert": {"title": "Disk full", "count": 3, "ratio": 0.25, "active": true},
+		"labels": {"k8s.pod.name": "api-1"},
+		"items": [{"id": "a"}, {"id": "b"}],
+		"empty": null
+	}`)
+
+	tests := []struct {
+		expr     string
+		expected string
+		found    bool
+	}{
+		{"$.alert.title", "Disk full", true},
+		{"$.alert.count", "3", true},
+		{"$.alert.ratio", "0.25", true},
+		{"$.alert.active", "true", true},
+		{"$['labels']['k8s.pod.name']", "api-1", true},
+		{`$.labels["k8s.pod.name"]`, "api-1", true},
+		{"$.items[1].id", "b", true},
+		{"$.items[-1].id", "b", true},
+		{"$.items[5].id", "", false},
+		{"$.alert.missing", "", false},
+		{"$.alert.title.nested", "", false},
+		{"$.empty", "", true},
+		{"$.items[0]", `{"id":"a"}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			path, err := parseJSONPath(tt.expr)
+			require.NoError(t, err)
+
+			value, found := path.evaluate(payload)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, stringifyValue(value))
+		})
+	}
+}
+
+func TestExpression_Evaluate(t *testing.T) {
+	payload := decodePayload(t, `{"host": "db-1", "level": "WARN", "nested": {"value": 42}}`)
+
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+	}{
+		{"jsonpath", "$.nested.value", "42"},
+		{"template", "{{ .host }} is {{ lower .level }}", "db-1 is warn"},
+		{"template missing key", "{{ .missing }}", ""},
+		{"template default", `{{ default "unknown" .missing }}`, "unknown"},
+		{"literal", "pod", "pod"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := compileExpression(tt.name, tt.raw)
+			require.NoError(t, err)
+
+			value, err := expr.evaluate(payload)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestCompileExpression_InvalidTemplate(t *testing.T) {
+	_, err := compileExpression("title", "{{ .host ")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field 'title': invalid template")
+}
@@ -0,0 +1,143 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/kubecano/cano-collector/pkg/core/event"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// Falco output fields mapped onto the issue subject
+const (
+	falcoFieldPodName       = "k8s.pod.name"
+	falcoFieldNamespaceName = "k8s.ns.name"
+	falcoFieldContainerName = "container.name"
+)
+
+// HandleFalco converts a Falco event posted by falcosidekick to /api/falco into an issue and processes it
+func (h *IngestHandler) HandleFalco(c *gin.Context) {
+	body, err := readBody(c)
+	if err != nil {
+		h.logger.Error("Failed to read Falco event", zap.Error(err))
+		c.JSON(readErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if len(body) == 0 {
+		h.logger.Error("Failed to read Falco event", zap.Error(errEmptyBody))
+		c.JSON(http.StatusBadRequest, gin.H{"error": errEmptyBody.Error()})
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var falcoEvent event.FalcoEvent
+	if err := decoder.Decode(&falcoEvent); err != nil {
+		h.logger.Error("Failed to parse Falco event", zap.Error(err))
+		h.metrics.IncAlertErrors("falco", "invalid_payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Falco event format"})
+		return
+	}
+
+	if err := falcoEvent.Validate(); err != nil {
+		h.logger.Error("Invalid Falco event structure", zap.Error(err))
+		h.metrics.IncAlertErrors("falco", "invalid_payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Falco event format: " + err.Error()})
+		return
+	}
+
+	iss := convertFalcoEvent(&falcoEvent, h.clusterName)
+
+	if err := h.issueProcessor.ProcessIssues(c.Request.Context(), []*issue.Issue{iss}); err != nil {
+		h.logger.Error("Failed to process Falco issue", zap.Error(err), zap.String("rule", falcoEvent.Rule))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process issues"})
+		return
+	}
+
+	h.logger.Info("Falco event processed successfully",
+		zap.String("rule", falcoEvent.Rule),
+		zap.String("priority", falcoEvent.Priority),
+		zap.String("pod", iss.Subject.Name))
+	c.JSON(http.StatusOK, gin.H{"status": "falco event processed", "fingerprint": iss.Fingerprint})
+}
+
+// convertFalcoEvent converts a Falco event to an Issue.
+// Events of the same rule on the same subject share a fingerprint so destinations can group them.
+func convertFalcoEvent(falcoEvent *event.FalcoEvent, clusterName string) *issue.Issue {
+	iss := issue.NewIssue(falcoEvent.Rule, falcoEvent.Rule)
+	iss.Description = falcoEvent.Output
+	iss.Severity = issue.SeverityFromFalcoPriority(falcoEvent.Priority)
+	iss.Status = issue.StatusFiring
+	iss.Source = issue.SourceFalco
+	iss.ClusterName = clusterName
+	if !falcoEvent.Time.IsZero() {
+		iss.StartsAt = falcoEvent.Time
+	}
+
+	iss.SetSubject(createFalcoSubject(falcoEvent))
+
+	if len(falcoEvent.OutputFields) > 0 {
+		iss.AddEnrichment(*createFalcoFieldsEnrichment(falcoEvent.OutputFields))
+	}
+
+	return iss
+}
+
+// createFalcoSubject creates the issue subject from Kubernetes output fields,
+// falling back to the node Falco runs on for host-level events
+func createFalcoSubject(falcoEvent *event.FalcoEvent) *issue.Subject {
+	fields := falcoEvent.OutputFields
+
+	var subject *issue.Subject
+	if podName := stringifyValue(fields[falcoFieldPodName]); podName != "" {
+		subject = issue.NewSubject(podName, issue.SubjectTypePod)
+		subject.Namespace = stringifyValue(fields[falcoFieldNamespaceName])
+		subject.Container = stringifyValue(fields[falcoFieldContainerName])
+		subject.Node = falcoEvent.Hostname
+	} else if falcoEvent.Hostname != "" {
+		subject = issue.NewSubject(falcoEvent.Hostname, issue.SubjectTypeNode)
+		subject.Node = falcoEvent.Hostname
+	} else {
+		subject = issue.NewSubject("", issue.SubjectTypeNone)
+	}
+
+	subject.Labels["rule"] = falcoEvent.Rule
+	subject.Labels["priority"] = strings.ToLower(falcoEvent.Priority)
+	if falcoEvent.Source != "" {
+		subject.Labels["falco_source"] = falcoEvent.Source
+	}
+	if len(falcoEvent.Tags) > 0 {
+		subject.Annotations["tags"] = strings.Join(falcoEvent.Tags, ",")
+	}
+
+	return subject
+}
+
+// createFalcoFieldsEnrichment creates a table with the rule's output fields
+func createFalcoFieldsEnrichment(fields map[string]interface{}) *issue.Enrichment {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		value := stringifyValue(fields[key])
+		if value == "" {
+			continue
+		}
+		rows = append(rows, []string{key, value})
+	}
+
+	enrichment := issue.NewEnrichmentWithType(issue.EnrichmentTypeAlertMetadata, "Falco output fields")
+	enrichment.AddBlock(issue.NewTableBlock([]string{"field", "value"}, rows, "output_fields", issue.TableBlockFormatVertical))
+	return enrichment
+}
@@ -0,0 +1,176 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config_webhook "github.com/kubecano/cano-collector/config/webhook"
+	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/core/event"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+const falcoTestPayload = `{
+  "uuid": "b7e3c3a2-2b8f-4b8e-9a3c-1f2d3e4f5a6b",
+  "output": "10:30:00.123: Notice A shell was spawned in a container (user=root container=api pod=api-0)",
+  "priority": "Critical",
+  "rule": "Terminal shell in container",
+  "time": "2024-01-15T10:30:00.123Z",
+  "output_fields": {
+    "k8s.pod.name": "api-0",
+    "k8s.ns.name": "prod",
+    "container.name": "api",
+    "proc.cmdline": "bash",
+    "proc.pid": 4242,
+    "fd.name": null
+  },
+  "source": "syscall",
+  "tags": ["container", "shell", "mitre_execution"],
+  "hostname": "worker-1"
+}`
+
+func setupFalcoHandlerTest(t *testing.T) (*gomock.Controller, *mocks.MockIssueProcessorInterface, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+	mockMetrics.EXPECT().IncAlertErrors(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockIssueProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+
+	handler, err := NewIngestHandler(config_webhook.WebhooksConfig{}, "test-cluster", mockLogger, mockMetrics, mockIssueProcessor, nil)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.POST("/api/falco", handler.HandleFalco)
+
+	return ctrl, mockIssueProcessor, r
+}
+
+func TestIngestHandler_HandleFalco(t *testing.T) {
+	ctrl, issueProcessor, router := setupFalcoHandlerTest(t)
+	defer ctrl.Finish()
+
+	var processed []*issue.Issue
+	issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Len(1)).DoAndReturn(
+		func(_ context.Context, issues []*issue.Issue) error {
+			processed = issues
+			return nil
+		})
+
+	w := doIssueRequest(router, http.MethodPost, "/api/falco", falcoTestPayload)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, processed, 1)
+
+	iss := processed[0]
+	assert.Equal(t, "Terminal shell in container", iss.Title)
+	assert.Equal(t, "Terminal shell in container", iss.AggregationKey)
+	assert.Contains(t, iss.Description, "A shell was spawned")
+	assert.Equal(t, issue.SeverityHigh, iss.Severity)
+	assert.Equal(t, issue.SourceFalco, iss.Source)
+	assert.Equal(t, "test-cluster", iss.ClusterName)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 123000000, time.UTC), iss.StartsAt)
+	assert.Contains(t, w.Body.String(), iss.Fingerprint)
+
+	assert.Equal(t, issue.SubjectTypePod, iss.Subject.SubjectType)
+	assert.Equal(t, "api-0", iss.Subject.Name)
+	assert.Equal(t, "prod", iss.Subject.Namespace)
+	assert.Equal(t, "api", iss.Subject.Container)
+	assert.Equal(t, "worker-1", iss.Subject.Node)
+	assert.Equal(t, "critical", iss.Subject.Labels["priority"])
+	assert.Equal(t, "syscall", iss.Subject.Labels["falco_source"])
+	assert.Equal(t, "container,shell,mitre_execution", iss.Subject.Annotations["tags"])
+
+	require.Len(t, iss.Enrichments, 1)
+	table, ok := iss.Enrichments[0].Blocks[0].(*issue.TableBlock)
+	require.True(t, ok)
+	assert.Contains(t, table.Rows, []string{"proc.pid", "4242"})
+	assert.NotContains(t, table.Rows, []string{"fd.name", ""})
+}
+
+func TestConvertFalcoEvent_SameRuleAndPodShareFingerprint(t *testing.T) {
+	first := convertFalcoEvent(&event.FalcoEvent{
+		Rule:         "Write below etc",
+		Priority:     "Error",
+		OutputFields: map[string]interface{}{"k8s.pod.name": "api-0", "k8s.ns.name": "prod"},
+	}, "test-cluster")
+	second := convertFalcoEvent(&event.FalcoEvent{
+		Rule:         "Write below etc",
+		Priority:     "Error",
+		Output:       "different output",
+		OutputFields: map[string]interface{}{"k8s.pod.name": "api-0", "k8s.ns.name": "prod"},
+	}, "test-cluster")
+	other := convertFalcoEvent(&event.FalcoEvent{
+		Rule:         "Write below etc",
+		Priority:     "Error",
+		OutputFields: map[string]interface{}{"k8s.pod.name": "api-1", "k8s.ns.name": "prod"},
+	}, "test-cluster")
+
+	assert.Equal(t, first.Fingerprint, second.Fingerprint)
+	assert.NotEqual(t, first.Fingerprint, other.Fingerprint)
+}
+
+func TestConvertFalcoEvent_HostEvent(t *testing.T) {
+	iss := convertFalcoEvent(&event.FalcoEvent{
+		Rule:     "Linux kernel module injection",
+		Priority: "Warning",
+		Hostname: "worker-2",
+	}, "test-cluster")
+
+	assert.Equal(t, issue.SeverityLow, iss.Severity)
+	assert.Equal(t, issue.SubjectTypeNode, iss.Subject.SubjectType)
+	assert.Equal(t, "worker-2", iss.Subject.Name)
+	assert.Empty(t, iss.Enrichments)
+}
+
+func TestIngestHandler_HandleFalco_BadRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"empty body", "", "empty JSON body"},
+		{"invalid json", "{", "invalid Falco event format"},
+		{"missing rule", `{"priority": "Warning", "output": "x"}`, "missing rule"},
+		{"missing priority", `{"rule": "Write below etc"}`, "missing priority"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _, router := setupFalcoHandlerTest(t)
+			defer ctrl.Finish()
+
+			w := doIssueRequest(router, http.MethodPost, "/api/falco", tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantErr)
+		})
+	}
+}
+
+func TestIngestHandler_HandleFalco_ProcessingError(t *testing.T) {
+	ctrl, issueProcessor, router := setupFalcoHandlerTest(t)
+	defer ctrl.Finish()
+
+	issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Return(errors.New("dispatch failed"))
+
+	w := doIssueRequest(router, http.MethodPost, "/api/falco", falcoTestPayload)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,63 @@
+package ingest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/kubecano/cano-collector/pkg/core/event"
+)
+
+// HandleGrafana converts a Grafana unified alerting webhook payload posted to /api/grafana into issues and processes them
+func (h *IngestHandler) HandleGrafana(c *gin.Context) {
+	body, err := readBody(c)
+	if err != nil {
+		h.logger.Error("Failed to read Grafana payload", zap.Error(err))
+		c.JSON(readErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if len(body) == 0 {
+		h.logger.Error("Failed to read Grafana payload", zap.Error(errEmptyBody))
+		c.JSON(http.StatusBadRequest, gin.H{"error": errEmptyBody.Error()})
+		return
+	}
+
+	var grafanaEvent event.GrafanaEvent
+	if err := json.Unmarshal(body, &grafanaEvent); err != nil {
+		h.logger.Error("Failed to parse Grafana alert", zap.Error(err))
+		h.metrics.IncAlertErrors("grafana", "invalid_payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert format"})
+		return
+	}
+
+	if err := grafanaEvent.Validate(); err != nil {
+		h.logger.Error("Invalid Grafana alert structure", zap.Error(err))
+		h.metrics.IncAlertErrors(grafanaEvent.GetAlertName(), "invalid_payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert format: " + err.Error()})
+		return
+	}
+
+	h.metrics.ObserveAlert(grafanaEvent.Receiver, grafanaEvent.Status)
+
+	issues, err := h.converter.ConvertGrafanaEventToIssues(&grafanaEvent)
+	if err != nil {
+		h.logger.Error("Failed to convert Grafana alert to issues", zap.Error(err))
+		h.metrics.IncAlertErrors(grafanaEvent.GetAlertName(), "conversion_failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to convert alert"})
+		return
+	}
+
+	if err := h.issueProcessor.ProcessIssues(c.Request.Context(), issues); err != nil {
+		h.logger.Error("Failed to process Grafana issues", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process issues"})
+		return
+	}
+
+	h.logger.Info("Grafana alert processed successfully",
+		zap.String("receiver", grafanaEvent.Receiver),
+		zap.Int64("org_id", grafanaEvent.OrgID),
+		zap.Int("issues_count", len(issues)))
+	c.JSON(http.StatusOK, gin.H{"status": "grafana alert processed", "issues": len(issues)})
+}
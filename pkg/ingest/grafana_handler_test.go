@@ -0,0 +1,161 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config_webhook "github.com/kubecano/cano-collector/config/webhook"
+	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/alert"
+	alert_interfaces "github.com/kubecano/cano-collector/pkg/alert/interfaces"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/logger"
+)
+
+const grafanaTestPayload = `{
+  "receiver": "cano-collector",
+  "status": "firing",
+  "orgId": 1,
+  "alerts": [{
+    "status": "firing",
+    "labels": {"alertname": "HighCPU", "severity": "critical", "pod": "api-0", "namespace": "prod"},
+    "annotations": {"summary": "CPU above 90%"},
+    "startsAt": "2024-01-15T10:30:00Z",
+    "endsAt": "0001-01-01T00:00:00Z",
+    "fingerprint": "57c6d9296de2ad39",
+    "silenceURL": "https://grafana.example.com/alerting/silence/new",
+    "dashboardURL": "https://grafana.example.com/d/dash",
+    "panelURL": "https://grafana.example.com/d/dash?viewPanel=2",
+    "imageURL": "https://grafana.example.com/render/panel.png",
+    "values": {"B": 93.5}
+  }],
+  "state": "alerting",
+  "title": "[FIRING:1] HighCPU"
+}`
+
+func setupGrafanaHandlerTest(t *testing.T, converter alert_interfaces.ConverterInterface) (*gomock.Controller, *mocks.MockIssueProcessorInterface, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+	mockMetrics.EXPECT().ObserveAlert(gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().IncAlertErrors(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockIssueProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+
+	if converter == nil {
+		converter = alert.NewConverter(logger.NewLogger("info", "test"))
+	}
+
+	handler, err := NewIngestHandler(config_webhook.WebhooksConfig{}, "test-cluster", mockLogger, mockMetrics, mockIssueProcessor, converter)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.POST("/api/grafana", handler.HandleGrafana)
+
+	return ctrl, mockIssueProcessor, r
+}
+
+func TestIngestHandler_HandleGrafana(t *testing.T) {
+	ctrl, issueProcessor, router := setupGrafanaHandlerTest(t, nil)
+	defer ctrl.Finish()
+
+	var processed []*issue.Issue
+	issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, issues []*issue.Issue) error {
+			processed = issues
+			return nil
+		})
+
+	w := doIssueRequest(router, http.MethodPost, "/api/grafana", grafanaTestPayload)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "grafana alert processed", "issues": 1}`, w.Body.String())
+
+	require.Len(t, processed, 1)
+	assert.Equal(t, issue.SourceGrafana, processed[0].Source)
+	assert.Equal(t, "57c6d9296de2ad39", processed[0].Fingerprint)
+
+	var linkTypes []issue.LinkType
+	for _, link := range processed[0].Links {
+		linkTypes = append(linkTypes, link.Type)
+	}
+	assert.Contains(t, linkTypes, issue.LinkTypeDashboard)
+	assert.Contains(t, linkTypes, issue.LinkTypePanel)
+	assert.Contains(t, linkTypes, issue.LinkTypeSilence)
+
+	var image *issue.ImageBlock
+	for _, enrichment := range processed[0].Enrichments {
+		for _, block := range enrichment.Blocks {
+			if imageBlock, ok := block.(*issue.ImageBlock); ok {
+				image = imageBlock
+			}
+		}
+	}
+	require.NotNil(t, image)
+	assert.Equal(t, "https://grafana.example.com/render/panel.png", image.URL)
+}
+
+func TestIngestHandler_HandleGrafana_BadRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"empty body", "", "empty JSON body"},
+		{"invalid json", "{", "invalid alert format"},
+		{"no alerts", `{"receiver": "cano-collector", "status": "firing", "alerts": []}`, "missing alerts"},
+		{"invalid alert status", `{"alerts": [{"status": "alerting", "labels": {"alertname": "HighCPU"}}]}`, "invalid status value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _, router := setupGrafanaHandlerTest(t, nil)
+			defer ctrl.Finish()
+
+			w := doIssueRequest(router, http.MethodPost, "/api/grafana", tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantErr)
+		})
+	}
+}
+
+func TestIngestHandler_HandleGrafana_ConversionError(t *testing.T) {
+	converterCtrl := gomock.NewController(t)
+	defer converterCtrl.Finish()
+
+	mockConverter := mocks.NewMockConverterInterface(converterCtrl)
+	mockConverter.EXPECT().ConvertGrafanaEventToIssues(gomock.Any()).Return(nil, errors.New("conversion failed"))
+
+	ctrl, _, router := setupGrafanaHandlerTest(t, mockConverter)
+	defer ctrl.Finish()
+
+	w := doIssueRequest(router, http.MethodPost, "/api/grafana", grafanaTestPayload)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestIngestHandler_HandleGrafana_ProcessingError(t *testing.T) {
+	ctrl, issueProcessor, router := setupGrafanaHandlerTest(t, nil)
+	defer ctrl.Finish()
+
+	issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Return(errors.New("dispatch failed"))
+
+	w := doIssueRequest(router, http.MethodPost, "/api/grafana", grafanaTestPayload)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
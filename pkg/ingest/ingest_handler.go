@@ -0,0 +1,146 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	config_webhook "github.com/kubecano/cano-collector/config/webhook"
+	alert_interfaces "github.com/kubecano/cano-collector/pkg/alert/interfaces"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+)
+
+// IngestHandler handles issues pushed by sources other than Alertmanager
+type IngestHandler struct {
+	logger         logger_interfaces.LoggerInterface
+	metrics        metric_interfaces.MetricsInterface
+	issueProcessor alert_interfaces.IssueProcessorInterface
+	converter      alert_interfaces.ConverterInterface
+	webhookMappers map[string]*WebhookMapper
+	issueStore     *IssueStore
+	clusterName    string
+}
+
+// NewIngestHandler creates a new ingest handler and compiles the configured webhook mappings
+func NewIngestHandler(
+	webhooks config_webhook.WebhooksConfig,
+	clusterName string,
+	logger logger_interfaces.LoggerInterface,
+	metrics metric_interfaces.MetricsInterface,
+	issueProcessor alert_interfaces.IssueProcessorInterface,
+	converter alert_interfaces.ConverterInterface,
+) (*IngestHandler, error) {
+	mappers := make(map[string]*WebhookMapper, len(webhooks.Webhooks))
+	for _, webhook := range webhooks.Webhooks {
+		mapper, err := NewWebhookMapper(webhook, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		mappers[webhook.Name] = mapper
+	}
+
+	return &IngestHandler{
+		logger:         logger,
+		metrics:        metrics,
+		issueProcessor: issueProcessor,
+		converter:      converter,
+		webhookMappers: mappers,
+		issueStore:     NewIssueStore(defaultIssueStoreTTL),
+		clusterName:    clusterName,
+	}, nil
+}
+
+// SetStateStore shares issues received through the API between HA replicas
+func (h *IngestHandler) SetStateStore(store ha_interfaces.StateStoreInterface) {
+	h.issueStore.SetStateStore(store)
+}
+
+// HandleWebhook maps a generic JSON payload posted to /api/webhooks/:name into issues and processes them
+func (h *IngestHandler) HandleWebhook(c *gin.Context) {
+	name := c.Param("name")
+	mapper, exists := h.webhookMappers[name]
+	if !exists {
+		h.logger.Warn("Received payload for unknown webhook", zap.String("webhook", name))
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown webhook: " + name})
+		return
+	}
+
+	payload, err := readJSONPayload(c)
+	if err != nil {
+		h.logger.Error("Failed to parse webhook payload", zap.Error(err), zap.String("webhook", name))
+		h.metrics.IncAlertErrors(name, "invalid_payload")
+		c.JSON(readErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	issues, err := mapper.Map(payload)
+	if err != nil {
+		h.logger.Error("Failed to map webhook payload to issues", zap.Error(err), zap.String("webhook", name))
+		h.metrics.IncAlertErrors(name, "mapping_failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to map payload: " + err.Error()})
+		return
+	}
+
+	if err := h.issueProcessor.ProcessIssues(c.Request.Context(), issues); err != nil {
+		h.logger.Error("Failed to process webhook issues", zap.Error(err), zap.String("webhook", name))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process issues"})
+		return
+	}
+
+	h.logger.Info("Webhook processed successfully",
+		zap.String("webhook", name),
+		zap.Int("issues_count", len(issues)))
+	c.JSON(http.StatusOK, gin.H{"status": "webhook processed", "issues": len(issues)})
+}
+
+// errEmptyBody rejects requests without a body
+var errEmptyBody = errors.New("empty JSON body")
+
+// readErrorStatus returns the status of a request whose body could not be read or decoded: 413
+// when the body exceeds the limit of the server, which is only known while streaming a chunked
+// body, and 400 otherwise
+func readErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// readBody reads the whole request body
+func readBody(c *gin.Context) ([]byte, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	return bytes.TrimSpace(body), nil
+}
+
+// readJSONPayload reads the request body and decodes it keeping numbers intact
+func readJSONPayload(c *gin.Context) (interface{}, error) {
+	body, err := readBody(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, errEmptyBody
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+
+	return payload, nil
+}
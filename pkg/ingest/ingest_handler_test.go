@@ -0,0 +1,192 @@
+package ingest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config_webhook "github.com/kubecano/cano-collector/config/webhook"
+	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+type ingestHandlerTestDeps struct {
+	ctrl           *gomock.Controller
+	issueProcessor *mocks.MockIssueProcessorInterface
+	handler        *IngestHandler
+	router         *gin.Engine
+}
+
+func setupIngestHandlerTest(t *testing.T) ingestHandlerTestDeps {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+	mockMetrics.EXPECT().IncAlertErrors(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockIssueProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+
+	webhooks := config_webhook.WebhooksConfig{
+		Webhooks: []config_webhook.Webhook{
+			{
+				Name:      "ci-pipeline",
+				ItemsPath: "$.events",
+				Mapping: config_webhook.FieldMapping{
+					Title:    "$.title",
+					Severity: "$.level",
+				},
+			},
+		},
+	}
+
+	handler, err := NewIngestHandler(webhooks, "test-cluster", mockLogger, mockMetrics, mockIssueProcessor, nil)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.POST("/api/webhooks/:name", handler.HandleWebhook)
+
+	return ingestHandlerTestDeps{
+		ctrl:           ctrl,
+		issueProcessor: mockIssueProcessor,
+		handler:        handler,
+		router:         r,
+	}
+}
+
+func postWebhook(router *gin.Engine, name, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/webhooks/"+name, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewIngestHandler_InvalidMapping(t *testing.T) {
+	webhooks := config_webhook.WebhooksConfig{
+		Webhooks: []config_webhook.Webhook{
+			{Name: "broken", Mapping: config_webhook.FieldMapping{Title: "{{ .title "}},
+		},
+	}
+
+	handler, err := NewIngestHandler(webhooks, "test-cluster", nil, nil, nil, nil)
+
+	require.Error(t, err)
+	assert.Nil(t, handler)
+}
+
+func TestIngestHandler_HandleWebhook_HappyPath(t *testing.T) {
+	deps := setupIngestHandlerTest(t)
+	defer deps.ctrl.Finish()
+
+	var processed []*issue.Issue
+	deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, issues []*issue.Issue) error {
+			processed = issues
+			return nil
+		})
+
+	w := postWebhook(deps.router, "ci-pipeline", `{"events": [{"title": "Build failed", "level": "critical"}, {"title": "Tests flaky", "level": "warning"}]}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "webhook processed", "issues": 2}`, w.Body.String())
+	require.Len(t, processed, 2)
+	assert.Equal(t, "Build failed", processed[0].Title)
+	assert.Equal(t, issue.SeverityHigh, processed[0].Severity)
+	assert.Equal(t, issue.SourceWebhook, processed[0].Source)
+	assert.Equal(t, "test-cluster", processed[0].ClusterName)
+	assert.Equal(t, issue.SeverityLow, processed[1].Severity)
+}
+
+func TestIngestHandler_HandleWebhook_UnknownWebhook(t *testing.T) {
+	deps := setupIngestHandlerTest(t)
+	defer deps.ctrl.Finish()
+
+	w := postWebhook(deps.router, "unknown", `{"events": []}`)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown webhook: unknown")
+}
+
+func TestIngestHandler_HandleWebhook_BadRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"empty body", "", "empty JSON body"},
+		{"invalid json", "{not json", "invalid JSON body"},
+		{"mapping failure", `{"events": "not-a-list"}`, "failed to map payload"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := setupIngestHandlerTest(t)
+			defer deps.ctrl.Finish()
+
+			w := postWebhook(deps.router, "ci-pipeline", tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantErr)
+		})
+	}
+}
+
+func TestIngestHandler_ChunkedBodyTooLarge(t *testing.T) {
+	deps := setupIngestHandlerTest(t)
+	defer deps.ctrl.Finish()
+
+	// the limit of the server, enforced while reading a body of unknown length
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 16)
+	})
+	r.POST("/api/webhooks/:name", deps.handler.HandleWebhook)
+	r.POST("/api/falco", deps.handler.HandleFalco)
+	r.POST("/api/grafana", deps.handler.HandleGrafana)
+	r.POST("/api/v1/issues", deps.handler.CreateIssue)
+	r.PATCH("/api/v1/issues/:fingerprint", deps.handler.ResolveIssue)
+
+	for _, route := range [][2]string{
+		{http.MethodPost, "/api/webhooks/ci-pipeline"},
+		{http.MethodPost, "/api/falco"},
+		{http.MethodPost, "/api/grafana"},
+		{http.MethodPost, "/api/v1/issues"},
+		{http.MethodPatch, "/api/v1/issues/abc"},
+	} {
+		t.Run(route[1], func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(route[0], route[1], bytes.NewBufferString(`{"events": [{"title": "Build failed"}]}`))
+			req.ContentLength = -1
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		})
+	}
+}
+
+func TestIngestHandler_HandleWebhook_ProcessingFailure(t *testing.T) {
+	deps := setupIngestHandlerTest(t)
+	defer deps.ctrl.Finish()
+
+	deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Return(errors.New("dispatch failed"))
+
+	w := postWebhook(deps.router, "ci-pipeline", `{"events": [{"title": "Build failed"}]}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to process issues")
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// IngestHandlerInterface defines HTTP handlers for issue sources other than Alertmanager.
+//
+//go:generate mockgen -source=ingest.go -destination=../../../mocks/ingest_handler_mock.go -package=mocks
+type IngestHandlerInterface interface {
+	HandleWebhook(c *gin.Context)
+	HandleGrafana(c *gin.Context)
+	HandleFalco(c *gin.Context)
+	CreateIssue(c *gin.Context)
+	ResolveIssue(c *gin.Context)
+}
@@ -0,0 +1,198 @@
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// Result values reported by the issue API
+const (
+	issueResultCreated   = "created"
+	issueResultResolved  = "resolved"
+	issueResultDuplicate = "duplicate"
+)
+
+// resolveIssueRequest is the optional body of PATCH /api/v1/issues/:fingerprint
+type resolveIssueRequest struct {
+	Status *issue.Status `json:"status,omitempty"`
+	EndsAt *time.Time    `json:"ends_at,omitempty"`
+}
+
+// CreateIssue accepts an issue.Issue JSON document posted to /api/v1/issues.
+// Submitting the same fingerprint with the same status again is a no-op.
+func (h *IngestHandler) CreateIssue(c *gin.Context) {
+	body, err := readBody(c)
+	if err != nil {
+		h.logger.Error("Failed to read issue", zap.Error(err))
+		c.JSON(readErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if len(body) == 0 {
+		h.logger.Error("Failed to read issue", zap.Error(errEmptyBody))
+		c.JSON(http.StatusBadRequest, gin.H{"error": errEmptyBody.Error()})
+		return
+	}
+
+	var newIssue issue.Issue
+	if err := json.Unmarshal(body, &newIssue); err != nil {
+		h.logger.Error("Failed to parse issue", zap.Error(err))
+		h.metrics.IncAlertErrors("api", "invalid_payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid issue format: " + err.Error()})
+		return
+	}
+
+	if err := h.normalizeAPIIssue(&newIssue); err != nil {
+		h.metrics.IncAlertErrors("api", "invalid_payload")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The store keeps the issue as submitted; processing adds workflow enrichments to its own copy
+	previous, stored := h.issueStore.Remember(newIssue.Clone())
+	if !stored {
+		h.logger.Info("Duplicate issue ignored",
+			zap.String("fingerprint", newIssue.Fingerprint),
+			zap.String("status", newIssue.Status.String()))
+		c.JSON(http.StatusOK, issueResponse(previous, issueResultDuplicate))
+		return
+	}
+
+	if err := h.issueProcessor.ProcessIssues(c.Request.Context(), []*issue.Issue{&newIssue}); err != nil {
+		// Forget the submission so the client can retry
+		h.issueStore.Restore(newIssue.Fingerprint, previous)
+		h.logger.Error("Failed to process issue", zap.Error(err), zap.String("fingerprint", newIssue.Fingerprint))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process issue"})
+		return
+	}
+
+	h.logger.Info("Issue created via API",
+		zap.String("fingerprint", newIssue.Fingerprint),
+		zap.String("source", newIssue.Source.String()))
+	c.JSON(http.StatusCreated, issueResponse(&newIssue, issueResultCreated))
+}
+
+// ResolveIssue resolves a previously created issue identified by its fingerprint
+func (h *IngestHandler) ResolveIssue(c *gin.Context) {
+	fingerprint := c.Param("fingerprint")
+
+	var request resolveIssueRequest
+	body, err := readBody(c)
+	if err != nil {
+		c.JSON(readErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request format: " + err.Error()})
+			return
+		}
+	}
+	if request.Status != nil && *request.Status != issue.StatusResolved {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only resolving an issue is supported"})
+		return
+	}
+
+	existing, found := h.issueStore.Get(fingerprint)
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "issue not found: " + fingerprint})
+		return
+	}
+	if existing.IsResolved() {
+		c.JSON(http.StatusOK, issueResponse(existing, issueResultDuplicate))
+		return
+	}
+
+	resolved := existing.Clone()
+	resolved.Status = issue.StatusResolved
+	endsAt := time.Now()
+	if request.EndsAt != nil {
+		endsAt = *request.EndsAt
+	}
+	resolved.EndsAt = &endsAt
+
+	previous, stored := h.issueStore.Remember(resolved.Clone())
+	if !stored {
+		c.JSON(http.StatusOK, issueResponse(previous, issueResultDuplicate))
+		return
+	}
+
+	if err := h.issueProcessor.ProcessIssues(c.Request.Context(), []*issue.Issue{resolved}); err != nil {
+		h.issueStore.Restore(fingerprint, previous)
+		h.logger.Error("Failed to process resolved issue", zap.Error(err), zap.String("fingerprint", fingerprint))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process issue"})
+		return
+	}
+
+	h.logger.Info("Issue resolved via API", zap.String("fingerprint", fingerprint))
+	c.JSON(http.StatusOK, issueResponse(resolved, issueResultResolved))
+}
+
+// normalizeAPIIssue validates an issue received through the API and fills in defaults
+func (h *IngestHandler) normalizeAPIIssue(iss *issue.Issue) error {
+	if iss.Title == "" {
+		return errors.New("title is required")
+	}
+
+	switch iss.Source {
+	case issue.SourceUnknown:
+		iss.Source = issue.SourceCustom
+	case issue.SourceCustom, issue.SourceOperator:
+	default:
+		return fmt.Errorf("source must be CUSTOM or OPERATOR, got %s", iss.Source)
+	}
+
+	if iss.ID == uuid.Nil {
+		iss.ID = uuid.New()
+	}
+	if iss.AggregationKey == "" {
+		iss.AggregationKey = iss.Title
+	}
+	if iss.ClusterName == "" {
+		iss.ClusterName = h.clusterName
+	}
+	if iss.Subject == nil {
+		iss.Subject = issue.NewSubject("", issue.SubjectTypeNone)
+	}
+	if iss.Subject.Labels == nil {
+		iss.Subject.Labels = make(map[string]string)
+	}
+	if iss.Subject.Annotations == nil {
+		iss.Subject.Annotations = make(map[string]string)
+	}
+	if iss.Enrichments == nil {
+		iss.Enrichments = make([]issue.Enrichment, 0)
+	}
+	if iss.Links == nil {
+		iss.Links = make([]issue.Link, 0)
+	}
+	if iss.StartsAt.IsZero() {
+		iss.StartsAt = time.Now()
+	}
+	if iss.IsResolved() && iss.EndsAt == nil {
+		endsAt := time.Now()
+		iss.EndsAt = &endsAt
+	}
+	if iss.Fingerprint == "" {
+		iss.RefreshFingerprint()
+	}
+
+	return nil
+}
+
+// issueResponse builds the API response for an issue
+func issueResponse(iss *issue.Issue, result string) gin.H {
+	return gin.H{
+		"id":          iss.ID.String(),
+		"fingerprint": iss.Fingerprint,
+		"result":      result,
+	}
+}
@@ -0,0 +1,284 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config_webhook "github.com/kubecano/cano-collector/config/webhook"
+	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+type issueAPITestDeps struct {
+	ctrl           *gomock.Controller
+	issueProcessor *mocks.MockIssueProcessorInterface
+	router         *gin.Engine
+}
+
+func setupIssueAPITest(t *testing.T) issueAPITestDeps {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+	mockMetrics.EXPECT().IncAlertErrors(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockIssueProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+
+	handler, err := NewIngestHandler(config_webhook.WebhooksConfig{}, "test-cluster", mockLogger, mockMetrics, mockIssueProcessor, nil)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.POST("/api/v1/issues", handler.CreateIssue)
+	r.PATCH("/api/v1/issues/:fingerprint", handler.ResolveIssue)
+
+	return issueAPITestDeps{ctrl: ctrl, issueProcessor: mockIssueProcessor, router: r}
+}
+
+func doIssueRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeResponse(t *testing.T, w *httptest.ResponseRecorder) map[string]string {
+	t.Helper()
+	var response map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func TestIngestHandler_CreateIssue_WithEnrichments(t *testing.T) {
+	deps := setupIssueAPITest(t)
+	defer deps.ctrl.Finish()
+
+	var processed *issue.Issue
+	deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Len(1)).DoAndReturn(
+		func(_ context.Context, issues []*issue.Issue) error {
+			processed = issues[0]
+			return nil
+		})
+
+	body := `{
+		"title": "Backup job failed",
+		"severity": "HIGH",
+		"source": "OPERATOR",
+		"fingerprint": "backup-nightly",
+		"subject": {"name": "backup-28", "subject_type": "JOB", "namespace": "ops"},
+		"links": [{"text": "Runbook", "url": "https://example.com/runbook", "type": "RUNBOOK"}],
+		"enrichments": [{
+			"type": "logs",
+			"title": "Job output",
+			"blocks": [
+				{"type": "markdown", "text": "*exit code 1*"},
+				{"type": "table", "headers": ["step", "result"], "rows": [["dump", "failed"]]}
+			]
+		}]
+	}`
+
+	w := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	response := decodeResponse(t, w)
+	assert.Equal(t, "created", response["result"])
+	assert.Equal(t, "backup-nightly", response["fingerprint"])
+
+	require.NotNil(t, processed)
+	assert.Equal(t, response["id"], processed.ID.String())
+	assert.Equal(t, issue.SeverityHigh, processed.Severity)
+	assert.Equal(t, issue.SourceOperator, processed.Source)
+	assert.Equal(t, "Backup job failed", processed.AggregationKey)
+	assert.Equal(t, "test-cluster", processed.ClusterName)
+	assert.Equal(t, issue.SubjectTypeJob, processed.Subject.SubjectType)
+	assert.NotNil(t, processed.Subject.Labels)
+	require.Len(t, processed.Links, 1)
+	assert.Equal(t, issue.LinkTypeRunbook, processed.Links[0].Type)
+	require.Len(t, processed.Enrichments, 1)
+	assert.Equal(t, issue.EnrichmentTypeLogs, processed.Enrichments[0].Type)
+	require.Len(t, processed.Enrichments[0].Blocks, 2)
+	assert.IsType(t, &issue.MarkdownBlock{}, processed.Enrichments[0].Blocks[0])
+	assert.IsType(t, &issue.TableBlock{}, processed.Enrichments[0].Blocks[1])
+}
+
+func TestIngestHandler_CreateIssue_IdempotentByFingerprint(t *testing.T) {
+	deps := setupIssueAPITest(t)
+	defer deps.ctrl.Finish()
+
+	deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	body := `{"title": "Quota exceeded", "fingerprint": "quota-1"}`
+	first := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body)
+	second := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body)
+
+	require.Equal(t, http.StatusCreated, first.Code)
+	require.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "duplicate", decodeResponse(t, second)["result"])
+	assert.Equal(t, decodeResponse(t, first)["id"], decodeResponse(t, second)["id"])
+}
+
+func TestIngestHandler_CreateIssue_GeneratesFingerprint(t *testing.T) {
+	deps := setupIssueAPITest(t)
+	defer deps.ctrl.Finish()
+
+	deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	body := `{"title": "Quota exceeded", "subject": {"name": "team-a", "subject_type": "NAMESPACE"}}`
+	first := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body)
+	second := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body)
+
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.NotEmpty(t, decodeResponse(t, first)["fingerprint"])
+	assert.Equal(t, "duplicate", decodeResponse(t, second)["result"])
+}
+
+func TestIngestHandler_CreateIssue_BadRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"empty body", "", "empty JSON body"},
+		{"invalid json", "{", "invalid issue format"},
+		{"invalid severity", `{"title": "t", "severity": "EXTREME"}`, "unknown severity"},
+		{"unknown block", `{"title": "t", "enrichments": [{"blocks": [{"type": "chart"}]}]}`, "unknown block type"},
+		{"missing title", `{"severity": "LOW"}`, "title is required"},
+		{"foreign source", `{"title": "t", "source": "PROMETHEUS"}`, "source must be CUSTOM or OPERATOR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := setupIssueAPITest(t)
+			defer deps.ctrl.Finish()
+
+			w := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantErr)
+		})
+	}
+}
+
+func TestIngestHandler_CreateIssue_ProcessingFailureAllowsRetry(t *testing.T) {
+	deps := setupIssueAPITest(t)
+	defer deps.ctrl.Finish()
+
+	gomock.InOrder(
+		deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Return(errors.New("slack down")),
+		deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	body := `{"title": "Quota exceeded", "fingerprint": "quota-1"}`
+	first := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body)
+	second := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body)
+
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+}
+
+func TestIngestHandler_ResolveIssue(t *testing.T) {
+	deps := setupIssueAPITest(t)
+	defer deps.ctrl.Finish()
+
+	var processed []*issue.Issue
+	deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, issues []*issue.Issue) error {
+			processed = append(processed, issues...)
+			return nil
+		}).Times(2)
+
+	created := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", `{"title": "Quota exceeded", "fingerprint": "quota-1"}`)
+	require.Equal(t, http.StatusCreated, created.Code)
+
+	resolved := doIssueRequest(deps.router, http.MethodPatch, "/api/v1/issues/quota-1", `{"status": "resolved", "ends_at": "2026-01-02T03:04:05Z"}`)
+	require.Equal(t, http.StatusOK, resolved.Code)
+	assert.Equal(t, "resolved", decodeResponse(t, resolved)["result"])
+
+	require.Len(t, processed, 2)
+	assert.Equal(t, issue.StatusFiring, processed[0].Status)
+	assert.Equal(t, issue.StatusResolved, processed[1].Status)
+	assert.Equal(t, "quota-1", processed[1].Fingerprint)
+	assert.Equal(t, "Quota exceeded", processed[1].Title)
+	require.NotNil(t, processed[1].EndsAt)
+	assert.Equal(t, "2026-01-02T03:04:05Z", processed[1].EndsAt.UTC().Format("2006-01-02T15:04:05Z"))
+
+	again := doIssueRequest(deps.router, http.MethodPatch, "/api/v1/issues/quota-1", "")
+	require.Equal(t, http.StatusOK, again.Code)
+	assert.Equal(t, "duplicate", decodeResponse(t, again)["result"])
+}
+
+func TestIngestHandler_ResolveIssue_AfterWorkflowEnrichment(t *testing.T) {
+	deps := setupIssueAPITest(t)
+	defer deps.ctrl.Finish()
+
+	var processed []*issue.Issue
+	enriched := make(chan struct{})
+	gomock.InOrder(
+		// Workflows keep enriching the processed issue after the request returned
+		deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, issues []*issue.Issue) error {
+				processed = append(processed, issues...)
+				go func() {
+					defer close(enriched)
+					issues[0].AddEnrichmentWithType([]issue.BaseBlock{issue.NewMarkdownBlock("pod logs")}, issue.EnrichmentTypeLogs, "Logs")
+				}()
+				return nil
+			}),
+		deps.issueProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, issues []*issue.Issue) error {
+				processed = append(processed, issues...)
+				issues[0].AddEnrichmentWithType([]issue.BaseBlock{issue.NewMarkdownBlock("pod logs")}, issue.EnrichmentTypeLogs, "Logs")
+				return nil
+			}),
+	)
+
+	body := `{"title": "Quota exceeded", "fingerprint": "quota-1", "enrichments": [{"type": "alert_labels", "title": "Labels", "blocks": [{"type": "markdown", "text": "team=a"}]}]}`
+	require.Equal(t, http.StatusCreated, doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body).Code)
+	// A duplicate submission reads the stored issue while the workflow is still running
+	duplicate := doIssueRequest(deps.router, http.MethodPost, "/api/v1/issues", body)
+	require.Equal(t, http.StatusOK, duplicate.Code)
+	assert.Equal(t, "duplicate", decodeResponse(t, duplicate)["result"])
+	<-enriched
+
+	require.Equal(t, http.StatusOK, doIssueRequest(deps.router, http.MethodPatch, "/api/v1/issues/quota-1", "").Code)
+
+	require.Len(t, processed, 2)
+	resolved := processed[1]
+	assert.Equal(t, issue.StatusResolved, resolved.Status)
+	require.Len(t, resolved.Enrichments, 2)
+	assert.Equal(t, "Labels", resolved.Enrichments[0].Title)
+	assert.Equal(t, "Logs", resolved.Enrichments[1].Title)
+}
+
+func TestIngestHandler_ResolveIssue_Errors(t *testing.T) {
+	deps := setupIssueAPITest(t)
+	defer deps.ctrl.Finish()
+
+	w := doIssueRequest(deps.router, http.MethodPatch, "/api/v1/issues/unknown", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = doIssueRequest(deps.router, http.MethodPatch, "/api/v1/issues/unknown", `{"status": "firing"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "only resolving")
+
+	w = doIssueRequest(deps.router, http.MethodPatch, "/api/v1/issues/unknown", `{`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
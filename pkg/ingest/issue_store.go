@@ -0,0 +1,166 @@
+package ingest
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+)
+
+const (
+	// defaultIssueStoreTTL is how long an issue is remembered for idempotency and resolving
+	defaultIssueStoreTTL = 24 * time.Hour
+	// issueStoreSweepInterval limits how often the whole store is scanned for expired issues
+	issueStoreSweepInterval = time.Minute
+)
+
+// storedIssue is an issue remembered by fingerprint together with its last update time
+type storedIssue struct {
+	issue     *issue.Issue
+	updatedAt time.Time
+}
+
+// IssueStore remembers issues received through the API by fingerprint so repeated
+// submissions are idempotent and issues can later be resolved by fingerprint
+type IssueStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	issues    map[string]storedIssue
+	lastSweep time.Time
+	now       func() time.Time
+	// stateStore shares issues between HA replicas; nil keeps them local
+	stateStore ha_interfaces.StateStoreInterface
+}
+
+// NewIssueStore creates a new in-memory issue store
+func NewIssueStore(ttl time.Duration) *IssueStore {
+	return &IssueStore{
+		ttl:    ttl,
+		issues: make(map[string]storedIssue),
+		now:    time.Now,
+	}
+}
+
+// SetStateStore shares remembered issues with other replicas, so they can be resolved after a leader failover
+func (s *IssueStore) SetStateStore(store ha_interfaces.StateStoreInterface) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stateStore = store
+}
+
+// Get returns the remembered issue for a fingerprint
+func (s *IssueStore) Get(fingerprint string) (*issue.Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.getLocked(fingerprint)
+}
+
+// Remember stores the issue unless an issue with the same fingerprint and status is
+// already known. It returns the previously stored issue (nil if none) and whether
+// the issue was stored; false means the submission is a duplicate of the previous one.
+// The store keeps the issue, so callers must not change it afterwards.
+func (s *IssueStore) Remember(iss *issue.Issue) (*issue.Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweepLocked()
+	previous, exists := s.getLocked(iss.Fingerprint)
+	if exists && previous.Status == iss.Status {
+		return previous, false
+	}
+
+	s.storeLocked(iss)
+	return previous, true
+}
+
+// Restore puts back a previously remembered issue, e.g. after processing failed,
+// or forgets the fingerprint when there was no previous issue
+func (s *IssueStore) Restore(fingerprint string, previous *issue.Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if previous == nil {
+		delete(s.issues, fingerprint)
+		if s.stateStore != nil {
+			s.stateStore.Delete(issueStateKey(fingerprint))
+		}
+		return
+	}
+	s.storeLocked(previous)
+}
+
+// storeLocked remembers the issue locally and in the shared state; the caller must hold the lock
+func (s *IssueStore) storeLocked(iss *issue.Issue) {
+	s.issues[iss.Fingerprint] = storedIssue{issue: iss, updatedAt: s.now()}
+	if s.stateStore == nil {
+		return
+	}
+
+	// Enrichments can be large and are not needed to resolve the issue
+	shared := *iss
+	shared.Enrichments = nil
+	if data, err := json.Marshal(&shared); err == nil {
+		s.stateStore.Set(issueStateKey(iss.Fingerprint), string(data), s.ttl)
+	}
+}
+
+// sharedLocked returns an issue remembered by another replica; the caller must hold the lock
+func (s *IssueStore) sharedLocked(fingerprint string) (*issue.Issue, bool) {
+	if s.stateStore == nil {
+		return nil, false
+	}
+	data, found := s.stateStore.Get(issueStateKey(fingerprint))
+	if !found {
+		return nil, false
+	}
+	var shared issue.Issue
+	if err := json.Unmarshal([]byte(data), &shared); err != nil {
+		return nil, false
+	}
+	s.issues[fingerprint] = storedIssue{issue: &shared, updatedAt: s.now()}
+	return &shared, true
+}
+
+func issueStateKey(fingerprint string) string {
+	return "issue/" + fingerprint
+}
+
+// getLocked returns a non-expired issue; the caller must hold the lock
+func (s *IssueStore) getLocked(fingerprint string) (*issue.Issue, bool) {
+	stored, exists := s.issues[fingerprint]
+	if !exists {
+		return s.sharedLocked(fingerprint)
+	}
+	if s.now().Sub(stored.updatedAt) > s.ttl {
+		delete(s.issues, fingerprint)
+		return nil, false
+	}
+	return stored.issue, true
+}
+
+// sweepLocked periodically drops all expired issues; the caller must hold the lock
+func (s *IssueStore) sweepLocked() {
+	now := s.now()
+	if now.Sub(s.lastSweep) < issueStoreSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for fingerprint, stored := range s.issues {
+		if now.Sub(stored.updatedAt) > s.ttl {
+			delete(s.issues, fingerprint)
+		}
+	}
+}
+
+// Len returns the number of remembered issues
+func (s *IssueStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.issues)
+}
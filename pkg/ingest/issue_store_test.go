@@ -0,0 +1,101 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/ha"
+)
+
+func newTestIssue(fingerprint string, status issue.Status) *issue.Issue {
+	iss := issue.NewIssue("title", "key")
+	iss.Fingerprint = fingerprint
+	iss.Status = status
+	return iss
+}
+
+func TestIssueStore_RememberDuplicates(t *testing.T) {
+	store := NewIssueStore(time.Hour)
+
+	first := newTestIssue("fp-1", issue.StatusFiring)
+	previous, stored := store.Remember(first)
+	assert.True(t, stored)
+	assert.Nil(t, previous)
+
+	previous, stored = store.Remember(newTestIssue("fp-1", issue.StatusFiring))
+	assert.False(t, stored)
+	assert.Same(t, first, previous)
+
+	resolved := newTestIssue("fp-1", issue.StatusResolved)
+	previous, stored = store.Remember(resolved)
+	assert.True(t, stored)
+	assert.Same(t, first, previous)
+
+	current, found := store.Get("fp-1")
+	require.True(t, found)
+	assert.Same(t, resolved, current)
+}
+
+func TestIssueStore_Restore(t *testing.T) {
+	store := NewIssueStore(time.Hour)
+	first := newTestIssue("fp-1", issue.StatusFiring)
+
+	_, _ = store.Remember(first)
+	store.Restore("fp-1", nil)
+	_, found := store.Get("fp-1")
+	assert.False(t, found)
+
+	_, _ = store.Remember(first)
+	_, _ = store.Remember(newTestIssue("fp-1", issue.StatusResolved))
+	store.Restore("fp-1", first)
+	current, found := store.Get("fp-1")
+	require.True(t, found)
+	assert.Same(t, first, current)
+}
+
+func TestIssueStore_Expiry(t *testing.T) {
+	now := time.Now()
+	store := NewIssueStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	_, _ = store.Remember(newTestIssue("fp-1", issue.StatusFiring))
+	_, _ = store.Remember(newTestIssue("fp-2", issue.StatusFiring))
+	assert.Equal(t, 2, store.Len())
+
+	now = now.Add(2 * time.Hour)
+
+	_, found := store.Get("fp-1")
+	assert.False(t, found)
+
+	// Remember sweeps the remaining expired issues
+	_, stored := store.Remember(newTestIssue("fp-3", issue.StatusFiring))
+	assert.True(t, stored)
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestIssueStore_SharedState(t *testing.T) {
+	shared := ha.NewMemoryStateStore()
+
+	previousLeader := NewIssueStore(time.Hour)
+	previousLeader.SetStateStore(shared)
+	_, _ = previousLeader.Remember(newTestIssue("fp-1", issue.StatusFiring))
+
+	// after a failover the new leader knows the firing issue
+	store := NewIssueStore(time.Hour)
+	store.SetStateStore(shared)
+	current, found := store.Get("fp-1")
+	require.True(t, found)
+	assert.Equal(t, "fp-1", current.Fingerprint)
+	assert.Equal(t, issue.StatusFiring, current.Status)
+
+	_, stored := store.Remember(newTestIssue("fp-1", issue.StatusFiring))
+	assert.False(t, stored, "a duplicate of an issue sent by the previous leader is ignored")
+
+	store.Restore("fp-1", nil)
+	_, found = shared.Get("issue/fp-1")
+	assert.False(t, found)
+}
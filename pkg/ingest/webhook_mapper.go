@@ -0,0 +1,282 @@
+package ingest
+
+import (
+	"fmt"
+	"strings"
+
+	config_webhook "github.com/kubecano/cano-collector/config/webhook"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// compiledLink is a link mapping with pre-compiled expressions
+type compiledLink struct {
+	text     *expression
+	url      *expression
+	linkType issue.LinkType
+}
+
+// WebhookMapper converts generic JSON webhook payloads into Issues according to a configured field mapping
+type WebhookMapper struct {
+	name           string
+	clusterName    string
+	itemsPath      jsonPath
+	title          *expression
+	description    *expression
+	severity       *expression
+	severityMap    map[string]string
+	status         *expression
+	statusMap      map[string]string
+	fingerprint    *expression
+	aggregationKey *expression
+	subjectKind    *expression
+	subjectName    *expression
+	subjectNS      *expression
+	subjectNode    *expression
+	subjectCont    *expression
+	labels         map[string]*expression
+	labelsFrom     jsonPath
+	links          []compiledLink
+}
+
+// NewWebhookMapper compiles all expressions of the webhook mapping
+func NewWebhookMapper(webhook config_webhook.Webhook, clusterName string) (*WebhookMapper, error) {
+	mapping := webhook.Mapping
+	m := &WebhookMapper{
+		name:        webhook.Name,
+		clusterName: clusterName,
+		severityMap: lowerKeys(mapping.SeverityMap),
+		statusMap:   lowerKeys(mapping.StatusMap),
+		labels:      make(map[string]*expression, len(mapping.Labels)),
+	}
+
+	var err error
+	if webhook.ItemsPath != "" {
+		if m.itemsPath, err = parseJSONPath(webhook.ItemsPath); err != nil {
+			return nil, fmt.Errorf("webhook '%s': items_path: %w", webhook.Name, err)
+		}
+	}
+	if mapping.LabelsFrom != "" {
+		if m.labelsFrom, err = parseJSONPath(mapping.LabelsFrom); err != nil {
+			return nil, fmt.Errorf("webhook '%s': labels_from: %w", webhook.Name, err)
+		}
+	}
+
+	fields := []struct {
+		name   string
+		raw    string
+		target **expression
+	}{
+		{"title", mapping.Title, &m.title},
+		{"description", mapping.Description, &m.description},
+		{"severity", mapping.Severity, &m.severity},
+		{"status", mapping.Status, &m.status},
+		{"fingerprint", mapping.Fingerprint, &m.fingerprint},
+		{"aggregation_key", mapping.AggregationKey, &m.aggregationKey},
+		{"subject.kind", mapping.Subject.Kind, &m.subjectKind},
+		{"subject.name", mapping.Subject.Name, &m.subjectName},
+		{"subject.namespace", mapping.Subject.Namespace, &m.subjectNS},
+		{"subject.node", mapping.Subject.Node, &m.subjectNode},
+		{"subject.container", mapping.Subject.Container, &m.subjectCont},
+	}
+	for _, field := range fields {
+		if *field.target, err = compileExpression(field.name, field.raw); err != nil {
+			return nil, fmt.Errorf("webhook '%s': %w", webhook.Name, err)
+		}
+	}
+
+	for key, raw := range mapping.Labels {
+		if m.labels[key], err = compileExpression("labels."+key, raw); err != nil {
+			return nil, fmt.Errorf("webhook '%s': %w", webhook.Name, err)
+		}
+	}
+
+	for i, link := range mapping.Links {
+		compiled := compiledLink{linkType: issue.LinkTypeGeneral}
+		if compiled.text, err = compileExpression(fmt.Sprintf("links[%d].text", i), link.Text); err != nil {
+			return nil, fmt.Errorf("webhook '%s': %w", webhook.Name, err)
+		}
+		if compiled.url, err = compileExpression(fmt.Sprintf("links[%d].url", i), link.URL); err != nil {
+			return nil, fmt.Errorf("webhook '%s': %w", webhook.Name, err)
+		}
+		if link.Type != "" {
+			if compiled.linkType, err = issue.LinkTypeFromString(link.Type); err != nil {
+				return nil, fmt.Errorf("webhook '%s': links[%d]: %w", webhook.Name, i, err)
+			}
+		}
+		m.links = append(m.links, compiled)
+	}
+
+	return m, nil
+}
+
+// Name returns the webhook name served by this mapper
+func (m *WebhookMapper) Name() string {
+	return m.name
+}
+
+// Map converts a decoded JSON payload into one issue, or one issue per element of items_path
+func (m *WebhookMapper) Map(payload interface{}) ([]*issue.Issue, error) {
+	items := []interface{}{payload}
+	if m.itemsPath != nil {
+		value, found := m.itemsPath.evaluate(payload)
+		if !found {
+			return nil, fmt.Errorf("items_path did not match the payload")
+		}
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("items_path must select an array, got %T", value)
+		}
+		items = list
+	}
+
+	issues := make([]*issue.Issue, 0, len(items))
+	for i, item := range items {
+		mapped, err := m.mapItem(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		issues = append(issues, mapped)
+	}
+
+	return issues, nil
+}
+
+// mapItem converts a single payload item into an Issue
+func (m *WebhookMapper) mapItem(item interface{}) (*issue.Issue, error) {
+	values := make(map[string]string)
+	exprs := map[string]*expression{
+		"title":             m.title,
+		"description":       m.description,
+		"severity":          m.severity,
+		"status":            m.status,
+		"fingerprint":       m.fingerprint,
+		"aggregation_key":   m.aggregationKey,
+		"subject.kind":      m.subjectKind,
+		"subject.name":      m.subjectName,
+		"subject.namespace": m.subjectNS,
+		"subject.node":      m.subjectNode,
+		"subject.container": m.subjectCont,
+	}
+	for field, expr := range exprs {
+		value, err := expr.evaluate(item)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", field, err)
+		}
+		values[field] = value
+	}
+
+	aggregationKey := values["aggregation_key"]
+	if aggregationKey == "" {
+		aggregationKey = m.name
+	}
+	title := values["title"]
+	if title == "" {
+		title = aggregationKey
+	}
+
+	result := issue.NewIssue(title, aggregationKey)
+	result.Source = issue.SourceWebhook
+	result.ClusterName = m.clusterName
+	result.Description = values["description"]
+	result.Severity = m.mapSeverity(values["severity"])
+	result.Status = m.mapStatus(values["status"])
+
+	subject, err := m.mapSubject(item, values)
+	if err != nil {
+		return nil, err
+	}
+	result.SetSubject(subject)
+	result.SetFingerprint(values["fingerprint"])
+
+	for i, link := range m.links {
+		url, err := link.url.evaluate(item)
+		if err != nil {
+			return nil, fmt.Errorf("links[%d].url: %w", i, err)
+		}
+		if url == "" {
+			continue
+		}
+		text, err := link.text.evaluate(item)
+		if err != nil {
+			return nil, fmt.Errorf("links[%d].text: %w", i, err)
+		}
+		if text == "" {
+			text = url
+		}
+		result.AddLink(*issue.NewLink(text, url, link.linkType))
+	}
+
+	return result, nil
+}
+
+// mapSubject builds the issue subject including labels
+func (m *WebhookMapper) mapSubject(item interface{}, values map[string]string) (*issue.Subject, error) {
+	subjectType := issue.SubjectTypeNone
+	if kind := values["subject.kind"]; kind != "" {
+		// Unknown kinds keep the subject name but are not treated as Kubernetes resources
+		if parsed, err := issue.SubjectTypeFromString(kind); err == nil {
+			subjectType = parsed
+		}
+	}
+
+	subject := issue.NewSubject(values["subject.name"], subjectType)
+	subject.Namespace = values["subject.namespace"]
+	subject.Node = values["subject.node"]
+	subject.Container = values["subject.container"]
+
+	if m.labelsFrom != nil {
+		if value, found := m.labelsFrom.evaluate(item); found {
+			if object, ok := value.(map[string]interface{}); ok {
+				for key, labelValue := range object {
+					if s := stringifyValue(labelValue); s != "" {
+						subject.Labels[key] = s
+					}
+				}
+			}
+		}
+	}
+
+	for key, expr := range m.labels {
+		value, err := expr.evaluate(item)
+		if err != nil {
+			return nil, fmt.Errorf("labels.%s: %w", key, err)
+		}
+		if value != "" {
+			subject.Labels[key] = value
+		}
+	}
+
+	return subject, nil
+}
+
+// mapSeverity translates the payload severity through severity_map into an issue severity.
+// Both issue severities (HIGH, LOW, ...) and Prometheus labels (critical, warning, ...) are accepted.
+func (m *WebhookMapper) mapSeverity(value string) issue.Severity {
+	if mapped, exists := m.severityMap[strings.ToLower(value)]; exists {
+		value = mapped
+	}
+	if value == "" {
+		return issue.SeverityInfo
+	}
+	if severity, err := issue.SeverityFromString(value); err == nil {
+		return severity
+	}
+	return issue.SeverityFromPrometheusLabel(value)
+}
+
+// mapStatus translates the payload status through status_map into an issue status
+func (m *WebhookMapper) mapStatus(value string) issue.Status {
+	if mapped, exists := m.statusMap[strings.ToLower(value)]; exists {
+		value = mapped
+	}
+	return issue.StatusFromPrometheusStatus(value)
+}
+
+// lowerKeys returns a copy of the map with lower-cased keys for case-insensitive lookups
+func lowerKeys(values map[string]string) map[string]string {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[strings.ToLower(key)] = value
+	}
+	return result
+}
@@ -0,0 +1,207 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config_webhook "github.com/kubecano/cano-collector/config/webhook"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+func createTestWebhook() config_webhook.Webhook {
+	return config_webhook.Webhook{
+		Name: "ci-pipeline",
+		Mapping: config_webhook.FieldMapping{
+			Title:          "$.title",
+			Description:    "{{ .message }} ({{ .job.id }})",
+			Severity:       "$.priority",
+			SeverityMap:    map[string]string{"P1": "critical", "P3": "info"},
+			Status:         "$.state",
+			StatusMap:      map[string]string{"ok": "resolved"},
+			Fingerprint:    "$.job.id",
+			AggregationKey: "$.rule",
+			Subject: config_webhook.SubjectMapping{
+				Kind:      "pod",
+				Name:      "$.job.pod",
+				Namespace: "$.job.namespace",
+				Container: "$.job.container",
+			},
+			Labels:     map[string]string{"team": "$.team"},
+			LabelsFrom: "$.tags",
+			Links: []config_webhook.LinkMapping{
+				{Text: "Pipeline", URL: "$.url", Type: "investigate"},
+				{URL: "$.runbook", Type: "runbook"},
+				{Text: "Missing", URL: "$.missing"},
+			},
+		},
+	}
+}
+
+func TestNewWebhookMapper_InvalidExpressions(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(w *config_webhook.Webhook)
+		wantErr string
+	}{
+		{"invalid jsonpath", func(w *config_webhook.Webhook) { w.Mapping.Title = "$.items[" }, "field 'title'"},
+		{"invalid template", func(w *config_webhook.Webhook) { w.Mapping.Description = "{{ .x " }, "field 'description'"},
+		{"invalid label", func(w *config_webhook.Webhook) { w.Mapping.Labels["bad"] = "$..x" }, "labels.bad"},
+		{"invalid link type", func(w *config_webhook.Webhook) { w.Mapping.Links[0].Type = "chart" }, "unknown link type"},
+		{"invalid items path", func(w *config_webhook.Webhook) { w.ItemsPath = "$.[" }, "items_path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := createTestWebhook()
+			tt.modify(&webhook)
+
+			mapper, err := NewWebhookMapper(webhook, "test-cluster")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Nil(t, mapper)
+		})
+	}
+}
+
+func TestWebhookMapper_Map_AllFields(t *testing.T) {
+	mapper, err := NewWebhookMapper(createTestWebhook(), "test-cluster")
+	require.NoError(t, err)
+	assert.Equal(t, "ci-pipeline", mapper.Name())
+
+	payload := decodePayload(t, `{
+		"title": "Deploy failed",
+		"message": "Rollout stuck",
+		"priority": "P1",
+		"state": "alerting",
+		"rule": "DeployFailed",
+		"team": "payments",
+		"tags": {"env": "prod", "retries": 2},
+		"url": "https://ci.example.com/jobs/42",
+		"runbook": "https://runbooks.example.com/deploy",
+		"job": {"id": 42, "pod": "api-7d9f", "namespace": "shop", "container": "app"}
+	}`)
+
+	issues, err := mapper.Map(payload)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+
+	result := issues[0]
+	assert.Equal(t, "Deploy failed", result.Title)
+	assert.Equal(t, "Rollout stuck (42)", result.Description)
+	assert.Equal(t, "DeployFailed", result.AggregationKey)
+	assert.Equal(t, issue.SeverityHigh, result.Severity)
+	assert.Equal(t, issue.StatusFiring, result.Status)
+	assert.Equal(t, issue.SourceWebhook, result.Source)
+	assert.Equal(t, "test-cluster", result.ClusterName)
+	assert.Equal(t, "42", result.Fingerprint)
+
+	require.NotNil(t, result.Subject)
+	assert.Equal(t, issue.SubjectTypePod, result.Subject.SubjectType)
+	assert.Equal(t, "api-7d9f", result.Subject.Name)
+	assert.Equal(t, "shop", result.Subject.Namespace)
+	assert.Equal(t, "app", result.Subject.Container)
+	assert.Equal(t, map[string]string{"team": "payments", "env": "prod", "retries": "2"}, result.Subject.Labels)
+
+	require.Len(t, result.Links, 2)
+	assert.Equal(t, issue.Link{Text: "Pipeline", URL: "https://ci.example.com/jobs/42", Type: issue.LinkTypeInvestigate}, result.Links[0])
+	assert.Equal(t, issue.Link{Text: "https://runbooks.example.com/deploy", URL: "https://runbooks.example.com/deploy", Type: issue.LinkTypeRunbook}, result.Links[1])
+}
+
+func TestWebhookMapper_Map_Defaults(t *testing.T) {
+	mapper, err := NewWebhookMapper(config_webhook.Webhook{
+		Name:    "minimal",
+		Mapping: config_webhook.FieldMapping{Title: "$.title"},
+	}, "test-cluster")
+	require.NoError(t, err)
+
+	issues, err := mapper.Map(decodePayload(t, `{"other": "value"}`))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+
+	result := issues[0]
+	assert.Equal(t, "minimal", result.Title)
+	assert.Equal(t, "minimal", result.AggregationKey)
+	assert.Equal(t, issue.SeverityInfo, result.Severity)
+	assert.Equal(t, issue.StatusFiring, result.Status)
+	assert.Equal(t, issue.SubjectTypeNone, result.Subject.SubjectType)
+	assert.NotEmpty(t, result.Fingerprint)
+}
+
+func TestWebhookMapper_Map_SeverityAndStatus(t *testing.T) {
+	mapper, err := NewWebhookMapper(createTestWebhook(), "test-cluster")
+	require.NoError(t, err)
+
+	tests := []struct {
+		priority         string
+		state            string
+		expectedSeverity issue.Severity
+		expectedStatus   issue.Status
+	}{
+		{"p1", "OK", issue.SeverityHigh, issue.StatusResolved},
+		{"P3", "resolved", issue.SeverityInfo, issue.StatusResolved},
+		{"warning", "firing", issue.SeverityLow, issue.StatusFiring},
+		{"LOW", "pending", issue.SeverityLow, issue.StatusFiring},
+		{"debug", "", issue.SeverityDebug, issue.StatusFiring},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.priority+"/"+tt.state, func(t *testing.T) {
+			payload := map[string]interface{}{"title": "t", "priority": tt.priority, "state": tt.state, "job": map[string]interface{}{}}
+
+			issues, err := mapper.Map(payload)
+			require.NoError(t, err)
+			require.Len(t, issues, 1)
+			assert.Equal(t, tt.expectedSeverity, issues[0].Severity)
+			assert.Equal(t, tt.expectedStatus, issues[0].Status)
+		})
+	}
+}
+
+func TestWebhookMapper_Map_ItemsPath(t *testing.T) {
+	webhook := config_webhook.Webhook{
+		Name:      "batch",
+		ItemsPath: "$.events",
+		Mapping: config_webhook.FieldMapping{
+			Title:       "$.name",
+			Fingerprint: "$.id",
+		},
+	}
+	mapper, err := NewWebhookMapper(webhook, "test-cluster")
+	require.NoError(t, err)
+
+	issues, err := mapper.Map(decodePayload(t, `{"events": [{"id": "1", "name": "first"}, {"id": "2", "name": "second"}]}`))
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "first", issues[0].Title)
+	assert.Equal(t, "1", issues[0].Fingerprint)
+	assert.Equal(t, "second", issues[1].Title)
+	assert.Equal(t, "2", issues[1].Fingerprint)
+
+	_, err = mapper.Map(decodePayload(t, `{"events": {"id": "1"}}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "items_path must select an array")
+
+	_, err = mapper.Map(decodePayload(t, `{"other": []}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "items_path did not match")
+}
+
+func TestWebhookMapper_Map_UnknownSubjectKind(t *testing.T) {
+	mapper, err := NewWebhookMapper(config_webhook.Webhook{
+		Name: "vm",
+		Mapping: config_webhook.FieldMapping{
+			Title:   "$.title",
+			Subject: config_webhook.SubjectMapping{Kind: "virtualmachine", Name: "$.vm"},
+		},
+	}, "test-cluster")
+	require.NoError(t, err)
+
+	issues, err := mapper.Map(decodePayload(t, `{"title": "VM down", "vm": "vm-1"}`))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, issue.SubjectTypeNone, issues[0].Subject.SubjectType)
+	assert.Equal(t, "vm-1", issues[0].Subject.Name)
+}
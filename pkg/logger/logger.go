@@ -14,6 +14,15 @@ type Logger struct {
 }
 
 func NewLogger(level, env string) *Logger {
+	return newLogger(level, env, "stdout")
+}
+
+// NewStderrLogger creates a logger writing to stderr, for commands whose output goes to stdout
+func NewStderrLogger(level, env string) *Logger {
+	return newLogger(level, env, "stderr")
+}
+
+func newLogger(level, env, output string) *Logger {
 	var logLevel zapcore.Level
 
 	switch level {
@@ -33,7 +42,7 @@ func NewLogger(level, env string) *Logger {
 		Encoding:         "json",
 		Development:      env != "production",
 		Level:            zap.NewAtomicLevelAt(logLevel),
-		OutputPaths:      []string{"stdout"},
+		OutputPaths:      []string{output},
 		ErrorOutputPaths: []string{"stderr"},
 		EncoderConfig: zapcore.EncoderConfig{
 			TimeKey:      "timestamp",
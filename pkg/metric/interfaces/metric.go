@@ -26,6 +26,19 @@ type MetricsInterface interface {
 
 	// HTTP metrics
 	ObserveHTTPRequestDuration(method, path, status string, duration time.Duration)
+	IncAuthRejections(path, reason string)
+
+	// HA metrics
+	SetHALeader(leader bool)
+	IncHARequests(outcome string)
+
+	// Sharding metrics
+	SetShardPeers(count int)
+	IncShardIssues(outcome string)
+
+	// Configuration reload metrics
+	IncConfigReloads(result string)
+	SetConfigLastReloadSuccessful(successful bool)
 
 	// Routing metrics
 	IncRoutingDecisions(teamName, destinationType, decision string)
@@ -15,6 +15,7 @@ import (
 type MetricsCollector struct {
 	httpRequestsTotal             *prometheus.CounterVec
 	httpRequestDuration           *prometheus.HistogramVec
+	authRejectionsTotal           *prometheus.CounterVec
 	alertManagerAlertsTotal       *prometheus.CounterVec
 	alertsProcessedTotal          *prometheus.CounterVec
 	alertsProcessingDuration      *prometheus.HistogramVec
@@ -27,6 +28,12 @@ type MetricsCollector struct {
 	workflowsExecutedTotal        *prometheus.CounterVec
 	workflowEnrichmentsTotal      *prometheus.HistogramVec
 	workflowEnrichmentErrorsTotal *prometheus.CounterVec
+	haLeader                      prometheus.Gauge
+	haRequestsTotal               *prometheus.CounterVec
+	shardPeers                    prometheus.Gauge
+	shardIssuesTotal              *prometheus.CounterVec
+	configReloadsTotal            *prometheus.CounterVec
+	configLastReloadSuccessful    prometheus.Gauge
 	logger                        logger_interfaces.LoggerInterface
 }
 
@@ -55,6 +62,14 @@ func NewMetricsCollector(log logger_interfaces.LoggerInterface) interfaces.Metri
 		[]string{"method", "path", "status"},
 	), "httpRequestDuration").(*prometheus.HistogramVec)
 
+	mc.authRejectionsTotal = mc.registerCollector(prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cano_auth_rejections_total",
+			Help: "Total number of requests rejected by authentication",
+		},
+		[]string{"path", "reason"},
+	), "authRejectionsTotal").(*prometheus.CounterVec)
+
 	mc.alertManagerAlertsTotal = mc.registerCollector(prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "alertmanager_alerts_total",
@@ -158,6 +173,54 @@ func NewMetricsCollector(log logger_interfaces.LoggerInterface) interfaces.Metri
 		[]string{"workflow_name", "error_type"},
 	), "workflowEnrichmentErrorsTotal").(*prometheus.CounterVec)
 
+	// HA metrics
+	mc.haLeader = mc.registerCollector(prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cano_ha_leader",
+			Help: "Whether this replica is the HA leader (1) or a follower (0)",
+		},
+	), "haLeader").(prometheus.Gauge)
+
+	mc.haRequestsTotal = mc.registerCollector(prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cano_ha_requests_total",
+			Help: "Total number of requests handled by HA coordination, by outcome",
+		},
+		[]string{"outcome"},
+	), "haRequestsTotal").(*prometheus.CounterVec)
+
+	// Sharding metrics
+	mc.shardPeers = mc.registerCollector(prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cano_shard_peers",
+			Help: "Number of replicas on the fingerprint hash ring, including this one",
+		},
+	), "shardPeers").(prometheus.Gauge)
+
+	mc.shardIssuesTotal = mc.registerCollector(prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cano_shard_issues_total",
+			Help: "Total number of issues handled by fingerprint sharding, by outcome",
+		},
+		[]string{"outcome"},
+	), "shardIssuesTotal").(*prometheus.CounterVec)
+
+	// Configuration reload metrics
+	mc.configReloadsTotal = mc.registerCollector(prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cano_config_reloads_total",
+			Help: "Total number of attempts to apply a changed configuration, by result",
+		},
+		[]string{"result"},
+	), "configReloadsTotal").(*prometheus.CounterVec)
+
+	mc.configLastReloadSuccessful = mc.registerCollector(prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cano_config_last_reload_successful",
+			Help: "Whether the last configuration reload attempt was successful (1) or not (0)",
+		},
+	), "configLastReloadSuccessful").(prometheus.Gauge)
+
 	return mc
 }
 
@@ -242,6 +305,52 @@ func (mc *MetricsCollector) ObserveHTTPRequestDuration(method, path, status stri
 	mc.logger.Debugf("Observed HTTP request duration for %s %s %s, duration: %v", method, path, status, duration)
 }
 
+func (mc *MetricsCollector) IncAuthRejections(path, reason string) {
+	mc.authRejectionsTotal.WithLabelValues(path, reason).Inc()
+	mc.logger.Debugf("Incremented auth rejections counter for path: %s, reason: %s", path, reason)
+}
+
+// HA metrics implementations
+func (mc *MetricsCollector) SetHALeader(leader bool) {
+	if leader {
+		mc.haLeader.Set(1)
+	} else {
+		mc.haLeader.Set(0)
+	}
+	mc.logger.Debugf("Set HA leader gauge: %t", leader)
+}
+
+func (mc *MetricsCollector) IncHARequests(outcome string) {
+	mc.haRequestsTotal.WithLabelValues(outcome).Inc()
+	mc.logger.Debugf("Incremented HA requests counter for outcome: %s", outcome)
+}
+
+// Sharding metrics implementations
+func (mc *MetricsCollector) SetShardPeers(count int) {
+	mc.shardPeers.Set(float64(count))
+	mc.logger.Debugf("Set shard peers gauge: %d", count)
+}
+
+func (mc *MetricsCollector) IncShardIssues(outcome string) {
+	mc.shardIssuesTotal.WithLabelValues(outcome).Inc()
+	mc.logger.Debugf("Incremented shard issues counter for outcome: %s", outcome)
+}
+
+// Configuration reload metrics implementations
+func (mc *MetricsCollector) IncConfigReloads(result string) {
+	mc.configReloadsTotal.WithLabelValues(result).Inc()
+	mc.logger.Debugf("Incremented config reloads counter for result: %s", result)
+}
+
+func (mc *MetricsCollector) SetConfigLastReloadSuccessful(successful bool) {
+	if successful {
+		mc.configLastReloadSuccessful.Set(1)
+	} else {
+		mc.configLastReloadSuccessful.Set(0)
+	}
+	mc.logger.Debugf("Set config last reload successful gauge: %t", successful)
+}
+
 // Routing metrics implementations
 func (mc *MetricsCollector) IncRoutingDecisions(teamName, destinationType, decision string) {
 	mc.routingDecisionsTotal.WithLabelValues(teamName, destinationType, decision).Inc()
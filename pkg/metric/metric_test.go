@@ -126,3 +126,68 @@ func TestObserveAlert(t *testing.T) {
 		assert.Contains(t, metricsOutput, expected, "Expected alert metric: %s", expected)
 	}
 }
+
+func TestIncAuthRejections(t *testing.T) {
+	metrics := setupTestMetricsCollector(t)
+
+	metrics.IncAuthRejections("/api/alerts", "missing_credentials")
+	metrics.IncAuthRejections("/api/alerts", "invalid_bearer_token")
+
+	metricsW := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	promhttp.Handler().ServeHTTP(metricsW, req)
+
+	metricsOutput := metricsW.Body.String()
+	assert.Contains(t, metricsOutput, `cano_auth_rejections_total{path="/api/alerts",reason="missing_credentials"} 1`)
+	assert.Contains(t, metricsOutput, `cano_auth_rejections_total{path="/api/alerts",reason="invalid_bearer_token"} 1`)
+}
+
+func TestHAMetrics(t *testing.T) {
+	metrics := setupTestMetricsCollector(t)
+
+	metrics.SetHALeader(true)
+	metrics.IncHARequests("forwarded")
+	metrics.IncHARequests("forwarded")
+
+	metricsW := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	promhttp.Handler().ServeHTTP(metricsW, req)
+
+	metricsOutput := metricsW.Body.String()
+	assert.Contains(t, metricsOutput, "cano_ha_leader 1")
+	assert.Contains(t, metricsOutput, `cano_ha_requests_total{outcome="forwarded"} 2`)
+}
+
+func TestShardMetrics(t *testing.T) {
+	metrics := setupTestMetricsCollector(t)
+
+	metrics.SetShardPeers(3)
+	metrics.IncShardIssues("forwarded")
+	metrics.IncShardIssues("local")
+
+	metricsW := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	promhttp.Handler().ServeHTTP(metricsW, req)
+
+	metricsOutput := metricsW.Body.String()
+	assert.Contains(t, metricsOutput, "cano_shard_peers 3")
+	assert.Contains(t, metricsOutput, `cano_shard_issues_total{outcome="forwarded"} 1`)
+	assert.Contains(t, metricsOutput, `cano_shard_issues_total{outcome="local"} 1`)
+}
+
+func TestConfigReloadMetrics(t *testing.T) {
+	metrics := setupTestMetricsCollector(t)
+
+	metrics.IncConfigReloads("success")
+	metrics.IncConfigReloads("failure")
+	metrics.SetConfigLastReloadSuccessful(false)
+
+	metricsW := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	promhttp.Handler().ServeHTTP(metricsW, req)
+
+	metricsOutput := metricsW.Body.String()
+	assert.Contains(t, metricsOutput, `cano_config_reloads_total{result="success"} 1`)
+	assert.Contains(t, metricsOutput, `cano_config_reloads_total{result="failure"} 1`)
+	assert.Contains(t, metricsOutput, "cano_config_last_reload_successful 0")
+}
@@ -0,0 +1,219 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kubecano/cano-collector/config"
+	config_destination "github.com/kubecano/cano-collector/config/destination"
+	config_team "github.com/kubecano/cano-collector/config/team"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+)
+
+// Results reported in the cano_config_reloads_total metric
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// loadedConfig is the part of the configuration that can be reloaded
+type loadedConfig struct {
+	destinations config_destination.DestinationsConfig
+	teams        config_team.TeamsConfig
+	workflows    config_workflow.WorkflowConfig
+}
+
+// Reloader loads destinations, teams and workflows when their files change and every interval,
+// and swaps the components of the runtime when they changed. Mounted ConfigMaps and Secrets are
+// updated by kubelet through an atomic symlink swap, so every load sees either the old or the new
+// files.
+type Reloader struct {
+	cfg     config.ReloadConfig
+	loader  config.FullConfigLoader
+	build   Builder
+	runtime *Runtime
+	logger  logger_interfaces.LoggerInterface
+	metrics metric_interfaces.MetricsInterface
+	// changes triggers a reload before the next interval when the loader watches its sources
+	changes <-chan struct{}
+
+	mu     sync.Mutex
+	active loadedConfig
+	// rejected is the last configuration that failed, so it is not rebuilt until it changes again
+	rejected  *loadedConfig
+	lastError string
+}
+
+// NewReloader creates a reloader for runtime, whose components were built from initial
+func NewReloader(
+	cfg config.ReloadConfig,
+	loader config.FullConfigLoader,
+	build Builder,
+	runtime *Runtime,
+	initial config.Config,
+	logger logger_interfaces.LoggerInterface,
+	metrics metric_interfaces.MetricsInterface,
+) *Reloader {
+	return &Reloader{
+		cfg:     cfg,
+		loader:  loader,
+		build:   build,
+		runtime: runtime,
+		logger:  logger,
+		metrics: metrics,
+		active: loadedConfig{
+			destinations: initial.Destinations,
+			teams:        initial.Teams,
+			workflows:    initial.Workflows,
+		},
+	}
+}
+
+// ChangeNotifier is implemented by loaders that watch their sources for changes
+type ChangeNotifier interface {
+	Changed() <-chan struct{}
+}
+
+// SetChangeNotifier reloads the configuration as soon as notifier reports a change
+func (r *Reloader) SetChangeNotifier(notifier ChangeNotifier) {
+	r.changes = notifier.Changed()
+}
+
+func (r *Reloader) Enabled() bool {
+	return r.cfg.Enabled && r.cfg.Interval > 0
+}
+
+// Run checks the configuration when the watched files change, on every change notification and
+// every interval until ctx is cancelled. The interval is a fallback for changes the watcher misses.
+func (r *Reloader) Run(ctx context.Context) {
+	if !r.Enabled() {
+		return
+	}
+
+	r.logger.Info("Watching configuration for changes", zap.Duration("interval", r.cfg.Interval))
+	r.metrics.SetConfigLastReloadSuccessful(true)
+
+	watcher := r.startWatcher(ctx)
+	var fileChanges <-chan struct{}
+	if watcher != nil {
+		fileChanges = watcher.Changed()
+	}
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reload(watcher)
+		case <-r.changes:
+			r.reload(watcher)
+		case <-fileChanges:
+			r.reload(watcher)
+		}
+	}
+}
+
+// startWatcher watches the files of the loader and the credential files of the active
+// destinations. It returns nil when the loader reads no files or they cannot be watched.
+func (r *Reloader) startWatcher(ctx context.Context) *fileWatcher {
+	if _, ok := r.loader.(config.FileSource); !ok {
+		return nil
+	}
+	watcher, err := newFileWatcher(r.logger)
+	if err != nil {
+		r.logger.Warn("Failed to watch configuration files, checking them every interval", zap.Error(err))
+		return nil
+	}
+	watcher.Watch(r.watchedFiles())
+	go watcher.Run(ctx)
+	return watcher
+}
+
+// reload reloads the configuration and watches the credential files of the destinations in use
+func (r *Reloader) reload(watcher *fileWatcher) {
+	_, _ = r.Reload()
+	if watcher != nil {
+		watcher.Watch(r.watchedFiles())
+	}
+}
+
+func (r *Reloader) watchedFiles() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	files := r.loader.(config.FileSource).Files()
+	return append(files, r.active.destinations.CredentialFiles()...)
+}
+
+// Reload loads the configuration and, when it changed, validates it and swaps the components.
+// It reports whether new components were swapped in; on error the active components are kept.
+func (r *Reloader) Reload() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	destinations, teams, workflows, err := r.loader.Load()
+	if err != nil {
+		err = fmt.Errorf("failed to load configuration: %w", err)
+		r.reject(nil, err)
+		return false, err
+	}
+	loaded := loadedConfig{destinations: destinations, teams: teams, workflows: workflows}
+	if reflect.DeepEqual(loaded, r.active) {
+		// the files were reverted after a failed reload
+		if r.lastError != "" {
+			r.clearRejection()
+		}
+		return false, nil
+	}
+	if r.rejected != nil && reflect.DeepEqual(loaded, *r.rejected) {
+		return false, nil
+	}
+
+	if err := config_workflow.ValidateWorkflowConfig(&loaded.workflows); err != nil {
+		err = fmt.Errorf("workflow config validation failed: %w", err)
+		r.reject(&loaded, err)
+		return false, err
+	}
+	components, err := r.build(loaded.destinations, loaded.teams, loaded.workflows)
+	if err != nil {
+		r.reject(&loaded, err)
+		return false, err
+	}
+
+	r.runtime.Swap(components)
+	r.active = loaded
+	r.clearRejection()
+	r.metrics.IncConfigReloads(resultSuccess)
+	r.logger.Info("Configuration reloaded",
+		zap.Int("destinations", loaded.destinations.Count()),
+		zap.Int("teams", len(loaded.teams.Teams)),
+		zap.Int("workflows", len(loaded.workflows.ActiveWorkflows)))
+	return true, nil
+}
+
+// reject keeps the active components. A configuration that fails the same way as the previous
+// one is only reported once, so a broken file does not log an error every interval.
+func (r *Reloader) reject(loaded *loadedConfig, err error) {
+	r.rejected = loaded
+	if err.Error() == r.lastError {
+		return
+	}
+	r.lastError = err.Error()
+	r.metrics.IncConfigReloads(resultFailure)
+	r.metrics.SetConfigLastReloadSuccessful(false)
+	r.logger.Error("Failed to reload configuration, keeping the active configuration", zap.Error(err))
+}
+
+func (r *Reloader) clearRejection() {
+	r.rejected = nil
+	r.lastError = ""
+	r.metrics.SetConfigLastReloadSuccessful(true)
+}
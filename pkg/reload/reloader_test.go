@@ -0,0 +1,247 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/config"
+	config_destination "github.com/kubecano/cano-collector/config/destination"
+	config_team "github.com/kubecano/cano-collector/config/team"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+	"github.com/kubecano/cano-collector/mocks"
+)
+
+func testWorkflows(name string) config_workflow.WorkflowConfig {
+	return config_workflow.WorkflowConfig{
+		ActiveWorkflows: []config_workflow.WorkflowDefinition{{
+			Name:     name,
+			Triggers: []config_workflow.TriggerDefinition{{OnAlertmanagerAlert: &config_workflow.AlertmanagerAlertTrigger{}}},
+			Actions:  []config_workflow.ActionDefinition{{ActionType: "label_filter"}},
+		}},
+	}
+}
+
+func testTeams(destinations ...string) config_team.TeamsConfig {
+	return config_team.TeamsConfig{Teams: []config_team.Team{{Name: "ops", Destinations: destinations}}}
+}
+
+type reloaderTestDeps struct {
+	reloader *Reloader
+	runtime  *Runtime
+	loader   *mocks.MockFullConfigLoader
+	metrics  *mocks.MockMetricsInterface
+	// built holds the teams passed to the builder, in order
+	built []config_team.TeamsConfig
+	// buildErr is returned by the builder when set
+	buildErr error
+}
+
+// setupReloaderTest creates a reloader whose builder records the teams it builds components for
+func setupReloaderTest(t *testing.T) *reloaderTestDeps {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	deps := &reloaderTestDeps{
+		loader:  mocks.NewMockFullConfigLoader(ctrl),
+		metrics: mocks.NewMockMetricsInterface(ctrl),
+	}
+	deps.runtime = NewRuntime(&Components{TeamResolver: mocks.NewMockTeamResolverInterface(ctrl)})
+
+	build := func(_ config_destination.DestinationsConfig, teams config_team.TeamsConfig, _ config_workflow.WorkflowConfig) (*Components, error) {
+		deps.built = append(deps.built, teams)
+		if deps.buildErr != nil {
+			return nil, deps.buildErr
+		}
+		return &Components{TeamResolver: mocks.NewMockTeamResolverInterface(ctrl)}, nil
+	}
+
+	initial := config.Config{Teams: testTeams("slack-ops"), Workflows: testWorkflows("enrich")}
+	deps.reloader = NewReloader(config.ReloadConfig{Enabled: true, Interval: time.Second}, deps.loader, build, deps.runtime, initial, mockLogger, deps.metrics)
+	return deps
+}
+
+func (d *reloaderTestDeps) load(teams config_team.TeamsConfig, workflows config_workflow.WorkflowConfig) {
+	d.loader.EXPECT().Load().Return(config_destination.DestinationsConfig{}, teams, workflows, nil).Times(1)
+}
+
+func TestReloader_UnchangedConfig(t *testing.T) {
+	deps := setupReloaderTest(t)
+	active := deps.runtime.current()
+	deps.load(testTeams("slack-ops"), testWorkflows("enrich"))
+
+	reloaded, err := deps.reloader.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+	assert.Empty(t, deps.built)
+	assert.Same(t, active, deps.runtime.current())
+}
+
+func TestReloader_SwapsChangedConfig(t *testing.T) {
+	deps := setupReloaderTest(t)
+	active := deps.runtime.current()
+	deps.load(testTeams("slack-ops", "slack-dev"), testWorkflows("enrich"))
+	deps.metrics.EXPECT().IncConfigReloads(resultSuccess).Times(1)
+	deps.metrics.EXPECT().SetConfigLastReloadSuccessful(true).Times(1)
+
+	reloaded, err := deps.reloader.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	require.Len(t, deps.built, 1)
+	assert.Equal(t, testTeams("slack-ops", "slack-dev"), deps.built[0])
+	assert.NotSame(t, active, deps.runtime.current())
+
+	// the new configuration is active, so loading it again changes nothing
+	deps.load(testTeams("slack-ops", "slack-dev"), testWorkflows("enrich"))
+	reloaded, err = deps.reloader.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+}
+
+func TestReloader_InvalidConfigKeepsActive(t *testing.T) {
+	deps := setupReloaderTest(t)
+	active := deps.runtime.current()
+	deps.buildErr = errors.New("team 'ops' references non-existent destination 'slack-missing'")
+	deps.metrics.EXPECT().IncConfigReloads(resultFailure).Times(1)
+	deps.metrics.EXPECT().SetConfigLastReloadSuccessful(false).Times(1)
+
+	deps.load(testTeams("slack-missing"), testWorkflows("enrich"))
+	reloaded, err := deps.reloader.Reload()
+	assert.ErrorContains(t, err, "slack-missing")
+	assert.False(t, reloaded)
+	assert.Same(t, active, deps.runtime.current())
+
+	// the same rejected configuration is neither rebuilt nor reported again
+	deps.load(testTeams("slack-missing"), testWorkflows("enrich"))
+	reloaded, err = deps.reloader.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+	assert.Len(t, deps.built, 1)
+
+	// reverting the files marks the active configuration as successful again
+	deps.metrics.EXPECT().SetConfigLastReloadSuccessful(true).Times(1)
+	deps.load(testTeams("slack-ops"), testWorkflows("enrich"))
+	_, err = deps.reloader.Reload()
+	require.NoError(t, err)
+}
+
+func TestReloader_InvalidWorkflowsKeepActive(t *testing.T) {
+	deps := setupReloaderTest(t)
+	active := deps.runtime.current()
+	deps.metrics.EXPECT().IncConfigReloads(resultFailure).Times(1)
+	deps.metrics.EXPECT().SetConfigLastReloadSuccessful(false).Times(1)
+
+	deps.load(testTeams("slack-ops"), config_workflow.WorkflowConfig{})
+	_, err := deps.reloader.Reload()
+	assert.ErrorContains(t, err, "workflow config validation failed")
+	assert.Empty(t, deps.built)
+	assert.Same(t, active, deps.runtime.current())
+}
+
+func TestReloader_LoadErrorKeepsActive(t *testing.T) {
+	deps := setupReloaderTest(t)
+	active := deps.runtime.current()
+	deps.metrics.EXPECT().IncConfigReloads(resultFailure).Times(1)
+	deps.metrics.EXPECT().SetConfigLastReloadSuccessful(false).Times(1)
+
+	loadErr := errors.New("failed to decode teams YAML")
+	deps.loader.EXPECT().Load().Return(config_destination.DestinationsConfig{}, config_team.TeamsConfig{}, config_workflow.WorkflowConfig{}, loadErr).Times(2)
+
+	_, err := deps.reloader.Reload()
+	assert.ErrorIs(t, err, loadErr)
+	// the same error is reported once
+	_, err = deps.reloader.Reload()
+	assert.ErrorIs(t, err, loadErr)
+	assert.Same(t, active, deps.runtime.current())
+}
+
+func TestReloader_Disabled(t *testing.T) {
+	deps := setupReloaderTest(t)
+	deps.reloader.cfg.Enabled = false
+	assert.False(t, deps.reloader.Enabled())
+
+	// Run returns immediately without loading the configuration
+	deps.reloader.Run(t.Context())
+}
+
+type testNotifier chan struct{}
+
+func (n testNotifier) Changed() <-chan struct{} {
+	return n
+}
+
+func TestReloader_ReloadsOnChange(t *testing.T) {
+	deps := setupReloaderTest(t)
+	deps.reloader.cfg.Interval = time.Hour
+	notifier := make(testNotifier, 1)
+	deps.reloader.SetChangeNotifier(notifier)
+
+	reloaded := make(chan struct{})
+	deps.metrics.EXPECT().SetConfigLastReloadSuccessful(true).Times(2)
+	deps.metrics.EXPECT().IncConfigReloads(resultSuccess).Do(func(string) { close(reloaded) }).Times(1)
+	deps.load(testTeams("slack-ops", "slack-dev"), testWorkflows("enrich"))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go deps.reloader.Run(ctx)
+	notifier <- struct{}{}
+
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration was not reloaded after a change notification")
+	}
+}
+
+// fileLoader is a loader reading files, so the reloader watches them
+type fileLoader struct {
+	*mocks.MockFullConfigLoader
+	files []string
+}
+
+func (l fileLoader) Files() []string {
+	return l.files
+}
+
+func TestReloader_ReloadsOnFileChange(t *testing.T) {
+	deps := setupReloaderTest(t)
+	deps.reloader.cfg.Interval = time.Hour
+	file := filepath.Join(t.TempDir(), "teams.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("teams: []"), 0o600))
+	deps.reloader.loader = fileLoader{MockFullConfigLoader: deps.loader, files: []string{file}}
+
+	reloaded := make(chan struct{})
+	deps.metrics.EXPECT().SetConfigLastReloadSuccessful(true).Times(2)
+	deps.metrics.EXPECT().IncConfigReloads(resultSuccess).Do(func(string) { close(reloaded) }).Times(1)
+	deps.loader.EXPECT().Load().Return(config_destination.DestinationsConfig{}, testTeams("slack-ops", "slack-dev"), testWorkflows("enrich"), nil).MinTimes(1)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go deps.reloader.Run(ctx)
+
+	// the watcher starts in the background, so the file is written until the change is seen
+	deadline := time.After(5 * time.Second)
+	for {
+		require.NoError(t, os.WriteFile(file, []byte("teams: [{name: ops}]"), 0o600))
+		select {
+		case <-reloaded:
+			return
+		case <-deadline:
+			t.Fatal("configuration was not reloaded after the file changed")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
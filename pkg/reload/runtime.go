@@ -0,0 +1,81 @@
+package reload
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+
+	config_destination "github.com/kubecano/cano-collector/config/destination"
+	config_team "github.com/kubecano/cano-collector/config/team"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+	alert_interfaces "github.com/kubecano/cano-collector/pkg/alert/interfaces"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	destination_interfaces "github.com/kubecano/cano-collector/pkg/destination/interfaces"
+	workflow_interfaces "github.com/kubecano/cano-collector/pkg/workflow/interfaces"
+)
+
+// Components are the parts of the collector built from the destinations, teams and workflows.
+// IssueProcessor and AlertHandler are built from Registry, TeamResolver and WorkflowEngine of the
+// same configuration, so an alert is routed, enriched and sent by one configuration.
+type Components struct {
+	Registry       destination_interfaces.DestinationRegistryInterface
+	TeamResolver   alert_interfaces.TeamResolverInterface
+	WorkflowEngine workflow_interfaces.WorkflowEngineInterface
+	IssueProcessor alert_interfaces.IssueProcessorInterface
+	AlertHandler   alert_interfaces.AlertHandlerInterface
+}
+
+// Builder creates and validates the components of a configuration
+type Builder func(
+	destinations config_destination.DestinationsConfig,
+	teams config_team.TeamsConfig,
+	workflows config_workflow.WorkflowConfig,
+) (*Components, error)
+
+// Runtime holds the active components. The handlers use the views returned by IssueProcessor and
+// AlertHandler, which pick the components swapped in last once per request and use them until
+// the request is done.
+type Runtime struct {
+	active atomic.Pointer[Components]
+}
+
+// NewRuntime creates a runtime serving components, which may be nil until the first Swap
+func NewRuntime(components *Components) *Runtime {
+	r := &Runtime{}
+	r.active.Store(components)
+	return r
+}
+
+// Swap replaces all components at once
+func (r *Runtime) Swap(components *Components) {
+	r.active.Store(components)
+}
+
+func (r *Runtime) current() *Components {
+	return r.active.Load()
+}
+
+func (r *Runtime) IssueProcessor() alert_interfaces.IssueProcessorInterface {
+	return &issueProcessorView{runtime: r}
+}
+
+func (r *Runtime) AlertHandler() alert_interfaces.AlertHandlerInterface {
+	return &alertHandlerView{runtime: r}
+}
+
+type issueProcessorView struct {
+	runtime *Runtime
+}
+
+func (v *issueProcessorView) ProcessIssues(ctx context.Context, issues []*issuepkg.Issue) error {
+	return v.runtime.current().IssueProcessor.ProcessIssues(ctx, issues)
+}
+
+type alertHandlerView struct {
+	runtime *Runtime
+}
+
+func (v *alertHandlerView) HandleAlert(c *gin.Context) {
+	v.runtime.current().AlertHandler.HandleAlert(c)
+}
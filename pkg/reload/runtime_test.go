@@ -0,0 +1,50 @@
+package reload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+func TestRuntime_ViewsFollowSwap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issues := []*issuepkg.Issue{issuepkg.NewIssue("Disk full", "DiskFull")}
+	oldProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+	oldProcessor.EXPECT().ProcessIssues(gomock.Any(), issues).Return(nil).Times(1)
+	newProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+	newProcessor.EXPECT().ProcessIssues(gomock.Any(), issues).Return(nil).Times(1)
+
+	runtime := NewRuntime(&Components{IssueProcessor: oldProcessor})
+	processor := runtime.IssueProcessor()
+	assert.NoError(t, processor.ProcessIssues(context.Background(), issues))
+
+	runtime.Swap(&Components{IssueProcessor: newProcessor})
+	assert.NoError(t, processor.ProcessIssues(context.Background(), issues))
+}
+
+func TestRuntime_RequestKeepsSnapshotAcrossSwap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issues := []*issuepkg.Issue{issuepkg.NewIssue("Disk full", "DiskFull")}
+	runtime := NewRuntime(nil)
+	newProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+	newProcessor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Times(0)
+	oldProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+	// a reload while the request is processed does not change the components it uses
+	oldProcessor.EXPECT().ProcessIssues(gomock.Any(), issues).DoAndReturn(func(context.Context, []*issuepkg.Issue) error {
+		runtime.Swap(&Components{IssueProcessor: newProcessor})
+		return nil
+	}).Times(1)
+	runtime.Swap(&Components{IssueProcessor: oldProcessor})
+
+	assert.NoError(t, runtime.IssueProcessor().ProcessIssues(context.Background(), issues))
+	assert.Same(t, newProcessor, runtime.current().IssueProcessor)
+}
@@ -0,0 +1,134 @@
+package reload
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+)
+
+// dataLink is the symlink kubelet swaps to update the files of a mounted ConfigMap or Secret
+const dataLink = "..data"
+
+// watchDelay collects the events of one update, which writes several files, into one change
+const watchDelay = 500 * time.Millisecond
+
+// fileWatcher reports changes of files. Kubelet mounts the files of a ConfigMap or Secret as
+// symlinks into the ..data directory and updates them by replacing the ..data symlink, which
+// creates no events for the files themselves. The watcher therefore watches the directories of
+// the files and reports a change when a watched file or the ..data symlink changes.
+type fileWatcher struct {
+	watcher *fsnotify.Watcher
+	logger  logger_interfaces.LoggerInterface
+	delay   time.Duration
+	changed chan struct{}
+
+	mu sync.Mutex
+	// dirs maps every watched directory to the names of the watched files in it
+	dirs map[string]map[string]bool
+}
+
+func newFileWatcher(logger logger_interfaces.LoggerInterface) (*fileWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	return &fileWatcher{
+		watcher: watcher,
+		logger:  logger,
+		delay:   watchDelay,
+		changed: make(chan struct{}, 1),
+		dirs:    make(map[string]map[string]bool),
+	}, nil
+}
+
+// Changed receives a value after a watched file changed
+func (w *fileWatcher) Changed() <-chan struct{} {
+	return w.changed
+}
+
+// Watch replaces the watched files with files. Directories that cannot be watched are skipped,
+// the periodic reload still picks up their changes.
+func (w *fileWatcher) Watch(files []string) {
+	dirs := make(map[string]map[string]bool)
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if dirs[dir] == nil {
+			dirs[dir] = make(map[string]bool)
+		}
+		dirs[dir][filepath.Base(file)] = true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for dir := range w.dirs {
+		if dirs[dir] == nil {
+			_ = w.watcher.Remove(dir)
+			delete(w.dirs, dir)
+		}
+	}
+	for dir, names := range dirs {
+		if w.dirs[dir] == nil {
+			if err := w.watcher.Add(dir); err != nil {
+				w.logger.Warn("Failed to watch configuration directory", zap.String("dir", dir), zap.Error(err))
+				continue
+			}
+		}
+		w.dirs[dir] = names
+	}
+}
+
+// Run reports changes until ctx is cancelled and closes the watcher
+func (w *fileWatcher) Run(ctx context.Context) {
+	defer func() { _ = w.watcher.Close() }()
+
+	timer := time.NewTimer(w.delay)
+	timer.Stop()
+	// pending is set while the events of an update are collected
+	pending := false
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if !pending && w.relevant(event) {
+				pending = true
+				timer.Reset(w.delay)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Warn("Failed to watch configuration files", zap.Error(err))
+		case <-timer.C:
+			pending = false
+			select {
+			case w.changed <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (w *fileWatcher) relevant(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Base(event.Name)
+	if name == dataLink {
+		return true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dirs[filepath.Dir(event.Name)][name]
+}
@@ -0,0 +1,110 @@
+package reload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+)
+
+// mountConfigMap lays out dir like kubelet mounts a ConfigMap: the files are symlinks into the
+// ..data symlink, which points at a timestamped directory with the content
+func mountConfigMap(t *testing.T, dir, version, content string) {
+	t.Helper()
+	versionDir := filepath.Join(dir, "..2026_10_18_"+version)
+	require.NoError(t, os.Mkdir(versionDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(versionDir, "teams.yaml"), []byte(content), 0o600))
+
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(filepath.Base(versionDir), tmpLink))
+	require.NoError(t, os.Rename(tmpLink, filepath.Join(dir, dataLink)))
+
+	file := filepath.Join(dir, "teams.yaml")
+	if _, err := os.Lstat(file); os.IsNotExist(err) {
+		require.NoError(t, os.Symlink(filepath.Join(dataLink, "teams.yaml"), file))
+	}
+}
+
+func startTestWatcher(t *testing.T, files ...string) *fileWatcher {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	watcher, err := newFileWatcher(mocks.NewMockLoggerInterface(ctrl))
+	require.NoError(t, err)
+	watcher.delay = 10 * time.Millisecond
+	watcher.Watch(files)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go watcher.Run(ctx)
+	return watcher
+}
+
+func assertChanged(t *testing.T, watcher *fileWatcher) {
+	t.Helper()
+	select {
+	case <-watcher.Changed():
+	case <-time.After(5 * time.Second):
+		t.Fatal("change was not reported")
+	}
+}
+
+func assertUnchanged(t *testing.T, watcher *fileWatcher) {
+	t.Helper()
+	select {
+	case <-watcher.Changed():
+		t.Fatal("unexpected change")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestFileWatcher_ConfigMapSymlinkSwap(t *testing.T) {
+	dir := t.TempDir()
+	mountConfigMap(t, dir, "1", "teams: []")
+	watcher := startTestWatcher(t, filepath.Join(dir, "teams.yaml"))
+
+	mountConfigMap(t, dir, "2", "teams: [{name: ops}]")
+	assertChanged(t, watcher)
+}
+
+func TestFileWatcher_FileWrite(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(file, []byte("old"), 0o600))
+	watcher := startTestWatcher(t, file)
+
+	require.NoError(t, os.WriteFile(file, []byte("new"), 0o600))
+	assertChanged(t, watcher)
+}
+
+func TestFileWatcher_IgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(file, []byte("token"), 0o600))
+	watcher := startTestWatcher(t, file)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other"), []byte("other"), 0o600))
+	assertUnchanged(t, watcher)
+}
+
+func TestFileWatcher_WatchReplacesFiles(t *testing.T) {
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	oldFile, newFile := filepath.Join(oldDir, "token"), filepath.Join(newDir, "token")
+	require.NoError(t, os.WriteFile(oldFile, []byte("old"), 0o600))
+	require.NoError(t, os.WriteFile(newFile, []byte("new"), 0o600))
+	watcher := startTestWatcher(t, oldFile)
+
+	watcher.Watch([]string{newFile})
+	require.NoError(t, os.WriteFile(oldFile, []byte("changed"), 0o600))
+	assertUnchanged(t, watcher)
+
+	require.NoError(t, os.WriteFile(newFile, []byte("changed"), 0o600))
+	assertChanged(t, watcher)
+}
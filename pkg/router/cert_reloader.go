@@ -0,0 +1,103 @@
+package router
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+)
+
+// certReloader serves the TLS certificate from disk and reloads it when the files change,
+// so certificates rotated by cert-manager are picked up without a restart
+type certReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	logger   logger_interfaces.LoggerInterface
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	lastCheck time.Time
+	now       func() time.Time
+}
+
+func newCertReloader(certFile, keyFile string, interval time.Duration, logger logger_interfaces.LoggerInterface) (*certReloader, error) {
+	r := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(certMod, keyMod); err != nil {
+		return nil, err
+	}
+	r.lastCheck = r.now()
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate
+func (r *certReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.interval > 0 && now.Sub(r.lastCheck) >= r.interval {
+		r.lastCheck = now
+		r.reloadIfChanged()
+	}
+	return r.cert, nil
+}
+
+// reloadIfChanged keeps serving the previous certificate when the new files cannot be loaded,
+// e.g. while the key and certificate are being replaced one after another
+func (r *certReloader) reloadIfChanged() {
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		r.logger.Error("Failed to check TLS certificate files", zap.Error(err))
+		return
+	}
+	if certMod.Equal(r.certMod) && keyMod.Equal(r.keyMod) {
+		return
+	}
+	if err := r.load(certMod, keyMod); err != nil {
+		r.logger.Error("Failed to reload TLS certificate, keeping the previous one", zap.Error(err))
+		return
+	}
+	r.logger.Info("TLS certificate reloaded", zap.String("cert_file", r.certFile))
+}
+
+func (r *certReloader) load(certMod, keyMod time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	r.cert = &cert
+	r.certMod = certMod
+	r.keyMod = keyMod
+	return nil
+}
+
+func (r *certReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat TLS certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat TLS key: %w", err)
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
@@ -0,0 +1,106 @@
+package router
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+)
+
+// writeKeyPair writes a self-signed certificate for commonName and sets the file modification time
+func writeKeyPair(t *testing.T, dir, commonName string, modTime time.Time) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+	return certFile, keyFile
+}
+
+func servedCommonName(t *testing.T, r *certReloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestCertReloader_ReloadsChangedFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).Times(1)
+
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Hour)
+	certFile, keyFile := writeKeyPair(t, dir, "first", base)
+
+	reloader, err := newCertReloader(certFile, keyFile, time.Minute, mockLogger)
+	require.NoError(t, err)
+	now := time.Now()
+	reloader.now = func() time.Time { return now }
+
+	writeKeyPair(t, dir, "second", base.Add(time.Minute))
+	assert.Equal(t, "first", servedCommonName(t, reloader), "files are not checked before the interval")
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, "second", servedCommonName(t, reloader))
+}
+
+func TestCertReloader_KeepsCertificateOnInvalidFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Hour)
+	certFile, keyFile := writeKeyPair(t, dir, "valid", base)
+
+	reloader, err := newCertReloader(certFile, keyFile, time.Minute, mockLogger)
+	require.NoError(t, err)
+	reloader.now = func() time.Time { return time.Now().Add(time.Minute) }
+
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0o600))
+	require.NoError(t, os.Chtimes(certFile, base.Add(time.Minute), base.Add(time.Minute)))
+
+	assert.Equal(t, "valid", servedCommonName(t, reloader))
+}
+
+func TestNewCertReloader_MissingFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := newCertReloader("/nonexistent/tls.crt", "/nonexistent/tls.key", time.Minute, mocks.NewMockLoggerInterface(ctrl))
+	require.Error(t, err)
+}
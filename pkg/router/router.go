@@ -2,10 +2,14 @@ package router
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -29,8 +33,15 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/kubecano/cano-collector/config"
+	auth_interfaces "github.com/kubecano/cano-collector/pkg/auth/interfaces"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
 	health_interfaces "github.com/kubecano/cano-collector/pkg/health/interfaces"
+	ingest_interfaces "github.com/kubecano/cano-collector/pkg/ingest/interfaces"
 	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	"github.com/kubecano/cano-collector/pkg/schema"
+	schema_interfaces "github.com/kubecano/cano-collector/pkg/schema/interfaces"
+	"github.com/kubecano/cano-collector/pkg/shard"
+	shard_interfaces "github.com/kubecano/cano-collector/pkg/shard/interfaces"
 )
 
 type RouterManager struct {
@@ -40,6 +51,14 @@ type RouterManager struct {
 	metrics metric_interfaces.MetricsInterface
 	health  health_interfaces.HealthInterface
 	alerts  alert_interfaces.AlertHandlerInterface
+	ingest  ingest_interfaces.IngestHandlerInterface
+	auth    auth_interfaces.AuthenticatorInterface
+	ha      ha_interfaces.CoordinatorInterface
+	sharder shard_interfaces.SharderInterface
+	schemas schema_interfaces.SchemaHandlerInterface
+
+	// draining is set once shutdown starts so /readyz stops reporting ready
+	draining atomic.Bool
 }
 
 func NewRouterManager(
@@ -49,6 +68,11 @@ func NewRouterManager(
 	metrics metric_interfaces.MetricsInterface,
 	health health_interfaces.HealthInterface,
 	alerts alert_interfaces.AlertHandlerInterface,
+	ingest ingest_interfaces.IngestHandlerInterface,
+	auth auth_interfaces.AuthenticatorInterface,
+	ha ha_interfaces.CoordinatorInterface,
+	sharder shard_interfaces.SharderInterface,
+	schemas schema_interfaces.SchemaHandlerInterface,
 ) *RouterManager {
 	return &RouterManager{
 		cfg:     cfg,
@@ -57,6 +81,11 @@ func NewRouterManager(
 		metrics: metrics,
 		health:  health,
 		alerts:  alerts,
+		ingest:  ingest,
+		auth:    auth,
+		ha:      ha,
+		sharder: sharder,
+		schemas: schemas,
 	}
 }
 
@@ -110,6 +139,49 @@ func (rm *RouterManager) SetupRouter() *gin.Engine {
 	r.Use(rm.metrics.PrometheusMiddleware())
 
 	r.GET("/", rm.rootHandler)
+	if !rm.cfg.Server.InternalEnabled() {
+		rm.registerInternalRoutes(r)
+	}
+
+	// Schemas describe the configuration files only, so editors may fetch them without credentials
+	r.GET(schema.IndexPath, rm.schemas.HandleIndex)
+	r.GET(schema.IndexPath+"/:name", rm.schemas.HandleSchema)
+
+	api := r.Group("/api")
+	api.Use(bodyLimitMiddleware(rm.cfg.Server.MaxBodyBytes))
+	api.Use(rm.auth.Middleware())
+	api.Use(rm.ha.Middleware())
+	{
+		api.POST("/alerts", rm.alerts.HandleAlert)
+		api.POST("/webhooks/:name", rm.ingest.HandleWebhook)
+		api.POST("/grafana", rm.ingest.HandleGrafana)
+		api.POST("/falco", rm.ingest.HandleFalco)
+
+		v1 := api.Group("/v1")
+		v1.POST("/issues", rm.ingest.CreateIssue)
+		v1.PATCH("/issues/:fingerprint", rm.ingest.ResolveIssue)
+	}
+
+	// Requests buffered while no leader was reachable are replayed through the router
+	rm.ha.SetHandler(r)
+
+	rm.logger.Debug("Router setup complete")
+	return r
+}
+
+// SetupInternalRouter builds the router of the internal listener serving metrics and health endpoints
+func (rm *RouterManager) SetupInternalRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(ginzap.RecoveryWithZap(rm.logger.GetLogger(), true))
+	rm.registerInternalRoutes(r)
+	// Forwarded issues bypass the API middleware, so they are never served on the public listener
+	if rm.sharder.Enabled() {
+		r.POST(shard.ForwardedIssuesPath, rm.sharder.HandleForwardedIssues)
+	}
+	return r
+}
+
+func (rm *RouterManager) registerInternalRoutes(r gin.IRoutes) {
 	r.GET("/metrics", func(c *gin.Context) {
 		metricsFamilies, err := prometheus.DefaultGatherer.Gather()
 		if err != nil || len(metricsFamilies) == 0 {
@@ -123,16 +195,52 @@ func (rm *RouterManager) SetupRouter() *gin.Engine {
 	r.GET("/livez", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
-	r.GET("/readyz", gin.WrapH(rm.health.Handler()))
+	r.GET("/readyz", rm.readyHandler)
+
 	r.GET("/healthz", gin.WrapH(rm.health.Handler()))
+}
 
-	api := r.Group("/api")
-	{
-		api.POST("/alerts", rm.alerts.HandleAlert)
+func (rm *RouterManager) readyHandler(c *gin.Context) {
+	if rm.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "shutting down"})
+		return
+	}
+	if !rm.ha.Enabled() && !rm.sharder.Enabled() {
+		rm.health.Handler().ServeHTTP(c.Writer, c.Request)
+		return
 	}
 
-	rm.logger.Debug("Router setup complete")
-	return r
+	// Followers stay ready to accept and forward requests, so leadership and peers are reported, not enforced
+	rec := httptest.NewRecorder()
+	rm.health.Handler().ServeHTTP(rec, c.Request)
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		body["status"] = http.StatusText(rec.Code)
+	}
+	if rm.ha.Enabled() {
+		body["ha"] = rm.ha.Status()
+	}
+	if rm.sharder.Enabled() {
+		body["shard"] = rm.sharder.Status()
+	}
+	c.JSON(rec.Code, body)
+}
+
+// bodyLimitMiddleware rejects requests whose body exceeds limit bytes; a limit of 0 disables the check.
+// A body of unknown length, like a chunked one, fails while it is read and the handlers answer 413.
+func bodyLimitMiddleware(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
 }
 
 func (rm *RouterManager) rootHandler(c *gin.Context) {
@@ -143,35 +251,100 @@ func (rm *RouterManager) rootHandler(c *gin.Context) {
 }
 
 func (rm *RouterManager) StartServer(router *gin.Engine) {
-	rm.logger.Info("Cano-collector server starting...")
-	srv := &http.Server{
-		Addr:    ":8080",
-		Handler: router,
+	rm.logger.Info("Cano-collector server starting...", zap.String("address", rm.cfg.Server.Address()))
+	srv, err := rm.newAPIServer(router)
+	if err != nil {
+		rm.logger.Fatalf("Failed to configure server: %v", err)
+		return
 	}
 
+	servers := []*http.Server{srv}
 	go func() {
 		// service connections
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if srv.TLSConfig != nil {
+			// the certificate is provided by TLSConfig.GetCertificate
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			rm.logger.Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
-	// Wait for interrupt signal to gracefully shutdown the server with
-	// a timeout of 5 seconds.
+	if rm.cfg.Server.InternalEnabled() {
+		internal := rm.newServer(rm.cfg.Server.InternalAddress(), rm.SetupInternalRouter())
+		servers = append(servers, internal)
+		rm.logger.Info("Internal server starting...", zap.String("address", internal.Addr))
+		go func() {
+			if err := internal.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				rm.logger.Fatalf("Failed to start internal server: %v", err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	// kill (no param) default send syscall.SIGTERM
 	// kill -2 is syscall.SIGINT
 	// kill -9 is syscall.SIGKILL but can't be caught, so don't need add it
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	rm.logger.Info("Cano-collector shutdown server ...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	rm.shutdown(servers)
+	rm.logger.Info("Cano-collector server exiting")
+}
+
+// shutdown reports not ready for ShutdownDelay so the pod is removed from Service endpoints,
+// then stops accepting connections and waits up to ShutdownTimeout for in-flight requests
+func (rm *RouterManager) shutdown(servers []*http.Server) {
+	rm.draining.Store(true)
+	// Releasing the lease first lets another replica take over while this one drains;
+	// requests still arriving here are forwarded to the new leader
+	rm.ha.Stop()
+	rm.logger.Info("Cano-collector draining connections ...",
+		zap.Duration("shutdown_delay", rm.cfg.Server.ShutdownDelay),
+		zap.Duration("shutdown_timeout", rm.cfg.Server.ShutdownTimeout))
+	time.Sleep(rm.cfg.Server.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), rm.cfg.Server.ShutdownTimeout)
 	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil {
-		rm.logger.Fatalf("Cano-collector server shutdown: %v", err)
+	for _, srv := range servers {
+		if err := srv.Shutdown(ctx); err != nil {
+			rm.logger.Error("Cano-collector server shutdown did not complete", zap.String("address", srv.Addr), zap.Error(err))
+		}
 	}
-	// catching ctx.Done(). timeout of 5 seconds.
-	<-ctx.Done()
-	rm.logger.Info("Cano-collector server exiting")
+}
+
+func (rm *RouterManager) newServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadTimeout:       rm.cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: rm.cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      rm.cfg.Server.WriteTimeout,
+		IdleTimeout:       rm.cfg.Server.IdleTimeout,
+	}
+}
+
+func (rm *RouterManager) newAPIServer(handler http.Handler) (*http.Server, error) {
+	srv := rm.newServer(rm.cfg.Server.Address(), handler)
+	if !rm.cfg.Server.TLSEnabled() {
+		return srv, nil
+	}
+
+	reloader, err := newCertReloader(rm.cfg.Server.TLSCertFile, rm.cfg.Server.TLSKeyFile, rm.cfg.Server.TLSReloadInterval, rm.logger)
+	if err != nil {
+		return nil, err
+	}
+	srv.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+	if rm.cfg.Auth.ClientCAFile != "" {
+		// Client certificates are verified by the auth middleware, so requests
+		// without one can still authenticate with other methods
+		srv.TLSConfig.ClientAuth = tls.RequestClientCert
+	}
+	return srv, nil
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -22,10 +23,18 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kubecano/cano-collector/config"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
 	"github.com/kubecano/cano-collector/pkg/metric"
+	"github.com/kubecano/cano-collector/pkg/shard"
+	shard_interfaces "github.com/kubecano/cano-collector/pkg/shard/interfaces"
 )
 
 func setupTestRouter(t *testing.T) *RouterManager {
+	t.Helper()
+	return setupTestRouterWithOptions(t, config.ServerConfig{}, func(c *gin.Context) { c.Next() })
+}
+
+func setupTestRouterWithOptions(t *testing.T, server config.ServerConfig, authMiddleware gin.HandlerFunc) *RouterManager {
 	t.Helper()
 	gin.SetMode(gin.TestMode)
 
@@ -41,12 +50,37 @@ func setupTestRouter(t *testing.T) *RouterManager {
 	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 
 	mockTracer := mocks.NewMockTracerInterface(ctrl)
 	mockAlerts := mocks.NewMockAlertHandlerInterface(ctrl)
 	mockAlerts.EXPECT().HandleAlert(gomock.Any()).DoAndReturn(func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "alert received"})
 	}).AnyTimes()
+	mockIngest := mocks.NewMockIngestHandlerInterface(ctrl)
+	mockIngest.EXPECT().HandleWebhook(gomock.Any()).DoAndReturn(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "webhook received", "name": c.Param("name")})
+	}).AnyTimes()
+	mockIngest.EXPECT().HandleGrafana(gomock.Any()).DoAndReturn(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "grafana alert received"})
+	}).AnyTimes()
+	mockIngest.EXPECT().HandleFalco(gomock.Any()).DoAndReturn(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "falco event received"})
+	}).AnyTimes()
+	mockIngest.EXPECT().CreateIssue(gomock.Any()).DoAndReturn(func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"result": "created"})
+	}).AnyTimes()
+	mockIngest.EXPECT().ResolveIssue(gomock.Any()).DoAndReturn(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"result": "resolved", "fingerprint": c.Param("fingerprint")})
+	}).AnyTimes()
+
+	mockSchemas := mocks.NewMockSchemaHandlerInterface(ctrl)
+	mockSchemas.EXPECT().HandleIndex(gomock.Any()).DoAndReturn(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"schemas": gin.H{"teams": "/api/schema/teams"}})
+	}).AnyTimes()
+	mockSchemas.EXPECT().HandleSchema(gomock.Any()).DoAndReturn(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"title": c.Param("name")})
+	}).AnyTimes()
 
 	mockHealth := mocks.NewMockHealthInterface(ctrl)
 
@@ -62,9 +96,13 @@ func setupTestRouter(t *testing.T) *RouterManager {
 	cfg := config.Config{
 		AppName:    "cano-collector",
 		AppVersion: "1.0.0",
+		Server:     server,
 	}
 
-	routerManager := NewRouterManager(cfg, mockLogger, mockTracer, mockMetrics, mockHealth, mockAlerts)
+	mockAuth := mocks.NewMockAuthenticatorInterface(ctrl)
+	mockAuth.EXPECT().Middleware().Return(authMiddleware).AnyTimes()
+
+	routerManager := NewRouterManager(cfg, mockLogger, mockTracer, mockMetrics, mockHealth, mockAlerts, mockIngest, mockAuth, newPassThroughCoordinator(ctrl), newDisabledSharder(ctrl), mockSchemas)
 
 	if routerManager.logger == nil {
 		panic("RouterManager.logger is nil!")
@@ -73,6 +111,23 @@ func setupTestRouter(t *testing.T) *RouterManager {
 	return routerManager
 }
 
+// newPassThroughCoordinator returns a coordinator with HA mode disabled
+func newPassThroughCoordinator(ctrl *gomock.Controller) *mocks.MockCoordinatorInterface {
+	coordinator := mocks.NewMockCoordinatorInterface(ctrl)
+	coordinator.EXPECT().Enabled().Return(false).AnyTimes()
+	coordinator.EXPECT().Middleware().Return(func(c *gin.Context) { c.Next() }).AnyTimes()
+	coordinator.EXPECT().SetHandler(gomock.Any()).AnyTimes()
+	coordinator.EXPECT().Stop().AnyTimes()
+	return coordinator
+}
+
+// newDisabledSharder returns a sharder with fingerprint sharding disabled
+func newDisabledSharder(ctrl *gomock.Controller) *mocks.MockSharderInterface {
+	sharder := mocks.NewMockSharderInterface(ctrl)
+	sharder.EXPECT().Enabled().Return(false).AnyTimes()
+	return sharder
+}
+
 func TestStartServer(t *testing.T) {
 	prometheus.DefaultRegisterer = prometheus.NewRegistry()
 
@@ -220,6 +275,87 @@ func TestApiAlertsEndpoint(t *testing.T) {
 	assert.JSONEq(t, `{"status": "alert received"}`, w.Body.String())
 }
 
+func TestApiWebhooksEndpoint(t *testing.T) {
+	routerManager := setupTestRouter(t)
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/webhooks/ci-pipeline", bytes.NewBufferString(`{"title": "Build failed"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "webhook received", "name": "ci-pipeline"}`, w.Body.String())
+}
+
+func TestApiGrafanaEndpoint(t *testing.T) {
+	routerManager := setupTestRouter(t)
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/grafana", bytes.NewBufferString(`{"alerts": []}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "grafana alert received"}`, w.Body.String())
+}
+
+func TestApiFalcoEndpoint(t *testing.T) {
+	routerManager := setupTestRouter(t)
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/falco", bytes.NewBufferString(`{"rule": "Terminal shell in container"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "falco event received"}`, w.Body.String())
+}
+
+func TestApiIssuesEndpoints(t *testing.T) {
+	routerManager := setupTestRouter(t)
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/issues", bytes.NewBufferString(`{"title": "Backup failed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"result": "created"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPatch, "/api/v1/issues/abc123", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"result": "resolved", "fingerprint": "abc123"}`, w.Body.String())
+}
+
+func TestApiSchemaEndpoints(t *testing.T) {
+	routerManager := setupTestRouter(t)
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/schema", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"schemas": {"teams": "/api/schema/teams"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/schema/teams", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"title": "teams"}`, w.Body.String())
+}
+
 func TestMetricsEndpoint(t *testing.T) {
 	routerManager := setupTestRouter(t)
 	router := routerManager.SetupRouter()
@@ -273,7 +409,10 @@ func TestMetricsEndpoint_Uninitialized(t *testing.T) {
 		AppVersion: "1.0.0",
 	}
 
-	routerManager := NewRouterManager(cfg, mockLogger, mockTracer, mockMetrics, mockHealth, mockAlerts)
+	mockAuth := mocks.NewMockAuthenticatorInterface(ctrl)
+	mockAuth.EXPECT().Middleware().Return(func(c *gin.Context) { c.Next() }).AnyTimes()
+
+	routerManager := NewRouterManager(cfg, mockLogger, mockTracer, mockMetrics, mockHealth, mockAlerts, mocks.NewMockIngestHandlerInterface(ctrl), mockAuth, newPassThroughCoordinator(ctrl), newDisabledSharder(ctrl), mocks.NewMockSchemaHandlerInterface(ctrl))
 	router := routerManager.SetupRouter()
 
 	w := httptest.NewRecorder()
@@ -283,3 +422,201 @@ func TestMetricsEndpoint_Uninitialized(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code, "Expected 500 Internal Server Error when Prometheus is not initialized")
 	assert.Contains(t, w.Body.String(), "Prometheus collector not initialized", "Expected error message in response body")
 }
+
+func TestApiRoutesRequireAuthentication(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+
+	routerManager := setupTestRouterWithOptions(t, config.ServerConfig{}, func(c *gin.Context) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	})
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/alerts", bytes.NewBufferString(`{}`))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/healthz", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/schema/teams", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestApiBodyLimit(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+
+	routerManager := setupTestRouterWithOptions(t, config.ServerConfig{MaxBodyBytes: 16}, func(c *gin.Context) { c.Next() })
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/alerts", bytes.NewBufferString(`{"alerts":["too large"]}`))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api/alerts", bytes.NewBufferString(`{}`))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestBodyLimitMiddleware_StreamedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(bodyLimitMiddleware(4))
+	r.POST("/", func(c *gin.Context) {
+		if _, err := c.GetRawData(); err != nil {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString("0123456789"))
+	req.ContentLength = -1
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestInternalRouter(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+
+	routerManager := setupTestRouterWithOptions(t, config.ServerConfig{Port: 8080, InternalPort: 9090}, func(c *gin.Context) { c.Next() })
+	router := routerManager.SetupRouter()
+	internal := routerManager.SetupInternalRouter()
+
+	for _, path := range []string{"/healthz", "/readyz", "/livez", "/metrics"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code, "%s must not be served by the API listener", path)
+	}
+
+	for _, path := range []string{"/healthz", "/readyz", "/livez"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		internal.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, "%s must be served by the internal listener", path)
+	}
+}
+
+func TestShutdown_DrainsReadiness(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+
+	routerManager := setupTestRouterWithOptions(t, config.ServerConfig{ShutdownTimeout: time.Second}, func(c *gin.Context) { c.Next() })
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+	routerManager.shutdown([]*http.Server{srv.Config})
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/livez", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReadyz_ReportsLeadership(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	routerManager := setupTestRouter(t)
+	coordinator := mocks.NewMockCoordinatorInterface(ctrl)
+	coordinator.EXPECT().Enabled().Return(true).AnyTimes()
+	coordinator.EXPECT().Middleware().Return(func(c *gin.Context) { c.Next() }).AnyTimes()
+	coordinator.EXPECT().SetHandler(gomock.Any()).Times(1)
+	coordinator.EXPECT().Status().Return(ha_interfaces.LeadershipStatus{
+		Enabled:        true,
+		Leader:         false,
+		Identity:       "cano-collector-1",
+		LeaderIdentity: "cano-collector-0",
+	}).Times(1)
+	routerManager.ha = coordinator
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "followers stay ready")
+	var body struct {
+		HA ha_interfaces.LeadershipStatus `json:"ha"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.False(t, body.HA.Leader)
+	assert.Equal(t, "cano-collector-0", body.HA.LeaderIdentity)
+}
+
+func TestRouter_ShardingEndpointNotPublic(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	routerManager := setupTestRouter(t)
+	sharder := mocks.NewMockSharderInterface(ctrl)
+	sharder.EXPECT().Enabled().Return(true).AnyTimes()
+	sharder.EXPECT().HandleForwardedIssues(gomock.Any()).Times(0)
+	routerManager.sharder = sharder
+	// without an internal port the internal routes are served by the public router
+	routerManager.cfg.Server.InternalPort = 0
+	router := routerManager.SetupRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, shard.ForwardedIssuesPath, strings.NewReader(`{"issues":[]}`))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestInternalRouter_ShardingEndpoint(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	routerManager := setupTestRouter(t)
+	sharder := mocks.NewMockSharderInterface(ctrl)
+	sharder.EXPECT().Enabled().Return(true).AnyTimes()
+	sharder.EXPECT().HandleForwardedIssues(gomock.Any()).Do(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "issues processed"})
+	}).Times(1)
+	sharder.EXPECT().Status().Return(shard_interfaces.ShardStatus{
+		Enabled: true,
+		Self:    "10.0.0.1",
+		Peers:   []string{"10.0.0.1", "10.0.0.2"},
+	}).Times(1)
+	routerManager.sharder = sharder
+	router := routerManager.SetupInternalRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, shard.ForwardedIssuesPath, strings.NewReader(`{"issues":[]}`))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Shard shard_interfaces.ShardStatus `json:"shard"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, body.Shard.Peers)
+}
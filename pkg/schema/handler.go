@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IndexPath is the path of the list of schemas; each schema is served below it by name
+const IndexPath = "/api/schema"
+
+// Handler serves a fixed set of schemas by name
+type Handler struct {
+	schemas map[string]*Schema
+}
+
+// NewHandler creates a handler for schemas, keyed by the name used in the URL
+func NewHandler(schemas map[string]*Schema) *Handler {
+	return &Handler{schemas: schemas}
+}
+
+// HandleIndex lists the URLs of the schemas
+func (h *Handler) HandleIndex(c *gin.Context) {
+	names := make([]string, 0, len(h.schemas))
+	for name := range h.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	urls := make(map[string]string, len(names))
+	for _, name := range names {
+		urls[name] = IndexPath + "/" + name
+	}
+	c.JSON(http.StatusOK, gin.H{"schemas": urls})
+}
+
+// HandleSchema serves the schema named by the name path parameter
+func (h *Handler) HandleSchema(c *gin.Context) {
+	name := c.Param("name")
+	s, ok := h.schemas[name]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown schema: " + name})
+		return
+	}
+	c.Header("Content-Type", "application/schema+json")
+	c.JSON(http.StatusOK, s)
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestHandler() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewHandler(map[string]*Schema{
+		"teams":     {Title: "teams"},
+		"workflows": {Title: "workflows"},
+	})
+
+	r := gin.New()
+	r.GET(IndexPath, handler.HandleIndex)
+	r.GET(IndexPath+"/:name", handler.HandleSchema)
+	return r
+}
+
+func TestHandler_HandleIndex(t *testing.T) {
+	r := setupTestHandler()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/schema", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"schemas": {"teams": "/api/schema/teams", "workflows": "/api/schema/workflows"}}`, w.Body.String())
+}
+
+func TestHandler_HandleSchema(t *testing.T) {
+	r := setupTestHandler()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/schema/teams", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/schema+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"title": "teams"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/schema/unknown", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error": "unknown schema: unknown"}`, w.Body.String())
+}
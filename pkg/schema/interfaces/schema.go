@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// SchemaHandlerInterface serves the JSON Schemas of the configuration files.
+//
+//go:generate mockgen -source=schema.go -destination=../../../mocks/schema_handler_mock.go -package=mocks
+type SchemaHandlerInterface interface {
+	HandleIndex(c *gin.Context)
+	HandleSchema(c *gin.Context)
+}
@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Const       interface{}        `json:"const,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// AdditionalProperties is the schema of the values of a map
+	AdditionalProperties *Schema   `json:"additionalProperties,omitempty"`
+	Items                *Schema   `json:"items,omitempty"`
+	AnyOf                []*Schema `json:"anyOf,omitempty"`
+	Not                  *Schema   `json:"not,omitempty"`
+}
+
+// String returns a schema for strings
+func String(description string) *Schema {
+	return &Schema{Type: "string", Description: description}
+}
+
+// Boolean returns a schema for booleans
+func Boolean(description string) *Schema {
+	return &Schema{Type: "boolean", Description: description}
+}
+
+// StringMap returns a schema for objects with string values
+func StringMap(description string) *Schema {
+	return &Schema{Type: "object", Description: description, AdditionalProperties: &Schema{Type: "string"}}
+}
+
+// StringArray returns a schema for arrays of strings
+func StringArray(description string) *Schema {
+	return &Schema{Type: "array", Description: description, Items: &Schema{Type: "string"}}
+}
+
+// Object returns a schema for objects with the given properties
+func Object(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// Reflector generates schemas from Go types using the yaml field names. Fields are described by
+// the jsonschema_description tag and constrained by the jsonschema tag, a comma separated list of
+// "required", "enum=a|b" and "-" to leave a field out.
+type Reflector struct {
+	// Overrides replaces the generated schema of a type, for types decoded by custom code
+	Overrides map[reflect.Type]*Schema
+}
+
+// Reflect returns the schema of the type of v
+func Reflect(v interface{}) *Schema {
+	return (&Reflector{}).Reflect(v)
+}
+
+// Reflect returns the schema of the type of v
+func (r *Reflector) Reflect(v interface{}) *Schema {
+	return r.reflectType(reflect.TypeOf(v))
+}
+
+func (r *Reflector) reflectType(t reflect.Type) *Schema {
+	if override, ok := r.Overrides[t]; ok {
+		return override
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.reflectType(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.reflectType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.reflectType(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		r.addFields(s, t)
+		return s
+	default:
+		// interface{} values may hold anything
+		return &Schema{}
+	}
+}
+
+func (r *Reflector) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// the fields of embedded structs are promoted even when the struct type is unexported
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		options := strings.Split(field.Tag.Get("jsonschema"), ",")
+		if options[0] == "-" {
+			continue
+		}
+		name, inline := yamlName(field)
+		if name == "-" {
+			continue
+		}
+		if inline && field.Type.Kind() == reflect.Struct {
+			r.addFields(s, field.Type)
+			continue
+		}
+
+		property := r.reflectType(field.Type)
+		if description := field.Tag.Get("jsonschema_description"); description != "" {
+			property = withDescription(property, description)
+		}
+		for _, option := range options {
+			switch {
+			case option == "required":
+				s.Required = append(s.Required, name)
+			case strings.HasPrefix(option, "enum="):
+				for _, value := range strings.Split(strings.TrimPrefix(option, "enum="), "|") {
+					property.Enum = append(property.Enum, value)
+				}
+			}
+		}
+		s.Properties[name] = property
+	}
+}
+
+// withDescription returns a copy of s with the description, so overrides are not modified
+func withDescription(s *Schema, description string) *Schema {
+	described := *s
+	described.Description = description
+	return &described
+}
+
+// yamlName returns the YAML key of field and whether it is inlined
+func yamlName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("yaml")
+	name, flags, _ := strings.Cut(tag, ",")
+	inline := strings.Contains(flags, "inline")
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, inline
+}
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBase struct {
+	Internal string `yaml:"internal"`
+}
+
+type testChild struct {
+	Key string `yaml:"key" jsonschema:"required"`
+}
+
+type testOverridden struct {
+	Raw map[string]interface{} `yaml:",inline"`
+}
+
+type testConfig struct {
+	testBase `yaml:",inline" jsonschema:"-"`
+
+	Name     string            `yaml:"name" jsonschema:"required" jsonschema_description:"Name of the item"`
+	Status   string            `yaml:"status,omitempty" jsonschema:"enum=firing|resolved"`
+	Count    int               `yaml:"count"`
+	Ratio    float64           `yaml:"ratio"`
+	Enabled  *bool             `yaml:"enabled,omitempty"`
+	Labels   map[string]string `yaml:"labels"`
+	Children []testChild       `yaml:"children"`
+	Any      interface{}       `yaml:"any"`
+	Custom   testOverridden    `yaml:"custom"`
+	Skipped  string            `yaml:"-"`
+	hidden   string
+}
+
+func TestReflect(t *testing.T) {
+	custom := Object(map[string]*Schema{"type": String("Custom type")}, "type")
+	reflector := &Reflector{Overrides: map[reflect.Type]*Schema{reflect.TypeOf(testOverridden{}): custom}}
+
+	s := reflector.Reflect(testConfig{})
+
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"name"}, s.Required)
+	assert.ElementsMatch(t,
+		[]string{"name", "status", "count", "ratio", "enabled", "labels", "children", "any", "custom"},
+		keys(s.Properties))
+
+	assert.Equal(t, &Schema{Type: "string", Description: "Name of the item"}, s.Properties["name"])
+	assert.Equal(t, []interface{}{"firing", "resolved"}, s.Properties["status"].Enum)
+	assert.Equal(t, "integer", s.Properties["count"].Type)
+	assert.Equal(t, "number", s.Properties["ratio"].Type)
+	assert.Equal(t, "boolean", s.Properties["enabled"].Type)
+	assert.Equal(t, StringMap(""), s.Properties["labels"])
+	assert.Equal(t, &Schema{}, s.Properties["any"])
+	assert.Same(t, custom, s.Properties["custom"])
+
+	children := s.Properties["children"]
+	assert.Equal(t, "array", children.Type)
+	assert.Equal(t, []string{"key"}, children.Items.Required)
+}
+
+func TestReflect_InlineStruct(t *testing.T) {
+	type config struct {
+		testChild `yaml:",inline"`
+		Name      string `yaml:"name"`
+	}
+
+	s := Reflect(config{})
+
+	assert.ElementsMatch(t, []string{"key", "name"}, keys(s.Properties))
+	assert.Equal(t, []string{"key"}, s.Required)
+}
+
+func TestSchema_MarshalJSON(t *testing.T) {
+	s := Object(map[string]*Schema{"kind": {Const: "pod"}}, "kind")
+	s.Schema = Draft
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {"kind": {"const": "pod"}},
+		"required": ["kind"]
+	}`, string(data))
+}
+
+func keys(properties map[string]*Schema) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	return names
+}
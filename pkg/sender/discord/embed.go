@@ -0,0 +1,57 @@
+package discord
+
+// Message is the payload posted to a Discord webhook
+type Message struct {
+	Username  string  `json:"username,omitempty"`
+	AvatarURL string  `json:"avatar_url,omitempty"`
+	Embeds    []Embed `json:"embeds,omitempty"`
+	// Attachments describes the uploaded files of a multipart message by their index
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Embed is a rich message block shown in the channel
+type Embed struct {
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Color       int          `json:"color,omitempty"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+	Image       *EmbedImage  `json:"image,omitempty"`
+}
+
+// EmbedField is a name-value pair of an embed. Inline fields are shown side by side.
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// EmbedFooter is the small text at the bottom of an embed
+type EmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// EmbedImage is an image shown in an embed
+type EmbedImage struct {
+	URL string `json:"url"`
+}
+
+// Attachment names the file uploaded as files[ID] of a multipart message
+type Attachment struct {
+	ID       int    `json:"id"`
+	Filename string `json:"filename"`
+}
+
+// size returns the characters of the embed that count towards the limit of a message
+func (e Embed) size() int {
+	size := len([]rune(e.Title)) + len([]rune(e.Description))
+	for _, field := range e.Fields {
+		size += len([]rune(field.Name)) + len([]rune(field.Value))
+	}
+	if e.Footer != nil {
+		size += len([]rune(e.Footer.Text))
+	}
+	return size
+}
@@ -0,0 +1,402 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+// Limits of Discord messages, longer values are rejected
+const (
+	maxEmbeds           = 10
+	maxMessageChars     = 6000
+	maxFields           = 25
+	maxTitleChars       = 256
+	maxDescriptionChars = 4096
+	maxFieldNameChars   = 256
+	maxFieldValueChars  = 1024
+	maxFiles            = 10
+	// maxUploadSize stays below the upload limit of servers without boosts
+	maxUploadSize = 8 << 20
+
+	// maxTableRows limits the rows of every table
+	maxTableRows = 20
+)
+
+// Colours of the embeds by severity, matching the attachments of Slack messages
+const (
+	colorHigh     = 0xEF311F
+	colorLow      = 0xFFCC00
+	colorInfo     = 0x00B302
+	colorDebug    = 0x36A64F
+	colorResolved = 0x00B302
+)
+
+type SenderDiscord struct {
+	webhookURL  string
+	username    string
+	avatarURL   string
+	logger      logger_interfaces.LoggerInterface
+	client      util.HTTPClient
+	retryPolicy sender.RetryPolicy
+}
+
+func NewSenderDiscord(webhookURL, username, avatarURL string, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderDiscord {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	return &SenderDiscord{
+		webhookURL:  webhookURL,
+		username:    username,
+		avatarURL:   avatarURL,
+		logger:      logger,
+		client:      client,
+		retryPolicy: sender.DefaultRetryPolicy,
+	}
+}
+
+// Send posts the issue as embeds to the webhook, split into as many messages as the limits of
+// Discord require. Files of the enrichments are uploaded with the last message.
+func (s *SenderDiscord) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	s.logger.Info("Sending Discord notification",
+		zap.String("status", issue.Status.String()),
+		zap.String("fingerprint", issue.Fingerprint),
+	)
+
+	messages := s.BuildMessages(issue)
+	uploads := groupFiles(sender.FileBlocks(issue))
+	for i, message := range messages {
+		var files []*issuepkg.FileBlock
+		if i == len(messages)-1 && len(uploads) > 0 {
+			files, uploads = uploads[0], uploads[1:]
+		}
+		if err := s.post(ctx, message, files); err != nil {
+			return fmt.Errorf("failed to send Discord message: %w", err)
+		}
+	}
+	for _, files := range uploads {
+		message := Message{Username: s.username, AvatarURL: s.avatarURL}
+		if err := s.post(ctx, message, files); err != nil {
+			return fmt.Errorf("failed to upload files to Discord: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderDiscord) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderDiscord) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+// BuildMessages renders the issue as a header embed followed by an embed for every enrichment,
+// packed into messages of at most ten embeds and 6000 characters
+func (s *SenderDiscord) BuildMessages(issue *issuepkg.Issue) []Message {
+	color := severityColor(issue)
+	embeds := []Embed{buildHeader(issue, color)}
+	for _, enrichment := range issue.Enrichments {
+		if embed, ok := buildEnrichment(enrichment, color); ok {
+			embeds = append(embeds, embed)
+		}
+	}
+
+	var messages []Message
+	current := Message{Username: s.username, AvatarURL: s.avatarURL}
+	size := 0
+	for _, embed := range embeds {
+		fitEmbed(&embed)
+		if len(current.Embeds) == maxEmbeds || (len(current.Embeds) > 0 && size+embed.size() > maxMessageChars) {
+			messages = append(messages, current)
+			current = Message{Username: s.username, AvatarURL: s.avatarURL}
+			size = 0
+		}
+		current.Embeds = append(current.Embeds, embed)
+		size += embed.size()
+	}
+	return append(messages, current)
+}
+
+// post sends a message as JSON, or as multipart form with the files uploaded next to it
+func (s *SenderDiscord) post(ctx context.Context, message Message, files []*issuepkg.FileBlock) error {
+	if len(files) == 0 {
+		_, err := s.retryPolicy.Do(ctx, func() ([]byte, error) {
+			return sender.DoJSON(ctx, s.client, http.MethodPost, s.webhookURL, nil, message)
+		})
+		return err
+	}
+
+	for i, file := range files {
+		message.Attachments = append(message.Attachments, Attachment{ID: i, Filename: file.Filename})
+	}
+	body, contentType, err := multipartBody(message, files)
+	if err != nil {
+		return err
+	}
+	header := http.Header{"Content-Type": {contentType}}
+	_, err = s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		return sender.DoRequest(ctx, s.client, http.MethodPost, s.webhookURL, header, body)
+	})
+	return err
+}
+
+// multipartBody returns the message as payload_json part followed by the files as files[n]
+// parts, and the content type of the body
+func multipartBody(message Message, files []*issuepkg.FileBlock) ([]byte, string, error) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("payload_json", string(payload)); err != nil {
+		return nil, "", err
+	}
+	for i, file := range files {
+		part, err := writer.CreateFormFile(fmt.Sprintf("files[%d]", i), file.Filename)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(sender.FileTail(file, maxUploadSize)); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+// buildHeader returns the embed with the title, the description, the subject and the links of
+// the issue
+func buildHeader(issue *issuepkg.Issue, color int) Embed {
+	embed := Embed{
+		Title:       sender.FormatTitle(issue),
+		Description: issue.Description,
+		Color:       color,
+		Footer:      &EmbedFooter{Text: sender.FormatStatus(issue)},
+	}
+	if !issue.StartsAt.IsZero() {
+		embed.Timestamp = issue.StartsAt.UTC().Format(time.RFC3339)
+	}
+
+	add := func(name, value string) {
+		if value != "" {
+			embed.Fields = append(embed.Fields, EmbedField{Name: name, Value: value, Inline: true})
+		}
+	}
+	add("Cluster", issue.ClusterName)
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		add("Subject", fmt.Sprintf("%s %s", strings.ToLower(subject.SubjectType.String()), subject.Name))
+		add("Namespace", subject.Namespace)
+		add("Node", subject.Node)
+		add("Container", subject.Container)
+	}
+	if issue.EndsAt != nil && issue.IsResolved() {
+		add("Ended", issue.EndsAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+	if links := formatLinks(issue.Links, maxFieldValueChars); links != "" {
+		embed.Fields = append(embed.Fields, EmbedField{Name: "Links", Value: links})
+	}
+	return embed
+}
+
+// severityColor colours the embeds by severity, or green once the issue is resolved
+func severityColor(issue *issuepkg.Issue) int {
+	if issue.IsResolved() {
+		return colorResolved
+	}
+	switch issue.Severity {
+	case issuepkg.SeverityHigh:
+		return colorHigh
+	case issuepkg.SeverityLow:
+		return colorLow
+	case issuepkg.SeverityInfo:
+		return colorInfo
+	case issuepkg.SeverityDebug:
+		return colorDebug
+	default:
+		return colorInfo
+	}
+}
+
+// buildEnrichment returns the embed of an enrichment, with its blocks rendered as markdown
+// into the description. The first image is shown as image of the embed.
+func buildEnrichment(enrichment issuepkg.Enrichment, color int) (Embed, bool) {
+	embed := Embed{Title: enrichment.Title, Color: color}
+	var parts []string
+	for _, block := range enrichment.Blocks {
+		if image, ok := block.(*issuepkg.ImageBlock); ok && embed.Image == nil && image.URL != "" {
+			embed.Image = &EmbedImage{URL: image.URL}
+			continue
+		}
+		if text := renderBlock(block); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	if len(enrichment.Blocks) == 0 && enrichment.Content != "" {
+		parts = append(parts, enrichment.Content)
+	}
+	if len(parts) == 0 && embed.Image == nil {
+		return Embed{}, false
+	}
+	embed.Description = strings.Join(parts, "\n\n")
+	return embed, true
+}
+
+// renderBlock renders a block as Discord markdown
+func renderBlock(block issuepkg.BaseBlock) string {
+	switch b := block.(type) {
+	case *issuepkg.MarkdownBlock:
+		return b.Text
+	case *issuepkg.HeaderBlock:
+		return "**" + b.Text + "**"
+	case *issuepkg.TableBlock:
+		return renderTable(b)
+	case *issuepkg.ListBlock:
+		return b.ToMarkdown()
+	case *issuepkg.JsonBlock:
+		return codeBlock("json", b.ToJson())
+	case *issuepkg.LinksBlock:
+		return formatLinks(b.Links, maxDescriptionChars)
+	case *issuepkg.FileBlock:
+		return fmt.Sprintf("📎 %s (%.1f KB)", b.Filename, b.GetSizeKB())
+	case *issuepkg.ImageBlock:
+		return formatLinks([]issuepkg.Link{{Text: b.AltText, URL: b.URL}}, maxDescriptionChars)
+	case *issuepkg.DividerBlock:
+		return "───"
+	default:
+		return ""
+	}
+}
+
+// renderTable renders a table as aligned text in a code block, since embeds have no tables.
+// Rows beyond maxTableRows are left out with a note.
+func renderTable(table *issuepkg.TableBlock) string {
+	columns := table.GetColumnCount()
+	if columns == 0 {
+		return ""
+	}
+	rows := table.Rows
+	if len(rows) > maxTableRows {
+		rows = rows[:maxTableRows]
+	}
+
+	lines := rows
+	if len(table.Headers) > 0 {
+		lines = append([][]string{table.Headers}, rows...)
+	}
+	widths := make([]int, columns)
+	for _, line := range lines {
+		for i := range widths {
+			widths[i] = max(widths[i], len([]rune(sender.Cell(line, i))))
+		}
+	}
+
+	var text strings.Builder
+	for _, line := range lines {
+		cells := make([]string, columns)
+		for i := range cells {
+			value := sender.Cell(line, i)
+			cells[i] = value + strings.Repeat(" ", widths[i]-len([]rune(value)))
+		}
+		text.WriteString(strings.TrimRight(strings.Join(cells, "  "), " "))
+		text.WriteString("\n")
+	}
+
+	var parts []string
+	if table.TableName != "" {
+		parts = append(parts, "**"+table.TableName+"**")
+	}
+	parts = append(parts, codeBlock("", strings.TrimSuffix(text.String(), "\n")))
+	if len(rows) < len(table.Rows) {
+		parts = append(parts, fmt.Sprintf("_Showing %d of %d rows_", len(rows), len(table.Rows)))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// codeBlock wraps text in a code block, breaking up backtick fences inside it
+func codeBlock(language, text string) string {
+	return "```" + language + "\n" + strings.ReplaceAll(text, "```", "`\u200b``") + "\n```"
+}
+
+// formatLinks returns markdown links, one per line, leaving out the links that do not fit into
+// maxChars
+func formatLinks(links []issuepkg.Link, maxChars int) string {
+	var lines []string
+	size := 0
+	for _, link := range links {
+		if link.URL == "" {
+			continue
+		}
+		text := link.Text
+		if text == "" {
+			text = link.URL
+		}
+		line := fmt.Sprintf("[%s](%s)", strings.NewReplacer("[", "(", "]", ")").Replace(text), link.URL)
+		if size+len([]rune(line))+1 > maxChars {
+			break
+		}
+		lines = append(lines, line)
+		size += len([]rune(line)) + 1
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fitEmbed truncates the parts of an embed to their limits and the whole embed to the limit of
+// a message, shortening the description first and dropping fields from the end after it
+func fitEmbed(embed *Embed) {
+	embed.Title = sender.Truncate(embed.Title, maxTitleChars)
+	embed.Description = sender.TruncateMarkdown(embed.Description, maxDescriptionChars)
+	if len(embed.Fields) > maxFields {
+		embed.Fields = embed.Fields[:maxFields]
+	}
+	for i := range embed.Fields {
+		embed.Fields[i].Name = sender.Truncate(embed.Fields[i].Name, maxFieldNameChars)
+		embed.Fields[i].Value = sender.TruncateMarkdown(embed.Fields[i].Value, maxFieldValueChars)
+	}
+
+	if excess := embed.size() - maxMessageChars; excess > 0 {
+		description := []rune(embed.Description)
+		embed.Description = sender.TruncateMarkdown(embed.Description, max(len(description)-excess, 0))
+	}
+	for len(embed.Fields) > 0 && embed.size() > maxMessageChars {
+		embed.Fields = embed.Fields[:len(embed.Fields)-1]
+	}
+}
+
+// groupFiles packs the files into uploads of at most ten files and maxUploadSize bytes
+func groupFiles(files []*issuepkg.FileBlock) [][]*issuepkg.FileBlock {
+	var groups [][]*issuepkg.FileBlock
+	var current []*issuepkg.FileBlock
+	size := 0
+	for _, file := range files {
+		fileSize := len(sender.FileTail(file, maxUploadSize))
+		if len(current) == maxFiles || (len(current) > 0 && size+fileSize > maxUploadSize) {
+			groups = append(groups, current)
+			current, size = nil, 0
+		}
+		current = append(current, file)
+		size += fileSize
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+	return groups
+}
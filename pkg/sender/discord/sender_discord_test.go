@@ -0,0 +1,184 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testWebhookURL = "https://discord.com/api/webhooks/1/token"
+
+func setupSender(t *testing.T) (*SenderDiscord, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	s := NewSenderDiscord(testWebhookURL, "Cano", "", logger, client)
+	s.retryPolicy = sendertest.RetryPolicy
+	return s, client
+}
+
+func TestSenderDiscord_BuildMessages_Header(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddLink(issuepkg.Link{Text: "Runbook", URL: "https://runbooks/crashloop"})
+
+	messages := s.BuildMessages(issue)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Cano", messages[0].Username)
+	require.Len(t, messages[0].Embeds, 1)
+
+	header := messages[0].Embeds[0]
+	assert.Equal(t, "🔴 Pod is crash looping", header.Title)
+	assert.Equal(t, "Pod payments/api-0 is restarting", header.Description)
+	assert.Equal(t, 0xEF311F, header.Color)
+	assert.Equal(t, "Firing · High severity · PROMETHEUS", header.Footer.Text)
+	assert.Contains(t, header.Fields, EmbedField{Name: "Cluster", Value: "prod", Inline: true})
+	assert.Contains(t, header.Fields, EmbedField{Name: "Subject", Value: "pod api-0", Inline: true})
+	assert.Contains(t, header.Fields, EmbedField{Name: "Links", Value: "[Runbook](https://runbooks/crashloop)"})
+
+	issue.Status = issuepkg.StatusResolved
+	header = s.BuildMessages(issue)[0].Embeds[0]
+	assert.Equal(t, "✅ Resolved: Pod is crash looping", header.Title)
+	assert.Equal(t, 0x00B302, header.Color)
+}
+
+func TestSenderDiscord_BuildMessages_Enrichments(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"container", "restarts"}, [][]string{{"api", "5"}, {"sidecar", "0"}}, "", issuepkg.TableBlockFormatVertical),
+		issuepkg.NewFileBlock("api.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Containers")
+
+	messages := s.BuildMessages(issue)
+	require.Len(t, messages, 1)
+	require.Len(t, messages[0].Embeds, 2)
+
+	embed := messages[0].Embeds[1]
+	assert.Equal(t, "Containers", embed.Title)
+	assert.Equal(t, 0xEF311F, embed.Color)
+	assert.Equal(t, "```\ncontainer  restarts\napi        5\nsidecar    0\n```\n\n📎 api.log (0.0 KB)", embed.Description)
+}
+
+func TestSenderDiscord_BuildMessages_Limits(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.Title = strings.Repeat("t", 300)
+	for i := 0; i < 12; i++ {
+		issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+			issuepkg.NewMarkdownBlock(strings.Repeat("x", 5000)),
+		}, issuepkg.EnrichmentTypeLogs, fmt.Sprintf("Enrichment %d", i))
+	}
+
+	messages := s.BuildMessages(issue)
+	embeds := 0
+	for _, message := range messages {
+		assert.LessOrEqual(t, len(message.Embeds), maxEmbeds)
+		size := 0
+		for _, embed := range message.Embeds {
+			assert.LessOrEqual(t, len([]rune(embed.Title)), maxTitleChars)
+			assert.LessOrEqual(t, len([]rune(embed.Description)), maxDescriptionChars)
+			size += embed.size()
+		}
+		assert.LessOrEqual(t, size, maxMessageChars)
+		embeds += len(message.Embeds)
+	}
+	assert.Equal(t, 13, embeds)
+	assert.Greater(t, len(messages), 1)
+}
+
+func TestFitEmbed(t *testing.T) {
+	embed := Embed{Description: strings.Repeat("d", 3000)}
+	for i := 0; i < 30; i++ {
+		embed.Fields = append(embed.Fields, EmbedField{Name: "name", Value: strings.Repeat("v", 200)})
+	}
+
+	fitEmbed(&embed)
+	assert.Len(t, embed.Fields, maxFields)
+	assert.LessOrEqual(t, embed.size(), maxMessageChars)
+}
+
+func TestSenderDiscord_Send(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, testWebhookURL, req.URL.String())
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		var message Message
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&message))
+		require.Len(t, message.Embeds, 1)
+		return sendertest.Response(http.StatusNoContent, ""), nil
+	}).Times(1)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderDiscord_Send_UploadsFiles(t *testing.T) {
+	s, mockClient := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 1\nline 2"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		require.NoError(t, req.ParseMultipartForm(1<<20))
+		var message Message
+		require.NoError(t, json.Unmarshal([]byte(req.FormValue("payload_json")), &message))
+		assert.Len(t, message.Embeds, 2)
+		assert.Equal(t, []Attachment{{ID: 0, Filename: "api.log"}}, message.Attachments)
+
+		file, header, err := req.FormFile("files[0]")
+		require.NoError(t, err)
+		defer file.Close()
+		assert.Equal(t, "api.log", header.Filename)
+		contents, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "line 1\nline 2", string(contents))
+		return sendertest.Response(http.StatusOK, ""), nil
+	}).Times(1)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderDiscord_Send_RetriesRateLimit(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	sendertest.RetriesRateLimit(t, mockClient, sendertest.Response(http.StatusNoContent, ""), func() error {
+		return s.Send(context.Background(), sendertest.NewIssue())
+	})
+}
+
+func TestSenderDiscord_Send_Error(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusNotFound, ""), nil).Times(1)
+
+	err := s.Send(context.Background(), sendertest.NewIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send Discord message: unexpected status 404")
+	assert.NotContains(t, err.Error(), "token")
+}
+
+func TestGroupFiles(t *testing.T) {
+	var files []*issuepkg.FileBlock
+	for i := 0; i < 12; i++ {
+		files = append(files, issuepkg.NewFileBlock(fmt.Sprintf("%d.log", i), []byte("x"), "text/plain"))
+	}
+
+	groups := groupFiles(files)
+	require.Len(t, groups, 2)
+	assert.Len(t, groups[0], maxFiles)
+	assert.Len(t, groups[1], 2)
+}
@@ -0,0 +1,84 @@
+package googlechat
+
+// Message is the payload posted to a Google Chat webhook
+type Message struct {
+	CardsV2 []CardWithID `json:"cardsV2"`
+	// Thread groups the messages with the same key into one thread of the space
+	Thread *Thread `json:"thread,omitempty"`
+}
+
+// Thread identifies a thread by a key chosen by the sender
+type Thread struct {
+	ThreadKey string `json:"threadKey"`
+}
+
+// CardWithID is a card of a message
+type CardWithID struct {
+	CardID string `json:"cardId"`
+	Card   Card   `json:"card"`
+}
+
+// Card is a header followed by sections of widgets
+type Card struct {
+	Header   *CardHeader `json:"header,omitempty"`
+	Sections []Section   `json:"sections"`
+}
+
+// CardHeader is shown at the top of the card. Title and subtitle are plain text.
+type CardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+// Section is a group of widgets, optionally with a header. Collapsible sections show their
+// first UncollapsibleWidgetsCount widgets until they are expanded.
+type Section struct {
+	Header                    string   `json:"header,omitempty"`
+	Collapsible               bool     `json:"collapsible,omitempty"`
+	UncollapsibleWidgetsCount int      `json:"uncollapsibleWidgetsCount,omitempty"`
+	Widgets                   []Widget `json:"widgets"`
+}
+
+// Widget is an element of a section; exactly one of its fields is set
+type Widget struct {
+	TextParagraph *TextParagraph `json:"textParagraph,omitempty"`
+	DecoratedText *DecoratedText `json:"decoratedText,omitempty"`
+	ButtonList    *ButtonList    `json:"buttonList,omitempty"`
+	Image         *Image         `json:"image,omitempty"`
+	Divider       *struct{}      `json:"divider,omitempty"`
+}
+
+// TextParagraph is text formatted with the HTML subset of cards
+type TextParagraph struct {
+	Text string `json:"text"`
+}
+
+// DecoratedText is text with a label above it
+type DecoratedText struct {
+	TopLabel string `json:"topLabel,omitempty"`
+	Text     string `json:"text"`
+	WrapText bool   `json:"wrapText,omitempty"`
+}
+
+type ButtonList struct {
+	Buttons []Button `json:"buttons"`
+}
+
+// Button opens a link when it is clicked
+type Button struct {
+	Text    string  `json:"text"`
+	OnClick OnClick `json:"onClick"`
+}
+
+type OnClick struct {
+	OpenLink OpenLink `json:"openLink"`
+}
+
+type OpenLink struct {
+	URL string `json:"url"`
+}
+
+type Image struct {
+	ImageURL string `json:"imageUrl"`
+	AltText  string `json:"altText,omitempty"`
+}
@@ -0,0 +1,336 @@
+package googlechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+// Limits of Google Chat messages
+const (
+	maxMessageBytes = 32000
+	maxTitleChars   = 256
+	// maxTextChars limits the text of every paragraph
+	maxTextChars = 4096
+
+	// maxTableRows limits the rows of every table
+	maxTableRows = 20
+	// maxVisibleWidgets is the number of widgets of an enrichment shown before it is expanded
+	maxVisibleWidgets = 3
+)
+
+// replyOption makes a message with a thread key reply in the thread of that key, or start it
+const replyOption = "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD"
+
+var (
+	markdownBold = regexp.MustCompile(`\*\*([^*\n]+)\*\*`)
+	markdownLink = regexp.MustCompile(`\[([^\]\n]+)\]\((https?://[^)\s]+)\)`)
+	// escapeText escapes the characters that cards would read as HTML
+	escapeText = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
+)
+
+type SenderGoogleChat struct {
+	webhookURL  string
+	logger      logger_interfaces.LoggerInterface
+	client      util.HTTPClient
+	retryPolicy sender.RetryPolicy
+}
+
+func NewSenderGoogleChat(webhookURL string, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderGoogleChat {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	return &SenderGoogleChat{
+		webhookURL:  threadedURL(webhookURL),
+		logger:      logger,
+		client:      client,
+		retryPolicy: sender.DefaultRetryPolicy,
+	}
+}
+
+// Send posts the issue as card to the webhook, in the thread of its fingerprint
+func (s *SenderGoogleChat) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	s.logger.Info("Sending Google Chat notification",
+		zap.String("status", issue.Status.String()),
+		zap.String("fingerprint", issue.Fingerprint),
+	)
+
+	message := s.BuildMessage(issue)
+	_, err := s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		return sender.DoJSON(ctx, s.client, http.MethodPost, s.webhookURL, nil, message)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send Google Chat message: %w", err)
+	}
+	return nil
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderGoogleChat) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderGoogleChat) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+// BuildMessage renders the issue as card with a section for the subject, one for every
+// enrichment and one with the links as buttons. Enrichments are left out from the end while
+// the message exceeds its limit.
+func (s *SenderGoogleChat) BuildMessage(issue *issuepkg.Issue) Message {
+	var enrichments []Section
+	for _, enrichment := range issue.Enrichments {
+		if section, ok := buildEnrichment(enrichment); ok {
+			enrichments = append(enrichments, section)
+		}
+	}
+
+	message := Message{}
+	if issue.Fingerprint != "" {
+		message.Thread = &Thread{ThreadKey: issue.Fingerprint}
+	}
+	for omitted := 0; ; omitted++ {
+		kept := enrichments[:len(enrichments)-omitted]
+		message.CardsV2 = []CardWithID{{CardID: "issue", Card: buildCard(issue, kept, omitted)}}
+		if len(kept) == 0 || messageSize(message) <= maxMessageBytes {
+			return message
+		}
+	}
+}
+
+// buildCard returns the card of the issue with the given enrichment sections and a note on the
+// omitted ones
+func buildCard(issue *issuepkg.Issue, enrichments []Section, omitted int) Card {
+	card := Card{
+		Header: &CardHeader{
+			Title:    sender.Truncate(sender.FormatTitle(issue), maxTitleChars),
+			Subtitle: sender.FormatStatus(issue),
+		},
+	}
+	if section, ok := buildSubject(issue); ok {
+		card.Sections = append(card.Sections, section)
+	}
+	card.Sections = append(card.Sections, enrichments...)
+	if omitted > 0 {
+		note := fmt.Sprintf("<i>%d more enrichments did not fit into the message</i>", omitted)
+		card.Sections = append(card.Sections, Section{Widgets: []Widget{paragraph(note)}})
+	}
+	if buttons := buildButtons(issue.Links); buttons != nil {
+		card.Sections = append(card.Sections, Section{Widgets: []Widget{*buttons}})
+	}
+	if len(card.Sections) == 0 {
+		// cards need a section
+		card.Sections = []Section{{Widgets: []Widget{paragraph(escapeText(issue.Title))}}}
+	}
+	return card
+}
+
+// buildSubject returns the section with the description of the issue and its cluster, subject
+// and end
+func buildSubject(issue *issuepkg.Issue) (Section, bool) {
+	var widgets []Widget
+	if issue.Description != "" {
+		widgets = append(widgets, paragraph(markdownToHTML(issue.Description)))
+	}
+
+	add := func(label, value string) {
+		if value != "" {
+			widgets = append(widgets, Widget{DecoratedText: &DecoratedText{TopLabel: label, Text: escapeText(value), WrapText: true}})
+		}
+	}
+	add("Cluster", issue.ClusterName)
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		add("Subject", fmt.Sprintf("%s %s", strings.ToLower(subject.SubjectType.String()), subject.Name))
+		add("Namespace", subject.Namespace)
+		add("Node", subject.Node)
+		add("Container", subject.Container)
+	}
+	if issue.EndsAt != nil && issue.IsResolved() {
+		add("Ended", issue.EndsAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+	return Section{Widgets: widgets}, len(widgets) > 0
+}
+
+// buildEnrichment returns the section of an enrichment, headed by its title or else by its
+// first header block. Sections of many widgets collapse.
+func buildEnrichment(enrichment issuepkg.Enrichment) (Section, bool) {
+	section := Section{Header: escapeText(enrichment.Title)}
+	for _, block := range enrichment.Blocks {
+		if header, ok := block.(*issuepkg.HeaderBlock); ok && section.Header == "" && len(section.Widgets) == 0 {
+			section.Header = escapeText(header.Text)
+			continue
+		}
+		section.Widgets = append(section.Widgets, renderBlock(block)...)
+	}
+	if len(enrichment.Blocks) == 0 && enrichment.Content != "" {
+		section.Widgets = append(section.Widgets, paragraph(markdownToHTML(enrichment.Content)))
+	}
+	if len(section.Widgets) == 0 {
+		return Section{}, false
+	}
+	if len(section.Widgets) > maxVisibleWidgets {
+		section.Collapsible = true
+		section.UncollapsibleWidgetsCount = maxVisibleWidgets
+	}
+	return section, true
+}
+
+// renderBlock renders a block as card widgets. Files are left out, webhooks cannot attach them.
+func renderBlock(block issuepkg.BaseBlock) []Widget {
+	switch b := block.(type) {
+	case *issuepkg.MarkdownBlock:
+		return textWidgets(markdownToHTML(b.Text))
+	case *issuepkg.HeaderBlock:
+		return textWidgets("<b>" + escapeText(b.Text) + "</b>")
+	case *issuepkg.TableBlock:
+		return renderTable(b)
+	case *issuepkg.ListBlock:
+		return textWidgets(markdownToHTML(b.ToMarkdown()))
+	case *issuepkg.JsonBlock:
+		return textWidgets(escapeText(b.ToJson()))
+	case *issuepkg.LinksBlock:
+		if buttons := buildButtons(b.Links); buttons != nil {
+			return []Widget{*buttons}
+		}
+	case *issuepkg.ImageBlock:
+		if b.URL != "" {
+			return []Widget{{Image: &Image{ImageURL: b.URL, AltText: b.AltText}}}
+		}
+	case *issuepkg.DividerBlock:
+		return []Widget{{Divider: &struct{}{}}}
+	}
+	return nil
+}
+
+// renderTable renders the rows of a two-column table as labelled values, and the rows of other
+// tables as lines of cells labelled with their headers. Rows beyond maxTableRows are left out
+// with a note.
+func renderTable(table *issuepkg.TableBlock) []Widget {
+	columns := table.GetColumnCount()
+	if columns == 0 || len(table.Rows) == 0 {
+		return nil
+	}
+	rows := table.Rows
+	if len(rows) > maxTableRows {
+		rows = rows[:maxTableRows]
+	}
+
+	var widgets []Widget
+	if table.TableName != "" {
+		widgets = append(widgets, paragraph("<b>"+escapeText(table.TableName)+"</b>"))
+	}
+	if columns == 2 {
+		for _, row := range rows {
+			widgets = append(widgets, Widget{DecoratedText: &DecoratedText{
+				TopLabel: escapeText(sender.Cell(row, 0)),
+				Text:     escapeText(sender.Cell(row, 1)),
+				WrapText: true,
+			}})
+		}
+	} else {
+		lines := make([]string, len(rows))
+		for i, row := range rows {
+			cells := make([]string, columns)
+			for j := range cells {
+				cells[j] = escapeText(sender.Cell(row, j))
+				if header := sender.Cell(table.Headers, j); header != "" {
+					cells[j] = "<b>" + escapeText(header) + "</b>: " + cells[j]
+				}
+			}
+			lines[i] = strings.Join(cells, " · ")
+		}
+		widgets = append(widgets, paragraph(strings.Join(lines, "\n")))
+	}
+	if len(rows) < len(table.Rows) {
+		widgets = append(widgets, paragraph(fmt.Sprintf("<i>Showing %d of %d rows</i>", len(rows), len(table.Rows))))
+	}
+	return widgets
+}
+
+// buildButtons returns a button opening every link, or nil without links
+func buildButtons(links []issuepkg.Link) *Widget {
+	var buttons []Button
+	for _, link := range links {
+		if link.URL == "" {
+			continue
+		}
+		text := link.Text
+		if text == "" {
+			text = link.URL
+		}
+		buttons = append(buttons, Button{Text: text, OnClick: OnClick{OpenLink: OpenLink{URL: link.URL}}})
+	}
+	if len(buttons) == 0 {
+		return nil
+	}
+	return &Widget{ButtonList: &ButtonList{Buttons: buttons}}
+}
+
+// textWidgets returns a paragraph of the text, or none for empty text
+func textWidgets(text string) []Widget {
+	if text == "" {
+		return nil
+	}
+	return []Widget{paragraph(text)}
+}
+
+func paragraph(text string) Widget {
+	return Widget{TextParagraph: &TextParagraph{Text: text}}
+}
+
+// markdownToHTML converts the bold text and links of markdown to the HTML of cards, which has
+// no code formatting, so code is kept as escaped text. Text beyond maxTextChars is cut off.
+func markdownToHTML(text string) string {
+	text = sender.Truncate(strings.TrimSpace(text), maxTextChars)
+	var b strings.Builder
+	for _, segment := range sender.SplitCodeBlocks(text) {
+		if !segment.Code {
+			b.WriteString(formattingToHTML(segment.Text))
+			continue
+		}
+		b.WriteString(escapeText(strings.Trim(sender.TrimCodeLanguage(segment.Text), "\n")))
+	}
+	return b.String()
+}
+
+func formattingToHTML(text string) string {
+	escaped := escapeText(strings.ReplaceAll(text, "`", ""))
+	escaped = markdownLink.ReplaceAllStringFunc(escaped, func(link string) string {
+		match := markdownLink.FindStringSubmatch(link)
+		return `<a href="` + strings.ReplaceAll(match[2], `"`, "%22") + `">` + match[1] + "</a>"
+	})
+	return markdownBold.ReplaceAllString(escaped, "<b>$1</b>")
+}
+
+func messageSize(message Message) int {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return 0
+	}
+	return len(payload)
+}
+
+// threadedURL adds the reply option to the webhook URL, without which Google Chat ignores the
+// thread key. URLs that do not parse are left as they are.
+func threadedURL(webhookURL string) string {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return webhookURL
+	}
+	query := u.Query()
+	query.Set("messageReplyOption", replyOption)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
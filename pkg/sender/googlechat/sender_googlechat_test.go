@@ -0,0 +1,202 @@
+package googlechat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testWebhookURL = "https://chat.googleapis.com/v1/spaces/AAA/messages?key=api-key&token=secret-token"
+
+func setupSender(t *testing.T) (*SenderGoogleChat, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	s := NewSenderGoogleChat(testWebhookURL, logger, client)
+	s.retryPolicy = sendertest.RetryPolicy
+	return s, client
+}
+
+func TestSenderGoogleChat_BuildMessage(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.Description = "Pod **payments/api-0** is restarting <again>"
+	issue.AddLink(issuepkg.Link{Text: "Runbook", URL: "https://runbooks/crashloop"})
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"label", "value"}, [][]string{{"restarts", "5"}}, "", issuepkg.TableBlockFormatVertical),
+		issuepkg.NewMarkdownBlock("Reason is `OOMKilled`, see [docs](https://k8s.io/oom)"),
+		issuepkg.NewFileBlock("api.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeContainerInfo, "Containers")
+
+	payload, err := json.Marshal(s.BuildMessage(issue))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"thread": {"threadKey": "fingerprint-1"},
+		"cardsV2": [{
+			"cardId": "issue",
+			"card": {
+				"header": {"title": "🔴 Pod is crash looping", "subtitle": "Firing · High severity · PROMETHEUS"},
+				"sections": [
+					{"widgets": [
+						{"textParagraph": {"text": "Pod <b>payments/api-0</b> is restarting &lt;again&gt;"}},
+						{"decoratedText": {"topLabel": "Cluster", "text": "prod", "wrapText": true}},
+						{"decoratedText": {"topLabel": "Subject", "text": "pod api-0", "wrapText": true}},
+						{"decoratedText": {"topLabel": "Namespace", "text": "payments", "wrapText": true}}
+					]},
+					{"header": "Containers", "widgets": [
+						{"decoratedText": {"topLabel": "restarts", "text": "5", "wrapText": true}},
+						{"textParagraph": {"text": "Reason is OOMKilled, see <a href=\"https://k8s.io/oom\">docs</a>"}}
+					]},
+					{"widgets": [
+						{"buttonList": {"buttons": [{"text": "Runbook", "onClick": {"openLink": {"url": "https://runbooks/crashloop"}}}]}}
+					]}
+				]
+			}
+		}]
+	}`, string(payload))
+}
+
+func TestSenderGoogleChat_BuildMessage_Resolved(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.Status = issuepkg.StatusResolved
+	endsAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	issue.EndsAt = &endsAt
+
+	message := s.BuildMessage(issue)
+	require.NotNil(t, message.Thread)
+	assert.Equal(t, "fingerprint-1", message.Thread.ThreadKey)
+	card := message.CardsV2[0].Card
+	assert.Equal(t, "✅ Resolved: Pod is crash looping", card.Header.Title)
+	assert.Equal(t, "Resolved · High severity · PROMETHEUS", card.Header.Subtitle)
+	widgets := card.Sections[0].Widgets
+	assert.Equal(t, &DecoratedText{TopLabel: "Ended", Text: "2024-05-01 12:30:00 UTC", WrapText: true}, widgets[len(widgets)-1].DecoratedText)
+}
+
+func TestSenderGoogleChat_BuildEnrichment(t *testing.T) {
+	enrichment := issuepkg.Enrichment{Blocks: []issuepkg.BaseBlock{
+		issuepkg.NewHeaderBlock("Pod events"),
+		issuepkg.NewTableBlock([]string{"type", "reason", "message"}, [][]string{{"Warning", "BackOff", "Back-off <restarting>"}}, "Events", issuepkg.TableBlockFormatHorizontal),
+		issuepkg.NewDividerBlock(),
+		issuepkg.NewLinksBlock([]issuepkg.Link{{Text: "Logs", URL: "https://grafana/logs"}}, ""),
+	}}
+
+	section, ok := buildEnrichment(enrichment)
+	require.True(t, ok)
+	assert.Equal(t, "Pod events", section.Header)
+	require.Len(t, section.Widgets, 4)
+	assert.Equal(t, "<b>Events</b>", section.Widgets[0].TextParagraph.Text)
+	assert.Equal(t, "<b>type</b>: Warning · <b>reason</b>: BackOff · <b>message</b>: Back-off &lt;restarting&gt;", section.Widgets[1].TextParagraph.Text)
+	assert.NotNil(t, section.Widgets[2].Divider)
+	assert.Equal(t, "https://grafana/logs", section.Widgets[3].ButtonList.Buttons[0].OnClick.OpenLink.URL)
+	assert.True(t, section.Collapsible)
+	assert.Equal(t, 3, section.UncollapsibleWidgetsCount)
+
+	_, ok = buildEnrichment(issuepkg.Enrichment{Title: "Logs", Blocks: []issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 1"), "text/plain"),
+	}})
+	assert.False(t, ok)
+}
+
+func TestSenderGoogleChat_RenderTable_RowLimit(t *testing.T) {
+	rows := make([][]string, 25)
+	for i := range rows {
+		rows[i] = []string{"key", "value"}
+	}
+
+	widgets := renderTable(issuepkg.NewTableBlock(nil, rows, "", issuepkg.TableBlockFormatVertical))
+	require.Len(t, widgets, maxTableRows+1)
+	assert.Equal(t, "<i>Showing 20 of 25 rows</i>", widgets[maxTableRows].TextParagraph.Text)
+}
+
+func TestSenderGoogleChat_BuildMessage_Overflow(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddLink(issuepkg.Link{Text: "Runbook", URL: "https://runbooks/crashloop"})
+	for _, title := range []string{"First", "Second", "Third", "Fourth", "Fifth", "Sixth", "Seventh", "Eighth", "Ninth"} {
+		issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+			issuepkg.NewMarkdownBlock(strings.Repeat("<", 1000)),
+		}, issuepkg.EnrichmentTypeLogs, title)
+	}
+
+	message := s.BuildMessage(issue)
+	payload, err := json.Marshal(message)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(payload), maxMessageBytes)
+
+	sections := message.CardsV2[0].Card.Sections
+	assert.Equal(t, "First", sections[1].Header)
+	note := sections[len(sections)-2]
+	assert.Regexp(t, `^<i>\d more enrichments did not fit into the message</i>$`, note.Widgets[0].TextParagraph.Text)
+	assert.NotNil(t, sections[len(sections)-1].Widgets[0].ButtonList)
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		html     string
+	}{
+		{name: "escapes", markdown: "a < b && \"c\"", html: "a &lt; b &amp;&amp; \"c\""},
+		{name: "bold", markdown: "**Reason**: crash", html: "<b>Reason</b>: crash"},
+		{name: "link", markdown: "[Grafana](https://grafana/d/1?a=1&b=2)", html: `<a href="https://grafana/d/1?a=1&amp;b=2">Grafana</a>`},
+		{name: "inline code", markdown: "run `kubectl logs`", html: "run kubectl logs"},
+		{name: "code block", markdown: "logs:\n```text\nline <1>\n```", html: "logs:\nline &lt;1&gt;"},
+		{name: "truncated", markdown: strings.Repeat("x", maxTextChars+1), html: strings.Repeat("x", maxTextChars-1) + "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.html, markdownToHTML(tt.markdown))
+		})
+	}
+}
+
+func TestSenderGoogleChat_Send(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/v1/spaces/AAA/messages", req.URL.Path)
+		assert.Equal(t, "api-key", req.URL.Query().Get("key"))
+		assert.Equal(t, "secret-token", req.URL.Query().Get("token"))
+		assert.Equal(t, "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD", req.URL.Query().Get("messageReplyOption"))
+
+		var message Message
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&message))
+		assert.Equal(t, "fingerprint-1", message.Thread.ThreadKey)
+		return sendertest.Response(http.StatusOK, `{"name":"spaces/AAA/messages/1"}`), nil
+	}).Times(1)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderGoogleChat_Send_RetriesRateLimit(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	sendertest.RetriesRateLimit(t, mockClient, sendertest.Response(http.StatusOK, `{}`), func() error {
+		return s.Send(context.Background(), sendertest.NewIssue())
+	})
+}
+
+func TestSenderGoogleChat_Send_Error(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusBadRequest, `{"error":{"code":400,"message":"Invalid JSON payload"}}`), nil).Times(1)
+
+	err := s.Send(context.Background(), sendertest.NewIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send Google Chat message: unexpected status 400")
+	assert.Contains(t, err.Error(), "Invalid JSON payload")
+	assert.NotContains(t, err.Error(), "secret-token")
+}
@@ -0,0 +1,156 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+// maxErrorBodyLength limits the response body kept in an HTTPError
+const maxErrorBodyLength = 512
+
+// HTTPError is returned by DoJSON for responses with a status other than 2xx
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the delay requested by the Retry-After header, zero when there is none
+	RetryAfter time.Duration
+}
+
+// Retryable reports whether the request may succeed when it is sent again: the service is rate
+// limiting or failed on its side
+func (e *HTTPError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// DoJSON sends payload encoded as JSON with the given method and headers and returns the response
+// body. Errors never contain the URL, since the webhook URLs of many services carry their token.
+func DoJSON(ctx context.Context, client util.HTTPClient, method, endpoint string, header http.Header, payload interface{}) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	jsonHeader := header.Clone()
+	if jsonHeader == nil {
+		jsonHeader = http.Header{}
+	}
+	jsonHeader.Set("Content-Type", "application/json")
+	return DoRequest(ctx, client, method, endpoint, jsonHeader, body)
+}
+
+// DoRequest sends body with the given method and headers and returns the response body. Like
+// DoJSON, it leaves the URL out of errors and returns an HTTPError for statuses other than 2xx.
+func DoRequest(ctx context.Context, client util.HTTPClient, method, endpoint string, header http.Header, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", unwrapURLError(err))
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message := strings.TrimSpace(string(respBody))
+		if len(message) > maxErrorBodyLength {
+			message = message[:maxErrorBodyLength] + "..."
+		}
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: message, RetryAfter: retryAfter(resp.Header)}
+	}
+
+	return respBody, nil
+}
+
+// retryAfter parses a Retry-After header given in seconds
+func retryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// unwrapURLError drops the URL that net/http adds to its errors
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// RetryPolicy sends a request again while it fails with a retryable HTTPError
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one
+	MaxAttempts int
+	// Backoff is the delay before the second attempt, doubled for every further attempt up to
+	// MaxBackoff. A Retry-After header of the response takes precedence.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy retries rate limited (429) and failed (5xx) requests with an increasing delay
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	Backoff:     time.Second,
+	MaxBackoff:  30 * time.Second,
+}
+
+// Do calls send until it succeeds, fails with an error that is not retryable, the attempts are
+// used up or ctx is done, and returns the result of the last call
+func (p RetryPolicy) Do(ctx context.Context, send func() ([]byte, error)) ([]byte, error) {
+	backoff := p.Backoff
+	for attempt := 1; ; attempt++ {
+		body, err := send()
+		var httpErr *HTTPError
+		if err == nil || attempt >= p.MaxAttempts || !errors.As(err, &httpErr) || !httpErr.Retryable() {
+			return body, err
+		}
+
+		delay := backoff
+		if httpErr.RetryAfter > 0 {
+			delay = httpErr.RetryAfter
+		}
+		if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+			delay = p.MaxBackoff
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w (gave up retrying: %w)", err, ctx.Err())
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,153 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+)
+
+func TestDoJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "token", req.Header.Get("Authorization"))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"text":"hello"}`, string(body))
+		return &http.Response{StatusCode: http.StatusAccepted, Body: io.NopCloser(strings.NewReader(`{"ok":true}`))}, nil
+	})
+
+	header := http.Header{"Authorization": []string{"token"}}
+	body, err := DoJSON(context.Background(), client, http.MethodPost, "https://example.com/hooks/secret", header, map[string]string{"text": "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"ok":true}`, string(body))
+}
+
+func TestDoJSON_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       io.NopCloser(strings.NewReader("invalid payload\n")),
+	}, nil)
+	_, err := DoJSON(context.Background(), client, http.MethodPost, "https://example.com/hooks/secret", nil, struct{}{})
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	assert.EqualError(t, err, "unexpected status 400: invalid payload")
+
+	// webhook URLs carry their token, so they are left out of errors
+	_, err = DoJSON(context.Background(), client, http.MethodPost, "https://example.com/hooks/\x7fsecret", nil, struct{}{})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+
+	client.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: errors.New("connection refused")}
+	})
+	_, err = DoJSON(context.Background(), client, http.MethodPost, "https://example.com/hooks/secret", nil, struct{}{})
+	assert.EqualError(t, err, "failed to send request: connection refused")
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      string
+	}{
+		{
+			name:         "success",
+			errs:         []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "rate limited, then success",
+			errs:         []error{&HTTPError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}, nil},
+			wantAttempts: 2,
+		},
+		{
+			name: "server errors until the attempts are used up",
+			errs: []error{
+				&HTTPError{StatusCode: http.StatusInternalServerError},
+				&HTTPError{StatusCode: http.StatusBadGateway},
+				&HTTPError{StatusCode: http.StatusServiceUnavailable},
+			},
+			wantAttempts: 3,
+			wantErr:      "unexpected status 503",
+		},
+		{
+			name:         "client errors are not retried",
+			errs:         []error{&HTTPError{StatusCode: http.StatusBadRequest, Body: "invalid routing key"}},
+			wantAttempts: 1,
+			wantErr:      "unexpected status 400: invalid routing key",
+		},
+		{
+			name:         "request errors are not retried",
+			errs:         []error{errors.New("failed to marshal payload")},
+			wantAttempts: 1,
+			wantErr:      "failed to marshal payload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			_, err := policy.Do(context.Background(), func() ([]byte, error) {
+				err := tt.errs[attempts]
+				attempts++
+				return nil, err
+			})
+			assert.Equal(t, tt.wantAttempts, attempts)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Do_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Hour}
+
+	_, err := policy.Do(ctx, func() ([]byte, error) {
+		cancel()
+		return nil, &HTTPError{StatusCode: http.StatusTooManyRequests}
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "unexpected status 429")
+}
+
+func TestDoJSON_RetryAfter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockHTTPClient(ctrl)
+
+	client.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+		Body:       http.NoBody,
+	}, nil)
+	_, err := DoJSON(context.Background(), client, http.MethodPost, "https://example.com", nil, struct{}{})
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.True(t, httpErr.Retryable())
+	assert.Equal(t, 30*time.Second, httpErr.RetryAfter)
+}
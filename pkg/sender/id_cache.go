@@ -0,0 +1,132 @@
+package sender
+
+import (
+	"sync"
+	"time"
+
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+)
+
+// IDCache remembers an ID by the fingerprint of its issue for a TTL, like the thread of a firing
+// issue that its resolution replies to. With a state store the IDs are shared between HA
+// replicas, so they survive a leader failover.
+type IDCache struct {
+	// keyPrefix namespaces the keys in the state store, like "slack-thread/<channel>/"
+	keyPrefix string
+	ttl       time.Duration
+
+	mutex   sync.RWMutex
+	entries map[string]idCacheEntry
+	// nextSweep is when expired entries are dropped next; sweeping at most once per TTL keeps
+	// inserts constant time on average
+	nextSweep time.Time
+
+	stateStore ha_interfaces.StateStoreInterface
+	stateTTL   time.Duration
+}
+
+type idCacheEntry struct {
+	id        string
+	timestamp time.Time
+}
+
+// NewIDCache creates a cache whose entries expire after ttl
+func NewIDCache(keyPrefix string, ttl time.Duration) *IDCache {
+	return &IDCache{
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+		entries:   make(map[string]idCacheEntry),
+		nextSweep: time.Now().Add(ttl),
+	}
+}
+
+// SetStateStore shares the IDs with other replicas, where they expire after ttl
+func (c *IDCache) SetStateStore(store ha_interfaces.StateStoreInterface, ttl time.Duration) {
+	c.stateStore = store
+	c.stateTTL = ttl
+}
+
+// Cached returns the ID of the fingerprint set on this replica, unless it expired
+func (c *IDCache) Cached(fingerprint string) (string, bool) {
+	c.mutex.RLock()
+	entry, exists := c.entries[fingerprint]
+	c.mutex.RUnlock()
+
+	if exists && time.Since(entry.timestamp) < c.ttl {
+		return entry.id, true
+	}
+	return "", false
+}
+
+// Get returns the ID of the fingerprint, looking it up in the state store when this replica
+// does not have it, e.g. after a leader failover. IDs found there are cached locally.
+func (c *IDCache) Get(fingerprint string) (string, bool) {
+	if id, ok := c.Cached(fingerprint); ok {
+		return id, true
+	}
+	if c.stateStore == nil {
+		return "", false
+	}
+	id, found := c.stateStore.Get(c.keyPrefix + fingerprint)
+	if !found {
+		return "", false
+	}
+	c.cache(fingerprint, id)
+	return id, true
+}
+
+// Set remembers the ID of the fingerprint, on this replica and in the state store
+func (c *IDCache) Set(fingerprint, id string) {
+	c.cache(fingerprint, id)
+	if c.stateStore != nil {
+		c.stateStore.Set(c.keyPrefix+fingerprint, id, c.stateTTL)
+	}
+}
+
+// Delete forgets the ID of the fingerprint, on this replica and in the state store
+func (c *IDCache) Delete(fingerprint string) {
+	c.mutex.Lock()
+	delete(c.entries, fingerprint)
+	c.mutex.Unlock()
+	if c.stateStore != nil {
+		c.stateStore.Delete(c.keyPrefix + fingerprint)
+	}
+}
+
+// Cleanup drops the expired entries and returns their fingerprints
+func (c *IDCache) Cleanup() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.sweep(time.Now())
+}
+
+// Len returns the number of entries, including expired ones that were not dropped yet
+func (c *IDCache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.entries)
+}
+
+func (c *IDCache) cache(fingerprint, id string) {
+	now := time.Now()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Fingerprints that never resolve would pile up without dropping expired entries
+	if !now.Before(c.nextSweep) {
+		c.sweep(now)
+	}
+	c.entries[fingerprint] = idCacheEntry{id: id, timestamp: now}
+}
+
+func (c *IDCache) sweep(now time.Time) []string {
+	var expired []string
+	for fingerprint, entry := range c.entries {
+		if now.Sub(entry.timestamp) >= c.ttl {
+			delete(c.entries, fingerprint)
+			expired = append(expired, fingerprint)
+		}
+	}
+	c.nextSweep = now.Add(c.ttl)
+	return expired
+}
@@ -0,0 +1,76 @@
+package sender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubecano/cano-collector/pkg/ha"
+)
+
+func TestIDCache(t *testing.T) {
+	cache := NewIDCache("test/", time.Hour)
+
+	_, found := cache.Get("fingerprint-1")
+	assert.False(t, found)
+
+	cache.Set("fingerprint-1", "id-1")
+	id, found := cache.Get("fingerprint-1")
+	assert.True(t, found)
+	assert.Equal(t, "id-1", id)
+
+	cache.Delete("fingerprint-1")
+	_, found = cache.Cached("fingerprint-1")
+	assert.False(t, found)
+}
+
+func TestIDCache_Expires(t *testing.T) {
+	cache := NewIDCache("test/", 5*time.Millisecond)
+
+	cache.Set("fingerprint-1", "id-1")
+	cache.Set("fingerprint-2", "id-2")
+	time.Sleep(10 * time.Millisecond)
+	_, found := cache.Get("fingerprint-1")
+	assert.False(t, found)
+	assert.Equal(t, 2, cache.Len())
+
+	// the first insert after a TTL drops the expired entries
+	cache.Set("fingerprint-3", "id-3")
+	assert.Equal(t, 1, cache.Len())
+
+	// inserts within a TTL of the last sweep don't walk the entries again
+	cache.Set("fingerprint-4", "id-4")
+	assert.Equal(t, 2, cache.Len())
+
+	time.Sleep(10 * time.Millisecond)
+	assert.ElementsMatch(t, []string{"fingerprint-3", "fingerprint-4"}, cache.Cleanup())
+	assert.Zero(t, cache.Len())
+}
+
+func TestIDCache_SharedState(t *testing.T) {
+	store := ha.NewMemoryStateStore()
+	leader := NewIDCache("test/", time.Hour)
+	leader.SetStateStore(store, time.Hour)
+	leader.Set("fingerprint-1", "id-1")
+
+	value, found := store.Get("test/fingerprint-1")
+	assert.True(t, found)
+	assert.Equal(t, "id-1", value)
+
+	// a new leader finds the ID and keeps it locally
+	follower := NewIDCache("test/", time.Hour)
+	follower.SetStateStore(store, time.Hour)
+	_, found = follower.Cached("fingerprint-1")
+	assert.False(t, found)
+	id, found := follower.Get("fingerprint-1")
+	assert.True(t, found)
+	assert.Equal(t, "id-1", id)
+	id, found = follower.Cached("fingerprint-1")
+	assert.True(t, found)
+	assert.Equal(t, "id-1", id)
+
+	follower.Delete("fingerprint-1")
+	_, found = store.Get("test/fingerprint-1")
+	assert.False(t, found)
+}
@@ -0,0 +1,147 @@
+package jira
+
+import (
+	"strings"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// adfNode is a node of the Atlassian Document Format
+type adfNode struct {
+	Type    string                 `json:"type"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Content []adfNode              `json:"content,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Marks   []adfMark              `json:"marks,omitempty"`
+}
+
+type adfMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// adfDoc is the root node of a document
+type adfDoc struct {
+	Type    string    `json:"type"`
+	Version int       `json:"version"`
+	Content []adfNode `json:"content"`
+}
+
+// adfDocument builds a document in the Atlassian Document Format of API version 3
+type adfDocument struct {
+	content []adfNode
+}
+
+func (d *adfDocument) paragraph(text string) {
+	d.content = append(d.content, adfNode{Type: "paragraph", Content: adfText(text)})
+}
+
+func (d *adfDocument) heading(text string) {
+	if text == "" {
+		return
+	}
+	d.content = append(d.content, adfNode{Type: "heading", Attrs: map[string]interface{}{"level": 3}, Content: adfText(text)})
+}
+
+func (d *adfDocument) table(headers []string, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	columns := len(headers)
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	tableRows := make([]adfNode, 0, len(rows)+1)
+	if len(headers) > 0 {
+		tableRows = append(tableRows, adfRow("tableHeader", headers, columns))
+	}
+	for _, row := range rows {
+		tableRows = append(tableRows, adfRow("tableCell", row, columns))
+	}
+	d.content = append(d.content, adfNode{Type: "table", Content: tableRows})
+}
+
+func adfRow(cellType string, values []string, columns int) adfNode {
+	cells := make([]adfNode, columns)
+	for i := range cells {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		cells[i] = adfNode{Type: cellType, Content: []adfNode{{Type: "paragraph", Content: adfText(value)}}}
+	}
+	return adfNode{Type: "tableRow", Content: cells}
+}
+
+func (d *adfDocument) list(items []string, ordered bool) {
+	if len(items) == 0 {
+		return
+	}
+	listType := "bulletList"
+	if ordered {
+		listType = "orderedList"
+	}
+	listItems := make([]adfNode, 0, len(items))
+	for _, item := range items {
+		listItems = append(listItems, adfNode{Type: "listItem", Content: []adfNode{{Type: "paragraph", Content: adfText(item)}}})
+	}
+	d.content = append(d.content, adfNode{Type: listType, Content: listItems})
+}
+
+func (d *adfDocument) code(text string) {
+	node := adfNode{Type: "codeBlock"}
+	if text != "" {
+		node.Content = []adfNode{{Type: "text", Text: text}}
+	}
+	d.content = append(d.content, node)
+}
+
+func (d *adfDocument) links(links []issuepkg.Link) {
+	items := make([]adfNode, 0, len(links))
+	for _, link := range links {
+		if link.URL == "" {
+			continue
+		}
+		text := link.Text
+		if text == "" {
+			text = link.URL
+		}
+		linkText := adfNode{
+			Type:  "text",
+			Text:  text,
+			Marks: []adfMark{{Type: "link", Attrs: map[string]interface{}{"href": link.URL}}},
+		}
+		items = append(items, adfNode{Type: "listItem", Content: []adfNode{{Type: "paragraph", Content: []adfNode{linkText}}}})
+	}
+	if len(items) > 0 {
+		d.content = append(d.content, adfNode{Type: "bulletList", Content: items})
+	}
+}
+
+func (d *adfDocument) rule() {
+	d.content = append(d.content, adfNode{Type: "rule"})
+}
+
+func (d *adfDocument) body() interface{} {
+	content := d.content
+	if content == nil {
+		content = []adfNode{}
+	}
+	return adfDoc{Type: "doc", Version: 1, Content: content}
+}
+
+// adfText returns the text nodes of text, with hard breaks for its line breaks. Text nodes must
+// not be empty, so empty lines only leave their break.
+func adfText(text string) []adfNode {
+	var nodes []adfNode
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			nodes = append(nodes, adfNode{Type: "hardBreak"})
+		}
+		if line != "" {
+			nodes = append(nodes, adfNode{Type: "text", Text: line})
+		}
+	}
+	return nodes
+}
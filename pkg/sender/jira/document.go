@@ -0,0 +1,124 @@
+package jira
+
+import (
+	"fmt"
+	"time"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// maxTableRows limits the rows of a table in a description or comment
+const maxTableRows = 50
+
+// document builds the rich text of descriptions and comments: Atlassian Document Format for
+// API version 3 and wiki markup for version 2
+type document interface {
+	paragraph(text string)
+	heading(text string)
+	table(headers []string, rows [][]string)
+	list(items []string, ordered bool)
+	code(text string)
+	links(links []issuepkg.Link)
+	rule()
+	// body returns the document in the format of the API
+	body() interface{}
+}
+
+func newDocument(apiVersion string) document {
+	if apiVersion == "2" {
+		return &wikiDocument{}
+	}
+	return &adfDocument{}
+}
+
+// renderIssue adds the description, the facts, the enrichments and the links of the issue
+func renderIssue(doc document, issue *issuepkg.Issue) {
+	if issue.Description != "" {
+		doc.paragraph(issue.Description)
+	}
+	doc.table(nil, facts(issue))
+	renderEnrichments(doc, issue)
+	if len(issue.Links) > 0 {
+		doc.heading("Links")
+		doc.links(issue.Links)
+	}
+}
+
+// facts returns the cluster, the subject and the timing of the issue as rows of a table
+func facts(issue *issuepkg.Issue) [][]string {
+	rows := [][]string{{"Severity", issue.Severity.String()}}
+	if issue.ClusterName != "" {
+		rows = append(rows, []string{"Cluster", issue.ClusterName})
+	}
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		rows = append(rows, []string{"Subject", fmt.Sprintf("%s %s", subject.SubjectType.String(), subject.Name)})
+		for _, fact := range [][]string{{"Namespace", subject.Namespace}, {"Node", subject.Node}, {"Container", subject.Container}} {
+			if fact[1] != "" {
+				rows = append(rows, fact)
+			}
+		}
+	}
+	rows = append(rows, []string{"Source", issue.Source.String()})
+	if !issue.StartsAt.IsZero() {
+		rows = append(rows, []string{"Started", issue.StartsAt.UTC().Format(time.RFC3339)})
+	}
+	return rows
+}
+
+// renderEnrichments adds every enrichment under its title. Files are uploaded as attachments,
+// so the document only names them.
+func renderEnrichments(doc document, issue *issuepkg.Issue) {
+	for _, enrichment := range issue.Enrichments {
+		if len(enrichment.Blocks) == 0 {
+			continue
+		}
+		if enrichment.Title != "" {
+			doc.heading(enrichment.Title)
+		}
+		for _, block := range enrichment.Blocks {
+			renderBlock(doc, block)
+		}
+	}
+}
+
+func renderBlock(doc document, block issuepkg.BaseBlock) {
+	switch b := block.(type) {
+	case *issuepkg.MarkdownBlock:
+		if b.Text != "" {
+			doc.paragraph(b.Text)
+		}
+	case *issuepkg.HeaderBlock:
+		doc.heading(b.Text)
+	case *issuepkg.TableBlock:
+		if b.TableName != "" {
+			doc.paragraph(b.TableName)
+		}
+		rows := b.Rows
+		if len(rows) > maxTableRows {
+			rows = rows[:maxTableRows]
+		}
+		doc.table(b.Headers, rows)
+		if len(b.Rows) > maxTableRows {
+			doc.paragraph(fmt.Sprintf("Showing %d of %d rows", maxTableRows, len(b.Rows)))
+		}
+	case *issuepkg.ListBlock:
+		if b.ListName != "" {
+			doc.paragraph(b.ListName)
+		}
+		doc.list(b.Items, b.Ordered)
+	case *issuepkg.JsonBlock:
+		doc.code(b.ToJson())
+	case *issuepkg.LinksBlock:
+		doc.links(b.Links)
+	case *issuepkg.FileBlock:
+		doc.paragraph("Attached " + b.Filename)
+	case *issuepkg.ImageBlock:
+		text := b.AltText
+		if text == "" {
+			text = "Image"
+		}
+		doc.links([]issuepkg.Link{{Text: text, URL: b.URL}})
+	case *issuepkg.DividerBlock:
+		doc.rule()
+	}
+}
@@ -0,0 +1,381 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+const (
+	// FingerprintLabelPrefix starts the label that connects a ticket with the fingerprint of its
+	// issue
+	FingerprintLabelPrefix = "cano-fp-"
+
+	// maxSummaryLength is the longest summary Jira accepts
+	maxSummaryLength = 255
+)
+
+// Config describes the Jira site and the tickets the sender creates
+type Config struct {
+	URL string
+	// APIVersion is "3" for Jira Cloud, which takes rich text as ADF, or "2" for Jira Data
+	// Center, which takes wiki markup
+	APIVersion string
+	// Username selects basic authentication with the API token; without it the token is a
+	// bearer token
+	Username   string
+	APIToken   string
+	ProjectKey string
+	IssueType  string
+	// Priorities maps lower case severities, like "high", to Jira priorities
+	Priorities    map[string]string
+	Components    []string
+	Labels        []string
+	CustomFields  map[string]interface{}
+	ResolveStatus string
+}
+
+type SenderJira struct {
+	cfg         Config
+	logger      logger_interfaces.LoggerInterface
+	client      util.HTTPClient
+	retryPolicy sender.RetryPolicy
+}
+
+func NewSenderJira(cfg Config, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderJira {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	return &SenderJira{
+		cfg:         cfg,
+		logger:      logger,
+		client:      client,
+		retryPolicy: sender.DefaultRetryPolicy,
+	}
+}
+
+// Send opens a ticket for a firing issue, unless an open ticket with its fingerprint label
+// exists, which gets a comment instead. Files are attached unless the ticket has a file of the
+// same name already. A resolved issue transitions its open ticket to the resolve status.
+func (s *SenderJira) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	key, err := s.findOpenTicket(ctx, issue.Fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to search Jira tickets: %w", err)
+	}
+
+	if issue.IsResolved() {
+		if key == "" {
+			s.logger.Info("No open Jira ticket for resolved issue", zap.String("fingerprint", issue.Fingerprint))
+			return nil
+		}
+		return s.resolveTicket(ctx, key, issue)
+	}
+
+	attached := make(map[string]bool)
+	if key == "" {
+		key, err = s.createTicket(ctx, issue)
+		if err != nil {
+			return fmt.Errorf("failed to create Jira ticket: %w", err)
+		}
+		s.logger.Info("Created Jira ticket", zap.String("key", key), zap.String("fingerprint", issue.Fingerprint))
+	} else {
+		if err := s.addComment(ctx, key, s.BuildRefireComment(issue)); err != nil {
+			return fmt.Errorf("failed to comment on Jira ticket %s: %w", key, err)
+		}
+		s.logger.Info("Commented on Jira ticket", zap.String("key", key), zap.String("fingerprint", issue.Fingerprint))
+		if len(sender.FileBlocks(issue)) > 0 {
+			if attached, err = s.attachmentNames(ctx, key); err != nil {
+				return fmt.Errorf("failed to get attachments of Jira ticket %s: %w", key, err)
+			}
+		}
+	}
+
+	for _, file := range sender.FileBlocks(issue) {
+		if attached[file.Filename] {
+			continue
+		}
+		if err := s.uploadAttachment(ctx, key, file); err != nil {
+			return fmt.Errorf("failed to attach %s to Jira ticket %s: %w", file.Filename, key, err)
+		}
+		attached[file.Filename] = true
+	}
+	return nil
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderJira) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderJira) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+// FingerprintLabel returns the label of the tickets of an issue. Labels cannot contain spaces.
+func FingerprintLabel(fingerprint string) string {
+	return FingerprintLabelPrefix + strings.Join(strings.Fields(fingerprint), "_")
+}
+
+// BuildCreateRequest returns the request that creates the ticket of the issue
+func (s *SenderJira) BuildCreateRequest(issue *issuepkg.Issue) map[string]interface{} {
+	fields := make(map[string]interface{}, len(s.cfg.CustomFields)+8)
+	for name, value := range s.cfg.CustomFields {
+		fields[name] = value
+	}
+
+	doc := newDocument(s.cfg.APIVersion)
+	renderIssue(doc, issue)
+
+	fields["project"] = map[string]string{"key": s.cfg.ProjectKey}
+	fields["issuetype"] = map[string]string{"name": s.cfg.IssueType}
+	fields["summary"] = sender.Truncate(issue.Title, maxSummaryLength)
+	fields["description"] = doc.body()
+	fields["labels"] = append(append([]string{}, s.cfg.Labels...), FingerprintLabel(issue.Fingerprint))
+	if priority := s.cfg.Priorities[strings.ToLower(issue.Severity.String())]; priority != "" {
+		fields["priority"] = map[string]string{"name": priority}
+	}
+	if len(s.cfg.Components) > 0 {
+		components := make([]map[string]string, 0, len(s.cfg.Components))
+		for _, component := range s.cfg.Components {
+			components = append(components, map[string]string{"name": component})
+		}
+		fields["components"] = components
+	}
+	return map[string]interface{}{"fields": fields}
+}
+
+// BuildRefireComment returns the comment added to an open ticket when its issue fires again
+func (s *SenderJira) BuildRefireComment(issue *issuepkg.Issue) interface{} {
+	doc := newDocument(s.cfg.APIVersion)
+	doc.paragraph("The alert fired again.")
+	renderIssue(doc, issue)
+	return doc.body()
+}
+
+// BuildResolveComment returns the comment added to a ticket when its issue is resolved
+func (s *SenderJira) BuildResolveComment(issue *issuepkg.Issue) interface{} {
+	doc := newDocument(s.cfg.APIVersion)
+	text := "The alert was resolved."
+	if issue.EndsAt != nil {
+		text = fmt.Sprintf("The alert was resolved at %s.", issue.EndsAt.UTC().Format(time.RFC3339))
+	}
+	doc.paragraph(text)
+	return doc.body()
+}
+
+// findOpenTicket returns the key of the most recent ticket of the fingerprint that is not done,
+// or an empty key when there is none
+func (s *SenderJira) findOpenTicket(ctx context.Context, fingerprint string) (string, error) {
+	jql := fmt.Sprintf(`project = %s AND labels = %s AND statusCategory != Done ORDER BY created DESC`,
+		jqlString(s.cfg.ProjectKey), jqlString(FingerprintLabel(fingerprint)))
+	query := url.Values{"jql": {jql}, "fields": {"status"}, "maxResults": {"1"}}
+
+	// Jira Cloud replaced the search endpoint of version 3
+	path := "/search/jql"
+	if s.cfg.APIVersion == "2" {
+		path = "/search"
+	}
+
+	var result struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	if err := s.doJSON(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &result); err != nil {
+		return "", err
+	}
+	if len(result.Issues) == 0 {
+		return "", nil
+	}
+	return result.Issues[0].Key, nil
+}
+
+// createTicket creates the ticket of the issue. A request that timed out or failed with a
+// server error may still have created the ticket, so it is searched by its fingerprint label
+// before the request is sent again.
+func (s *SenderJira) createTicket(ctx context.Context, issue *issuepkg.Issue) (string, error) {
+	payload, err := json.Marshal(s.BuildCreateRequest(issue))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	key := ""
+	attempt := 0
+	_, err = s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		if attempt++; attempt > 1 {
+			existing, err := s.findOpenTicket(ctx, issue.Fingerprint)
+			if err != nil || existing != "" {
+				key = existing
+				return nil, err
+			}
+		}
+
+		header := http.Header{"Content-Type": {"application/json"}}
+		respBody, err := s.request(ctx, http.MethodPost, "/issue", header, payload)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Key string `json:"key"`
+		}
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		key = result.Key
+		return respBody, nil
+	})
+	return key, err
+}
+
+func (s *SenderJira) addComment(ctx context.Context, key string, body interface{}) error {
+	return s.doJSON(ctx, http.MethodPost, "/issue/"+url.PathEscape(key)+"/comment", map[string]interface{}{"body": body}, nil)
+}
+
+// resolveTicket comments on the ticket and moves it to the resolve status with the transition
+// that leads there in the workflow of the project
+func (s *SenderJira) resolveTicket(ctx context.Context, key string, issue *issuepkg.Issue) error {
+	if err := s.addComment(ctx, key, s.BuildResolveComment(issue)); err != nil {
+		return fmt.Errorf("failed to comment on Jira ticket %s: %w", key, err)
+	}
+
+	var result struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := s.doJSON(ctx, http.MethodGet, "/issue/"+url.PathEscape(key)+"/transitions", nil, &result); err != nil {
+		return fmt.Errorf("failed to get transitions of Jira ticket %s: %w", key, err)
+	}
+
+	transitionID := ""
+	for _, transition := range result.Transitions {
+		if strings.EqualFold(transition.To.Name, s.cfg.ResolveStatus) || strings.EqualFold(transition.Name, s.cfg.ResolveStatus) {
+			transitionID = transition.ID
+			break
+		}
+	}
+	if transitionID == "" {
+		return fmt.Errorf("no transition of Jira ticket %s leads to status '%s'", key, s.cfg.ResolveStatus)
+	}
+
+	request := map[string]interface{}{"transition": map[string]string{"id": transitionID}}
+	if err := s.doJSON(ctx, http.MethodPost, "/issue/"+url.PathEscape(key)+"/transitions", request, nil); err != nil {
+		return fmt.Errorf("failed to transition Jira ticket %s: %w", key, err)
+	}
+	s.logger.Info("Resolved Jira ticket", zap.String("key", key), zap.String("status", s.cfg.ResolveStatus))
+	return nil
+}
+
+// attachmentNames returns the file names of the attachments of the ticket
+func (s *SenderJira) attachmentNames(ctx context.Context, key string) (map[string]bool, error) {
+	var result struct {
+		Fields struct {
+			Attachment []struct {
+				Filename string `json:"filename"`
+			} `json:"attachment"`
+		} `json:"fields"`
+	}
+	if err := s.doJSON(ctx, http.MethodGet, "/issue/"+url.PathEscape(key)+"?fields=attachment", nil, &result); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(result.Fields.Attachment))
+	for _, attachment := range result.Fields.Attachment {
+		names[attachment.Filename] = true
+	}
+	return names, nil
+}
+
+func (s *SenderJira) uploadAttachment(ctx context.Context, key string, file *issuepkg.FileBlock) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", file.Filename)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(file.Contents); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	header := http.Header{
+		"Content-Type": {writer.FormDataContentType()},
+		// Jira rejects uploads without it as cross-site requests
+		"X-Atlassian-Token": {"no-check"},
+	}
+	_, err = s.do(ctx, http.MethodPost, "/issue/"+url.PathEscape(key)+"/attachments", header, body.Bytes())
+	return err
+}
+
+// doJSON sends payload, unless it is nil, to a path of the REST API and decodes the response
+// into result, unless it is nil
+func (s *SenderJira) doJSON(ctx context.Context, method, path string, payload, result interface{}) error {
+	var body []byte
+	header := http.Header{}
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = encoded
+		header.Set("Content-Type", "application/json")
+	}
+
+	respBody, err := s.do(ctx, method, path, header, body)
+	if err != nil {
+		return err
+	}
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// do sends an authenticated request to a path of the REST API and retries it while it fails
+func (s *SenderJira) do(ctx context.Context, method, path string, header http.Header, body []byte) ([]byte, error) {
+	return s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		return s.request(ctx, method, path, header, body)
+	})
+}
+
+// request sends an authenticated request to a path of the REST API once
+func (s *SenderJira) request(ctx context.Context, method, path string, header http.Header, body []byte) ([]byte, error) {
+	header.Set("Accept", "application/json")
+	if s.cfg.Username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(s.cfg.Username + ":" + s.cfg.APIToken))
+		header.Set("Authorization", "Basic "+credentials)
+	} else {
+		header.Set("Authorization", "Bearer "+s.cfg.APIToken)
+	}
+
+	endpoint := fmt.Sprintf("%s/rest/api/%s%s", s.cfg.URL, s.cfg.APIVersion, path)
+	return sender.DoRequest(ctx, s.client, method, endpoint, header, body)
+}
+
+// jqlString quotes a value for JQL
+func jqlString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
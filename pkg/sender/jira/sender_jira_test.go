@@ -0,0 +1,342 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testURL = "https://example.atlassian.net"
+
+func testConfig() Config {
+	return Config{
+		URL:           testURL + "/",
+		APIVersion:    "3",
+		Username:      "bot@example.com",
+		APIToken:      "api-token",
+		ProjectKey:    "OPS",
+		IssueType:     "Incident",
+		Priorities:    map[string]string{"high": "Highest"},
+		Components:    []string{"platform"},
+		Labels:        []string{"k8s"},
+		CustomFields:  map[string]interface{}{"customfield_10010": "cano"},
+		ResolveStatus: "Done",
+	}
+}
+
+func setupSender(t *testing.T, cfg Config) (*SenderJira, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	s := NewSenderJira(cfg, logger, client)
+	s.retryPolicy = sendertest.RetryPolicy
+	return s, client
+}
+
+func searchResponse(keys ...string) *http.Response {
+	issues := make([]map[string]string, 0, len(keys))
+	for _, key := range keys {
+		issues = append(issues, map[string]string{"key": key})
+	}
+	body, _ := json.Marshal(map[string]interface{}{"issues": issues})
+	return sendertest.Response(http.StatusOK, string(body))
+}
+
+func TestFingerprintLabel(t *testing.T) {
+	assert.Equal(t, "cano-fp-abc123", FingerprintLabel("abc123"))
+	assert.Equal(t, "cano-fp-a_b", FingerprintLabel("a b"))
+}
+
+func TestSenderJira_BuildCreateRequest(t *testing.T) {
+	s, _ := setupSender(t, testConfig())
+
+	fields := s.BuildCreateRequest(sendertest.NewIssue())["fields"].(map[string]interface{})
+	assert.Equal(t, map[string]string{"key": "OPS"}, fields["project"])
+	assert.Equal(t, map[string]string{"name": "Incident"}, fields["issuetype"])
+	assert.Equal(t, "Pod is crash looping", fields["summary"])
+	assert.Equal(t, []string{"k8s", "cano-fp-fingerprint-1"}, fields["labels"])
+	assert.Equal(t, map[string]string{"name": "Highest"}, fields["priority"])
+	assert.Equal(t, []map[string]string{{"name": "platform"}}, fields["components"])
+	assert.Equal(t, "cano", fields["customfield_10010"])
+
+	doc, ok := fields["description"].(adfDoc)
+	require.True(t, ok)
+	assert.Equal(t, "doc", doc.Type)
+	assert.Equal(t, 1, doc.Version)
+	assert.Equal(t, "paragraph", doc.Content[0].Type)
+	assert.Equal(t, "table", doc.Content[1].Type)
+}
+
+func TestSenderJira_BuildCreateRequest_UnmappedPriority(t *testing.T) {
+	s, _ := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.Severity = issuepkg.SeverityLow
+
+	fields := s.BuildCreateRequest(issue)["fields"].(map[string]interface{})
+	assert.NotContains(t, fields, "priority")
+}
+
+func TestSenderJira_BuildCreateRequest_WikiMarkup(t *testing.T) {
+	cfg := testConfig()
+	cfg.APIVersion = "2"
+	s, _ := setupSender(t, cfg)
+	issue := sendertest.NewIssue()
+	issue.AddLink(issuepkg.Link{Text: "Runbook", URL: "https://runbooks/crashloop"})
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"name", "value"}, [][]string{{"restarts", "5|6"}}, "", issuepkg.TableBlockFormatVertical),
+		issuepkg.NewFileBlock("api.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	description := s.BuildCreateRequest(issue)["fields"].(map[string]interface{})["description"].(string)
+	assert.Contains(t, description, "Pod payments/api-0 is restarting")
+	assert.Contains(t, description, "|Subject|POD api-0|")
+	assert.Contains(t, description, "h3. Logs\n\n||name||value||\n|restarts|5\\|6|")
+	assert.Contains(t, description, "Attached api.log")
+	assert.Contains(t, description, "h3. Links\n\n* [Runbook|https://runbooks/crashloop]")
+}
+
+func TestAdfText(t *testing.T) {
+	nodes := adfText("line 1\n\nline 2")
+	require.Len(t, nodes, 4)
+	assert.Equal(t, "line 1", nodes[0].Text)
+	assert.Equal(t, "hardBreak", nodes[1].Type)
+	assert.Equal(t, "hardBreak", nodes[2].Type)
+	assert.Equal(t, "line 2", nodes[3].Text)
+	assert.Empty(t, adfText(""))
+}
+
+func TestSenderJira_Send_CreatesTicket(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 1\nline 2"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, "/rest/api/3/search/jql", req.URL.Path)
+			assert.Equal(t, `project = "OPS" AND labels = "cano-fp-fingerprint-1" AND statusCategory != Done ORDER BY created DESC`, req.URL.Query().Get("jql"))
+			username, token, ok := req.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "bot@example.com", username)
+			assert.Equal(t, "api-token", token)
+			return searchResponse(), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, testURL+"/rest/api/3/issue", req.URL.String())
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			var request struct {
+				Fields map[string]interface{} `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+			assert.Equal(t, "Pod is crash looping", request.Fields["summary"])
+			return sendertest.Response(http.StatusCreated, `{"id":"10001","key":"OPS-1"}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, testURL+"/rest/api/3/issue/OPS-1/attachments", req.URL.String())
+			assert.Equal(t, "no-check", req.Header.Get("X-Atlassian-Token"))
+			require.NoError(t, req.ParseMultipartForm(1<<20))
+			file, header, err := req.FormFile("file")
+			require.NoError(t, err)
+			defer file.Close()
+			assert.Equal(t, "api.log", header.Filename)
+			contents, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, "line 1\nline 2", string(contents))
+			return sendertest.Response(http.StatusOK, `[{"id":"10000"}]`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderJira_Send_CommentsOnOpenTicket(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("OPS-7"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, testURL+"/rest/api/3/issue/OPS-7/comment", req.URL.String())
+			var request struct {
+				Body adfDoc `json:"body"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+			assert.Equal(t, "The alert fired again.", request.Body.Content[0].Content[0].Text)
+			return sendertest.Response(http.StatusCreated, `{"id":"10100"}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderJira_Send_RefireSkipsExistingAttachments(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 3"), "text/plain"),
+		issuepkg.NewFileBlock("worker.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("OPS-7"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusCreated, `{"id":"10100"}`), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, testURL+"/rest/api/3/issue/OPS-7?fields=attachment", req.URL.String())
+			return sendertest.Response(http.StatusOK, `{"key":"OPS-7","fields":{"attachment":[{"filename":"api.log"}]}}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, testURL+"/rest/api/3/issue/OPS-7/attachments", req.URL.String())
+			require.NoError(t, req.ParseMultipartForm(1<<20))
+			_, header, err := req.FormFile("file")
+			require.NoError(t, err)
+			assert.Equal(t, "worker.log", header.Filename)
+			return sendertest.Response(http.StatusOK, `[{"id":"10001"}]`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderJira_Send_CreateRetryFindsTicket(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse(), nil),
+		// the ticket was created, but the response got lost
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, testURL+"/rest/api/3/issue", req.URL.String())
+			return sendertest.Response(http.StatusGatewayTimeout, ""), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/rest/api/3/search/jql", req.URL.Path)
+			return searchResponse("OPS-1"), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderJira_Send_CreateRetry(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse(), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusServiceUnavailable, ""), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse(), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, testURL+"/rest/api/3/issue", req.URL.String())
+			var request struct {
+				Fields map[string]interface{} `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+			assert.Equal(t, "Pod is crash looping", request.Fields["summary"])
+			return sendertest.Response(http.StatusCreated, `{"key":"OPS-1"}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderJira_Send_ResolvesTicket(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.Status = issuepkg.StatusResolved
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("OPS-7"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, testURL+"/rest/api/3/issue/OPS-7/comment", req.URL.String())
+			return sendertest.Response(http.StatusCreated, `{"id":"10100"}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, testURL+"/rest/api/3/issue/OPS-7/transitions", req.URL.String())
+			return sendertest.Response(http.StatusOK, `{"transitions":[{"id":"11","name":"Start","to":{"name":"In Progress"}},{"id":"31","name":"Close","to":{"name":"Done"}}]}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"transition":{"id":"31"}}`, string(body))
+			return sendertest.Response(http.StatusNoContent, ""), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderJira_Send_ResolvedWithoutTicket(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.Status = issuepkg.StatusResolved
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse(), nil).Times(1)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderJira_Send_NoResolveTransition(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.Status = issuepkg.StatusResolved
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("OPS-7"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusCreated, `{"id":"10100"}`), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusOK, `{"transitions":[{"id":"11","name":"Start","to":{"name":"In Progress"}}]}`), nil),
+	)
+
+	err := s.Send(context.Background(), issue)
+	require.Error(t, err)
+	assert.Equal(t, "no transition of Jira ticket OPS-7 leads to status 'Done'", err.Error())
+}
+
+func TestSenderJira_Send_DataCenter(t *testing.T) {
+	cfg := testConfig()
+	cfg.APIVersion = "2"
+	cfg.Username = ""
+	s, mockClient := setupSender(t, cfg)
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/rest/api/2/search", req.URL.Path)
+			assert.Equal(t, "Bearer api-token", req.Header.Get("Authorization"))
+			return searchResponse(), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, testURL+"/rest/api/2/issue", req.URL.String())
+			var request struct {
+				Fields map[string]interface{} `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+			assert.IsType(t, "", request.Fields["description"])
+			return sendertest.Response(http.StatusCreated, `{"key":"OPS-2"}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderJira_Send_Unauthorized(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusUnauthorized, `{"errorMessages":["Unauthorized"]}`), nil).Times(1)
+
+	err := s.Send(context.Background(), sendertest.NewIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to search Jira tickets: unexpected status 401")
+	assert.NotContains(t, err.Error(), "api-token")
+}
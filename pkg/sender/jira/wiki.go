@@ -0,0 +1,104 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// wikiDocument builds a document in the wiki markup of API version 2
+type wikiDocument struct {
+	builder strings.Builder
+}
+
+func (d *wikiDocument) block(text string) {
+	if d.builder.Len() > 0 {
+		d.builder.WriteString("\n\n")
+	}
+	d.builder.WriteString(text)
+}
+
+func (d *wikiDocument) paragraph(text string) {
+	d.block(text)
+}
+
+func (d *wikiDocument) heading(text string) {
+	if text != "" {
+		d.block("h3. " + text)
+	}
+}
+
+func (d *wikiDocument) table(headers []string, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	lines := make([]string, 0, len(rows)+1)
+	if len(headers) > 0 {
+		lines = append(lines, "||"+strings.Join(wikiCells(headers), "||")+"||")
+	}
+	for _, row := range rows {
+		lines = append(lines, "|"+strings.Join(wikiCells(row), "|")+"|")
+	}
+	d.block(strings.Join(lines, "\n"))
+}
+
+// wikiCells escapes the cell separators and line breaks of table cells, and fills empty cells,
+// which wiki markup would merge
+func wikiCells(values []string) []string {
+	cells := make([]string, len(values))
+	for i, value := range values {
+		value = strings.ReplaceAll(value, "|", "\\|")
+		value = strings.ReplaceAll(value, "\n", " \\\\ ")
+		if value == "" {
+			value = " "
+		}
+		cells[i] = value
+	}
+	return cells
+}
+
+func (d *wikiDocument) list(items []string, ordered bool) {
+	if len(items) == 0 {
+		return
+	}
+	marker := "*"
+	if ordered {
+		marker = "#"
+	}
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = marker + " " + item
+	}
+	d.block(strings.Join(lines, "\n"))
+}
+
+func (d *wikiDocument) code(text string) {
+	d.block("{noformat}\n" + text + "\n{noformat}")
+}
+
+func (d *wikiDocument) links(links []issuepkg.Link) {
+	lines := make([]string, 0, len(links))
+	for _, link := range links {
+		if link.URL == "" {
+			continue
+		}
+		if link.Text == "" {
+			lines = append(lines, fmt.Sprintf("* [%s]", link.URL))
+			continue
+		}
+		text := strings.NewReplacer("|", " ", "[", "(", "]", ")").Replace(link.Text)
+		lines = append(lines, fmt.Sprintf("* [%s|%s]", text, link.URL))
+	}
+	if len(lines) > 0 {
+		d.block(strings.Join(lines, "\n"))
+	}
+}
+
+func (d *wikiDocument) rule() {
+	d.block("----")
+}
+
+func (d *wikiDocument) body() interface{} {
+	return d.builder.String()
+}
@@ -0,0 +1,121 @@
+package mattermost
+
+import (
+	"regexp"
+	"strings"
+
+	slackapi "github.com/slack-go/slack"
+
+	"github.com/kubecano/cano-collector/pkg/sender"
+)
+
+var (
+	// Slack links are written <url|text> or <url>
+	slackLinkWithText = regexp.MustCompile(`<([^|<>\s]+)\|([^<>]+)>`)
+	slackLink         = regexp.MustCompile(`<((?:https?|mailto):[^|<>\s]+)>`)
+	// Slack marks bold text with single asterisks and struck text with single tildes
+	slackBold   = regexp.MustCompile(`(^|[^\w*])\*([^*\n]+)\*`)
+	slackStrike = regexp.MustCompile(`(^|[^\w~])~([^~\n]+)~`)
+)
+
+// blocksToMarkdown renders Slack blocks as Mattermost markdown. Mattermost shows no blocks, so
+// headers, sections, context and buttons become paragraphs of the message.
+func blocksToMarkdown(blocks []slackapi.Block) string {
+	var parts []string
+	for _, block := range blocks {
+		if text := blockToMarkdown(block); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func blockToMarkdown(block slackapi.Block) string {
+	switch b := block.(type) {
+	case *slackapi.HeaderBlock:
+		if b.Text == nil {
+			return ""
+		}
+		return "#### " + b.Text.Text
+	case *slackapi.SectionBlock:
+		var lines []string
+		if b.Text != nil {
+			lines = append(lines, textToMarkdown(b.Text))
+		}
+		for _, field := range b.Fields {
+			lines = append(lines, textToMarkdown(field))
+		}
+		return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	case *slackapi.ContextBlock:
+		var elements []string
+		for _, element := range b.ContextElements.Elements {
+			if text, ok := element.(*slackapi.TextBlockObject); ok {
+				elements = append(elements, textToMarkdown(text))
+			}
+		}
+		return strings.Join(elements, " · ")
+	case *slackapi.ActionBlock:
+		if b.Elements == nil {
+			return ""
+		}
+		var links []string
+		for _, element := range b.Elements.ElementSet {
+			if button, ok := element.(*slackapi.ButtonBlockElement); ok && button.URL != "" && button.Text != nil {
+				links = append(links, "["+button.Text.Text+"]("+button.URL+")")
+			}
+		}
+		return strings.Join(links, " · ")
+	case *slackapi.ImageBlock:
+		return "![" + b.AltText + "](" + b.ImageURL + ")"
+	case *slackapi.DividerBlock:
+		return "---"
+	default:
+		return ""
+	}
+}
+
+func textToMarkdown(text *slackapi.TextBlockObject) string {
+	if text.Type == slackapi.MarkdownType {
+		return mrkdwnToMarkdown(text.Text)
+	}
+	return text.Text
+}
+
+// mrkdwnToMarkdown converts the bold, struck and link syntax of Slack to Markdown and puts code
+// blocks on lines of their own, leaving the contents of code untouched
+func mrkdwnToMarkdown(text string) string {
+	var b strings.Builder
+	for i, segment := range sender.SplitCodeBlocks(text) {
+		if !segment.Code {
+			if i > 0 && segment.Text != "" && !strings.HasPrefix(segment.Text, "\n") {
+				b.WriteString("\n")
+			}
+			b.WriteString(convertInline(segment.Text))
+			continue
+		}
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString("```\n" + strings.Trim(segment.Text, "\n") + "\n```")
+	}
+	return b.String()
+}
+
+// convertInline converts the text outside of inline code
+func convertInline(text string) string {
+	parts := strings.Split(text, "`")
+	if len(parts)%2 == 0 {
+		return convertFormatting(text)
+	}
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = convertFormatting(parts[i])
+	}
+	return strings.Join(parts, "`")
+}
+
+func convertFormatting(text string) string {
+	text = slackLinkWithText.ReplaceAllString(text, "[$2]($1)")
+	text = slackLink.ReplaceAllString(text, "$1")
+	text = slackBold.ReplaceAllString(text, "$1**$2**")
+	return slackStrike.ReplaceAllString(text, "$1~~$2~~")
+}
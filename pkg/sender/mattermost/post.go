@@ -0,0 +1,35 @@
+package mattermost
+
+// Post is a message created through the posts API of Mattermost
+type Post struct {
+	ChannelID string `json:"channel_id"`
+	Message   string `json:"message"`
+	// RootID is the post whose thread the post is a reply in
+	RootID  string   `json:"root_id,omitempty"`
+	FileIDs []string `json:"file_ids,omitempty"`
+	Props   *Props   `json:"props,omitempty"`
+}
+
+// Props carries the Slack-compatible attachments of a post
+type Props struct {
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment is a message attachment shown with a coloured border below the message
+type Attachment struct {
+	Fallback string `json:"fallback,omitempty"`
+	Color    string `json:"color,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+// createdPost is the part of the created post the sender needs
+type createdPost struct {
+	ID string `json:"id"`
+}
+
+// uploadedFiles is the response of a file upload
+type uploadedFiles struct {
+	FileInfos []struct {
+		ID string `json:"id"`
+	} `json:"file_infos"`
+}
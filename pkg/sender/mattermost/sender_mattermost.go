@@ -0,0 +1,271 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	slacksender "github.com/kubecano/cano-collector/pkg/sender/slack"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+// Limits of Mattermost posts
+const (
+	maxMessageChars = 16383
+	// maxFiles is the number of files older servers accept per post
+	maxFiles = 5
+	// maxFileSize is the default upload limit of Mattermost servers
+	maxFileSize = 100 << 20
+)
+
+type SenderMattermost struct {
+	url       string
+	token     string
+	channelID string
+	// renderer renders issues like Slack messages, whose attachments Mattermost understands
+	renderer      *slacksender.SenderSlack
+	threadManager *ThreadManager
+	logger        logger_interfaces.LoggerInterface
+	client        util.HTTPClient
+	retryPolicy   sender.RetryPolicy
+}
+
+func NewSenderMattermost(url, token, channelID string, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderMattermost {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	return &SenderMattermost{
+		url:         strings.TrimRight(url, "/"),
+		token:       token,
+		channelID:   channelID,
+		renderer:    slacksender.NewSenderSlack("", channelID, false, logger, nil),
+		logger:      logger,
+		client:      client,
+		retryPolicy: sender.DefaultRetryPolicy,
+	}
+}
+
+// Send posts the issue to the channel with the files of its enrichments attached. With threading
+// enabled, resolved issues are posted as replies to the post of the firing issue.
+func (s *SenderMattermost) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	s.logger.Info("Sending Mattermost notification",
+		zap.String("channel", s.channelID),
+		zap.String("status", issue.Status.String()),
+	)
+
+	post := s.BuildPost(issue)
+	if s.threadManager != nil && issue.Status == issuepkg.StatusResolved {
+		post.RootID = s.threadManager.GetRootID(issue.Fingerprint)
+	}
+
+	uploads := groupFiles(sender.FileBlocks(issue))
+	if len(uploads) > 0 {
+		post.FileIDs = s.uploadFiles(ctx, uploads[0])
+		uploads = uploads[1:]
+	}
+
+	postID, err := s.createPost(ctx, post)
+	var httpErr *sender.HTTPError
+	if err != nil && post.RootID != "" && errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusBadRequest {
+		// The firing post was deleted, Mattermost rejects replies to it
+		s.logger.Warn("Failed to reply in thread, posting a new message",
+			zap.String("fingerprint", issue.Fingerprint),
+			zap.Error(err))
+		s.threadManager.InvalidateThread(issue.Fingerprint)
+		post.RootID = ""
+		postID, err = s.createPost(ctx, post)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send Mattermost message: %w", err)
+	}
+
+	if s.threadManager != nil && issue.Status == issuepkg.StatusFiring {
+		s.threadManager.SetRootID(issue.Fingerprint, postID)
+	}
+
+	// Files beyond the limit of a post follow as replies
+	rootID := post.RootID
+	if rootID == "" {
+		rootID = postID
+	}
+	for _, files := range uploads {
+		fileIDs := s.uploadFiles(ctx, files)
+		if len(fileIDs) == 0 {
+			continue
+		}
+		if _, err := s.createPost(ctx, Post{ChannelID: s.channelID, RootID: rootID, FileIDs: fileIDs}); err != nil {
+			return fmt.Errorf("failed to upload files to Mattermost: %w", err)
+		}
+	}
+
+	s.logger.Info("Mattermost message sent successfully",
+		zap.String("channel", s.channelID),
+		zap.String("post_id", postID),
+	)
+	return nil
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderMattermost) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderMattermost) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+// EnableThreading posts resolved issues in the thread of the post of the firing issue, as long as
+// that post is at most cacheTTL old
+func (s *SenderMattermost) EnableThreading(cacheTTL time.Duration) {
+	s.threadManager = NewThreadManager(s.channelID, s.logger, cacheTTL)
+
+	s.logger.Info("Thread management enabled",
+		zap.String("channel", s.channelID),
+		zap.String("cacheTTL", cacheTTL.String()),
+	)
+}
+
+// SetThreadStateStore shares the thread relationships of the thread manager between HA replicas
+func (s *SenderMattermost) SetThreadStateStore(store ha_interfaces.StateStoreInterface) {
+	if s.threadManager != nil {
+		s.threadManager.SetStateStore(store)
+	}
+}
+
+// BuildPost renders the issue like a Slack message: the blocks become the markdown of the post
+// and the attachments keep their colours. Enrichments Slack shows as uploaded files are left out,
+// since their files are attached to the post.
+func (s *SenderMattermost) BuildPost(issue *issuepkg.Issue) Post {
+	rendered := *issue
+	rendered.Enrichments = nil
+	for _, enrichment := range issue.Enrichments {
+		if !isFileEnrichment(enrichment) {
+			rendered.Enrichments = append(rendered.Enrichments, enrichment)
+		}
+	}
+	message := s.renderer.BuildMessage(&rendered)
+
+	post := Post{
+		ChannelID: s.channelID,
+		Message:   sender.TruncateMarkdown(blocksToMarkdown(message.Blocks.BlockSet), maxMessageChars),
+	}
+	var attachments []Attachment
+	for _, attachment := range message.Attachments {
+		text := blocksToMarkdown(attachment.Blocks.BlockSet)
+		if text == "" {
+			continue
+		}
+		attachments = append(attachments, Attachment{Fallback: issue.Title, Color: attachment.Color, Text: text})
+	}
+	if len(attachments) > 0 {
+		post.Props = &Props{Attachments: attachments}
+	}
+	return post
+}
+
+func (s *SenderMattermost) createPost(ctx context.Context, post Post) (string, error) {
+	body, err := s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		return sender.DoJSON(ctx, s.client, http.MethodPost, s.url+"/api/v4/posts", s.header(), post)
+	})
+	if err != nil {
+		return "", err
+	}
+	var created createdPost
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("failed to decode post: %w", err)
+	}
+	return created.ID, nil
+}
+
+// uploadFiles uploads the files to the channel and returns their IDs. A failed upload is logged
+// and leaves the files out, the notification is more important than its logs.
+func (s *SenderMattermost) uploadFiles(ctx context.Context, files []*issuepkg.FileBlock) []string {
+	body, contentType, err := multipartBody(s.channelID, files)
+	if err == nil {
+		header := s.header()
+		header.Set("Content-Type", contentType)
+		body, err = s.retryPolicy.Do(ctx, func() ([]byte, error) {
+			return sender.DoRequest(ctx, s.client, http.MethodPost, s.url+"/api/v4/files", header, body)
+		})
+	}
+	var uploaded uploadedFiles
+	if err == nil {
+		err = json.Unmarshal(body, &uploaded)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to upload files to Mattermost", zap.Int("files", len(files)), zap.Error(err))
+		return nil
+	}
+
+	fileIDs := make([]string, 0, len(uploaded.FileInfos))
+	for _, info := range uploaded.FileInfos {
+		fileIDs = append(fileIDs, info.ID)
+	}
+	return fileIDs
+}
+
+func (s *SenderMattermost) header() http.Header {
+	return http.Header{"Authorization": {"Bearer " + s.token}}
+}
+
+// multipartBody returns the channel followed by the files as files parts, and the content type
+// of the body
+func multipartBody(channelID string, files []*issuepkg.FileBlock) ([]byte, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("channel_id", channelID); err != nil {
+		return nil, "", err
+	}
+	for _, file := range files {
+		part, err := writer.CreateFormFile("files", file.Filename)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(sender.FileTail(file, maxFileSize)); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+// isFileEnrichment reports whether Slack shows the enrichment as uploaded file only
+func isFileEnrichment(enrichment issuepkg.Enrichment) bool {
+	if enrichment.Type == issuepkg.EnrichmentTypeLogs || enrichment.Type == issuepkg.EnrichmentTypeTextFile {
+		return true
+	}
+	for _, block := range enrichment.Blocks {
+		if _, ok := block.(*issuepkg.FileBlock); !ok {
+			return false
+		}
+	}
+	return len(enrichment.Blocks) > 0
+}
+
+// groupFiles packs the files into uploads of at most maxFiles files
+func groupFiles(files []*issuepkg.FileBlock) [][]*issuepkg.FileBlock {
+	var groups [][]*issuepkg.FileBlock
+	for len(files) > maxFiles {
+		groups = append(groups, files[:maxFiles])
+		files = files[maxFiles:]
+	}
+	if len(files) > 0 {
+		groups = append(groups, files)
+	}
+	return groups
+}
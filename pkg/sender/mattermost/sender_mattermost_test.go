@@ -0,0 +1,230 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testURL = "https://mattermost.example.com"
+
+func setupSender(t *testing.T) (*SenderMattermost, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	s := NewSenderMattermost(testURL+"/", "bot-token", "channel-1", logger, client)
+	s.retryPolicy = sendertest.RetryPolicy
+	return s, client
+}
+
+func decodePost(t *testing.T, req *http.Request) Post {
+	t.Helper()
+	var post Post
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&post))
+	return post
+}
+
+func TestSenderMattermost_BuildPost(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"label", "value"}, [][]string{{"alertname", "KubePodCrashLooping"}}, "", issuepkg.TableBlockFormatVertical),
+	}, issuepkg.EnrichmentTypeAlertLabels, "Alert labels")
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	post := s.BuildPost(issue)
+	assert.Equal(t, "channel-1", post.ChannelID)
+	assert.Contains(t, post.Message, "#### 🔥 Alert firing 🔥")
+	assert.Contains(t, post.Message, "**Pod is crash looping**")
+	assert.Contains(t, post.Message, "Cluster: `prod`")
+	assert.Contains(t, post.Message, "```\nPod payments/api-0 is restarting\n```")
+	assert.NotContains(t, post.Message, "Logs")
+
+	require.NotNil(t, post.Props)
+	require.Len(t, post.Props.Attachments, 2)
+	labels := post.Props.Attachments[0]
+	assert.Equal(t, "#EF311F", labels.Color)
+	assert.Equal(t, "Pod is crash looping", labels.Fallback)
+	assert.Equal(t, "**Alert labels**\n• alertname: `KubePodCrashLooping`", labels.Text)
+	assert.Equal(t, "#FFCC00", post.Props.Attachments[1].Color)
+	assert.Contains(t, post.Props.Attachments[1].Text, "🌐 **Cluster:** `prod`")
+
+	issue.Status = issuepkg.StatusResolved
+	post = s.BuildPost(issue)
+	assert.Contains(t, post.Message, "Alert resolved")
+	assert.Equal(t, "#00B302", post.Props.Attachments[0].Color)
+}
+
+func TestMrkdwnToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		mrkdwn   string
+		markdown string
+	}{
+		{name: "bold", mrkdwn: "*Title* and *more*", markdown: "**Title** and **more**"},
+		{name: "struck", mrkdwn: "~gone~", markdown: "~~gone~~"},
+		{name: "link with text", mrkdwn: "<https://grafana/d/1|Dashboard>", markdown: "[Dashboard](https://grafana/d/1)"},
+		{name: "link", mrkdwn: "see <https://grafana/d/1>", markdown: "see https://grafana/d/1"},
+		{name: "inline code untouched", mrkdwn: "`*pod*` is *down*", markdown: "`*pod*` is **down**"},
+		{name: "code block on own lines", mrkdwn: "*Log*```a *b*```done", markdown: "**Log**\n```\na *b*\n```\ndone"},
+		{name: "unclosed code", mrkdwn: "``` *x*", markdown: "``` **x**"},
+		{name: "multiplication", mrkdwn: "2*3*4", markdown: "2*3*4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.markdown, mrkdwnToMarkdown(tt.mrkdwn))
+		})
+	}
+}
+
+func TestSenderMattermost_Send(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, testURL+"/api/v4/posts", req.URL.String())
+		assert.Equal(t, "Bearer bot-token", req.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		post := decodePost(t, req)
+		assert.Equal(t, "channel-1", post.ChannelID)
+		assert.Empty(t, post.RootID)
+		assert.Empty(t, post.FileIDs)
+		return sendertest.Response(http.StatusCreated, `{"id":"post-1"}`), nil
+	}).Times(1)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderMattermost_Send_UploadsFiles(t *testing.T) {
+	s, mockClient := setupSender(t)
+	issue := sendertest.NewIssue()
+	var blocks []issuepkg.BaseBlock
+	for i := 0; i < maxFiles+1; i++ {
+		blocks = append(blocks, issuepkg.NewFileBlock(fmt.Sprintf("%d.log", i), []byte("line"), "text/plain"))
+	}
+	issue.AddEnrichmentWithType(blocks, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, testURL+"/api/v4/files", req.URL.String())
+			assert.Equal(t, "Bearer bot-token", req.Header.Get("Authorization"))
+			require.NoError(t, req.ParseMultipartForm(1<<20))
+			assert.Equal(t, "channel-1", req.FormValue("channel_id"))
+			require.Len(t, req.MultipartForm.File["files"], maxFiles)
+			assert.Equal(t, "0.log", req.MultipartForm.File["files"][0].Filename)
+			return sendertest.Response(http.StatusCreated, `{"file_infos":[{"id":"file-1"},{"id":"file-2"},{"id":"file-3"},{"id":"file-4"},{"id":"file-5"}]}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			post := decodePost(t, req)
+			assert.Equal(t, []string{"file-1", "file-2", "file-3", "file-4", "file-5"}, post.FileIDs)
+			return sendertest.Response(http.StatusCreated, `{"id":"post-1"}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			require.NoError(t, req.ParseMultipartForm(1<<20))
+			require.Len(t, req.MultipartForm.File["files"], 1)
+			return sendertest.Response(http.StatusCreated, `{"file_infos":[{"id":"file-6"}]}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			post := decodePost(t, req)
+			assert.Equal(t, "post-1", post.RootID)
+			assert.Equal(t, []string{"file-6"}, post.FileIDs)
+			assert.Empty(t, post.Message)
+			return sendertest.Response(http.StatusCreated, `{"id":"post-2"}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderMattermost_Send_FailedUploadStillPosts(t *testing.T) {
+	s, mockClient := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusRequestEntityTooLarge, ""), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Empty(t, decodePost(t, req).FileIDs)
+			return sendertest.Response(http.StatusCreated, `{"id":"post-1"}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderMattermost_Send_Threading(t *testing.T) {
+	s, mockClient := setupSender(t)
+	s.EnableThreading(time.Hour)
+	issue := sendertest.NewIssue()
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Empty(t, decodePost(t, req).RootID)
+			return sendertest.Response(http.StatusCreated, `{"id":"post-1"}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "post-1", decodePost(t, req).RootID)
+			return sendertest.Response(http.StatusCreated, `{"id":"post-2"}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+	issue.Status = issuepkg.StatusResolved
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderMattermost_Send_ThreadOfDeletedPost(t *testing.T) {
+	s, mockClient := setupSender(t)
+	s.EnableThreading(time.Hour)
+	s.threadManager.SetRootID("fingerprint-1", "deleted-post")
+	issue := sendertest.NewIssue()
+	issue.Status = issuepkg.StatusResolved
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "deleted-post", decodePost(t, req).RootID)
+			return sendertest.Response(http.StatusBadRequest, `{"id":"api.post.create_post.root_id.app_error"}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Empty(t, decodePost(t, req).RootID)
+			return sendertest.Response(http.StatusCreated, `{"id":"post-2"}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+	assert.Empty(t, s.threadManager.GetRootID("fingerprint-1"))
+}
+
+func TestSenderMattermost_Send_RetriesRateLimit(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	sendertest.RetriesRateLimit(t, mockClient, sendertest.Response(http.StatusCreated, `{"id":"post-1"}`), func() error {
+		return s.Send(context.Background(), sendertest.NewIssue())
+	})
+}
+
+func TestSenderMattermost_Send_Error(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusForbidden, `{"message":"You do not have the appropriate permissions."}`), nil).Times(1)
+
+	err := s.Send(context.Background(), sendertest.NewIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send Mattermost message: unexpected status 403")
+	assert.NotContains(t, err.Error(), "bot-token")
+}
@@ -0,0 +1,62 @@
+package mattermost
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+)
+
+// ThreadManager remembers the post of every firing issue, so the notification of its resolution
+// is posted as a reply in the thread of that post. Unlike Slack, Mattermost cannot be searched
+// for the fingerprint without a team, so posts older than the TTL start new threads.
+type ThreadManager struct {
+	logger logger_interfaces.LoggerInterface
+	// cache holds the root posts by fingerprint, shared between HA replicas with a state store
+	cache    *sender.IDCache
+	cacheTTL time.Duration
+}
+
+func NewThreadManager(channelID string, logger logger_interfaces.LoggerInterface, cacheTTL time.Duration) *ThreadManager {
+	return &ThreadManager{
+		logger:   logger,
+		cache:    sender.NewIDCache("mattermost-thread/"+channelID+"/", cacheTTL),
+		cacheTTL: cacheTTL,
+	}
+}
+
+// GetRootID returns the post of the firing issue with the given fingerprint, or an empty string
+// when there is none or it is older than the TTL
+func (tm *ThreadManager) GetRootID(fingerprint string) string {
+	if rootID, found := tm.cache.Cached(fingerprint); found {
+		return rootID
+	}
+
+	// A thread started by another replica before a leader failover
+	if rootID, found := tm.cache.Get(fingerprint); found {
+		tm.logger.Debug("Thread found in shared state", zap.String("fingerprint", fingerprint), zap.String("rootID", rootID))
+		return rootID
+	}
+
+	return ""
+}
+
+// SetRootID remembers the post of a firing issue
+func (tm *ThreadManager) SetRootID(fingerprint, rootID string) {
+	tm.cache.Set(fingerprint, rootID)
+	tm.logger.Debug("Thread cached", zap.String("fingerprint", fingerprint), zap.String("rootID", rootID))
+}
+
+// InvalidateThread forgets the post of the issue with the given fingerprint, e.g. after it was deleted
+func (tm *ThreadManager) InvalidateThread(fingerprint string) {
+	tm.cache.Delete(fingerprint)
+	tm.logger.Debug("Thread invalidated", zap.String("fingerprint", fingerprint))
+}
+
+// SetStateStore shares thread relationships with other replicas, so threads continue after a leader failover
+func (tm *ThreadManager) SetStateStore(store ha_interfaces.StateStoreInterface) {
+	tm.cache.SetStateStore(store, tm.cacheTTL)
+}
@@ -0,0 +1,52 @@
+package mattermost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/ha"
+)
+
+func newTestThreadManager(t *testing.T, ttl time.Duration) *ThreadManager {
+	t.Helper()
+	mockLogger := mocks.NewMockLoggerInterface(gomock.NewController(t))
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	return NewThreadManager("channel-1", mockLogger, ttl)
+}
+
+func TestThreadManager_RootID(t *testing.T) {
+	tm := newTestThreadManager(t, time.Hour)
+
+	assert.Empty(t, tm.GetRootID("fingerprint-1"))
+	tm.SetRootID("fingerprint-1", "post-1")
+	assert.Equal(t, "post-1", tm.GetRootID("fingerprint-1"))
+
+	tm.InvalidateThread("fingerprint-1")
+	assert.Empty(t, tm.GetRootID("fingerprint-1"))
+}
+
+func TestThreadManager_Expires(t *testing.T) {
+	tm := newTestThreadManager(t, time.Millisecond)
+
+	tm.SetRootID("fingerprint-1", "post-1")
+	time.Sleep(2 * time.Millisecond)
+	assert.Empty(t, tm.GetRootID("fingerprint-1"))
+
+	tm.SetRootID("fingerprint-2", "post-2")
+	assert.Equal(t, 1, tm.cache.Len())
+}
+
+func TestThreadManager_SharedState(t *testing.T) {
+	store := ha.NewMemoryStateStore()
+	leader := newTestThreadManager(t, time.Hour)
+	leader.SetStateStore(store)
+	leader.SetRootID("fingerprint-1", "post-1")
+
+	follower := newTestThreadManager(t, time.Hour)
+	follower.SetStateStore(store)
+	assert.Equal(t, "post-1", follower.GetRootID("fingerprint-1"))
+}
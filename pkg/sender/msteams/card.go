@@ -0,0 +1,94 @@
+package msteams
+
+// Message is the payload posted to a Teams incoming webhook or Workflows URL
+type Message struct {
+	Type        string       `json:"type"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// Attachment carries an Adaptive Card in a Message
+type Attachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard is the card shown in the channel
+type AdaptiveCard struct {
+	Schema  string          `json:"$schema"`
+	Type    string          `json:"type"`
+	Version string          `json:"version"`
+	MSTeams *CardProperties `json:"msteams,omitempty"`
+	Body    []Element       `json:"body"`
+	Actions []Action        `json:"actions,omitempty"`
+}
+
+// CardProperties are the Teams specific properties of a card
+type CardProperties struct {
+	Width string `json:"width,omitempty"`
+}
+
+// Element is an element of a card body. Only the fields of its type are set.
+type Element struct {
+	Type string `json:"type"`
+
+	// TextBlock
+	Text     string `json:"text,omitempty"`
+	Size     string `json:"size,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+	Color    string `json:"color,omitempty"`
+	FontType string `json:"fontType,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+	Wrap     bool   `json:"wrap,omitempty"`
+
+	// Container, TableCell
+	Style string    `json:"style,omitempty"`
+	Bleed bool      `json:"bleed,omitempty"`
+	Items []Element `json:"items,omitempty"`
+
+	// FactSet
+	Facts []Fact `json:"facts,omitempty"`
+
+	// Image
+	URL     string `json:"url,omitempty"`
+	AltText string `json:"altText,omitempty"`
+
+	// Table, TableRow
+	Columns          []TableColumn `json:"columns,omitempty"`
+	Rows             []Element     `json:"rows,omitempty"`
+	FirstRowAsHeader *bool         `json:"firstRowAsHeader,omitempty"`
+	Cells            []Element     `json:"cells,omitempty"`
+
+	Separator bool   `json:"separator,omitempty"`
+	Spacing   string `json:"spacing,omitempty"`
+}
+
+// Fact is a name and value of a FactSet
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// TableColumn is the definition of a Table column
+type TableColumn struct {
+	Width int `json:"width"`
+}
+
+// Action is a button of a card
+type Action struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func textBlock(text string) Element {
+	return Element{Type: "TextBlock", Text: text, Wrap: true}
+}
+
+func monospaceBlock(text string) Element {
+	return Element{Type: "TextBlock", Text: text, Wrap: true, FontType: "Monospace", Size: "Small"}
+}
+
+func noteBlock(text string) Element {
+	return Element{Type: "TextBlock", Text: text, Wrap: true, IsSubtle: true, Size: "Small"}
+}
@@ -0,0 +1,384 @@
+package msteams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	// adaptiveCardVersion 1.5 is the first version with the Table element
+	adaptiveCardVersion = "1.5"
+
+	// maxPayloadSize is the size limit of messages posted to Teams webhooks
+	maxPayloadSize = 28 * 1024
+	// maxActions is the number of buttons Teams shows on a card
+	maxActions = 6
+	// maxTableRows limits the rows of every table
+	maxTableRows = 20
+	// maxFileLines and maxFileChars limit the file contents embedded in the card
+	maxFileLines = 50
+	maxFileChars = 4000
+)
+
+type SenderMSTeams struct {
+	webhookURL string
+	logger     logger_interfaces.LoggerInterface
+	client     util.HTTPClient
+}
+
+func NewSenderMSTeams(webhookURL string, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderMSTeams {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	return &SenderMSTeams{
+		webhookURL: webhookURL,
+		logger:     logger,
+		client:     client,
+	}
+}
+
+// Send posts the issue to the webhook URL of the sender
+func (s *SenderMSTeams) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	return s.SendTo(ctx, s.webhookURL, issue)
+}
+
+// SendTo posts the issue to webhookURL
+func (s *SenderMSTeams) SendTo(ctx context.Context, webhookURL string, issue *issuepkg.Issue) error {
+	s.logger.Info("Sending MS Teams notification",
+		zap.String("status", issue.Status.String()),
+		zap.String("fingerprint", issue.Fingerprint),
+	)
+
+	if _, err := sender.DoJSON(ctx, s.client, http.MethodPost, webhookURL, nil, s.BuildMessage(issue)); err != nil {
+		return fmt.Errorf("failed to send MS Teams message: %w", err)
+	}
+	return nil
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderMSTeams) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderMSTeams) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+// BuildMessage renders the issue as an Adaptive Card. Enrichments that do not fit into the size
+// limit of Teams messages are left out, starting with the last one.
+func (s *SenderMSTeams) BuildMessage(issue *issuepkg.Issue) Message {
+	card := AdaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		MSTeams: &CardProperties{Width: "Full"},
+		Body:    s.buildHeader(issue),
+	}
+
+	links := append([]issuepkg.Link{}, issue.Links...)
+	var sections [][]Element
+	for _, enrichment := range issue.Enrichments {
+		section, enrichmentLinks := s.convertEnrichment(enrichment)
+		if len(section) > 0 {
+			sections = append(sections, section)
+		}
+		links = append(links, enrichmentLinks...)
+	}
+	card.Actions = buildActions(links)
+	if len(card.Actions) > maxActions {
+		card.Actions = card.Actions[:maxActions]
+	}
+
+	header := card.Body
+	kept := len(sections)
+	for {
+		card.Body = append([]Element{}, header...)
+		for _, section := range sections[:kept] {
+			card.Body = append(card.Body, section...)
+		}
+		if omitted := len(sections) - kept; omitted > 0 {
+			card.Body = append(card.Body, noteBlock(fmt.Sprintf("%d more enrichment(s) left out to stay within the Teams message size limit", omitted)))
+		}
+		if kept == 0 || messageSize(card) <= maxPayloadSize {
+			break
+		}
+		kept--
+	}
+
+	return Message{
+		Type: "message",
+		Attachments: []Attachment{{
+			ContentType: adaptiveCardContentType,
+			Content:     card,
+		}},
+	}
+}
+
+func messageSize(card AdaptiveCard) int {
+	data, err := json.Marshal(card)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// buildHeader returns the severity coloured title, the description and the facts of the issue
+func (s *SenderMSTeams) buildHeader(issue *issuepkg.Issue) []Element {
+	title := Element{Type: "TextBlock", Text: sender.FormatTitle(issue), Size: "Large", Weight: "Bolder", Wrap: true}
+	status := Element{Type: "TextBlock", Text: sender.FormatStatus(issue), Spacing: "None", IsSubtle: true, Wrap: true}
+
+	elements := []Element{{
+		Type:  "Container",
+		Style: containerStyle(issue),
+		Bleed: true,
+		Items: []Element{title, status},
+	}}
+
+	if issue.Description != "" {
+		elements = append(elements, textBlock(issue.Description))
+	}
+	if facts := subjectFacts(issue); len(facts) > 0 {
+		elements = append(elements, Element{Type: "FactSet", Facts: facts})
+	}
+	return elements
+}
+
+// containerStyle colours the header by severity, or green once the issue is resolved
+func containerStyle(issue *issuepkg.Issue) string {
+	if issue.IsResolved() {
+		return "good"
+	}
+	switch issue.Severity {
+	case issuepkg.SeverityHigh:
+		return "attention"
+	case issuepkg.SeverityLow:
+		return "warning"
+	case issuepkg.SeverityInfo:
+		return "accent"
+	default:
+		return "emphasis"
+	}
+}
+
+func subjectFacts(issue *issuepkg.Issue) []Fact {
+	var facts []Fact
+	add := func(title, value string) {
+		if value != "" {
+			facts = append(facts, Fact{Title: title, Value: value})
+		}
+	}
+
+	add("Cluster", issue.ClusterName)
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		add("Subject", fmt.Sprintf("%s %s", strings.ToLower(subject.SubjectType.String()), subject.Name))
+		add("Namespace", subject.Namespace)
+		add("Node", subject.Node)
+		add("Container", subject.Container)
+	}
+	if !issue.StartsAt.IsZero() {
+		add("Started", issue.StartsAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+	if issue.EndsAt != nil && issue.IsResolved() {
+		add("Ended", issue.EndsAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+	return facts
+}
+
+// convertEnrichment returns the card elements of an enrichment and the links of its LinksBlocks,
+// which become buttons of the card
+func (s *SenderMSTeams) convertEnrichment(enrichment issuepkg.Enrichment) ([]Element, []issuepkg.Link) {
+	var elements []Element
+	var links []issuepkg.Link
+
+	for _, block := range enrichment.Blocks {
+		if linksBlock, ok := block.(*issuepkg.LinksBlock); ok {
+			links = append(links, linksBlock.Links...)
+			continue
+		}
+		elements = append(elements, s.convertBlock(block)...)
+	}
+	if len(enrichment.Blocks) == 0 && enrichment.Content != "" {
+		elements = append(elements, textBlock(enrichment.Content))
+	}
+
+	if len(elements) > 0 && enrichment.Title != "" {
+		title := Element{Type: "TextBlock", Text: enrichment.Title, Weight: "Bolder", Wrap: true}
+		elements = append([]Element{title}, elements...)
+	}
+	if len(elements) > 0 {
+		elements[0].Separator = true
+		elements[0].Spacing = "Medium"
+	}
+	return elements, links
+}
+
+func (s *SenderMSTeams) convertBlock(block issuepkg.BaseBlock) []Element {
+	switch b := block.(type) {
+	case *issuepkg.MarkdownBlock:
+		return []Element{textBlock(b.Text)}
+	case *issuepkg.HeaderBlock:
+		return []Element{{Type: "TextBlock", Text: b.Text, Size: "Medium", Weight: "Bolder", Wrap: true}}
+	case *issuepkg.TableBlock:
+		return convertTable(b)
+	case *issuepkg.ListBlock:
+		return []Element{textBlock(b.ToMarkdown())}
+	case *issuepkg.JsonBlock:
+		return []Element{monospaceBlock(sender.Truncate(b.ToJson(), maxFileChars))}
+	case *issuepkg.FileBlock:
+		return convertFile(b)
+	case *issuepkg.ImageBlock:
+		return []Element{{Type: "Image", URL: b.URL, AltText: b.AltText}}
+	case *issuepkg.DividerBlock:
+		return []Element{{Type: "Container", Separator: true}}
+	default:
+		s.logger.Warn("Unsupported block type for MS Teams", zap.String("block_type", block.BlockType()))
+		return nil
+	}
+}
+
+// convertTable renders two column tables as a FactSet and wider tables as a Table. Rows beyond
+// maxTableRows are left out with a note.
+func convertTable(table *issuepkg.TableBlock) []Element {
+	var elements []Element
+	if table.TableName != "" {
+		elements = append(elements, Element{Type: "TextBlock", Text: table.TableName, Weight: "Bolder", Wrap: true})
+	}
+
+	rows := table.Rows
+	if len(rows) > maxTableRows {
+		rows = rows[:maxTableRows]
+	}
+
+	columns := table.GetColumnCount()
+	switch {
+	case columns == 0:
+		return nil
+	case columns <= 2:
+		facts := make([]Fact, 0, len(rows))
+		for _, row := range rows {
+			facts = append(facts, Fact{Title: sender.Cell(row, 0), Value: sender.Cell(row, 1)})
+		}
+		elements = append(elements, Element{Type: "FactSet", Facts: facts})
+	default:
+		element := Element{Type: "Table", Columns: make([]TableColumn, columns)}
+		for i := range element.Columns {
+			element.Columns[i] = TableColumn{Width: 1}
+		}
+		hasHeader := len(table.Headers) > 0
+		element.FirstRowAsHeader = &hasHeader
+		if hasHeader {
+			element.Rows = append(element.Rows, tableRow(table.Headers, columns, "Bolder"))
+		}
+		for _, row := range rows {
+			element.Rows = append(element.Rows, tableRow(row, columns, ""))
+		}
+		elements = append(elements, element)
+	}
+
+	if len(rows) < len(table.Rows) {
+		elements = append(elements, noteBlock(fmt.Sprintf("Showing %d of %d rows", len(rows), len(table.Rows))))
+	}
+	return elements
+}
+
+func tableRow(values []string, columns int, weight string) Element {
+	row := Element{Type: "TableRow", Cells: make([]Element, columns)}
+	for i := range row.Cells {
+		text := Element{Type: "TextBlock", Text: sender.Cell(values, i), Weight: weight, Wrap: true}
+		row.Cells[i] = Element{Type: "TableCell", Items: []Element{text}}
+	}
+	return row
+}
+
+// convertFile embeds the end of a file, since Teams webhooks cannot upload files
+func convertFile(file *issuepkg.FileBlock) []Element {
+	title := fmt.Sprintf("📎 %s (%.1f KB)", file.Filename, file.GetSizeKB())
+	elements := []Element{{Type: "TextBlock", Text: title, Weight: "Bolder", Wrap: true}}
+	if len(file.Contents) == 0 {
+		return append(elements, noteBlock("The file is empty"))
+	}
+
+	content := strings.TrimRight(string(file.Contents), "\n")
+	snippet, shown, total := tail(content, maxFileLines, maxFileChars)
+	elements = append(elements, monospaceBlock(snippet))
+	if len(snippet) < len(content) {
+		elements = append(elements, noteBlock(fmt.Sprintf("Truncated to the last %d of %d lines", shown, total)))
+	}
+	return elements
+}
+
+// tail returns the last complete lines of content that fit into maxLines and maxChars, with the
+// number of lines shown and the total number of lines
+func tail(content string, maxLines, maxChars int) (string, int, int) {
+	lines := strings.Split(content, "\n")
+	total := len(lines)
+
+	start := total - maxLines
+	if start < 0 {
+		start = 0
+	}
+	size := 0
+	for i := total - 1; i >= start; i-- {
+		size += len(lines[i]) + 1
+		if size > maxChars {
+			start = i + 1
+			break
+		}
+	}
+	if start == total {
+		// even the last line is too long, keep its end
+		last := []rune(lines[total-1])
+		if len(last) > maxChars {
+			last = append([]rune("…"), last[len(last)-maxChars+1:]...)
+		}
+		return string(last), 1, total
+	}
+	return strings.Join(lines[start:], "\n"), total - start, total
+}
+
+// buildActions returns an Action.OpenUrl button for every link, sorted by link type so the most
+// useful links come first when the card has too many
+func buildActions(links []issuepkg.Link) []Action {
+	sorted := append([]issuepkg.Link{}, links...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return linkPriority(sorted[i].Type) < linkPriority(sorted[j].Type)
+	})
+
+	actions := make([]Action, 0, len(sorted))
+	for _, link := range sorted {
+		if link.URL == "" {
+			continue
+		}
+		title := link.Text
+		if title == "" {
+			title = link.URL
+		}
+		actions = append(actions, Action{Type: "Action.OpenUrl", Title: title, URL: link.URL})
+	}
+	return actions
+}
+
+func linkPriority(linkType issuepkg.LinkType) int {
+	switch linkType {
+	case issuepkg.LinkTypeInvestigate, issuepkg.LinkTypeRunbook:
+		return 0
+	case issuepkg.LinkTypeSilence:
+		return 2
+	default:
+		return 1
+	}
+}
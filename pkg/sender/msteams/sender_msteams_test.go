@@ -0,0 +1,188 @@
+package msteams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+func setupSender(t *testing.T) (*SenderMSTeams, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	return NewSenderMSTeams("https://example.webhook.office.com/webhookb2/test", logger, client), client
+}
+
+func cardOf(t *testing.T, message Message) AdaptiveCard {
+	t.Helper()
+	require.Len(t, message.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", message.Attachments[0].ContentType)
+	return message.Attachments[0].Content
+}
+
+func TestSenderMSTeams_BuildMessage_Header(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+
+	card := cardOf(t, s.BuildMessage(issue))
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	require.GreaterOrEqual(t, len(card.Body), 3)
+
+	header := card.Body[0]
+	assert.Equal(t, "Container", header.Type)
+	assert.Equal(t, "attention", header.Style)
+	assert.Equal(t, "🔴 Pod is crash looping", header.Items[0].Text)
+	assert.Equal(t, "Firing · High severity · PROMETHEUS", header.Items[1].Text)
+
+	assert.Equal(t, "Pod payments/api-0 is restarting", card.Body[1].Text)
+
+	facts := card.Body[2]
+	assert.Equal(t, "FactSet", facts.Type)
+	assert.Contains(t, facts.Facts, Fact{Title: "Cluster", Value: "prod"})
+	assert.Contains(t, facts.Facts, Fact{Title: "Subject", Value: "pod api-0"})
+	assert.Contains(t, facts.Facts, Fact{Title: "Namespace", Value: "payments"})
+
+	issue.Status = issuepkg.StatusResolved
+	card = cardOf(t, s.BuildMessage(issue))
+	assert.Equal(t, "good", card.Body[0].Style)
+	assert.Equal(t, "✅ Resolved: Pod is crash looping", card.Body[0].Items[0].Text)
+}
+
+func TestSenderMSTeams_BuildMessage_Enrichments(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddLink(issuepkg.Link{Text: "Silence", URL: "https://alertmanager/silence", Type: issuepkg.LinkTypeSilence})
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"label", "value"}, [][]string{{"team", "payments"}}, "Alert labels", issuepkg.TableBlockFormatVertical),
+	}, issuepkg.EnrichmentTypeAlertLabels, "Labels")
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock("*Restarts* increased"),
+		issuepkg.NewTableBlock([]string{"pod", "restarts", "reason"}, [][]string{{"api-0", "5", "OOMKilled"}}, "", issuepkg.TableBlockFormatHorizontal),
+		issuepkg.NewLinksBlock([]issuepkg.Link{{Text: "Investigate", URL: "https://grafana/explore", Type: issuepkg.LinkTypeInvestigate}}, ""),
+	}, issuepkg.EnrichmentTypeGraph, "Restarts")
+
+	card := cardOf(t, s.BuildMessage(issue))
+	body := card.Body[3:]
+	require.Len(t, body, 6)
+
+	assert.Equal(t, "Labels", body[0].Text)
+	assert.True(t, body[0].Separator)
+	assert.Equal(t, "Alert labels", body[1].Text)
+	assert.Equal(t, "FactSet", body[2].Type)
+	assert.Equal(t, []Fact{{Title: "team", Value: "payments"}}, body[2].Facts)
+
+	assert.Equal(t, "Restarts", body[3].Text)
+	assert.Equal(t, "*Restarts* increased", body[4].Text)
+	table := body[5]
+	assert.Equal(t, "Table", table.Type)
+	assert.Len(t, table.Columns, 3)
+	require.Len(t, table.Rows, 2)
+	assert.Equal(t, "restarts", table.Rows[0].Cells[1].Items[0].Text)
+	assert.Equal(t, "OOMKilled", table.Rows[1].Cells[2].Items[0].Text)
+
+	// links of the issue and of LinksBlocks become buttons, the most useful first
+	assert.Equal(t, []Action{
+		{Type: "Action.OpenUrl", Title: "Investigate", URL: "https://grafana/explore"},
+		{Type: "Action.OpenUrl", Title: "Silence", URL: "https://alertmanager/silence"},
+	}, card.Actions)
+}
+
+func TestSenderMSTeams_BuildMessage_LargeTable(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	rows := make([][]string, 30)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("pod-%d", i), "Running"}
+	}
+	issue.AddEnrichmentBlocks([]issuepkg.BaseBlock{issuepkg.NewTableBlock([]string{"pod", "phase"}, rows, "", issuepkg.TableBlockFormatHorizontal)})
+
+	card := cardOf(t, s.BuildMessage(issue))
+	body := card.Body[3:]
+	require.Len(t, body, 2)
+	assert.Len(t, body[0].Facts, maxTableRows)
+	assert.Equal(t, "Showing 20 of 30 rows", body[1].Text)
+}
+
+func TestSenderMSTeams_BuildMessage_TruncatesLogs(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	var logs strings.Builder
+	for i := 1; i <= 200; i++ {
+		fmt.Fprintf(&logs, "line %d\n", i)
+	}
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api-0.log", []byte(logs.String()), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	card := cardOf(t, s.BuildMessage(issue))
+	body := card.Body[3:]
+	require.Len(t, body, 4)
+	assert.Contains(t, body[1].Text, "api-0.log")
+	assert.Equal(t, "Monospace", body[2].FontType)
+	assert.True(t, strings.HasPrefix(body[2].Text, "line 151\n"))
+	assert.True(t, strings.HasSuffix(body[2].Text, "line 200"))
+	assert.Equal(t, "Truncated to the last 50 of 200 lines", body[3].Text)
+}
+
+func TestSenderMSTeams_BuildMessage_TruncatesWithinLimit(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentBlocks([]issuepkg.BaseBlock{
+		issuepkg.NewJsonBlock(map[string]string{"message": strings.Repeat("żółć ", 2000)}),
+		issuepkg.NewFileBlock("api-0.log", []byte(strings.Repeat("🔥", maxFileChars+10)), "text/plain"),
+	})
+
+	body := cardOf(t, s.BuildMessage(issue)).Body[3:]
+	require.Len(t, body, 4)
+	for _, element := range []Element{body[0], body[2]} {
+		assert.Equal(t, "Monospace", element.FontType)
+		assert.True(t, utf8.ValidString(element.Text))
+		assert.LessOrEqual(t, utf8.RuneCountInString(element.Text), maxFileChars)
+		assert.True(t, strings.Contains(element.Text, "…"))
+	}
+}
+
+func TestSenderMSTeams_BuildMessage_SizeLimit(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	for i := 0; i < 20; i++ {
+		issue.AddEnrichmentBlocks([]issuepkg.BaseBlock{issuepkg.NewMarkdownBlock(strings.Repeat("x", 3000))})
+	}
+
+	message := s.BuildMessage(issue)
+	data, err := json.Marshal(message)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(data), maxPayloadSize+100)
+
+	body := cardOf(t, message).Body
+	assert.Contains(t, body[len(body)-1].Text, "more enrichment(s) left out")
+}
+
+func TestSenderMSTeams_Send(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://example.webhook.office.com/webhookb2/test", req.URL.String())
+		var message Message
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&message))
+		assert.Equal(t, "message", message.Type)
+		assert.Equal(t, "1.5", message.Attachments[0].Content.Version)
+		return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody}, nil
+	})
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusBadRequest, "Bad payload received by generic incoming webhook."), nil)
+	err := s.Send(context.Background(), sendertest.NewIssue())
+	assert.EqualError(t, err, "failed to send MS Teams message: unexpected status 400: Bad payload received by generic incoming webhook.")
+}
@@ -0,0 +1,350 @@
+package opsgenie
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+// Limits of the Alert API, longer values are truncated by Opsgenie or rejected
+const (
+	maxMessageLength     = 130
+	maxDescriptionLength = 15000
+	maxNoteLength        = 25000
+	maxEntityLength      = 512
+	maxTags              = 20
+	maxTagLength         = 50
+	maxDetailsLength     = 8000
+	// maxFileLength limits the end of a file that is kept in the note
+	maxFileLength = 4000
+
+	alertSource = "cano-collector"
+)
+
+// Responder is a team, user, escalation or schedule notified of an alert
+type Responder struct {
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// NewResponder returns the responder of the given type, addressing users by username and
+// everything else by name
+func NewResponder(responderType, name string) Responder {
+	if responderType == "user" {
+		return Responder{Type: responderType, Username: name}
+	}
+	return Responder{Type: responderType, Name: name}
+}
+
+// CreateAlertRequest is the payload of the create alert request
+type CreateAlertRequest struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Responders  []Responder       `json:"responders,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Priority    string            `json:"priority,omitempty"`
+	Note        string            `json:"note,omitempty"`
+}
+
+// CloseAlertRequest is the payload of the close alert request
+type CloseAlertRequest struct {
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+type SenderOpsgenie struct {
+	apiKey     string
+	apiURL     string
+	responders []Responder
+	tags       []string
+	// priorities maps "critical" and the lower case severities, like "high", to priorities
+	priorities  map[string]string
+	logger      logger_interfaces.LoggerInterface
+	client      util.HTTPClient
+	retryPolicy sender.RetryPolicy
+}
+
+func NewSenderOpsgenie(apiKey, apiURL string, responders []Responder, tags []string, priorities map[string]string, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderOpsgenie {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	return &SenderOpsgenie{
+		apiKey:      apiKey,
+		apiURL:      strings.TrimSuffix(apiURL, "/"),
+		responders:  responders,
+		tags:        tags,
+		priorities:  priorities,
+		logger:      logger,
+		client:      client,
+		retryPolicy: sender.DefaultRetryPolicy,
+	}
+}
+
+// Send creates an alert for a firing issue and closes it for a resolved one. Both address the
+// alert by the fingerprint of the issue, which is its alias, so Opsgenie counts repeated
+// notifications as occurrences of one alert.
+func (s *SenderOpsgenie) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	if issue.IsResolved() {
+		return s.closeAlert(ctx, issue)
+	}
+	return s.createAlert(ctx, issue)
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderOpsgenie) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderOpsgenie) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+func (s *SenderOpsgenie) createAlert(ctx context.Context, issue *issuepkg.Issue) error {
+	s.logger.Info("Creating Opsgenie alert", zap.String("alias", issue.Fingerprint))
+
+	if err := s.post(ctx, s.apiURL+"/v2/alerts", s.BuildCreateAlertRequest(issue)); err != nil {
+		return fmt.Errorf("failed to create Opsgenie alert: %w", err)
+	}
+	return nil
+}
+
+func (s *SenderOpsgenie) closeAlert(ctx context.Context, issue *issuepkg.Issue) error {
+	s.logger.Info("Closing Opsgenie alert", zap.String("alias", issue.Fingerprint))
+
+	endpoint := fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", s.apiURL, url.PathEscape(issue.Fingerprint))
+	request := CloseAlertRequest{Source: alertSource, Note: "Resolved: " + issue.Title}
+	if err := s.post(ctx, endpoint, request); err != nil {
+		return fmt.Errorf("failed to close Opsgenie alert: %w", err)
+	}
+	return nil
+}
+
+// post sends a request of the Alert API. Opsgenie processes requests asynchronously, so the
+// response only confirms that the request was accepted.
+func (s *SenderOpsgenie) post(ctx context.Context, endpoint string, payload interface{}) error {
+	header := http.Header{"Authorization": []string{"GenieKey " + s.apiKey}}
+	_, err := s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		return sender.DoJSON(ctx, s.client, http.MethodPost, endpoint, header, payload)
+	})
+	return err
+}
+
+// BuildCreateAlertRequest returns the create alert request of the issue
+func (s *SenderOpsgenie) BuildCreateAlertRequest(issue *issuepkg.Issue) CreateAlertRequest {
+	details, note := enrichmentContent(issue)
+	return CreateAlertRequest{
+		Message:     sender.Truncate(issue.Title, maxMessageLength),
+		Alias:       issue.Fingerprint,
+		Description: sender.Truncate(description(issue), maxDescriptionLength),
+		Responders:  s.responders,
+		Tags:        s.alertTags(issue),
+		Details:     details,
+		Entity:      sender.Truncate(entity(issue), maxEntityLength),
+		Source:      alertSource,
+		Priority:    s.MapPriority(issue),
+		Note:        sender.Truncate(note, maxNoteLength),
+	}
+}
+
+// MapPriority returns the Opsgenie priority of an issue. A high issue of an alert labelled
+// severity=critical gets the priority of "critical", any other issue the one of its severity.
+// Without a priority, Opsgenie uses its default, P3.
+func (s *SenderOpsgenie) MapPriority(issue *issuepkg.Issue) string {
+	if issue.Severity == issuepkg.SeverityHigh && isCritical(issue) {
+		if priority, ok := s.priorities["critical"]; ok {
+			return priority
+		}
+	}
+	return s.priorities[strings.ToLower(issue.Severity.String())]
+}
+
+// isCritical reports whether the alert of the issue was labelled critical; the severities of
+// issues end at high
+func isCritical(issue *issuepkg.Issue) bool {
+	return issue.Subject != nil && strings.EqualFold(issue.Subject.Labels["severity"], "critical")
+}
+
+// description returns the description of the issue followed by its links
+func description(issue *issuepkg.Issue) string {
+	lines := make([]string, 0, len(issue.Links)+2)
+	if issue.Description != "" {
+		lines = append(lines, issue.Description)
+	}
+	if len(issue.Links) > 0 {
+		lines = append(lines, "")
+		for _, link := range issue.Links {
+			lines = append(lines, fmt.Sprintf("%s: %s", link.Text, link.URL))
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// entity names the affected resource: the subject, or the cluster when there is none
+func entity(issue *issuepkg.Issue) string {
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		if subject.Namespace != "" {
+			return subject.Namespace + "/" + subject.Name
+		}
+		return subject.Name
+	}
+	return issue.ClusterName
+}
+
+// alertTags returns the tags of the destination, the cluster and the labels of the subject as
+// key:value tags, sorted and limited to what Opsgenie accepts
+func (s *SenderOpsgenie) alertTags(issue *issuepkg.Issue) []string {
+	tags := append([]string{}, s.tags...)
+	if issue.ClusterName != "" {
+		tags = append(tags, "cluster:"+issue.ClusterName)
+	}
+	if subject := issue.Subject; subject != nil {
+		labels := make([]string, 0, len(subject.Labels))
+		for key, value := range subject.Labels {
+			labels = append(labels, key+":"+value)
+		}
+		sort.Strings(labels)
+		tags = append(tags, labels...)
+	}
+
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = sender.Truncate(tag, maxTagLength)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+		if len(result) == maxTags {
+			break
+		}
+	}
+	return result
+}
+
+// enrichmentContent returns the details and the note of the alert. The details contain the
+// cluster, the subject and the two column tables of the enrichments, like the alert labels, as
+// key-value pairs; all other enrichment content is rendered as text into the note.
+func enrichmentContent(issue *issuepkg.Issue) (map[string]string, string) {
+	details := map[string]string{
+		"source":      issue.Source.String(),
+		"fingerprint": issue.Fingerprint,
+	}
+	if issue.ClusterName != "" {
+		details["cluster"] = issue.ClusterName
+	}
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		details["subject"] = subject.Name
+		details["subject_type"] = subject.SubjectType.String()
+		for key, value := range map[string]string{"namespace": subject.Namespace, "node": subject.Node, "container": subject.Container} {
+			if value != "" {
+				details[key] = value
+			}
+		}
+	}
+	size := detailsSize(details)
+
+	var note strings.Builder
+	for _, enrichment := range issue.Enrichments {
+		var text strings.Builder
+		for _, block := range enrichment.Blocks {
+			if table, ok := block.(*issuepkg.TableBlock); ok && table.GetColumnCount() <= 2 {
+				if addDetails(details, table, &size) {
+					continue
+				}
+			}
+			if rendered := renderBlock(block); rendered != "" {
+				text.WriteString(rendered)
+				text.WriteString("\n")
+			}
+		}
+		if text.Len() == 0 {
+			continue
+		}
+		if enrichment.Title != "" {
+			note.WriteString(enrichment.Title + "\n")
+		}
+		note.WriteString(text.String())
+		note.WriteString("\n")
+	}
+	return details, strings.TrimSpace(note.String())
+}
+
+// addDetails adds the rows of a two column table to the details, without overwriting existing
+// keys, and reports whether they fit into the size limit of the details
+func addDetails(details map[string]string, table *issuepkg.TableBlock, size *int) bool {
+	added := 0
+	for _, row := range table.Rows {
+		if len(row) == 0 || row[0] == "" {
+			continue
+		}
+		if _, exists := details[row[0]]; !exists {
+			added += len(row[0]) + len(sender.Cell(row, 1))
+		}
+	}
+	if *size+added > maxDetailsLength {
+		return false
+	}
+	for _, row := range table.Rows {
+		if len(row) == 0 || row[0] == "" {
+			continue
+		}
+		if _, exists := details[row[0]]; !exists {
+			details[row[0]] = sender.Cell(row, 1)
+		}
+	}
+	*size += added
+	return true
+}
+
+func detailsSize(details map[string]string) int {
+	size := 0
+	for key, value := range details {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// renderBlock renders a block as plain text
+func renderBlock(block issuepkg.BaseBlock) string {
+	switch b := block.(type) {
+	case *issuepkg.MarkdownBlock:
+		return b.Text
+	case *issuepkg.HeaderBlock:
+		return b.Text
+	case *issuepkg.TableBlock:
+		return b.ToMarkdown()
+	case *issuepkg.ListBlock:
+		return b.ToMarkdown()
+	case *issuepkg.JsonBlock:
+		return b.ToJson()
+	case *issuepkg.LinksBlock:
+		lines := make([]string, 0, len(b.Links))
+		for _, link := range b.Links {
+			lines = append(lines, fmt.Sprintf("%s: %s", link.Text, link.URL))
+		}
+		return strings.Join(lines, "\n")
+	case *issuepkg.FileBlock:
+		return fmt.Sprintf("%s:\n%s", b.Filename, sender.TruncateStart(string(b.Contents), maxFileLength))
+	case *issuepkg.ImageBlock:
+		return fmt.Sprintf("%s: %s", b.AltText, b.URL)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,176 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testAPIURL = "https://api.eu.opsgenie.com"
+
+var testPriorities = map[string]string{"critical": "P1", "high": "P2", "low": "P3", "info": "P4", "debug": "P5"}
+
+func setupSender(t *testing.T) (*SenderOpsgenie, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	responders := []Responder{NewResponder("team", "sre"), NewResponder("user", "jane@example.com")}
+	s := NewSenderOpsgenie("api-key", testAPIURL+"/", responders, []string{"k8s"}, testPriorities, logger, client)
+	s.retryPolicy = sendertest.RetryPolicy
+	return s, client
+}
+
+// newTestIssue returns an issue whose subject has the labels that tags are made of
+func newTestIssue() *issuepkg.Issue {
+	issue := sendertest.NewIssue()
+	issue.Subject.Labels["team"] = "payments"
+	issue.Subject.Labels["app"] = "api"
+	return issue
+}
+
+func accepted() *http.Response {
+	return sendertest.Response(http.StatusAccepted, `{"result":"Request will be processed","requestId":"43a29c5c"}`)
+}
+
+func TestSenderOpsgenie_BuildCreateAlertRequest(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := newTestIssue()
+	issue.AddLink(issuepkg.Link{Text: "Runbook", URL: "https://runbooks/crashloop"})
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"label", "value"}, [][]string{{"alertname", "KubePodCrashLooping"}, {"cluster", "other"}}, "", issuepkg.TableBlockFormatVertical),
+	}, issuepkg.EnrichmentTypeAlertLabels, "Alert Labels")
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock("Container api was OOMKilled"),
+		issuepkg.NewFileBlock("api.log", []byte("line 1\nline 2"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	request := s.BuildCreateAlertRequest(issue)
+	assert.Equal(t, "Pod is crash looping", request.Message)
+	assert.Equal(t, "fingerprint-1", request.Alias)
+	assert.Equal(t, "P2", request.Priority)
+	assert.Equal(t, "payments/api-0", request.Entity)
+	assert.Equal(t, "cano-collector", request.Source)
+	assert.Equal(t, "Pod payments/api-0 is restarting\n\nRunbook: https://runbooks/crashloop", request.Description)
+	assert.Equal(t, []Responder{{Type: "team", Name: "sre"}, {Type: "user", Username: "jane@example.com"}}, request.Responders)
+	assert.Equal(t, []string{"k8s", "cluster:prod", "app:api", "team:payments"}, request.Tags)
+
+	assert.Equal(t, "prod", request.Details["cluster"], "labels do not overwrite the details of the issue")
+	assert.Equal(t, "api-0", request.Details["subject"])
+	assert.Equal(t, "payments", request.Details["namespace"])
+	assert.Equal(t, "KubePodCrashLooping", request.Details["alertname"])
+	assert.Equal(t, "Logs\nContainer api was OOMKilled\napi.log:\nline 1\nline 2", request.Note)
+}
+
+func TestRenderBlock_FileTail(t *testing.T) {
+	contents := "log start\n" + strings.Repeat("ż", maxFileLength)
+	rendered := renderBlock(issuepkg.NewFileBlock("api.log", []byte(contents), "text/plain"))
+
+	assert.True(t, utf8.ValidString(rendered))
+	assert.Equal(t, "api.log:\n…"+strings.Repeat("ż", maxFileLength-1), rendered)
+}
+
+func TestSenderOpsgenie_AlertTagsLimit(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := newTestIssue()
+	for i := 0; i < 30; i++ {
+		issue.Subject.Labels[strings.Repeat("x", i+1)] = strings.Repeat("y", 60)
+	}
+
+	tags := s.alertTags(issue)
+	assert.Len(t, tags, maxTags)
+	for _, tag := range tags {
+		assert.LessOrEqual(t, utf8.RuneCountInString(tag), maxTagLength)
+	}
+}
+
+func TestSenderOpsgenie_MapPriority(t *testing.T) {
+	tests := []struct {
+		name       string
+		severity   issuepkg.Severity
+		label      string
+		priorities map[string]string
+		expected   string
+	}{
+		{"critical", issuepkg.SeverityHigh, "critical", testPriorities, "P1"},
+		{"high", issuepkg.SeverityHigh, "error", testPriorities, "P2"},
+		{"low", issuepkg.SeverityLow, "warning", testPriorities, "P3"},
+		{"info", issuepkg.SeverityInfo, "info", testPriorities, "P4"},
+		{"debug", issuepkg.SeverityDebug, "debug", testPriorities, "P5"},
+		{"critical label of a lower severity", issuepkg.SeverityLow, "critical", testPriorities, "P3"},
+		{"critical without mapping", issuepkg.SeverityHigh, "critical", map[string]string{"high": "P2"}, "P2"},
+		{"configured", issuepkg.SeverityLow, "warning", map[string]string{"low": "P2"}, "P2"},
+		{"unmapped uses the default of Opsgenie", issuepkg.SeverityInfo, "info", map[string]string{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := setupSender(t)
+			s.priorities = tt.priorities
+			issue := newTestIssue()
+			issue.Severity = tt.severity
+			issue.Subject.Labels["severity"] = tt.label
+			assert.Equal(t, tt.expected, s.MapPriority(issue))
+		})
+	}
+}
+
+func TestSenderOpsgenie_Send_CreatesAlert(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, testAPIURL+"/v2/alerts", req.URL.String())
+		assert.Equal(t, "GenieKey api-key", req.Header.Get("Authorization"))
+		var request CreateAlertRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+		assert.Equal(t, "fingerprint-1", request.Alias)
+		return accepted(), nil
+	})
+
+	require.NoError(t, s.Send(context.Background(), newTestIssue()))
+}
+
+func TestSenderOpsgenie_Send_ClosesResolvedAlert(t *testing.T) {
+	s, mockClient := setupSender(t)
+	issue := newTestIssue()
+	issue.Status = issuepkg.StatusResolved
+	issue.SetFingerprint("fingerprint/1")
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, testAPIURL+"/v2/alerts/fingerprint%2F1/close?identifierType=alias", req.URL.String())
+		var request CloseAlertRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+		assert.Equal(t, "cano-collector", request.Source)
+		return accepted(), nil
+	})
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderOpsgenie_Send_RetriesRateLimitedRequests(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	sendertest.RetriesRateLimit(t, mockClient, accepted(), func() error {
+		return s.Send(context.Background(), newTestIssue())
+	})
+}
+
+func TestSenderOpsgenie_Send_Unauthorized(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusUnauthorized, `{"message":"Key format is not valid!"}`), nil).Times(1)
+
+	err := s.Send(context.Background(), newTestIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create Opsgenie alert: unexpected status 401")
+	assert.NotContains(t, err.Error(), "api-key")
+}
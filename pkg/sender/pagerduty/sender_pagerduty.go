@@ -0,0 +1,263 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+const (
+	EventActionTrigger = "trigger"
+	EventActionResolve = "resolve"
+
+	// maxSummaryLength is the longest summary PagerDuty accepts
+	maxSummaryLength = 1024
+	eventClient      = "cano-collector"
+)
+
+// Event is an alert event of the Events API v2
+type Event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key,omitempty"`
+	Payload     *EventPayload `json:"payload,omitempty"`
+	Client      string        `json:"client,omitempty"`
+	Links       []EventLink   `json:"links,omitempty"`
+}
+
+// EventPayload describes the alert of a trigger event
+type EventPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	Component     string                 `json:"component,omitempty"`
+	Group         string                 `json:"group,omitempty"`
+	Class         string                 `json:"class,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// EventLink is a link shown on the incident
+type EventLink struct {
+	Href string `json:"href"`
+	Text string `json:"text,omitempty"`
+}
+
+type SenderPagerDuty struct {
+	routingKey  string
+	eventsURL   string
+	logger      logger_interfaces.LoggerInterface
+	client      util.HTTPClient
+	retryPolicy sender.RetryPolicy
+}
+
+func NewSenderPagerDuty(routingKey, eventsURL string, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderPagerDuty {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	return &SenderPagerDuty{
+		routingKey:  routingKey,
+		eventsURL:   eventsURL,
+		logger:      logger,
+		client:      client,
+		retryPolicy: sender.DefaultRetryPolicy,
+	}
+}
+
+// Send triggers an alert for a firing issue and resolves it for a resolved one. Both use the
+// fingerprint of the issue as dedup_key, so repeated notifications update the same alert.
+func (s *SenderPagerDuty) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	event := s.BuildEvent(issue)
+	s.logger.Info("Sending PagerDuty event",
+		zap.String("event_action", event.EventAction),
+		zap.String("dedup_key", event.DedupKey),
+	)
+
+	_, err := s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		return sender.DoJSON(ctx, s.client, http.MethodPost, s.eventsURL, nil, event)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send PagerDuty %s event: %w", event.EventAction, err)
+	}
+	return nil
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderPagerDuty) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderPagerDuty) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+// BuildEvent returns the trigger or resolve event of the issue
+func (s *SenderPagerDuty) BuildEvent(issue *issuepkg.Issue) Event {
+	event := Event{
+		RoutingKey: s.routingKey,
+		DedupKey:   issue.Fingerprint,
+		Client:     eventClient,
+	}
+	if issue.IsResolved() {
+		event.EventAction = EventActionResolve
+		return event
+	}
+
+	event.EventAction = EventActionTrigger
+	event.Payload = &EventPayload{
+		Summary:       sender.Truncate(issue.Title, maxSummaryLength),
+		Source:        eventSource(issue),
+		Severity:      MapSeverity(issue.Severity),
+		Class:         issue.AggregationKey,
+		CustomDetails: customDetails(issue),
+	}
+	if !issue.StartsAt.IsZero() {
+		event.Payload.Timestamp = issue.StartsAt.UTC().Format(time.RFC3339)
+	}
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		event.Payload.Component = subject.Name
+		event.Payload.Group = subject.Namespace
+	}
+	event.Links = eventLinks(issue)
+	return event
+}
+
+// MapSeverity maps the severity of an issue to a PagerDuty severity
+func MapSeverity(severity issuepkg.Severity) string {
+	switch severity {
+	case issuepkg.SeverityHigh:
+		return "critical"
+	case issuepkg.SeverityLow:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// eventSource names the affected system: the subject, or the cluster when there is none
+func eventSource(issue *issuepkg.Issue) string {
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		source := subject.Name
+		if subject.Namespace != "" {
+			source = subject.Namespace + "/" + source
+		}
+		if issue.ClusterName != "" {
+			source = issue.ClusterName + "/" + source
+		}
+		return source
+	}
+	if issue.ClusterName != "" {
+		return issue.ClusterName
+	}
+	return eventClient
+}
+
+// customDetails contains the description, the subject, the cluster and the tables of the
+// enrichments, which PagerDuty shows as nested key-value pairs
+func customDetails(issue *issuepkg.Issue) map[string]interface{} {
+	details := map[string]interface{}{
+		"source":      issue.Source.String(),
+		"fingerprint": issue.Fingerprint,
+	}
+	if issue.Description != "" {
+		details["description"] = issue.Description
+	}
+	if issue.ClusterName != "" {
+		details["cluster"] = issue.ClusterName
+	}
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		subjectDetails := map[string]interface{}{
+			"name": subject.Name,
+			"type": subject.SubjectType.String(),
+		}
+		for key, value := range map[string]string{"namespace": subject.Namespace, "node": subject.Node, "container": subject.Container} {
+			if value != "" {
+				subjectDetails[key] = value
+			}
+		}
+		details["subject"] = subjectDetails
+	}
+
+	for i, enrichment := range issue.Enrichments {
+		for j, block := range enrichment.Blocks {
+			table, ok := block.(*issuepkg.TableBlock)
+			if !ok || len(table.Rows) == 0 {
+				continue
+			}
+			key := tableKey(table, enrichment, i, j)
+			for n := 2; details[key] != nil; n++ {
+				key = fmt.Sprintf("%s (%d)", tableKey(table, enrichment, i, j), n)
+			}
+			details[key] = flattenTable(table)
+		}
+	}
+	return details
+}
+
+func tableKey(table *issuepkg.TableBlock, enrichment issuepkg.Enrichment, enrichmentIndex, blockIndex int) string {
+	switch {
+	case table.TableName != "":
+		return table.TableName
+	case enrichment.Title != "":
+		return enrichment.Title
+	default:
+		return fmt.Sprintf("enrichment %d table %d", enrichmentIndex+1, blockIndex+1)
+	}
+}
+
+// flattenTable returns two column tables as a map of the first column to the second and wider
+// tables as a list of rows keyed by the headers
+func flattenTable(table *issuepkg.TableBlock) interface{} {
+	if table.GetColumnCount() <= 2 {
+		values := make(map[string]string, len(table.Rows))
+		for _, row := range table.Rows {
+			if len(row) > 0 {
+				values[row[0]] = sender.Cell(row, 1)
+			}
+		}
+		return values
+	}
+
+	rows := make([]map[string]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		values := make(map[string]string, len(row))
+		for i, value := range row {
+			header := sender.Cell(table.Headers, i)
+			if header == "" {
+				header = fmt.Sprintf("column %d", i+1)
+			}
+			values[header] = value
+		}
+		rows = append(rows, values)
+	}
+	return rows
+}
+
+// eventLinks returns the links of the issue and of the LinksBlocks of its enrichments
+func eventLinks(issue *issuepkg.Issue) []EventLink {
+	links := append([]issuepkg.Link{}, issue.Links...)
+	for _, enrichment := range issue.Enrichments {
+		for _, block := range enrichment.Blocks {
+			if linksBlock, ok := block.(*issuepkg.LinksBlock); ok {
+				links = append(links, linksBlock.Links...)
+			}
+		}
+	}
+
+	eventLinks := make([]EventLink, 0, len(links))
+	for _, link := range links {
+		if link.URL != "" {
+			eventLinks = append(eventLinks, EventLink{Href: link.URL, Text: link.Text})
+		}
+	}
+	return eventLinks
+}
@@ -0,0 +1,119 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+func setupSender(t *testing.T) (*SenderPagerDuty, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	s := NewSenderPagerDuty("routing-key", testEventsURL, logger, client)
+	s.retryPolicy = sendertest.RetryPolicy
+	return s, client
+}
+
+// newTestIssue returns an issue that started at a fixed time, which events carry as timestamp
+func newTestIssue() *issuepkg.Issue {
+	issue := sendertest.NewIssue()
+	issue.StartsAt = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	return issue
+}
+
+func TestSenderPagerDuty_BuildEvent_Trigger(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := newTestIssue()
+	issue.AddLink(issuepkg.Link{Text: "Runbook", URL: "https://runbooks/crashloop", Type: issuepkg.LinkTypeRunbook})
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"label", "value"}, [][]string{{"team", "payments"}, {"severity", "critical"}}, "Alert labels", issuepkg.TableBlockFormatVertical),
+	}, issuepkg.EnrichmentTypeAlertLabels, "Labels")
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"container", "restarts", "reason"}, [][]string{{"api", "5", "OOMKilled"}}, "", issuepkg.TableBlockFormatHorizontal),
+		issuepkg.NewLinksBlock([]issuepkg.Link{{Text: "Logs", URL: "https://grafana/logs"}}, ""),
+	}, issuepkg.EnrichmentTypeContainerInfo, "Containers")
+
+	event := s.BuildEvent(issue)
+	assert.Equal(t, "routing-key", event.RoutingKey)
+	assert.Equal(t, EventActionTrigger, event.EventAction)
+	assert.Equal(t, "fingerprint-1", event.DedupKey)
+
+	require.NotNil(t, event.Payload)
+	assert.Equal(t, "Pod is crash looping", event.Payload.Summary)
+	assert.Equal(t, "prod/payments/api-0", event.Payload.Source)
+	assert.Equal(t, "critical", event.Payload.Severity)
+	assert.Equal(t, "2025-05-01T12:00:00Z", event.Payload.Timestamp)
+	assert.Equal(t, "api-0", event.Payload.Component)
+	assert.Equal(t, "payments", event.Payload.Group)
+	assert.Equal(t, "KubePodCrashLooping", event.Payload.Class)
+
+	details := event.Payload.CustomDetails
+	assert.Equal(t, "prod", details["cluster"])
+	assert.Equal(t, "Pod payments/api-0 is restarting", details["description"])
+	assert.Equal(t, map[string]interface{}{"name": "api-0", "type": "POD", "namespace": "payments"}, details["subject"])
+	assert.Equal(t, map[string]string{"team": "payments", "severity": "critical"}, details["Alert labels"])
+	assert.Equal(t, []map[string]string{{"container": "api", "restarts": "5", "reason": "OOMKilled"}}, details["Containers"])
+
+	assert.Equal(t, []EventLink{
+		{Href: "https://runbooks/crashloop", Text: "Runbook"},
+		{Href: "https://grafana/logs", Text: "Logs"},
+	}, event.Links)
+}
+
+func TestSenderPagerDuty_BuildEvent_Resolve(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := newTestIssue()
+	issue.Status = issuepkg.StatusResolved
+
+	event := s.BuildEvent(issue)
+	assert.Equal(t, EventActionResolve, event.EventAction)
+	assert.Equal(t, "fingerprint-1", event.DedupKey)
+	assert.Nil(t, event.Payload)
+}
+
+func TestMapSeverity(t *testing.T) {
+	assert.Equal(t, "critical", MapSeverity(issuepkg.SeverityHigh))
+	assert.Equal(t, "warning", MapSeverity(issuepkg.SeverityLow))
+	assert.Equal(t, "info", MapSeverity(issuepkg.SeverityInfo))
+	assert.Equal(t, "info", MapSeverity(issuepkg.SeverityDebug))
+}
+
+func TestSenderPagerDuty_Send_RetriesRateLimitedEvents(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusTooManyRequests, `{"status":"throttle event"}`), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, testEventsURL, req.URL.String())
+			var event Event
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&event))
+			assert.Equal(t, EventActionTrigger, event.EventAction)
+			return sendertest.Response(http.StatusAccepted, `{"status":"success","dedup_key":"fingerprint-1"}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), newTestIssue()))
+}
+
+func TestSenderPagerDuty_Send_InvalidEvent(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	// invalid events fail the same way on every attempt, so they are not retried
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusBadRequest, `{"status":"invalid event","message":"Event object is invalid"}`), nil).Times(1)
+
+	err := s.Send(context.Background(), newTestIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send PagerDuty trigger event: unexpected status 400")
+}
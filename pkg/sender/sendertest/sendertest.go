@@ -0,0 +1,63 @@
+// Package sendertest provides the fixtures shared by the tests of the destination senders
+package sendertest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender"
+)
+
+// RetryPolicy retries like sender.DefaultRetryPolicy without slowing the tests down
+var RetryPolicy = sender.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+// NewMocks returns a logger that accepts any message and an HTTP client whose requests the test
+// expects
+func NewMocks(t *testing.T) (*mocks.MockLoggerInterface, *mocks.MockHTTPClient) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	logger := mocks.NewMockLoggerInterface(ctrl)
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	return logger, mocks.NewMockHTTPClient(ctrl)
+}
+
+// NewIssue returns a firing issue of a crash looping pod with the fingerprint "fingerprint-1"
+func NewIssue() *issuepkg.Issue {
+	issue := issuepkg.NewIssue("Pod is crash looping", "KubePodCrashLooping")
+	issue.Description = "Pod payments/api-0 is restarting"
+	issue.Severity = issuepkg.SeverityHigh
+	issue.Source = issuepkg.SourcePrometheus
+	issue.ClusterName = "prod"
+	subject := issuepkg.NewSubject("api-0", issuepkg.SubjectTypePod)
+	subject.Namespace = "payments"
+	issue.SetSubject(subject)
+	issue.SetFingerprint("fingerprint-1")
+	return issue
+}
+
+// Response returns an HTTP response with the status and the body
+func Response(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// RetriesRateLimit checks that send retries a request that is rate limited (429) and succeeds
+// once the request is answered with ok
+func RetriesRateLimit(t *testing.T, client *mocks.MockHTTPClient, ok *http.Response, send func() error) {
+	t.Helper()
+	gomock.InOrder(
+		client.EXPECT().Do(gomock.Any()).Return(Response(http.StatusTooManyRequests, ""), nil),
+		client.EXPECT().Do(gomock.Any()).Return(ok, nil),
+	)
+	require.NoError(t, send())
+}
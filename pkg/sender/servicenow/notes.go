@@ -0,0 +1,94 @@
+package servicenow
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// description returns the description of the issue followed by its facts and links
+func description(issue *issuepkg.Issue) string {
+	var lines []string
+	if issue.Description != "" {
+		lines = append(lines, issue.Description, "")
+	}
+
+	lines = append(lines, "Severity: "+issue.Severity.String())
+	if issue.ClusterName != "" {
+		lines = append(lines, "Cluster: "+issue.ClusterName)
+	}
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		lines = append(lines, fmt.Sprintf("Subject: %s %s", subject.SubjectType.String(), subject.Name))
+		for _, fact := range [][]string{{"Namespace", subject.Namespace}, {"Node", subject.Node}, {"Container", subject.Container}} {
+			if fact[1] != "" {
+				lines = append(lines, fact[0]+": "+fact[1])
+			}
+		}
+	}
+	lines = append(lines, "Source: "+issue.Source.String())
+	if !issue.StartsAt.IsZero() {
+		lines = append(lines, "Started: "+issue.StartsAt.UTC().Format(time.RFC3339))
+	}
+
+	if len(issue.Links) > 0 {
+		lines = append(lines, "")
+		for _, link := range issue.Links {
+			lines = append(lines, fmt.Sprintf("%s: %s", link.Text, link.URL))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// workNotes renders the enrichments of the issue as plain text under their titles
+func workNotes(issue *issuepkg.Issue) string {
+	var notes strings.Builder
+	for _, enrichment := range issue.Enrichments {
+		var text strings.Builder
+		for _, block := range enrichment.Blocks {
+			if rendered := renderBlock(block); rendered != "" {
+				text.WriteString(rendered)
+				text.WriteString("\n")
+			}
+		}
+		if text.Len() == 0 {
+			continue
+		}
+		if enrichment.Title != "" {
+			notes.WriteString(enrichment.Title + "\n")
+		}
+		notes.WriteString(text.String())
+		notes.WriteString("\n")
+	}
+	return strings.TrimSpace(notes.String())
+}
+
+// renderBlock renders a block as plain text. Files are uploaded as attachments, so the notes
+// only name them.
+func renderBlock(block issuepkg.BaseBlock) string {
+	switch b := block.(type) {
+	case *issuepkg.MarkdownBlock:
+		return b.Text
+	case *issuepkg.HeaderBlock:
+		return b.Text
+	case *issuepkg.TableBlock:
+		return b.ToMarkdown()
+	case *issuepkg.ListBlock:
+		return b.ToMarkdown()
+	case *issuepkg.JsonBlock:
+		return b.ToJson()
+	case *issuepkg.LinksBlock:
+		lines := make([]string, 0, len(b.Links))
+		for _, link := range b.Links {
+			lines = append(lines, fmt.Sprintf("%s: %s", link.Text, link.URL))
+		}
+		return strings.Join(lines, "\n")
+	case *issuepkg.FileBlock:
+		return "Attached " + b.Filename
+	case *issuepkg.ImageBlock:
+		return fmt.Sprintf("%s: %s", b.AltText, b.URL)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,373 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+const (
+	// correlationDisplay marks the incidents of the collector next to their correlation_id
+	correlationDisplay = "cano-collector"
+
+	// maxShortDescriptionLength is the size of the short_description column
+	maxShortDescriptionLength = 160
+	// maxWorkNotesLength keeps work notes readable; files are attached instead of inlined
+	maxWorkNotesLength = 32000
+)
+
+// Priority is the impact and urgency of an incident
+type Priority struct {
+	Impact  string
+	Urgency string
+}
+
+// FieldMapping names the fields that receive the cluster, the subject and its namespace
+type FieldMapping struct {
+	Cluster   string
+	Subject   string
+	Namespace string
+}
+
+// Config describes the ServiceNow instance and the incidents the sender creates
+type Config struct {
+	URL      string
+	Username string
+	Password string
+	Table    string
+	// Priorities maps lower case severities, like "high", to impact and urgency
+	Priorities      map[string]Priority
+	FieldMapping    FieldMapping
+	CallerID        string
+	AssignmentGroup string
+	Category        string
+	Subcategory     string
+	CustomFields    map[string]string
+	ResolveState    string
+	CloseCode       string
+}
+
+type SenderServiceNow struct {
+	cfg         Config
+	logger      logger_interfaces.LoggerInterface
+	client      util.HTTPClient
+	retryPolicy sender.RetryPolicy
+}
+
+func NewSenderServiceNow(cfg Config, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderServiceNow {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	return &SenderServiceNow{
+		cfg:         cfg,
+		logger:      logger,
+		client:      client,
+		retryPolicy: sender.DefaultRetryPolicy,
+	}
+}
+
+// Send creates an incident for a firing issue, unless an active incident with its fingerprint
+// as correlation_id exists, which gets a work note instead and only the files it does not have
+// yet. A resolved issue resolves its active incident.
+func (s *SenderServiceNow) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	sysID, number, err := s.findActiveIncident(ctx, issue.Fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to search ServiceNow incidents: %w", err)
+	}
+
+	if issue.IsResolved() {
+		if sysID == "" {
+			s.logger.Info("No active ServiceNow incident for resolved issue", zap.String("fingerprint", issue.Fingerprint))
+			return nil
+		}
+		if err := s.updateIncident(ctx, sysID, s.BuildResolveRequest(issue)); err != nil {
+			return fmt.Errorf("failed to resolve ServiceNow incident %s: %w", number, err)
+		}
+		s.logger.Info("Resolved ServiceNow incident", zap.String("number", number), zap.String("fingerprint", issue.Fingerprint))
+		return nil
+	}
+
+	attached := make(map[string]bool)
+	if sysID == "" {
+		sysID, number, err = s.createIncident(ctx, issue)
+		if err != nil {
+			return fmt.Errorf("failed to create ServiceNow incident: %w", err)
+		}
+		s.logger.Info("Created ServiceNow incident", zap.String("number", number), zap.String("fingerprint", issue.Fingerprint))
+	} else {
+		if err := s.updateIncident(ctx, sysID, s.BuildRefireRequest(issue)); err != nil {
+			return fmt.Errorf("failed to add work notes to ServiceNow incident %s: %w", number, err)
+		}
+		s.logger.Info("Added work notes to ServiceNow incident", zap.String("number", number), zap.String("fingerprint", issue.Fingerprint))
+		if len(sender.FileBlocks(issue)) > 0 {
+			if attached, err = s.attachmentNames(ctx, sysID); err != nil {
+				return fmt.Errorf("failed to get attachments of ServiceNow incident %s: %w", number, err)
+			}
+		}
+	}
+
+	for _, file := range sender.FileBlocks(issue) {
+		if attached[file.Filename] {
+			continue
+		}
+		if err := s.uploadAttachment(ctx, sysID, file); err != nil {
+			return fmt.Errorf("failed to attach %s to ServiceNow incident %s: %w", file.Filename, number, err)
+		}
+		attached[file.Filename] = true
+	}
+	return nil
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderServiceNow) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderServiceNow) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+// BuildCreateRequest returns the fields of the incident of the issue
+func (s *SenderServiceNow) BuildCreateRequest(issue *issuepkg.Issue) map[string]string {
+	fields := make(map[string]string, len(s.cfg.CustomFields)+12)
+	for name, value := range s.cfg.CustomFields {
+		fields[name] = value
+	}
+
+	priority := s.MapPriority(issue.Severity)
+	fields["short_description"] = sender.Truncate(issue.Title, maxShortDescriptionLength)
+	fields["description"] = description(issue)
+	fields["impact"] = priority.Impact
+	fields["urgency"] = priority.Urgency
+	fields["correlation_id"] = issue.Fingerprint
+	fields["correlation_display"] = correlationDisplay
+	for name, value := range map[string]string{
+		"caller_id":        s.cfg.CallerID,
+		"assignment_group": s.cfg.AssignmentGroup,
+		"category":         s.cfg.Category,
+		"subcategory":      s.cfg.Subcategory,
+	} {
+		if value != "" {
+			fields[name] = value
+		}
+	}
+	s.addMappedFields(fields, issue)
+	if notes := workNotes(issue); notes != "" {
+		fields["work_notes"] = sender.Truncate(notes, maxWorkNotesLength)
+	}
+	return fields
+}
+
+// BuildRefireRequest returns the update that notes an issue that fired again on its incident. The
+// enrichments are the work notes of the created incident, so repeated notifications don't add
+// them again.
+func (s *SenderServiceNow) BuildRefireRequest(issue *issuepkg.Issue) map[string]string {
+	return map[string]string{"work_notes": "The alert fired again."}
+}
+
+// BuildResolveRequest returns the update that resolves the incident of the issue
+func (s *SenderServiceNow) BuildResolveRequest(issue *issuepkg.Issue) map[string]string {
+	notes := "The alert was resolved."
+	if issue.EndsAt != nil {
+		notes = fmt.Sprintf("The alert was resolved at %s.", issue.EndsAt.UTC().Format(time.RFC3339))
+	}
+	return map[string]string{
+		"state":       s.cfg.ResolveState,
+		"close_code":  s.cfg.CloseCode,
+		"close_notes": notes,
+	}
+}
+
+// MapPriority returns the impact and urgency of a severity, defaulting to low
+func (s *SenderServiceNow) MapPriority(severity issuepkg.Severity) Priority {
+	if priority, ok := s.cfg.Priorities[strings.ToLower(severity.String())]; ok {
+		return priority
+	}
+	return Priority{Impact: "3", Urgency: "3"}
+}
+
+// addMappedFields sets the configured fields of the cluster, the subject and its namespace. A
+// field mapped twice, like cmdb_ci for both cluster and subject, keeps the subject.
+func (s *SenderServiceNow) addMappedFields(fields map[string]string, issue *issuepkg.Issue) {
+	mapping := s.cfg.FieldMapping
+	if mapping.Cluster != "" && issue.ClusterName != "" {
+		fields[mapping.Cluster] = issue.ClusterName
+	}
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		if mapping.Namespace != "" && subject.Namespace != "" {
+			fields[mapping.Namespace] = subject.Namespace
+		}
+		if mapping.Subject != "" {
+			fields[mapping.Subject] = subject.Name
+		}
+	}
+}
+
+// findActiveIncident returns the sys_id and number of the most recent active incident of the
+// fingerprint, or empty values when there is none
+func (s *SenderServiceNow) findActiveIncident(ctx context.Context, fingerprint string) (string, string, error) {
+	query := url.Values{
+		"sysparm_query":  {"active=true^correlation_id=" + encodedQueryValue(fingerprint) + "^ORDERBYDESCsys_created_on"},
+		"sysparm_fields": {"sys_id,number"},
+		"sysparm_limit":  {"1"},
+	}
+
+	var result struct {
+		Result []struct {
+			SysID  string `json:"sys_id"`
+			Number string `json:"number"`
+		} `json:"result"`
+	}
+	if err := s.doJSON(ctx, http.MethodGet, s.tablePath()+"?"+query.Encode(), nil, &result); err != nil {
+		return "", "", err
+	}
+	if len(result.Result) == 0 {
+		return "", "", nil
+	}
+	return result.Result[0].SysID, result.Result[0].Number, nil
+}
+
+// createIncident creates the incident of the issue. A create that failed may still have been
+// stored, so every retry first searches for the incident by its correlation_id.
+func (s *SenderServiceNow) createIncident(ctx context.Context, issue *issuepkg.Issue) (string, string, error) {
+	payload, err := json.Marshal(s.BuildCreateRequest(issue))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	sysID, number := "", ""
+	attempt := 0
+	_, err = s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		if attempt++; attempt > 1 {
+			existingID, existingNumber, err := s.findActiveIncident(ctx, issue.Fingerprint)
+			if err != nil || existingID != "" {
+				sysID, number = existingID, existingNumber
+				return nil, err
+			}
+		}
+
+		header := http.Header{"Content-Type": {"application/json"}}
+		respBody, err := s.request(ctx, http.MethodPost, s.tablePath(), header, payload)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Result struct {
+				SysID  string `json:"sys_id"`
+				Number string `json:"number"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		sysID, number = result.Result.SysID, result.Result.Number
+		return respBody, nil
+	})
+	return sysID, number, err
+}
+
+func (s *SenderServiceNow) updateIncident(ctx context.Context, sysID string, fields map[string]string) error {
+	return s.doJSON(ctx, http.MethodPatch, s.tablePath()+"/"+url.PathEscape(sysID), fields, nil)
+}
+
+// attachmentNames returns the file names of the attachments of an incident
+func (s *SenderServiceNow) attachmentNames(ctx context.Context, sysID string) (map[string]bool, error) {
+	query := url.Values{
+		"sysparm_query":  {"table_name=" + encodedQueryValue(s.cfg.Table) + "^table_sys_id=" + encodedQueryValue(sysID)},
+		"sysparm_fields": {"file_name"},
+	}
+
+	var result struct {
+		Result []struct {
+			FileName string `json:"file_name"`
+		} `json:"result"`
+	}
+	if err := s.doJSON(ctx, http.MethodGet, "/api/now/attachment?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(result.Result))
+	for _, attachment := range result.Result {
+		names[attachment.FileName] = true
+	}
+	return names, nil
+}
+
+func (s *SenderServiceNow) uploadAttachment(ctx context.Context, sysID string, file *issuepkg.FileBlock) error {
+	query := url.Values{
+		"table_name":   {s.cfg.Table},
+		"table_sys_id": {sysID},
+		"file_name":    {file.Filename},
+	}
+	contentType := file.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := http.Header{"Content-Type": {contentType}}
+	_, err := s.do(ctx, http.MethodPost, "/api/now/attachment/file?"+query.Encode(), header, file.Contents)
+	return err
+}
+
+func (s *SenderServiceNow) tablePath() string {
+	return "/api/now/table/" + url.PathEscape(s.cfg.Table)
+}
+
+// doJSON sends payload, unless it is nil, to a path of the instance and decodes the response
+// into result, unless it is nil
+func (s *SenderServiceNow) doJSON(ctx context.Context, method, path string, payload, result interface{}) error {
+	var body []byte
+	header := http.Header{}
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = encoded
+		header.Set("Content-Type", "application/json")
+	}
+
+	respBody, err := s.do(ctx, method, path, header, body)
+	if err != nil {
+		return err
+	}
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// do sends a request to a path of the instance, retrying it according to the retry policy
+func (s *SenderServiceNow) do(ctx context.Context, method, path string, header http.Header, body []byte) ([]byte, error) {
+	return s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		return s.request(ctx, method, path, header, body)
+	})
+}
+
+// request sends a request with basic authentication to a path of the instance once
+func (s *SenderServiceNow) request(ctx context.Context, method, path string, header http.Header, body []byte) ([]byte, error) {
+	header.Set("Accept", "application/json")
+	credentials := base64.StdEncoding.EncodeToString([]byte(s.cfg.Username + ":" + s.cfg.Password))
+	header.Set("Authorization", "Basic "+credentials)
+
+	return sender.DoRequest(ctx, s.client, method, s.cfg.URL+path, header, body)
+}
+
+// encodedQueryValue escapes the separator of encoded queries in a value
+func encodedQueryValue(value string) string {
+	return strings.ReplaceAll(value, "^", "^^")
+}
@@ -0,0 +1,285 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testURL = "https://example.service-now.com"
+
+func testConfig() Config {
+	return Config{
+		URL:      testURL + "/",
+		Username: "cano_bot",
+		Password: "password",
+		Table:    "incident",
+		Priorities: map[string]Priority{
+			"high": {Impact: "1", Urgency: "1"},
+			"low":  {Impact: "2", Urgency: "2"},
+		},
+		FieldMapping:    FieldMapping{Cluster: "u_cluster", Subject: "cmdb_ci"},
+		CallerID:        "cano_bot",
+		AssignmentGroup: "Platform",
+		CustomFields:    map[string]string{"u_environment": "production"},
+		ResolveState:    "6",
+		CloseCode:       "Resolved by caller",
+	}
+}
+
+func setupSender(t *testing.T, cfg Config) (*SenderServiceNow, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	s := NewSenderServiceNow(cfg, logger, client)
+	s.retryPolicy = sendertest.RetryPolicy
+	return s, client
+}
+
+func searchResponse(sysID, number string) *http.Response {
+	results := []map[string]string{}
+	if sysID != "" {
+		results = append(results, map[string]string{"sys_id": sysID, "number": number})
+	}
+	body, _ := json.Marshal(map[string]interface{}{"result": results})
+	return sendertest.Response(http.StatusOK, string(body))
+}
+
+func TestSenderServiceNow_BuildCreateRequest(t *testing.T) {
+	s, _ := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.AddLink(issuepkg.Link{Text: "Runbook", URL: "https://runbooks/crashloop"})
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock("Container api restarted 5 times"),
+		issuepkg.NewFileBlock("api.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	fields := s.BuildCreateRequest(issue)
+	assert.Equal(t, "Pod is crash looping", fields["short_description"])
+	assert.Equal(t, "1", fields["impact"])
+	assert.Equal(t, "1", fields["urgency"])
+	assert.Equal(t, "fingerprint-1", fields["correlation_id"])
+	assert.Equal(t, "cano-collector", fields["correlation_display"])
+	assert.Equal(t, "cano_bot", fields["caller_id"])
+	assert.Equal(t, "Platform", fields["assignment_group"])
+	assert.Equal(t, "prod", fields["u_cluster"])
+	assert.Equal(t, "api-0", fields["cmdb_ci"])
+	assert.Equal(t, "production", fields["u_environment"])
+	assert.NotContains(t, fields, "category")
+
+	assert.Contains(t, fields["description"], "Pod payments/api-0 is restarting")
+	assert.Contains(t, fields["description"], "Subject: POD api-0\nNamespace: payments")
+	assert.Contains(t, fields["description"], "Runbook: https://runbooks/crashloop")
+	assert.Equal(t, "Logs\nContainer api restarted 5 times\nAttached api.log", fields["work_notes"])
+}
+
+func TestSenderServiceNow_MapPriority(t *testing.T) {
+	s, _ := setupSender(t, testConfig())
+
+	assert.Equal(t, Priority{Impact: "1", Urgency: "1"}, s.MapPriority(issuepkg.SeverityHigh))
+	assert.Equal(t, Priority{Impact: "2", Urgency: "2"}, s.MapPriority(issuepkg.SeverityLow))
+	assert.Equal(t, Priority{Impact: "3", Urgency: "3"}, s.MapPriority(issuepkg.SeverityDebug))
+}
+
+func TestSenderServiceNow_Send_CreatesIncident(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 1\nline 2"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, "/api/now/table/incident", req.URL.Path)
+			assert.Equal(t, "active=true^correlation_id=fingerprint-1^ORDERBYDESCsys_created_on", req.URL.Query().Get("sysparm_query"))
+			username, password, ok := req.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "cano_bot", username)
+			assert.Equal(t, "password", password)
+			return searchResponse("", ""), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, testURL+"/api/now/table/incident", req.URL.String())
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			var fields map[string]string
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&fields))
+			assert.Equal(t, "fingerprint-1", fields["correlation_id"])
+			return sendertest.Response(http.StatusCreated, `{"result":{"sys_id":"abc123","number":"INC0010001"}}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "/api/now/attachment/file", req.URL.Path)
+			assert.Equal(t, "incident", req.URL.Query().Get("table_name"))
+			assert.Equal(t, "abc123", req.URL.Query().Get("table_sys_id"))
+			assert.Equal(t, "api.log", req.URL.Query().Get("file_name"))
+			assert.Equal(t, "text/plain", req.Header.Get("Content-Type"))
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "line 1\nline 2", string(body))
+			return sendertest.Response(http.StatusCreated, `{"result":{"sys_id":"def456"}}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderServiceNow_Send_AddsWorkNotes(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("abc123", "INC0010001"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPatch, req.Method)
+			assert.Equal(t, testURL+"/api/now/table/incident/abc123", req.URL.String())
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"work_notes":"The alert fired again."}`, string(body))
+			return sendertest.Response(http.StatusOK, `{"result":{}}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderServiceNow_Send_RefireSkipsExistingAttachments(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 3"), "text/plain"),
+		issuepkg.NewFileBlock("worker.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("abc123", "INC0010001"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"work_notes":"The alert fired again."}`, string(body))
+			return sendertest.Response(http.StatusOK, `{"result":{}}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, "/api/now/attachment", req.URL.Path)
+			assert.Equal(t, "table_name=incident^table_sys_id=abc123", req.URL.Query().Get("sysparm_query"))
+			return sendertest.Response(http.StatusOK, `{"result":[{"file_name":"api.log"}]}`), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/api/now/attachment/file", req.URL.Path)
+			assert.Equal(t, "worker.log", req.URL.Query().Get("file_name"))
+			return sendertest.Response(http.StatusCreated, `{"result":{"sys_id":"def456"}}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderServiceNow_Send_CreateRetryFindsIncident(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("", ""), nil),
+		// the incident was created, but the response got lost
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			return sendertest.Response(http.StatusGatewayTimeout, ""), nil
+		}),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, "active=true^correlation_id=fingerprint-1^ORDERBYDESCsys_created_on", req.URL.Query().Get("sysparm_query"))
+			return searchResponse("abc123", "INC0010001"), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderServiceNow_Send_CreateRetry(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("", ""), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusServiceUnavailable, ""), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("", ""), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, testURL+"/api/now/table/incident", req.URL.String())
+			var fields map[string]string
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&fields))
+			assert.Equal(t, "fingerprint-1", fields["correlation_id"])
+			return sendertest.Response(http.StatusCreated, `{"result":{"sys_id":"abc123","number":"INC0010001"}}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+}
+
+func TestSenderServiceNow_Send_ResolvesIncident(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.Status = issuepkg.StatusResolved
+	endsAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	issue.EndsAt = &endsAt
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("abc123", "INC0010001"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPatch, req.Method)
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"state":"6","close_code":"Resolved by caller","close_notes":"The alert was resolved at 2026-05-01T12:00:00Z."}`, string(body))
+			return sendertest.Response(http.StatusOK, `{"result":{}}`), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderServiceNow_Send_ResolvedWithoutIncident(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.Status = issuepkg.StatusResolved
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("", ""), nil).Times(1)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderServiceNow_Send_RetriesServerErrors(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+	issue := sendertest.NewIssue()
+	issue.Status = issuepkg.StatusResolved
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusServiceUnavailable, ""), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(searchResponse("", ""), nil),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderServiceNow_Send_Unauthorized(t *testing.T) {
+	s, mockClient := setupSender(t, testConfig())
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusUnauthorized, `{"error":{"message":"User Not Authenticated"}}`), nil).Times(1)
+
+	err := s.Send(context.Background(), sendertest.NewIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to search ServiceNow incidents: unexpected status 401")
+	assert.NotContains(t, err.Error(), "password")
+}
+
+func TestEncodedQueryValue(t *testing.T) {
+	assert.Equal(t, "a^^b", encodedQueryValue("a^b"))
+}
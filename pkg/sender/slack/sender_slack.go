@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 
 	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	sender_interfaces "github.com/kubecano/cano-collector/pkg/sender/interfaces"
 	"github.com/kubecano/cano-collector/pkg/sender/slack/templates"
@@ -257,6 +258,17 @@ func (s *SenderSlack) Send(ctx context.Context, issue *issuepkg.Issue) error {
 	return nil
 }
 
+// BuildMessage returns the message Send would post for issue, without uploading files or
+// contacting Slack
+func (s *SenderSlack) BuildMessage(issue *issuepkg.Issue) slackapi.Msg {
+	return slackapi.Msg{
+		Channel:     s.channel,
+		Text:        s.formatIssueToString(issue),
+		Blocks:      slackapi.Blocks{BlockSet: s.buildSlackBlocks(issue)},
+		Attachments: s.buildSlackAttachments(issue, issue.Enrichments),
+	}
+}
+
 // removeTimestampFromFilename removes timestamp suffix from filename for deduplication
 // Example: "pod-logs-namespace-pod-20251103-001242.log" -> "pod-logs-namespace-pod.log"
 func (s *SenderSlack) removeTimestampFromFilename(filename string) string {
@@ -598,7 +610,7 @@ func (s *SenderSlack) buildSlackAttachments(issue *issuepkg.Issue, enrichments [
 	}
 
 	// Show end time for resolved alerts
-	if issue.Status == issuepkg.StatusResolved && !issue.EndsAt.IsZero() {
+	if issue.Status == issuepkg.StatusResolved && issue.EndsAt != nil && !issue.EndsAt.IsZero() {
 		endText := "✅ *Ended:* " + issue.EndsAt.UTC().Format("2006-01-02 15:04:05 UTC")
 		endBlock := slackapi.NewSectionBlock(
 			slackapi.NewTextBlockObject("mrkdwn", endText, false, false),
@@ -729,6 +741,8 @@ func (s *SenderSlack) convertBlockToSlack(block issuepkg.BaseBlock) slackapi.Blo
 		return s.convertLinksBlockToSlack(b)
 	case *issuepkg.FileBlock:
 		return s.convertFileBlockToSlack(b)
+	case *issuepkg.ImageBlock:
+		return s.convertImageBlockToSlack(b)
 	case *issuepkg.DividerBlock:
 		return slackapi.NewDividerBlock()
 	default:
@@ -1348,6 +1362,16 @@ func (s *SenderSlack) recordFileUploadFailure() {
 	}
 }
 
+// convertImageBlockToSlack converts an image block to a Slack image block
+func (s *SenderSlack) convertImageBlockToSlack(image *issuepkg.ImageBlock) slackapi.Block {
+	altText := image.AltText
+	if altText == "" {
+		// Slack rejects image blocks without alt text
+		altText = "image"
+	}
+	return slackapi.NewImageBlock(image.URL, altText, "", nil)
+}
+
 // convertFileBlockToSlack converts a file block to Slack section block with actual file upload
 func (s *SenderSlack) convertFileBlockToSlack(file *issuepkg.FileBlock) slackapi.Block {
 	_, permalink, err := s.uploadFileToSlack(file.Filename, file.Contents)
@@ -1484,6 +1508,10 @@ func (s *SenderSlack) getLinkEmoji(linkType issuepkg.LinkType) string {
 		return "🔕"
 	case issuepkg.LinkTypePrometheusGenerator:
 		return "📊"
+	case issuepkg.LinkTypeDashboard:
+		return "📈"
+	case issuepkg.LinkTypePanel:
+		return "🖼️"
 	case issuepkg.LinkTypeGeneral:
 		return "🔗"
 	default:
@@ -1678,6 +1706,13 @@ func (s *SenderSlack) SetThreadManager(threadManager sender_interfaces.SlackThre
 	s.threadManager = threadManager
 }
 
+// SetThreadStateStore shares the thread relationships of the thread manager between HA replicas
+func (s *SenderSlack) SetThreadStateStore(store ha_interfaces.StateStoreInterface) {
+	if tm, ok := s.threadManager.(*ThreadManager); ok {
+		tm.SetStateStore(store)
+	}
+}
+
 // SetTableFormat sets the table formatting parameters
 func (s *SenderSlack) SetTableFormat(tableFormat string) {
 	s.tableFormat = tableFormat
@@ -903,6 +903,17 @@ func TestSenderSlack_EnrichmentSupport(t *testing.T) {
 		assert.True(t, ok, "Expected divider block")
 	})
 
+	t.Run("formats image blocks correctly", func(t *testing.T) {
+		imageBlock := issuepkg.NewImageBlock("https://grafana.example.com/render/panel.png", "")
+
+		slackBlock := slackSender.convertBlockToSlack(imageBlock)
+
+		image, ok := slackBlock.(*slackapi.ImageBlock)
+		assert.True(t, ok, "Expected image block")
+		assert.Equal(t, "https://grafana.example.com/render/panel.png", image.ImageURL)
+		assert.Equal(t, "image", image.AltText)
+	})
+
 	t.Run("handles unknown block types gracefully", func(t *testing.T) {
 		// Create a mock unknown block type
 		unknownBlock := &mockUnsupportedBlock{blockType: "unsupported-test-type"}
@@ -1704,6 +1715,8 @@ func TestSenderSlack_GetLinkEmoji(t *testing.T) {
 		{"Investigate link", issuepkg.LinkTypeInvestigate, "🔍"},
 		{"Silence link", issuepkg.LinkTypeSilence, "🔕"},
 		{"Prometheus link", issuepkg.LinkTypePrometheusGenerator, "📊"},
+		{"Dashboard link", issuepkg.LinkTypeDashboard, "📈"},
+		{"Panel link", issuepkg.LinkTypePanel, "🖼️"},
 		{"General link", issuepkg.LinkTypeGeneral, "🔗"},
 	}
 
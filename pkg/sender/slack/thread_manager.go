@@ -3,39 +3,33 @@ package slack
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
 
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
 	sender_interfaces "github.com/kubecano/cano-collector/pkg/sender/interfaces"
 )
 
 type ThreadManager struct {
-	client       sender_interfaces.SlackClientInterface
-	channel      string
-	logger       logger_interfaces.LoggerInterface
-	cache        map[string]*threadCacheEntry
-	cacheMutex   sync.RWMutex
-	cacheTTL     time.Duration
+	client  sender_interfaces.SlackClientInterface
+	channel string
+	logger  logger_interfaces.LoggerInterface
+	// cache holds the thread timestamps by fingerprint, shared between HA replicas with a state store
+	cache        *sender.IDCache
 	searchLimit  int
 	searchWindow time.Duration
 }
 
-type threadCacheEntry struct {
-	threadTS  string
-	timestamp time.Time
-}
-
 func NewThreadManager(client sender_interfaces.SlackClientInterface, channel string, logger logger_interfaces.LoggerInterface, cacheTTL time.Duration, searchLimit int, searchWindow time.Duration) *ThreadManager {
 	return &ThreadManager{
 		client:       client,
 		channel:      channel,
 		logger:       logger,
-		cache:        make(map[string]*threadCacheEntry),
-		cacheTTL:     cacheTTL,
+		cache:        sender.NewIDCache("slack-thread/"+channel+"/", cacheTTL),
 		searchLimit:  searchLimit,
 		searchWindow: searchWindow,
 	}
@@ -43,13 +37,15 @@ func NewThreadManager(client sender_interfaces.SlackClientInterface, channel str
 
 func (tm *ThreadManager) GetThreadTS(ctx context.Context, fingerprint string) (string, error) {
 	// First check cache
-	tm.cacheMutex.RLock()
-	entry, exists := tm.cache[fingerprint]
-	tm.cacheMutex.RUnlock()
+	if threadTS, found := tm.cache.Cached(fingerprint); found {
+		tm.logger.Debug("Thread found in cache", zap.String("fingerprint", fingerprint), zap.String("threadTS", threadTS))
+		return threadTS, nil
+	}
 
-	if exists && time.Since(entry.timestamp) < tm.cacheTTL {
-		tm.logger.Debug("Thread found in cache", zap.String("fingerprint", fingerprint), zap.String("threadTS", entry.threadTS))
-		return entry.threadTS, nil
+	// A thread started by another replica before a leader failover
+	if threadTS, found := tm.cache.Get(fingerprint); found {
+		tm.logger.Debug("Thread found in shared state", zap.String("fingerprint", fingerprint), zap.String("threadTS", threadTS))
+		return threadTS, nil
 	}
 
 	// Search Slack for existing message with this fingerprint
@@ -69,32 +65,24 @@ func (tm *ThreadManager) GetThreadTS(ctx context.Context, fingerprint string) (s
 }
 
 func (tm *ThreadManager) SetThreadTS(fingerprint, threadTS string) {
-	tm.cacheMutex.Lock()
-	tm.cache[fingerprint] = &threadCacheEntry{
-		threadTS:  threadTS,
-		timestamp: time.Now(),
-	}
-	tm.cacheMutex.Unlock()
+	tm.cache.Set(fingerprint, threadTS)
 	tm.logger.Debug("Thread cached", zap.String("fingerprint", fingerprint), zap.String("threadTS", threadTS))
 }
 
+// SetStateStore shares thread relationships with other replicas, so threads continue after a leader failover
+func (tm *ThreadManager) SetStateStore(store ha_interfaces.StateStoreInterface) {
+	// threads older than the search window are not continued, so neither are shared ones
+	tm.cache.SetStateStore(store, tm.searchWindow)
+}
+
 func (tm *ThreadManager) InvalidateThread(fingerprint string) {
-	tm.cacheMutex.Lock()
-	delete(tm.cache, fingerprint)
-	tm.cacheMutex.Unlock()
+	tm.cache.Delete(fingerprint)
 	tm.logger.Debug("Thread invalidated", zap.String("fingerprint", fingerprint))
 }
 
 func (tm *ThreadManager) Cleanup() {
-	tm.cacheMutex.Lock()
-	defer tm.cacheMutex.Unlock()
-
-	now := time.Now()
-	for fingerprint, entry := range tm.cache {
-		if now.Sub(entry.timestamp) > tm.cacheTTL {
-			delete(tm.cache, fingerprint)
-			tm.logger.Debug("Thread cache entry expired", zap.String("fingerprint", fingerprint))
-		}
+	for _, fingerprint := range tm.cache.Cleanup() {
+		tm.logger.Debug("Thread cache entry expired", zap.String("fingerprint", fingerprint))
 	}
 }
 
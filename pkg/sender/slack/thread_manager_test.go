@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/ha"
 )
 
 func TestNewThreadManager(t *testing.T) {
@@ -32,11 +33,10 @@ func TestNewThreadManager(t *testing.T) {
 	assert.Equal(t, mockClient, tm.client)
 	assert.Equal(t, channel, tm.channel)
 	assert.Equal(t, mockLogger, tm.logger)
-	assert.Equal(t, cacheTTL, tm.cacheTTL)
 	assert.Equal(t, searchLimit, tm.searchLimit)
 	assert.Equal(t, searchWindow, tm.searchWindow)
 	assert.NotNil(t, tm.cache)
-	assert.Empty(t, tm.cache)
+	assert.Zero(t, tm.cache.Len())
 }
 
 func TestThreadManager_SetThreadTS(t *testing.T) {
@@ -57,13 +57,10 @@ func TestThreadManager_SetThreadTS(t *testing.T) {
 	tm.SetThreadTS(fingerprint, threadTS)
 
 	// Verify cache entry was created
-	tm.cacheMutex.RLock()
-	entry, exists := tm.cache[fingerprint]
-	tm.cacheMutex.RUnlock()
+	cached, exists := tm.cache.Cached(fingerprint)
 
 	assert.True(t, exists)
-	assert.Equal(t, threadTS, entry.threadTS)
-	assert.WithinDuration(t, time.Now(), entry.timestamp, 1*time.Second)
+	assert.Equal(t, threadTS, cached)
 }
 
 func TestThreadManager_InvalidateThread(t *testing.T) {
@@ -86,18 +83,14 @@ func TestThreadManager_InvalidateThread(t *testing.T) {
 	tm.SetThreadTS(fingerprint, threadTS)
 
 	// Verify it exists
-	tm.cacheMutex.RLock()
-	_, exists := tm.cache[fingerprint]
-	tm.cacheMutex.RUnlock()
+	_, exists := tm.cache.Cached(fingerprint)
 	assert.True(t, exists)
 
 	// Invalidate it
 	tm.InvalidateThread(fingerprint)
 
 	// Verify it's gone
-	tm.cacheMutex.RLock()
-	_, exists = tm.cache[fingerprint]
-	tm.cacheMutex.RUnlock()
+	_, exists = tm.cache.Cached(fingerprint)
 	assert.False(t, exists)
 }
 
@@ -248,9 +241,7 @@ func TestThreadManager_Cleanup(t *testing.T) {
 	tm.SetThreadTS(fingerprint2, threadTS2)
 
 	// Verify both exist
-	tm.cacheMutex.RLock()
-	assert.Len(t, tm.cache, 2)
-	tm.cacheMutex.RUnlock()
+	assert.Equal(t, 2, tm.cache.Len())
 
 	// Wait for entries to expire
 	time.Sleep(5 * time.Millisecond)
@@ -259,9 +250,7 @@ func TestThreadManager_Cleanup(t *testing.T) {
 	tm.Cleanup()
 
 	// Verify expired entries were removed
-	tm.cacheMutex.RLock()
-	assert.Empty(t, tm.cache)
-	tm.cacheMutex.RUnlock()
+	assert.Zero(t, tm.cache.Len())
 }
 
 func TestThreadManager_searchSlackForThread_Success(t *testing.T) {
@@ -542,7 +531,34 @@ func TestThreadManager_SetThreadTS_ConcurrentAccess(t *testing.T) {
 	}
 
 	// Verify all entries were set
-	tm.cacheMutex.RLock()
-	assert.Len(t, tm.cache, 10)
-	tm.cacheMutex.RUnlock()
+	assert.Equal(t, 10, tm.cache.Len())
+}
+
+func TestThreadManager_GetThreadTS_FromSharedState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockSlackClientInterface(ctrl)
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Debug("Thread cached", gomock.Any()).Times(1)
+	mockLogger.EXPECT().Debug("Thread found in shared state", gomock.Any()).Times(1)
+	mockLogger.EXPECT().Debug("Thread invalidated", gomock.Any()).Times(1)
+
+	store := ha.NewMemoryStateStore()
+
+	// a thread started by the previous leader
+	previous := NewThreadManager(mocks.NewMockSlackClientInterface(ctrl), "#test", mockLogger, 10*time.Minute, 50, 24*time.Hour)
+	previous.SetStateStore(store)
+	previous.SetThreadTS("test-fingerprint", "1234567890.123456")
+
+	// the new leader continues it without searching Slack
+	tm := NewThreadManager(mockClient, "#test", mockLogger, 10*time.Minute, 50, 24*time.Hour)
+	tm.SetStateStore(store)
+	result, err := tm.GetThreadTS(context.Background(), "test-fingerprint")
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890.123456", result)
+
+	tm.InvalidateThread("test-fingerprint")
+	_, found := store.Get("slack-thread/#test/test-fingerprint")
+	assert.False(t, found)
 }
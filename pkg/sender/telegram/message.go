@@ -0,0 +1,41 @@
+package telegram
+
+// Message is the request of the sendMessage method of the Bot API
+type Message struct {
+	ChatID string `json:"chat_id"`
+	// MessageThreadID is the topic of a forum group
+	MessageThreadID    int                 `json:"message_thread_id,omitempty"`
+	Text               string              `json:"text"`
+	ParseMode          string              `json:"parse_mode,omitempty"`
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options,omitempty"`
+	ReplyParameters    *ReplyParameters    `json:"reply_parameters,omitempty"`
+}
+
+// LinkPreviewOptions controls the preview of the first link of a message
+type LinkPreviewOptions struct {
+	IsDisabled bool `json:"is_disabled"`
+}
+
+// ReplyParameters makes a message a reply to an earlier message of the chat
+type ReplyParameters struct {
+	MessageID int `json:"message_id"`
+	// AllowSendingWithoutReply sends the message even when the earlier message was deleted
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
+}
+
+// Document is a file sent with the sendDocument method
+type Document struct {
+	Filename string
+	Contents []byte
+	// Caption is HTML shown below the file
+	Caption string
+}
+
+// response is the envelope of all Bot API responses
+type response struct {
+	OK     bool `json:"ok"`
+	Result struct {
+		MessageID int `json:"message_id"`
+	} `json:"result"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,47 @@
+package telegram
+
+import (
+	"strconv"
+	"time"
+
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+)
+
+// messageStore remembers the message of every firing issue by fingerprint, so the notification
+// of its resolution replies to it. The Bot API cannot look up earlier messages of a chat.
+type messageStore struct {
+	cache *sender.IDCache
+	ttl   time.Duration
+}
+
+func newMessageStore(chatID string, ttl time.Duration) *messageStore {
+	return &messageStore{
+		cache: sender.NewIDCache("telegram-message/"+chatID+"/", ttl),
+		ttl:   ttl,
+	}
+}
+
+// get returns the message of the issue with the given fingerprint, or zero when there is none
+// or it is older than the TTL. Messages sent by another replica before a leader failover are
+// found through the state store.
+func (m *messageStore) get(fingerprint string) int {
+	value, found := m.cache.Get(fingerprint)
+	if !found {
+		return 0
+	}
+	messageID, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return messageID
+}
+
+func (m *messageStore) set(fingerprint string, messageID int) {
+	m.cache.Set(fingerprint, strconv.Itoa(messageID))
+}
+
+// setStateStore shares the messages between HA replicas
+func (m *messageStore) setStateStore(store ha_interfaces.StateStoreInterface) {
+	m.cache.SetStateStore(store, m.ttl)
+}
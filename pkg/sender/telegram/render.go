@@ -0,0 +1,257 @@
+package telegram
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender"
+)
+
+var (
+	markdownBold = regexp.MustCompile(`\*\*([^*\n]+)\*\*`)
+	markdownLink = regexp.MustCompile(`\[([^\]\n]+)\]\((https?://[^)\s]+)\)`)
+)
+
+// section is a part of the message, as HTML and as plain text. The plain text is about as long
+// as the text Telegram shows, which is what its limits count, and makes up the details document
+// when the section does not fit into the message.
+type section struct {
+	html string
+	text string
+}
+
+// size returns the characters of the section that count towards the limit of a message
+func (s section) size() int {
+	return utf16Len(s.text)
+}
+
+// utf16Len returns the length of text in UTF-16 code units, the characters Telegram counts
+func utf16Len(text string) int {
+	units := 0
+	for _, r := range text {
+		units += utf16.RuneLen(r)
+	}
+	return units
+}
+
+// truncateUTF16 shortens text to at most maxUnits UTF-16 code units, ending it with "…" when it
+// was cut. Characters outside the Basic Multilingual Plane, like emoji, are never split.
+func truncateUTF16(text string, maxUnits int) string {
+	if utf16Len(text) <= maxUnits {
+		return text
+	}
+	if maxUnits <= 0 {
+		return ""
+	}
+	units := 0
+	for i, r := range text {
+		// keep room for the ellipsis, a single code unit
+		if units+utf16.RuneLen(r) > maxUnits-1 {
+			return text[:i] + "…"
+		}
+		units += utf16.RuneLen(r)
+	}
+	return text
+}
+
+// buildHeader returns the title, status and subject of the issue
+func buildHeader(issue *issuepkg.Issue) section {
+	title := truncateUTF16(sender.FormatTitle(issue), maxTitleChars)
+	status := sender.FormatStatus(issue)
+	htmlLines := []string{"<b>" + html.EscapeString(title) + "</b>", "<i>" + html.EscapeString(status) + "</i>"}
+	textLines := []string{title, status}
+
+	add := func(name, value string) {
+		if value != "" {
+			htmlLines = append(htmlLines, name+": <code>"+html.EscapeString(value)+"</code>")
+			textLines = append(textLines, name+": "+value)
+		}
+	}
+	add("Cluster", issue.ClusterName)
+	if subject := issue.Subject; subject != nil && subject.Name != "" {
+		add("Subject", fmt.Sprintf("%s %s", strings.ToLower(subject.SubjectType.String()), subject.Name))
+		add("Namespace", subject.Namespace)
+		add("Node", subject.Node)
+		add("Container", subject.Container)
+	}
+	if issue.EndsAt != nil && issue.IsResolved() {
+		add("Ended", issue.EndsAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+	return section{html: strings.Join(htmlLines, "\n"), text: strings.Join(textLines, "\n")}
+}
+
+// buildSections returns the sections following the header: the description, the links and an
+// enrichment each
+func buildSections(issue *issuepkg.Issue) []section {
+	var sections []section
+	if issue.Description != "" {
+		sections = append(sections, section{html: html.EscapeString(issue.Description), text: issue.Description})
+	}
+	if links := renderLinks(issue.Links); links.text != "" {
+		sections = append(sections, links)
+	}
+	for _, enrichment := range issue.Enrichments {
+		if enrichmentSection, ok := buildEnrichment(enrichment); ok {
+			sections = append(sections, enrichmentSection)
+		}
+	}
+	return sections
+}
+
+// buildEnrichment returns the section of an enrichment with its title in bold
+func buildEnrichment(enrichment issuepkg.Enrichment) (section, bool) {
+	var htmlParts, textParts []string
+	for _, block := range enrichment.Blocks {
+		if rendered := renderBlock(block); rendered.text != "" {
+			htmlParts = append(htmlParts, rendered.html)
+			textParts = append(textParts, rendered.text)
+		}
+	}
+	if len(enrichment.Blocks) == 0 && enrichment.Content != "" {
+		htmlParts = append(htmlParts, markdownToHTML(enrichment.Content))
+		textParts = append(textParts, enrichment.Content)
+	}
+	if len(textParts) == 0 {
+		return section{}, false
+	}
+	if enrichment.Title != "" {
+		htmlParts = append([]string{"<b>" + html.EscapeString(enrichment.Title) + "</b>"}, htmlParts...)
+		textParts = append([]string{enrichment.Title}, textParts...)
+	}
+	return section{html: strings.Join(htmlParts, "\n"), text: strings.Join(textParts, "\n")}, true
+}
+
+// renderBlock renders a block as Telegram HTML and as plain text
+func renderBlock(block issuepkg.BaseBlock) section {
+	switch b := block.(type) {
+	case *issuepkg.MarkdownBlock:
+		return section{html: markdownToHTML(b.Text), text: b.Text}
+	case *issuepkg.HeaderBlock:
+		return section{html: "<b>" + html.EscapeString(b.Text) + "</b>", text: b.Text}
+	case *issuepkg.TableBlock:
+		return renderTable(b)
+	case *issuepkg.ListBlock:
+		text := b.ToMarkdown()
+		return section{html: markdownToHTML(text), text: text}
+	case *issuepkg.JsonBlock:
+		text := b.ToJson()
+		return section{html: `<pre><code class="language-json">` + html.EscapeString(text) + "</code></pre>", text: text}
+	case *issuepkg.LinksBlock:
+		return renderLinks(b.Links)
+	case *issuepkg.FileBlock:
+		text := fmt.Sprintf("📎 %s (%.1f KB)", b.Filename, b.GetSizeKB())
+		return section{html: html.EscapeString(text), text: text}
+	case *issuepkg.ImageBlock:
+		return renderLinks([]issuepkg.Link{{Text: b.AltText, URL: b.URL}})
+	case *issuepkg.DividerBlock:
+		return section{html: "───", text: "───"}
+	default:
+		return section{}
+	}
+}
+
+// renderTable renders a table as aligned text in a pre block, since Telegram has no tables.
+// Rows beyond maxTableRows are left out with a note.
+func renderTable(table *issuepkg.TableBlock) section {
+	columns := table.GetColumnCount()
+	if columns == 0 {
+		return section{}
+	}
+	rows := table.Rows
+	if len(rows) > maxTableRows {
+		rows = rows[:maxTableRows]
+	}
+
+	lines := rows
+	if len(table.Headers) > 0 {
+		lines = append([][]string{table.Headers}, rows...)
+	}
+	widths := make([]int, columns)
+	for _, line := range lines {
+		for i := range widths {
+			widths[i] = max(widths[i], len([]rune(sender.Cell(line, i))))
+		}
+	}
+
+	var aligned strings.Builder
+	for _, line := range lines {
+		cells := make([]string, columns)
+		for i := range cells {
+			value := sender.Cell(line, i)
+			cells[i] = value + strings.Repeat(" ", widths[i]-len([]rune(value)))
+		}
+		aligned.WriteString(strings.TrimRight(strings.Join(cells, "  "), " "))
+		aligned.WriteString("\n")
+	}
+	text := strings.TrimSuffix(aligned.String(), "\n")
+
+	var htmlParts, textParts []string
+	if table.TableName != "" {
+		htmlParts = append(htmlParts, "<b>"+html.EscapeString(table.TableName)+"</b>")
+		textParts = append(textParts, table.TableName)
+	}
+	htmlParts = append(htmlParts, "<pre>"+html.EscapeString(text)+"</pre>")
+	textParts = append(textParts, text)
+	if len(rows) < len(table.Rows) {
+		note := fmt.Sprintf("Showing %d of %d rows", len(rows), len(table.Rows))
+		htmlParts = append(htmlParts, "<i>"+note+"</i>")
+		textParts = append(textParts, note)
+	}
+	return section{html: strings.Join(htmlParts, "\n"), text: strings.Join(textParts, "\n")}
+}
+
+// renderLinks renders the links one per line
+func renderLinks(links []issuepkg.Link) section {
+	var htmlLines, textLines []string
+	for _, link := range links {
+		if link.URL == "" {
+			continue
+		}
+		text := link.Text
+		if text == "" {
+			text = link.URL
+		}
+		htmlLines = append(htmlLines, fmt.Sprintf(`🔗 <a href="%s">%s</a>`, html.EscapeString(link.URL), html.EscapeString(text)))
+		textLines = append(textLines, fmt.Sprintf("🔗 %s: %s", text, link.URL))
+	}
+	return section{html: strings.Join(htmlLines, "\n"), text: strings.Join(textLines, "\n")}
+}
+
+// markdownToHTML converts the code blocks, inline code, bold text and links of markdown to
+// Telegram HTML and escapes everything else
+func markdownToHTML(text string) string {
+	var b strings.Builder
+	for _, segment := range sender.SplitCodeBlocks(text) {
+		if !segment.Code {
+			b.WriteString(inlineToHTML(segment.Text))
+			continue
+		}
+		b.WriteString("<pre>" + html.EscapeString(strings.Trim(sender.TrimCodeLanguage(segment.Text), "\n")) + "</pre>")
+	}
+	return b.String()
+}
+
+func inlineToHTML(text string) string {
+	parts := strings.Split(text, "`")
+	if len(parts)%2 == 0 {
+		return formattingToHTML(text)
+	}
+	for i, part := range parts {
+		if i%2 == 0 {
+			parts[i] = formattingToHTML(part)
+		} else {
+			parts[i] = "<code>" + html.EscapeString(part) + "</code>"
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func formattingToHTML(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = markdownLink.ReplaceAllString(escaped, `<a href="$2">$1</a>`)
+	return markdownBold.ReplaceAllString(escaped, "<b>$1</b>")
+}
@@ -0,0 +1,269 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	ha_interfaces "github.com/kubecano/cano-collector/pkg/ha/interfaces"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+const apiURL = "https://api.telegram.org"
+
+// Limits of Telegram messages, in UTF-16 code units
+const (
+	maxMessageChars = 4096
+	maxCaptionChars = 1024
+	maxTitleChars   = 256
+	// maxFileSize is the upload limit of bots
+	maxFileSize = 50 << 20
+
+	// maxTableRows limits the rows of every table
+	maxTableRows = 20
+	// replyWindow is how long resolved issues reply to the message of the firing issue
+	replyWindow = 24 * time.Hour
+	// detailsFilename names the document with the sections that do not fit into the message
+	detailsFilename = "details.txt"
+)
+
+// detailsNote ends a message whose remaining sections are sent as document
+var detailsNote = section{
+	html: "<i>📎 More details are attached as " + detailsFilename + "</i>",
+	text: "📎 More details are attached as " + detailsFilename,
+}
+
+type SenderTelegram struct {
+	botToken        string
+	chatID          string
+	messageThreadID int
+	apiURL          string
+	messages        *messageStore
+	logger          logger_interfaces.LoggerInterface
+	client          util.HTTPClient
+	retryPolicy     sender.RetryPolicy
+}
+
+func NewSenderTelegram(botToken, chatID string, messageThreadID int, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderTelegram {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	return &SenderTelegram{
+		botToken:        botToken,
+		chatID:          chatID,
+		messageThreadID: messageThreadID,
+		apiURL:          apiURL,
+		messages:        newMessageStore(chatID, replyWindow),
+		logger:          logger,
+		client:          client,
+		retryPolicy:     sender.DefaultRetryPolicy,
+	}
+}
+
+// Send sends the issue as HTML message. Sections beyond the limit of a message and the files
+// of the enrichments follow as documents replying to it. Resolved issues reply to the message
+// of the firing issue.
+func (s *SenderTelegram) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	s.logger.Info("Sending Telegram notification",
+		zap.String("status", issue.Status.String()),
+		zap.String("fingerprint", issue.Fingerprint),
+	)
+
+	message, details := s.BuildMessage(issue)
+	if issue.IsResolved() {
+		if messageID := s.messages.get(issue.Fingerprint); messageID != 0 {
+			message.ReplyParameters = &ReplyParameters{MessageID: messageID, AllowSendingWithoutReply: true}
+		}
+	}
+
+	messageID, err := s.call(ctx, "sendMessage", func() ([]byte, error) {
+		return sender.DoJSON(ctx, s.client, http.MethodPost, s.methodURL("sendMessage"), nil, message)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send Telegram message: %w", err)
+	}
+	if issue.Status == issuepkg.StatusFiring {
+		s.messages.set(issue.Fingerprint, messageID)
+	}
+
+	// Documents are secondary, a failed one does not fail the notification
+	reply := &ReplyParameters{MessageID: messageID, AllowSendingWithoutReply: true}
+	documents := fileDocuments(issue)
+	if details != nil {
+		documents = append([]Document{*details}, documents...)
+	}
+	for _, document := range documents {
+		if err := s.sendDocument(ctx, document, reply); err != nil {
+			s.logger.Warn("Failed to send document to Telegram",
+				zap.String("filename", document.Filename),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderTelegram) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderTelegram) SetClient(client util.HTTPClient) {
+	s.client = client
+}
+
+// SetMessageStateStore shares the messages of firing issues between HA replicas, so resolved
+// issues reply to them after a leader failover
+func (s *SenderTelegram) SetMessageStateStore(store ha_interfaces.StateStoreInterface) {
+	s.messages.setStateStore(store)
+}
+
+// BuildMessage renders the issue as HTML message of at most 4096 UTF-16 code units, which is how
+// Telegram counts characters, so an emoji counts twice. The header always
+// comes first; the description, the links and the enrichments follow as long as they fit, the
+// others are returned as details document.
+func (s *SenderTelegram) BuildMessage(issue *issuepkg.Issue) (Message, *Document) {
+	const separator = "\n\n"
+	header := buildHeader(issue)
+	htmlParts := []string{header.html}
+	size := header.size()
+
+	var overflow []string
+	for _, part := range buildSections(issue) {
+		// keep room for the note on the details document
+		if size+len(separator)+part.size() > maxMessageChars-len(separator)-detailsNote.size() {
+			overflow = append(overflow, part.text)
+			continue
+		}
+		htmlParts = append(htmlParts, part.html)
+		size += len(separator) + part.size()
+	}
+
+	var details *Document
+	if len(overflow) > 0 {
+		htmlParts = append(htmlParts, detailsNote.html)
+		details = &Document{
+			Filename: detailsFilename,
+			Contents: []byte(header.text + separator + strings.Join(overflow, separator) + "\n"),
+			Caption:  "<b>" + escapeCaption(sender.FormatTitle(issue)) + "</b>",
+		}
+	}
+
+	return Message{
+		ChatID:             s.chatID,
+		MessageThreadID:    s.messageThreadID,
+		Text:               strings.Join(htmlParts, separator),
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &LinkPreviewOptions{IsDisabled: true},
+	}, details
+}
+
+// sendDocument sends a file as multipart form with the sendDocument method
+func (s *SenderTelegram) sendDocument(ctx context.Context, document Document, reply *ReplyParameters) error {
+	body, contentType, err := s.documentBody(document, reply)
+	if err != nil {
+		return err
+	}
+	header := http.Header{"Content-Type": {contentType}}
+	_, err = s.call(ctx, "sendDocument", func() ([]byte, error) {
+		return sender.DoRequest(ctx, s.client, http.MethodPost, s.methodURL("sendDocument"), header, body)
+	})
+	return err
+}
+
+// call sends a request of a Bot API method with retries and returns the ID of the sent message
+func (s *SenderTelegram) call(ctx context.Context, method string, send func() ([]byte, error)) (int, error) {
+	body, err := s.retryPolicy.Do(ctx, send)
+	if err != nil {
+		return 0, err
+	}
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if !resp.OK {
+		return 0, fmt.Errorf("%s failed: %s", method, resp.Description)
+	}
+	return resp.Result.MessageID, nil
+}
+
+// methodURL returns the URL of a Bot API method, which contains the bot token. The errors of
+// sender.DoJSON and sender.DoRequest leave it out.
+func (s *SenderTelegram) methodURL(method string) string {
+	return s.apiURL + "/bot" + s.botToken + "/" + method
+}
+
+// documentBody returns the fields of a sendDocument request followed by the file, and the
+// content type of the body
+func (s *SenderTelegram) documentBody(document Document, reply *ReplyParameters) ([]byte, string, error) {
+	fields := [][2]string{{"chat_id", s.chatID}}
+	if s.messageThreadID != 0 {
+		fields = append(fields, [2]string{"message_thread_id", strconv.Itoa(s.messageThreadID)})
+	}
+	if document.Caption != "" {
+		fields = append(fields, [2]string{"caption", document.Caption}, [2]string{"parse_mode", "HTML"})
+	}
+	if reply != nil {
+		replyJSON, err := json.Marshal(reply)
+		if err != nil {
+			return nil, "", err
+		}
+		fields = append(fields, [2]string{"reply_parameters", string(replyJSON)})
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, field := range fields {
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return nil, "", err
+		}
+	}
+	part, err := writer.CreateFormFile("document", document.Filename)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(document.Contents); err != nil {
+		return nil, "", err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+// fileDocuments returns the files of the enrichments as documents captioned with the title of
+// their enrichment
+func fileDocuments(issue *issuepkg.Issue) []Document {
+	var documents []Document
+	for _, enrichment := range issue.Enrichments {
+		for _, block := range enrichment.Blocks {
+			file, ok := block.(*issuepkg.FileBlock)
+			if !ok || len(file.Contents) == 0 {
+				continue
+			}
+			document := Document{Filename: file.Filename, Contents: sender.FileTail(file, maxFileSize)}
+			if enrichment.Title != "" {
+				document.Caption = "<b>" + escapeCaption(enrichment.Title) + "</b>"
+			}
+			documents = append(documents, document)
+		}
+	}
+	return documents
+}
+
+// escapeCaption escapes text for a bold caption, leaving room for the tags
+func escapeCaption(text string) string {
+	return html.EscapeString(truncateUTF16(text, maxCaptionChars-len("<b></b>")))
+}
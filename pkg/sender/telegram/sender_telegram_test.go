@@ -0,0 +1,270 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/ha"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testToken = "123456:bot-token"
+
+func setupSender(t *testing.T) (*SenderTelegram, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	s := NewSenderTelegram(testToken, "-1001234567890", 42, logger, client)
+	s.retryPolicy = sendertest.RetryPolicy
+	return s, client
+}
+
+func sentMessage(messageID string) *http.Response {
+	return sendertest.Response(http.StatusOK, `{"ok":true,"result":{"message_id":`+messageID+`}}`)
+}
+
+func decodeMessage(t *testing.T, req *http.Request) Message {
+	t.Helper()
+	var message Message
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&message))
+	return message
+}
+
+func TestSenderTelegram_BuildMessage(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.Description = "Pod payments/api-0 is restarting <again>"
+	issue.AddLink(issuepkg.Link{Text: "Runbook", URL: "https://runbooks/crashloop?a=1&b=2"})
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewTableBlock([]string{"container", "restarts"}, [][]string{{"api", "5"}}, "", issuepkg.TableBlockFormatVertical),
+		issuepkg.NewMarkdownBlock("**Reason** is `OOMKilled`"),
+	}, issuepkg.EnrichmentTypeContainerInfo, "Containers")
+
+	message, details := s.BuildMessage(issue)
+	assert.Nil(t, details)
+	assert.Equal(t, "-1001234567890", message.ChatID)
+	assert.Equal(t, 42, message.MessageThreadID)
+	assert.Equal(t, "HTML", message.ParseMode)
+	assert.Equal(t, strings.Join([]string{
+		"<b>🔴 Pod is crash looping</b>\n<i>Firing · High severity · PROMETHEUS</i>\nCluster: <code>prod</code>\nSubject: <code>pod api-0</code>\nNamespace: <code>payments</code>",
+		"Pod payments/api-0 is restarting &lt;again&gt;",
+		`🔗 <a href="https://runbooks/crashloop?a=1&amp;b=2">Runbook</a>`,
+		"<b>Containers</b>\n<pre>container  restarts\napi        5</pre>\n<b>Reason</b> is <code>OOMKilled</code>",
+	}, "\n\n"), message.Text)
+}
+
+func TestSenderTelegram_BuildMessage_Overflow(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock(strings.Repeat("x", 3000)),
+	}, issuepkg.EnrichmentTypeLogs, "First")
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock(strings.Repeat("y", 3000)),
+	}, issuepkg.EnrichmentTypeLogs, "Second")
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock("short"),
+	}, issuepkg.EnrichmentTypeLogs, "Third")
+
+	message, details := s.BuildMessage(issue)
+	assert.LessOrEqual(t, len([]rune(message.Text)), maxMessageChars)
+	assert.Contains(t, message.Text, "<b>First</b>")
+	assert.NotContains(t, message.Text, "<b>Second</b>")
+	assert.Contains(t, message.Text, "<b>Third</b>")
+	assert.True(t, strings.HasSuffix(message.Text, detailsNote.html))
+
+	require.NotNil(t, details)
+	assert.Equal(t, "details.txt", details.Filename)
+	assert.Equal(t, "<b>🔴 Pod is crash looping</b>", details.Caption)
+	assert.True(t, strings.HasPrefix(string(details.Contents), "🔴 Pod is crash looping\n"))
+	assert.Contains(t, string(details.Contents), "Second\n"+strings.Repeat("y", 3000))
+	assert.NotContains(t, string(details.Contents), "First")
+}
+
+func TestSenderTelegram_BuildMessage_CountsUTF16(t *testing.T) {
+	s, _ := setupSender(t)
+	issue := sendertest.NewIssue()
+	// 1500 emoji are 1500 characters but 3000 UTF-16 code units
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock(strings.Repeat("🔥", 1500)),
+	}, issuepkg.EnrichmentTypeLogs, "First")
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock(strings.Repeat("🔥", 1500)),
+	}, issuepkg.EnrichmentTypeLogs, "Second")
+
+	message, details := s.BuildMessage(issue)
+	assert.LessOrEqual(t, utf16Len(message.Text), maxMessageChars)
+	assert.Contains(t, message.Text, "<b>First</b>")
+	assert.NotContains(t, message.Text, "<b>Second</b>")
+	require.NotNil(t, details)
+	assert.Contains(t, string(details.Contents), "Second\n")
+}
+
+func TestTruncateUTF16(t *testing.T) {
+	assert.Equal(t, "Disk full", truncateUTF16("Disk full", 9))
+	assert.Equal(t, "Disk…", truncateUTF16("Disk full", 5))
+	assert.Equal(t, "🔥🔥…", truncateUTF16("🔥🔥🔥🔥", 6))
+	// an emoji that does not fit is dropped, not split
+	assert.Equal(t, "🔥…", truncateUTF16("🔥🔥🔥🔥", 4))
+	assert.Equal(t, 3, utf16Len(truncateUTF16("🔥🔥🔥🔥", 4)))
+	assert.Empty(t, truncateUTF16("Disk full", 0))
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		html     string
+	}{
+		{name: "escapes", markdown: "a < b && c", html: "a &lt; b &amp;&amp; c"},
+		{name: "bold", markdown: "**Reason**: crash", html: "<b>Reason</b>: crash"},
+		{name: "link", markdown: "[Grafana](https://grafana/d/1?a=1&b=2)", html: `<a href="https://grafana/d/1?a=1&amp;b=2">Grafana</a>`},
+		{name: "inline code", markdown: "`**x** <y>`", html: "<code>**x** &lt;y&gt;</code>"},
+		{name: "code block", markdown: "logs:\n```text\nline <1>\n```", html: "logs:\n<pre>line &lt;1&gt;</pre>"},
+		{name: "unclosed code", markdown: "``` a", html: "``` a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.html, markdownToHTML(tt.markdown))
+		})
+	}
+}
+
+func TestSenderTelegram_Send(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://api.telegram.org/bot"+testToken+"/sendMessage", req.URL.String())
+		message := decodeMessage(t, req)
+		assert.Nil(t, message.ReplyParameters)
+		return sentMessage("7"), nil
+	}).Times(1)
+
+	require.NoError(t, s.Send(context.Background(), sendertest.NewIssue()))
+	assert.Equal(t, 7, s.messages.get("fingerprint-1"))
+}
+
+func TestSenderTelegram_Send_Documents(t *testing.T) {
+	s, mockClient := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 1\nline 2"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs of api")
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(sentMessage("7"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "https://api.telegram.org/bot"+testToken+"/sendDocument", req.URL.String())
+			require.NoError(t, req.ParseMultipartForm(1<<20))
+			assert.Equal(t, "-1001234567890", req.FormValue("chat_id"))
+			assert.Equal(t, "42", req.FormValue("message_thread_id"))
+			assert.Equal(t, "<b>Logs of api</b>", req.FormValue("caption"))
+			assert.Equal(t, "HTML", req.FormValue("parse_mode"))
+			assert.JSONEq(t, `{"message_id":7,"allow_sending_without_reply":true}`, req.FormValue("reply_parameters"))
+
+			file, header, err := req.FormFile("document")
+			require.NoError(t, err)
+			defer file.Close()
+			assert.Equal(t, "api.log", header.Filename)
+			contents, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, "line 1\nline 2", string(contents))
+			return sentMessage("8"), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderTelegram_Send_FailedDocument(t *testing.T) {
+	s, mockClient := setupSender(t)
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewFileBlock("api.log", []byte("line 1"), "text/plain"),
+	}, issuepkg.EnrichmentTypeLogs, "Logs")
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(sentMessage("7"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusRequestEntityTooLarge, `{"ok":false,"description":"Request Entity Too Large"}`), nil),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderTelegram_Send_ResolvedReplies(t *testing.T) {
+	s, mockClient := setupSender(t)
+	issue := sendertest.NewIssue()
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(sentMessage("7"), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			message := decodeMessage(t, req)
+			require.NotNil(t, message.ReplyParameters)
+			assert.Equal(t, 7, message.ReplyParameters.MessageID)
+			assert.True(t, message.ReplyParameters.AllowSendingWithoutReply)
+			assert.Contains(t, message.Text, "✅ Resolved: Pod is crash looping")
+			return sentMessage("9"), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), issue))
+	issue.Status = issuepkg.StatusResolved
+	require.NoError(t, s.Send(context.Background(), issue))
+	assert.Equal(t, 7, s.messages.get("fingerprint-1"))
+}
+
+func TestSenderTelegram_Send_ResolvedAfterFailover(t *testing.T) {
+	store := ha.NewMemoryStateStore()
+	leader, leaderClient := setupSender(t)
+	leader.SetMessageStateStore(store)
+	follower, followerClient := setupSender(t)
+	follower.SetMessageStateStore(store)
+	issue := sendertest.NewIssue()
+
+	leaderClient.EXPECT().Do(gomock.Any()).Return(sentMessage("7"), nil)
+	followerClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		message := decodeMessage(t, req)
+		require.NotNil(t, message.ReplyParameters)
+		assert.Equal(t, 7, message.ReplyParameters.MessageID)
+		return sentMessage("9"), nil
+	})
+
+	require.NoError(t, leader.Send(context.Background(), issue))
+	issue.Status = issuepkg.StatusResolved
+	require.NoError(t, follower.Send(context.Background(), issue))
+
+	// the follower keeps the message it found in the shared state
+	store.Delete("telegram-message/-1001234567890/fingerprint-1")
+	assert.Equal(t, 7, follower.messages.get("fingerprint-1"))
+}
+
+func TestSenderTelegram_Send_RetriesRateLimit(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	sendertest.RetriesRateLimit(t, mockClient, sentMessage("7"), func() error {
+		return s.Send(context.Background(), sendertest.NewIssue())
+	})
+}
+
+func TestSenderTelegram_Send_Error(t *testing.T) {
+	s, mockClient := setupSender(t)
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`), nil).Times(1)
+
+	err := s.Send(context.Background(), sendertest.NewIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send Telegram message: unexpected status 400")
+	assert.Contains(t, err.Error(), "chat not found")
+	assert.NotContains(t, err.Error(), "bot-token")
+}
@@ -0,0 +1,149 @@
+package sender
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// ellipsis marks text shortened by Truncate
+const ellipsis = "…"
+
+// codeFence opens and closes Markdown code blocks
+const codeFence = "```"
+
+// Truncate shortens text to at most maxChars characters including the ellipsis that marks the
+// cut. Characters are counted as runes, so a multi-byte character is never split.
+func Truncate(text string, maxChars int) string {
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text
+	}
+	if maxChars <= 0 {
+		return ""
+	}
+	return string(runes[:maxChars-1]) + ellipsis
+}
+
+// TruncateStart works like Truncate but keeps the end of text, like the latest lines of a log,
+// and marks the cut with an ellipsis at the start
+func TruncateStart(text string, maxChars int) string {
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text
+	}
+	if maxChars <= 0 {
+		return ""
+	}
+	return ellipsis + string(runes[len(runes)-maxChars+1:])
+}
+
+// TruncateMarkdown works like Truncate but closes a code block the cut leaves open, so the rest
+// of the message is not rendered as code
+func TruncateMarkdown(text string, maxChars int) string {
+	cut := Truncate(text, maxChars)
+	if cut == text || strings.Count(cut, codeFence)%2 == 0 || maxChars <= len(codeFence)+2 {
+		return cut
+	}
+	runes := []rune(text)
+	return string(runes[:maxChars-len(codeFence)-2]) + "\n" + codeFence + ellipsis
+}
+
+// MarkdownSegment is a part of Markdown text, either prose or the contents of a code block
+type MarkdownSegment struct {
+	Text string
+	Code bool
+}
+
+// SplitCodeBlocks splits Markdown text into prose and code blocks, which alternate starting with
+// prose. An unclosed code block is no code block, so such text is returned as a single segment.
+func SplitCodeBlocks(text string) []MarkdownSegment {
+	parts := strings.Split(text, codeFence)
+	if len(parts)%2 == 0 {
+		return []MarkdownSegment{{Text: text}}
+	}
+	segments := make([]MarkdownSegment, len(parts))
+	for i, part := range parts {
+		segments[i] = MarkdownSegment{Text: part, Code: i%2 == 1}
+	}
+	return segments
+}
+
+// TrimCodeLanguage drops the first line of a code block when it names the language, like "go"
+func TrimCodeLanguage(code string) string {
+	if language, rest, found := strings.Cut(code, "\n"); found && !strings.ContainsAny(language, " \t") {
+		return rest
+	}
+	return code
+}
+
+// FormatTitle returns the title of issue headed by its severity, or marked resolved
+func FormatTitle(issue *issuepkg.Issue) string {
+	if issue.IsResolved() {
+		return "✅ Resolved: " + issue.Title
+	}
+	return issue.Severity.ToEmoji() + " " + issue.Title
+}
+
+// FormatStatus returns the status, the severity and the source of issue, like
+// "Firing · High severity · Prometheus"
+func FormatStatus(issue *issuepkg.Issue) string {
+	status := "Firing"
+	if issue.IsResolved() {
+		status = "Resolved"
+	}
+	return fmt.Sprintf("%s · %s severity · %s", status, SeverityName(issue.Severity), issue.Source.String())
+}
+
+// SeverityName returns the capitalised name of severity
+func SeverityName(severity issuepkg.Severity) string {
+	switch severity {
+	case issuepkg.SeverityHigh:
+		return "High"
+	case issuepkg.SeverityLow:
+		return "Low"
+	case issuepkg.SeverityInfo:
+		return "Info"
+	case issuepkg.SeverityDebug:
+		return "Debug"
+	default:
+		return severity.String()
+	}
+}
+
+// Cell returns column i of a table row, or an empty string when the row is shorter
+func Cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+// FileBlocks returns the non-empty files of the enrichments of issue
+func FileBlocks(issue *issuepkg.Issue) []*issuepkg.FileBlock {
+	var files []*issuepkg.FileBlock
+	for _, enrichment := range issue.Enrichments {
+		for _, block := range enrichment.Blocks {
+			if file, ok := block.(*issuepkg.FileBlock); ok && len(file.Contents) > 0 {
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+// FileTail returns the end of files larger than maxBytes, which keeps the latest log lines. The
+// cut skips the rest of a UTF-8 character it lands in, so a text file does not start with a
+// broken character.
+func FileTail(file *issuepkg.FileBlock, maxBytes int) []byte {
+	if len(file.Contents) <= maxBytes {
+		return file.Contents
+	}
+	tail := file.Contents[len(file.Contents)-maxBytes:]
+	for i := 0; i < utf8.UTFMax-1 && len(tail) > 0 && !utf8.RuneStart(tail[0]); i++ {
+		tail = tail[1:]
+	}
+	return tail
+}
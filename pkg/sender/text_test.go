@@ -0,0 +1,114 @@
+package sender
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxChars int
+		expected string
+	}{
+		{"short text is kept", "Disk full", 20, "Disk full"},
+		{"exact length is kept", "Disk full", 9, "Disk full"},
+		{"long text ends with an ellipsis", "Disk full on node-1", 10, "Disk full…"},
+		{"multi-byte characters are not split", "Dysk pełny: żółć", 12, "Dysk pełny:…"},
+		{"emoji are not split", "🔥🔥🔥🔥", 3, "🔥🔥…"},
+		{"zero limit", "Disk full", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			truncated := Truncate(tt.text, tt.maxChars)
+			assert.Equal(t, tt.expected, truncated)
+			assert.True(t, utf8.ValidString(truncated))
+			assert.LessOrEqual(t, utf8.RuneCountInString(truncated), max(tt.maxChars, 0))
+		})
+	}
+}
+
+func TestTruncateStart(t *testing.T) {
+	assert.Equal(t, "Disk full", TruncateStart("Disk full", 9))
+	assert.Equal(t, "…on node-1", TruncateStart("Disk full on node-1", 10))
+	assert.Equal(t, "…żółć", TruncateStart("Dysk pełny: żółć", 5))
+	assert.Equal(t, "", TruncateStart("Disk full", 0))
+}
+
+func TestTruncateMarkdown(t *testing.T) {
+	assert.Equal(t, "short", TruncateMarkdown("short", 10))
+	assert.Equal(t, "plain tex…", TruncateMarkdown("plain text that is long", 10))
+
+	truncated := TruncateMarkdown("```\nline 1\nline 2\nline 3\n```", 16)
+	assert.Equal(t, "```\nline 1\n\n```…", truncated)
+	assert.Equal(t, 16, utf8.RuneCountInString(truncated))
+
+	truncated = TruncateMarkdown("```\n"+strings.Repeat("x", 100)+"\n```", 50)
+	assert.Equal(t, 50, utf8.RuneCountInString(truncated))
+	assert.True(t, strings.HasSuffix(truncated, "\n```…"))
+}
+
+func TestSplitCodeBlocks(t *testing.T) {
+	assert.Equal(t, []MarkdownSegment{
+		{Text: "Logs:\n"},
+		{Text: "\npanic\n", Code: true},
+		{Text: " done"},
+	}, SplitCodeBlocks("Logs:\n```\npanic\n``` done"))
+
+	// an unclosed code block is kept as text
+	assert.Equal(t, []MarkdownSegment{{Text: "Logs:\n```panic"}}, SplitCodeBlocks("Logs:\n```panic"))
+}
+
+func TestTrimCodeLanguage(t *testing.T) {
+	assert.Equal(t, "fmt.Println()\n", TrimCodeLanguage("go\nfmt.Println()\n"))
+	assert.Equal(t, "panic\n", TrimCodeLanguage("\npanic\n"))
+	assert.Equal(t, "exit code 1\nrestarting", TrimCodeLanguage("exit code 1\nrestarting"))
+	assert.Equal(t, "panic", TrimCodeLanguage("panic"))
+}
+
+func TestFormatTitleAndStatus(t *testing.T) {
+	issue := issuepkg.NewIssue("Disk full", "DiskFull")
+	issue.Severity = issuepkg.SeverityHigh
+	issue.Source = issuepkg.SourcePrometheus
+	assert.Equal(t, issue.Severity.ToEmoji()+" Disk full", FormatTitle(issue))
+	assert.Equal(t, "Firing · High severity · "+issue.Source.String(), FormatStatus(issue))
+
+	issue.Status = issuepkg.StatusResolved
+	assert.Equal(t, "✅ Resolved: Disk full", FormatTitle(issue))
+	assert.Equal(t, "Resolved · High severity · "+issue.Source.String(), FormatStatus(issue))
+}
+
+func TestCell(t *testing.T) {
+	row := []string{"pod", "Running"}
+	assert.Equal(t, "Running", Cell(row, 1))
+	assert.Equal(t, "", Cell(row, 2))
+}
+
+func TestFileBlocks(t *testing.T) {
+	issue := issuepkg.NewIssue("Pod crashed", "PodCrashLooping")
+	logs := issuepkg.NewFileBlock("app.log", []byte("panic"), "text/plain")
+	issue.AddEnrichmentBlocks([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock("Restarted 5 times"),
+		logs,
+		issuepkg.NewFileBlock("empty.log", nil, "text/plain"),
+	})
+
+	assert.Equal(t, []*issuepkg.FileBlock{logs}, FileBlocks(issue))
+	assert.Empty(t, FileBlocks(issuepkg.NewIssue("Disk full", "DiskFull")))
+}
+
+func TestFileTail(t *testing.T) {
+	file := issuepkg.NewFileBlock("app.log", []byte("line 1\nline 2\n"), "text/plain")
+	assert.Equal(t, file.Contents, FileTail(file, 100))
+	assert.Equal(t, []byte("line 2\n"), FileTail(file, 7))
+
+	// the cut lands in the middle of "ł", whose first byte is dropped along with it
+	file = issuepkg.NewFileBlock("app.log", []byte("błąd"), "text/plain")
+	assert.Equal(t, []byte("ąd"), FileTail(file, 4))
+}
@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"go.uber.org/zap"
+
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	"github.com/kubecano/cano-collector/pkg/sender"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request body as "sha256=<hex>", the
+// header and format the collector verifies on its own API
+const SignatureHeader = "X-Cano-Signature"
+
+// templateFuncs are the helper functions available to body templates
+var templateFuncs = template.FuncMap{
+	"toJson": func(value interface{}) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ParseBodyTemplate parses the template of a request body
+func ParseBodyTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("body").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body_template: %w", err)
+	}
+	return tmpl, nil
+}
+
+type SenderWebhook struct {
+	url           string
+	method        string
+	header        http.Header
+	bodyTemplate  *template.Template
+	signingSecret string
+	logger        logger_interfaces.LoggerInterface
+	client        util.HTTPClient
+	retryPolicy   sender.RetryPolicy
+}
+
+// NewSenderWebhook returns a sender that posts every issue as JSON to url without retries; the
+// setters configure the rest
+func NewSenderWebhook(url, method string, logger logger_interfaces.LoggerInterface, client util.HTTPClient) *SenderWebhook {
+	if client == nil {
+		client = util.GetSharedHTTPClient()
+	}
+	return &SenderWebhook{
+		url:         url,
+		method:      method,
+		header:      http.Header{"Content-Type": []string{"application/json"}},
+		logger:      logger,
+		client:      client,
+		retryPolicy: sender.RetryPolicy{MaxAttempts: 1},
+	}
+}
+
+// Send renders the body of the issue and sends it to the endpoint
+func (s *SenderWebhook) Send(ctx context.Context, issue *issuepkg.Issue) error {
+	body, err := s.BuildBody(issue)
+	if err != nil {
+		return fmt.Errorf("failed to render webhook body: %w", err)
+	}
+
+	header := s.header.Clone()
+	if s.signingSecret != "" {
+		header.Set(SignatureHeader, Sign(s.signingSecret, body))
+	}
+
+	s.logger.Info("Sending issue to webhook",
+		zap.String("method", s.method),
+		zap.String("fingerprint", issue.Fingerprint),
+	)
+	_, err = s.retryPolicy.Do(ctx, func() ([]byte, error) {
+		return sender.DoRequest(ctx, s.client, s.method, s.url, header, body)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	return nil
+}
+
+// BuildBody returns the issue encoded as JSON, or rendered by the body template when one is set
+func (s *SenderWebhook) BuildBody(issue *issuepkg.Issue) ([]byte, error) {
+	if s.bodyTemplate == nil {
+		return json.Marshal(issue)
+	}
+	var buf bytes.Buffer
+	if err := s.bodyTemplate.Execute(&buf, issue); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Sign returns the value of the signature header: the HMAC-SHA256 of body keyed with secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetHeaders sets the headers sent with every request besides the content type
+func (s *SenderWebhook) SetHeaders(headers map[string]string) {
+	for name, value := range headers {
+		s.header.Set(name, value)
+	}
+}
+
+// SetContentType sets the content type of the request body
+func (s *SenderWebhook) SetContentType(contentType string) {
+	s.header.Set("Content-Type", contentType)
+}
+
+// SetBodyTemplate renders the request body with tmpl instead of encoding the issue as JSON
+func (s *SenderWebhook) SetBodyTemplate(tmpl *template.Template) {
+	s.bodyTemplate = tmpl
+}
+
+// SetSigningSecret signs every request body with secret
+func (s *SenderWebhook) SetSigningSecret(secret string) {
+	s.signingSecret = secret
+}
+
+// SetRetryPolicy sets how requests that fail with 429 or 5xx are retried
+func (s *SenderWebhook) SetRetryPolicy(policy sender.RetryPolicy) {
+	s.retryPolicy = policy
+}
+
+// SetLogger sets the logger of the sender
+func (s *SenderWebhook) SetLogger(logger logger_interfaces.LoggerInterface) {
+	s.logger = logger
+}
+
+// SetClient sets the HTTP client of the sender
+func (s *SenderWebhook) SetClient(client util.HTTPClient) {
+	s.client = client
+}
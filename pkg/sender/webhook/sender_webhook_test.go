@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/mocks"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/sender/sendertest"
+)
+
+const testURL = "https://hooks.internal/cano"
+
+func setupSender(t *testing.T) (*SenderWebhook, *mocks.MockHTTPClient) {
+	t.Helper()
+	logger, client := sendertest.NewMocks(t)
+	return NewSenderWebhook(testURL, http.MethodPost, logger, client), client
+}
+
+// newTestIssue returns an issue with an enrichment, which is sent along with the issue
+func newTestIssue() *issuepkg.Issue {
+	issue := sendertest.NewIssue()
+	issue.AddEnrichmentWithType([]issuepkg.BaseBlock{
+		issuepkg.NewMarkdownBlock("Container api was OOMKilled"),
+	}, issuepkg.EnrichmentTypeContainerInfo, "Containers")
+	return issue
+}
+
+func TestSenderWebhook_Send_IssueJSON(t *testing.T) {
+	s, mockClient := setupSender(t)
+	s.SetHeaders(map[string]string{"Authorization": "Bearer token"})
+	issue := newTestIssue()
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, testURL, req.URL.String())
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		assert.Empty(t, req.Header.Get(SignatureHeader))
+
+		var decoded issuepkg.Issue
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&decoded))
+		assert.Equal(t, issue.Title, decoded.Title)
+		assert.Equal(t, issuepkg.SeverityHigh, decoded.Severity)
+		assert.Equal(t, "fingerprint-1", decoded.Fingerprint)
+		require.Len(t, decoded.Enrichments, 1)
+		assert.Equal(t, issue.Enrichments[0].Blocks, decoded.Enrichments[0].Blocks)
+		return sendertest.Response(http.StatusOK, ""), nil
+	})
+
+	require.NoError(t, s.Send(context.Background(), issue))
+}
+
+func TestSenderWebhook_Send_TemplateBodyAndSignature(t *testing.T) {
+	s, mockClient := setupSender(t)
+	tmpl, err := ParseBodyTemplate(`{"text": {{ toJson .Title }}, "severity": "{{ lower .Severity.String }}", "cluster": "{{ .ClusterName }}"}`)
+	require.NoError(t, err)
+	s.SetBodyTemplate(tmpl)
+	s.SetContentType("application/vnd.alerts+json")
+	s.SetSigningSecret("signing-key")
+
+	expectedBody := `{"text": "Pod is crash looping", "severity": "high", "cluster": "prod"}`
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, expectedBody, string(body))
+		assert.Equal(t, "application/vnd.alerts+json", req.Header.Get("Content-Type"))
+		assert.Equal(t, Sign("signing-key", body), req.Header.Get(SignatureHeader))
+		return sendertest.Response(http.StatusNoContent, ""), nil
+	})
+
+	require.NoError(t, s.Send(context.Background(), newTestIssue()))
+}
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 test vector of RFC 4231, test case 2
+	assert.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", Sign("Jefe", []byte("what do ya want for nothing?")))
+}
+
+func TestParseBodyTemplate_Invalid(t *testing.T) {
+	_, err := ParseBodyTemplate(`{{ .Title `)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid body_template")
+}
+
+func TestSenderWebhook_Send_RetriesServerErrors(t *testing.T) {
+	s, mockClient := setupSender(t)
+	s.SetRetryPolicy(sendertest.RetryPolicy)
+
+	gomock.InOrder(
+		mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusBadGateway, ""), nil),
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			// the body is sent again in full
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), "Pod is crash looping")
+			return sendertest.Response(http.StatusOK, ""), nil
+		}),
+	)
+
+	require.NoError(t, s.Send(context.Background(), newTestIssue()))
+}
+
+func TestSenderWebhook_Send_ClientErrorIsNotRetried(t *testing.T) {
+	s, mockClient := setupSender(t)
+	s.SetRetryPolicy(sendertest.RetryPolicy)
+
+	mockClient.EXPECT().Do(gomock.Any()).Return(sendertest.Response(http.StatusUnprocessableEntity, ""), nil).Times(1)
+
+	err := s.Send(context.Background(), newTestIssue())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send webhook request: unexpected status 422")
+}
@@ -0,0 +1,62 @@
+package shard
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// peerDiscoverer lists the IP addresses of the collector replicas
+type peerDiscoverer interface {
+	Peers(ctx context.Context) ([]string, error)
+}
+
+// endpointSliceDiscoverer lists the ready endpoints of a Service through the EndpointSlice API
+type endpointSliceDiscoverer struct {
+	client    kubernetes.Interface
+	namespace string
+	service   string
+}
+
+func (d *endpointSliceDiscoverer) Peers(ctx context.Context) ([]string, error) {
+	endpointSlices, err := d.client.DiscoveryV1().EndpointSlices(d.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + d.service,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoint slices of service %s/%s: %w", d.namespace, d.service, err)
+	}
+
+	var peers []string
+	for _, slice := range endpointSlices.Items {
+		for _, endpoint := range slice.Endpoints {
+			// a missing condition means the endpoint is ready
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			peers = append(peers, endpoint.Addresses...)
+		}
+	}
+	return peers, nil
+}
+
+// dnsDiscoverer resolves the name of a headless Service, which returns one address per ready pod
+type dnsDiscoverer struct {
+	name       string
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+func newDNSDiscoverer(name string) *dnsDiscoverer {
+	return &dnsDiscoverer{name: name, lookupHost: net.DefaultResolver.LookupHost}
+}
+
+func (d *dnsDiscoverer) Peers(ctx context.Context) ([]string, error) {
+	peers, err := d.lookupHost(ctx, d.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", d.name, err)
+	}
+	return peers, nil
+}
@@ -0,0 +1,61 @@
+package shard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEndpointSliceDiscoverer_ReadyEndpoints(t *testing.T) {
+	ready, notReady := true, false
+	client := fake.NewClientset(
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cano-collector-abc",
+				Namespace: "monitoring",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "cano-collector"},
+			},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+				{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}},
+				{Addresses: []string{"10.0.0.3"}},
+			},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-abc",
+				Namespace: "monitoring",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "other"},
+			},
+			Endpoints: []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.9"}}},
+		},
+	)
+
+	discoverer := &endpointSliceDiscoverer{client: client, namespace: "monitoring", service: "cano-collector"}
+	peers, err := discoverer.Peers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.3"}, peers)
+}
+
+func TestDNSDiscoverer(t *testing.T) {
+	discoverer := newDNSDiscoverer("cano-collector-peers.monitoring.svc")
+	discoverer.lookupHost = func(_ context.Context, host string) ([]string, error) {
+		assert.Equal(t, "cano-collector-peers.monitoring.svc", host)
+		return []string{"10.0.0.1", "10.0.0.2"}, nil
+	}
+	peers, err := discoverer.Peers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, peers)
+
+	discoverer.lookupHost = func(context.Context, string) ([]string, error) {
+		return nil, errors.New("no such host")
+	}
+	_, err = discoverer.Peers(context.Background())
+	assert.ErrorContains(t, err, "no such host")
+}
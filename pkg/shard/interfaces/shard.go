@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	alert_interfaces "github.com/kubecano/cano-collector/pkg/alert/interfaces"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+)
+
+// ShardStatus describes the hash ring as seen by this replica
+type ShardStatus struct {
+	Enabled bool     `json:"enabled"`
+	Self    string   `json:"self,omitempty"`
+	Peers   []string `json:"peers,omitempty"`
+}
+
+// SharderInterface distributes issues across replicas by fingerprint, so threading, grouping and
+// deduplication state for a fingerprint stays on one replica. As an IssueProcessorInterface it
+// processes the issues owned by this replica and forwards the others.
+//
+//go:generate mockgen -source=shard.go -destination=../../../mocks/shard_mock.go -package=mocks
+type SharderInterface interface {
+	alert_interfaces.IssueProcessorInterface
+	// Enabled reports whether sharding is configured
+	Enabled() bool
+	// Run refreshes the peer list until ctx is cancelled
+	Run(ctx context.Context)
+	// Distribute forwards issues owned by other replicas to them and returns the issues this replica
+	// processes. The error reports issues an owner received but failed to process.
+	Distribute(ctx context.Context, issues []*issuepkg.Issue) ([]*issuepkg.Issue, error)
+	Status() ShardStatus
+	// HandleForwardedIssues processes issues forwarded by peers
+	HandleForwardedIssues(c *gin.Context)
+}
@@ -0,0 +1,62 @@
+package shard
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// hashRing assigns keys to peers with consistent hashing, so a membership change only moves the
+// keys of the joining or leaving peer. Peers with the same member list build the same ring.
+type hashRing struct {
+	peers  []string
+	points []ringPoint
+}
+
+type ringPoint struct {
+	hash uint64
+	peer string
+}
+
+// newHashRing places virtualNodes points per peer on the ring
+func newHashRing(peers []string, virtualNodes int) *hashRing {
+	peers = slices.Clone(peers)
+	slices.Sort(peers)
+	peers = slices.Compact(peers)
+
+	points := make([]ringPoint, 0, len(peers)*virtualNodes)
+	for _, peer := range peers {
+		for i := range virtualNodes {
+			points = append(points, ringPoint{hash: hashKey(peer + "#" + strconv.Itoa(i)), peer: peer})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].hash != points[j].hash {
+			return points[i].hash < points[j].hash
+		}
+		return points[i].peer < points[j].peer
+	})
+
+	return &hashRing{peers: peers, points: points}
+}
+
+// owner returns the peer owning key, or "" for an empty ring
+func (r *hashRing) owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	hash := hashKey(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= hash })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].peer
+}
+
+// hashKey spreads similar keys, such as the virtual nodes of one peer, evenly over the ring
+func hashKey(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
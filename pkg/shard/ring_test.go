@@ -0,0 +1,45 @@
+package shard
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashRing_Empty(t *testing.T) {
+	assert.Equal(t, "", newHashRing(nil, 16).owner("fingerprint"))
+}
+
+func TestHashRing_DeterministicAndBalanced(t *testing.T) {
+	peers := []string{"10.0.0.3", "10.0.0.1", "10.0.0.2", "10.0.0.1"}
+	ring := newHashRing(peers, 128)
+	same := newHashRing([]string{"10.0.0.2", "10.0.0.3", "10.0.0.1"}, 128)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, ring.peers)
+
+	counts := make(map[string]int)
+	for i := range 3000 {
+		key := "fingerprint-" + strconv.Itoa(i)
+		owner := ring.owner(key)
+		assert.Equal(t, owner, same.owner(key), "replicas with the same peers agree on owners")
+		counts[owner]++
+	}
+	for _, peer := range ring.peers {
+		assert.Greater(t, counts[peer], 600, "peer %s owns a fair share", peer)
+	}
+}
+
+func TestHashRing_MembershipChangeMovesFewKeys(t *testing.T) {
+	before := newHashRing([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, 128)
+	after := newHashRing([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}, 128)
+
+	moved := 0
+	for i := range 3000 {
+		key := "fingerprint-" + strconv.Itoa(i)
+		if owner := after.owner(key); owner != before.owner(key) {
+			assert.Equal(t, "10.0.0.4", owner, "keys only move to the new peer")
+			moved++
+		}
+	}
+	assert.Less(t, moved, 1200)
+}
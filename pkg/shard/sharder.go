@@ -0,0 +1,327 @@
+package shard
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubecano/cano-collector/config"
+	alert_interfaces "github.com/kubecano/cano-collector/pkg/alert/interfaces"
+	issuepkg "github.com/kubecano/cano-collector/pkg/core/issue"
+	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
+	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	shard_interfaces "github.com/kubecano/cano-collector/pkg/shard/interfaces"
+	"github.com/kubecano/cano-collector/pkg/util"
+)
+
+const (
+	// ForwardedIssuesPath is the internal endpoint peers post issues they do not own to
+	ForwardedIssuesPath = "/internal/shard/issues"
+
+	// discoveryTimeout bounds a single peer discovery
+	discoveryTimeout = 10 * time.Second
+)
+
+// Outcomes reported in the cano_shard_issues_total metric
+const (
+	outcomeLocal     = "local"
+	outcomeForwarded = "forwarded"
+	outcomeReceived  = "received"
+	// outcomeFallback counts issues processed locally because their owner was unreachable
+	outcomeFallback = "fallback"
+)
+
+// forwardedIssues is the body of requests to ForwardedIssuesPath
+type forwardedIssues struct {
+	Issues []*issuepkg.Issue `json:"issues"`
+}
+
+// Sharder assigns every fingerprint to one replica with consistent hashing over the discovered
+// peers. Issues owned by other replicas are forwarded to them over the internal port.
+type Sharder struct {
+	cfg        config.ShardingConfig
+	discoverer peerDiscoverer
+	processor  alert_interfaces.IssueProcessorInterface
+	httpClient util.HTTPClient
+	logger     logger_interfaces.LoggerInterface
+	metrics    metric_interfaces.MetricsInterface
+	token      string
+
+	mu   sync.RWMutex
+	ring *hashRing
+}
+
+// NewSharder creates a sharder processing owned issues with processor; client is only used for
+// EndpointSlice discovery
+func NewSharder(
+	cfg config.ShardingConfig,
+	client kubernetes.Interface,
+	processor alert_interfaces.IssueProcessorInterface,
+	httpClient util.HTTPClient,
+	logger logger_interfaces.LoggerInterface,
+	metrics metric_interfaces.MetricsInterface,
+) (*Sharder, error) {
+	s := &Sharder{
+		cfg:       cfg,
+		processor: processor,
+		logger:    logger,
+		metrics:   metrics,
+	}
+	if !cfg.Enabled {
+		return s, nil
+	}
+
+	if net.ParseIP(cfg.SelfAddress) == nil {
+		return nil, fmt.Errorf("sharding requires the IP address of this replica, set POD_IP, got %q", cfg.SelfAddress)
+	}
+	if cfg.Service == "" {
+		return nil, errors.New("sharding requires a service to discover peers, set SHARDING_SERVICE")
+	}
+	if cfg.PeerPort <= 0 {
+		return nil, errors.New("sharding requires an internal port for peers, set SERVER_INTERNAL_PORT")
+	}
+	if cfg.VirtualNodes <= 0 {
+		return nil, fmt.Errorf("sharding virtual nodes must be positive, got %d", cfg.VirtualNodes)
+	}
+
+	switch cfg.Discovery {
+	case "endpointslice":
+		if client == nil {
+			return nil, errors.New("EndpointSlice discovery requires a Kubernetes client")
+		}
+		s.discoverer = &endpointSliceDiscoverer{client: client, namespace: cfg.Namespace, service: cfg.Service}
+	case "dns":
+		s.discoverer = newDNSDiscoverer(cfg.Service)
+	default:
+		return nil, fmt.Errorf("unknown sharding discovery %q", cfg.Discovery)
+	}
+
+	if cfg.TokenFile != "" {
+		data, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sharding token: %w", err)
+		}
+		s.token = strings.TrimSpace(string(data))
+		if s.token == "" {
+			return nil, fmt.Errorf("sharding token file %s is empty", cfg.TokenFile)
+		}
+	}
+
+	if httpClient == nil {
+		httpClient = util.DefaultHTTPClient()
+	}
+	s.httpClient = httpClient
+	// until peers are discovered this replica owns every fingerprint
+	s.ring = newHashRing([]string{cfg.SelfAddress}, cfg.VirtualNodes)
+	return s, nil
+}
+
+func (s *Sharder) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// Run refreshes the peers every refresh interval until ctx is cancelled
+func (s *Sharder) Run(ctx context.Context) {
+	if !s.cfg.Enabled {
+		return
+	}
+
+	s.logger.Info("Starting fingerprint sharding",
+		zap.String("discovery", s.cfg.Discovery),
+		zap.String("service", s.cfg.Service),
+		zap.String("self", s.cfg.SelfAddress))
+	s.refresh(ctx)
+
+	ticker := time.NewTicker(s.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refresh(ctx)
+		}
+	}
+}
+
+// refresh rebuilds the ring from the discovered peers; on failure the previous ring is kept
+func (s *Sharder) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	peers, err := s.discoverer.Peers(ctx)
+	if err != nil {
+		s.logger.Error("Failed to discover shard peers, keeping previous peers", zap.Error(err))
+		return
+	}
+	// this replica is not published while it is not ready, but it still owns its share
+	ring := newHashRing(append(peers, s.cfg.SelfAddress), s.cfg.VirtualNodes)
+
+	s.mu.Lock()
+	changed := !slices.Equal(s.ring.peers, ring.peers)
+	s.ring = ring
+	s.mu.Unlock()
+
+	s.metrics.SetShardPeers(len(ring.peers))
+	if changed {
+		s.logger.Info("Shard peers changed", zap.Strings("peers", ring.peers))
+	}
+}
+
+func (s *Sharder) currentRing() *hashRing {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ring
+}
+
+func (s *Sharder) Status() shard_interfaces.ShardStatus {
+	if !s.cfg.Enabled {
+		return shard_interfaces.ShardStatus{Enabled: false}
+	}
+	return shard_interfaces.ShardStatus{
+		Enabled: true,
+		Self:    s.cfg.SelfAddress,
+		Peers:   slices.Clone(s.currentRing().peers),
+	}
+}
+
+// Distribute forwards issues owned by other replicas to them and returns the issues this replica
+// processes. Issues whose owner is unreachable or rejects them are processed locally, so they are
+// not lost; the returned error reports issues an owner accepted but failed to process.
+func (s *Sharder) Distribute(ctx context.Context, issues []*issuepkg.Issue) ([]*issuepkg.Issue, error) {
+	if !s.cfg.Enabled {
+		return issues, nil
+	}
+
+	ring := s.currentRing()
+	local := make([]*issuepkg.Issue, 0, len(issues))
+	remote := make(map[string][]*issuepkg.Issue)
+	for _, issueItem := range issues {
+		owner := ring.owner(issueItem.Fingerprint)
+		if owner == s.cfg.SelfAddress {
+			local = append(local, issueItem)
+			s.metrics.IncShardIssues(outcomeLocal)
+			continue
+		}
+		remote[owner] = append(remote[owner], issueItem)
+	}
+
+	peers := make([]string, 0, len(remote))
+	for peer := range remote {
+		peers = append(peers, peer)
+	}
+	sort.Strings(peers)
+
+	var errs []error
+	for _, peer := range peers {
+		batch := remote[peer]
+		status, err := s.forward(ctx, peer, batch)
+		switch {
+		case err != nil || (status >= http.StatusMultipleChoices && status < http.StatusInternalServerError):
+			s.logger.Warn("Failed to forward issues to owning replica, processing them locally",
+				zap.String("peer", peer), zap.Int("status", status), zap.Int("issues_count", len(batch)), zap.Error(err))
+			local = append(local, batch...)
+			s.incShardIssues(outcomeFallback, len(batch))
+		case status >= http.StatusInternalServerError:
+			s.incShardIssues(outcomeForwarded, len(batch))
+			errs = append(errs, fmt.Errorf("replica %s failed to process %d issues, status %d", peer, len(batch), status))
+		default:
+			s.incShardIssues(outcomeForwarded, len(batch))
+			s.logger.Debug("Forwarded issues to owning replica", zap.String("peer", peer), zap.Int("issues_count", len(batch)))
+		}
+	}
+
+	return local, errors.Join(errs...)
+}
+
+// ProcessIssues processes the issues owned by this replica and forwards the others to their owners
+func (s *Sharder) ProcessIssues(ctx context.Context, issues []*issuepkg.Issue) error {
+	local, err := s.Distribute(ctx, issues)
+	errs := []error{err}
+	if len(local) > 0 {
+		errs = append(errs, s.processor.ProcessIssues(ctx, local))
+	}
+	return errors.Join(errs...)
+}
+
+// HandleForwardedIssues processes issues forwarded by a peer. They are never forwarded again,
+// so replicas with briefly different peer lists cannot bounce issues between each other.
+func (s *Sharder) HandleForwardedIssues(c *gin.Context) {
+	if s.token != "" && !s.validToken(c.GetHeader("Authorization")) {
+		s.logger.Warn("Rejected forwarded issues with invalid token", zap.String("client_ip", c.ClientIP()))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var batch forwardedIssues
+	if err := json.NewDecoder(c.Request.Body).Decode(&batch); err != nil {
+		s.logger.Error("Failed to parse forwarded issues", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid forwarded issues: " + err.Error()})
+		return
+	}
+	s.incShardIssues(outcomeReceived, len(batch.Issues))
+
+	if err := s.processor.ProcessIssues(c.Request.Context(), batch.Issues); err != nil {
+		s.logger.Error("Failed to process forwarded issues", zap.Error(err), zap.Int("issues_count", len(batch.Issues)))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process issues"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "issues processed", "issues": len(batch.Issues)})
+}
+
+func (s *Sharder) validToken(header string) bool {
+	token, found := strings.CutPrefix(header, "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// forward posts issues to the internal endpoint of peer and returns the response status
+func (s *Sharder) forward(ctx context.Context, peer string, issues []*issuepkg.Issue) (int, error) {
+	body, err := json.Marshal(forwardedIssues{Issues: issues})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode issues: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.ForwardTimeout)
+	defer cancel()
+
+	url := "http://" + net.JoinHostPort(peer, strconv.Itoa(s.cfg.PeerPort)) + ForwardedIssuesPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build forward request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to forward issues: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+func (s *Sharder) incShardIssues(outcome string, count int) {
+	for range count {
+		s.metrics.IncShardIssues(outcome)
+	}
+}
@@ -0,0 +1,372 @@
+package shard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubecano/cano-collector/config"
+	config_team "github.com/kubecano/cano-collector/config/team"
+	config_workflow "github.com/kubecano/cano-collector/config/workflow"
+	"github.com/kubecano/cano-collector/mocks"
+	"github.com/kubecano/cano-collector/pkg/alert"
+	"github.com/kubecano/cano-collector/pkg/core/event"
+	"github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/logger"
+	"github.com/kubecano/cano-collector/pkg/metric"
+)
+
+const (
+	selfAddress = "10.0.0.1"
+	peerAddress = "127.0.0.1"
+)
+
+type staticDiscoverer []string
+
+func (d staticDiscoverer) Peers(context.Context) ([]string, error) {
+	return d, nil
+}
+
+func testShardingConfig() config.ShardingConfig {
+	return config.ShardingConfig{
+		Enabled:         true,
+		Discovery:       "dns",
+		Service:         "cano-collector-peers.monitoring.svc",
+		Namespace:       "monitoring",
+		SelfAddress:     selfAddress,
+		PeerPort:        9090,
+		RefreshInterval: time.Minute,
+		ForwardTimeout:  time.Second,
+		VirtualNodes:    64,
+	}
+}
+
+type sharderTestDeps struct {
+	sharder   *Sharder
+	processor *mocks.MockIssueProcessorInterface
+	metrics   *mocks.MockMetricsInterface
+}
+
+func setupSharderTest(t *testing.T, cfg config.ShardingConfig) sharderTestDeps {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+	mockMetrics.EXPECT().SetShardPeers(gomock.Any()).AnyTimes()
+	mockProcessor := mocks.NewMockIssueProcessorInterface(ctrl)
+
+	sharder, err := NewSharder(cfg, nil, mockProcessor, nil, mockLogger, mockMetrics)
+	require.NoError(t, err)
+	return sharderTestDeps{sharder: sharder, processor: mockProcessor, metrics: mockMetrics}
+}
+
+// withPeer adds peerAddress to the ring and points the peer port at server
+func withPeer(t *testing.T, s *Sharder, server *httptest.Server) {
+	t.Helper()
+	if server != nil {
+		_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		require.NoError(t, err)
+		s.cfg.PeerPort, _ = strconv.Atoi(port)
+	}
+	s.discoverer = staticDiscoverer{peerAddress}
+	s.refresh(context.Background())
+}
+
+// issuesByOwner returns one issue owned by this replica and one owned by the peer
+func issuesByOwner(t *testing.T, s *Sharder) (*issue.Issue, *issue.Issue) {
+	t.Helper()
+	var local, remote *issue.Issue
+	for i := 0; local == nil || remote == nil; i++ {
+		iss := issue.NewIssue("Issue "+strconv.Itoa(i), "Alert"+strconv.Itoa(i))
+		if s.currentRing().owner(iss.Fingerprint) == selfAddress {
+			local = iss
+		} else {
+			remote = iss
+		}
+	}
+	return local, remote
+}
+
+func TestSharder_Disabled(t *testing.T) {
+	cfg := testShardingConfig()
+	cfg.Enabled = false
+	deps := setupSharderTest(t, cfg)
+
+	issues := []*issue.Issue{issue.NewIssue("a", "a"), issue.NewIssue("b", "b")}
+	deps.processor.EXPECT().ProcessIssues(gomock.Any(), issues).Return(nil).Times(1)
+
+	assert.False(t, deps.sharder.Enabled())
+	assert.False(t, deps.sharder.Status().Enabled)
+	require.NoError(t, deps.sharder.ProcessIssues(context.Background(), issues))
+}
+
+func TestNewSharder_InvalidConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.ShardingConfig)
+		err    string
+	}{
+		{"missing self address", func(cfg *config.ShardingConfig) { cfg.SelfAddress = "" }, "POD_IP"},
+		{"missing service", func(cfg *config.ShardingConfig) { cfg.Service = "" }, "SHARDING_SERVICE"},
+		{"missing peer port", func(cfg *config.ShardingConfig) { cfg.PeerPort = 0 }, "SERVER_INTERNAL_PORT"},
+		{"endpointslice without client", func(cfg *config.ShardingConfig) { cfg.Discovery = "endpointslice" }, "Kubernetes client"},
+		{"missing token file", func(cfg *config.ShardingConfig) { cfg.TokenFile = "/nonexistent/token" }, "sharding token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testShardingConfig()
+			tt.modify(&cfg)
+			_, err := NewSharder(cfg, nil, nil, nil, mockLogger, mockMetrics)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestSharder_RefreshKeepsSelf(t *testing.T) {
+	deps := setupSharderTest(t, testShardingConfig())
+	s := deps.sharder
+	assert.Equal(t, []string{selfAddress}, s.Status().Peers)
+
+	s.discoverer = staticDiscoverer{"10.0.0.3", "10.0.0.2"}
+	s.refresh(context.Background())
+
+	status := s.Status()
+	assert.True(t, status.Enabled)
+	assert.Equal(t, selfAddress, status.Self)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, status.Peers)
+}
+
+func TestSharder_ForwardsIssuesToOwner(t *testing.T) {
+	cfg := testShardingConfig()
+	deps := setupSharderTest(t, cfg)
+	s := deps.sharder
+
+	// the peer is another sharder receiving the forwarded issues
+	peer := setupSharderTest(t, cfg)
+	router := gin.New()
+	router.POST(ForwardedIssuesPath, peer.sharder.HandleForwardedIssues)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	withPeer(t, s, server)
+
+	local, remote := issuesByOwner(t, s)
+	remote.AddEnrichment(*issue.NewEnrichmentWithType(issue.EnrichmentTypeAlertLabels, "Labels"))
+
+	peer.metrics.EXPECT().IncShardIssues(outcomeReceived).Times(1)
+	peer.processor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, issues []*issue.Issue) error {
+			require.Len(t, issues, 1)
+			assert.Equal(t, remote.Fingerprint, issues[0].Fingerprint)
+			assert.Equal(t, remote.Title, issues[0].Title)
+			assert.Len(t, issues[0].Enrichments, 1)
+			return nil
+		}).Times(1)
+	deps.metrics.EXPECT().IncShardIssues(outcomeLocal).Times(1)
+	deps.metrics.EXPECT().IncShardIssues(outcomeForwarded).Times(1)
+	deps.processor.EXPECT().ProcessIssues(gomock.Any(), []*issue.Issue{local}).Return(nil).Times(1)
+
+	require.NoError(t, s.ProcessIssues(context.Background(), []*issue.Issue{local, remote}))
+}
+
+func TestSharder_UnreachableOwnerFallsBackToLocal(t *testing.T) {
+	deps := setupSharderTest(t, testShardingConfig())
+	s := deps.sharder
+	server := httptest.NewServer(http.NotFoundHandler())
+	withPeer(t, s, server)
+	server.Close()
+
+	local, remote := issuesByOwner(t, s)
+	deps.metrics.EXPECT().IncShardIssues(outcomeLocal).Times(1)
+	deps.metrics.EXPECT().IncShardIssues(outcomeFallback).Times(1)
+
+	issues, err := s.Distribute(context.Background(), []*issue.Issue{local, remote})
+	require.NoError(t, err)
+	assert.Equal(t, []*issue.Issue{local, remote}, issues)
+}
+
+func TestSharder_OwnerFailureIsReported(t *testing.T) {
+	deps := setupSharderTest(t, testShardingConfig())
+	s := deps.sharder
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	withPeer(t, s, server)
+
+	_, remote := issuesByOwner(t, s)
+	deps.metrics.EXPECT().IncShardIssues(outcomeForwarded).Times(1)
+
+	issues, err := s.Distribute(context.Background(), []*issue.Issue{remote})
+	assert.Empty(t, issues)
+	assert.ErrorContains(t, err, "status 500")
+}
+
+func TestSharder_HandleForwardedIssues_Token(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("s3cret\n"), 0o600))
+	cfg := testShardingConfig()
+	cfg.TokenFile = tokenFile
+	deps := setupSharderTest(t, cfg)
+
+	router := gin.New()
+	router.POST(ForwardedIssuesPath, deps.sharder.HandleForwardedIssues)
+	post := func(authorization string) int {
+		req := httptest.NewRequest(http.MethodPost, ForwardedIssuesPath, strings.NewReader(`{"issues":[]}`))
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, post(""))
+	assert.Equal(t, http.StatusUnauthorized, post("Bearer wrong"))
+
+	deps.processor.EXPECT().ProcessIssues(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	assert.Equal(t, http.StatusOK, post("Bearer s3cret"))
+}
+
+func TestSharder_HandleForwardedIssues_InvalidBody(t *testing.T) {
+	deps := setupSharderTest(t, testShardingConfig())
+	router := gin.New()
+	router.POST(ForwardedIssuesPath, deps.sharder.HandleForwardedIssues)
+
+	req := httptest.NewRequest(http.MethodPost, ForwardedIssuesPath, strings.NewReader(`not json`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSharder_ForwardedAlertsProcessedLikeLocalAlerts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	log := logger.NewLogger("error", "test")
+	metrics := metric.NewMetricsCollector(log)
+	teams := config_team.TeamsConfig{Teams: []config_team.Team{
+		{Name: "platform", Destinations: []string{"platform-slack"}},
+		{Name: "payments", Destinations: []string{"payments-slack"}, Namespaces: []string{"payments"}},
+	}}
+
+	// workflows only enrich issues whose event carries the labels of the alert
+	workflowEngine := mocks.NewMockWorkflowEngineInterface(ctrl)
+	workflowEngine.EXPECT().SelectWorkflows(gomock.Any()).Return([]*config_workflow.WorkflowDefinition{{Name: "pod-details"}}).AnyTimes()
+	workflowEngine.EXPECT().ExecuteWorkflowsWithEnrichments(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ []*config_workflow.WorkflowDefinition, workflowEvent event.WorkflowEvent) ([]issue.Enrichment, error) {
+			alertEvent, ok := workflowEvent.(*event.AlertManagerWorkflowEvent)
+			if !ok {
+				return nil, nil
+			}
+			labels := alertEvent.GetLabels()
+			return []issue.Enrichment{{Title: "Pod", Content: labels["namespace"] + "/" + labels["pod"] + " " + alertEvent.GetStatus()}}, nil
+		}).AnyTimes()
+
+	var mu sync.Mutex
+	dispatched := make(map[string]*issue.Issue)
+	teamOf := make(map[string]string)
+	dispatcher := mocks.NewMockAlertDispatcherInterface(ctrl)
+	dispatcher.EXPECT().DispatchIssues(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, issues []*issue.Issue, team *config_team.Team) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, issueItem := range issues {
+				dispatched[issueItem.Fingerprint] = issueItem
+				teamOf[issueItem.Fingerprint] = team.Name
+			}
+			return nil
+		}).Times(2)
+
+	// both replicas run the same configuration
+	newReplica := func() (*alert.AlertHandler, *Sharder) {
+		resolver := alert.NewTeamResolver(teams, log, metrics)
+		processor := alert.NewIssueProcessor(log, metrics, resolver, dispatcher, workflowEngine)
+		sharder, err := NewSharder(testShardingConfig(), nil, processor, nil, log, metrics)
+		require.NoError(t, err)
+		handler := alert.NewAlertHandler(log, metrics, resolver, dispatcher, alert.NewConverter(log), workflowEngine)
+		handler.SetSharder(sharder)
+		return handler, sharder
+	}
+	handler, sharder := newReplica()
+	_, peer := newReplica()
+	router := gin.New()
+	router.POST(ForwardedIssuesPath, peer.HandleForwardedIssues)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	withPeer(t, sharder, server)
+
+	// one alert owned by this replica and one forwarded to the peer
+	var localFingerprint, remoteFingerprint string
+	for i := 0; localFingerprint == "" || remoteFingerprint == ""; i++ {
+		fingerprint := "fingerprint-" + strconv.Itoa(i)
+		if sharder.currentRing().owner(fingerprint) == selfAddress {
+			localFingerprint = fingerprint
+		} else {
+			remoteFingerprint = fingerprint
+		}
+	}
+	newAlert := func(fingerprint string) template.Alert {
+		return template.Alert{
+			Status:      "firing",
+			Labels:      map[string]string{"alertname": "KubePodCrashLooping", "namespace": "payments", "pod": "api-0"},
+			Annotations: map[string]string{"summary": "Pod is crash looping"},
+			StartsAt:    time.Now(),
+			Fingerprint: fingerprint,
+		}
+	}
+	body, err := json.Marshal(template.Data{
+		Receiver: "test-receiver",
+		Status:   "firing",
+		Alerts:   []template.Alert{newAlert(localFingerprint), newAlert(remoteFingerprint)},
+	})
+	require.NoError(t, err)
+
+	alertRouter := gin.New()
+	alertRouter.POST("/alert", handler.HandleAlert)
+	req := httptest.NewRequest(http.MethodPost, "/alert", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	alertRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	local, remote := dispatched[localFingerprint], dispatched[remoteFingerprint]
+	require.NotNil(t, local)
+	require.NotNil(t, remote)
+	assert.Equal(t, "payments", teamOf[localFingerprint])
+	assert.Equal(t, teamOf[localFingerprint], teamOf[remoteFingerprint])
+	assert.Contains(t, local.Enrichments, issue.Enrichment{Title: "Pod", Content: "payments/api-0 firing"})
+	// the forwarded issue went through JSON, so the enrichments are compared as JSON
+	localEnrichments, err := json.Marshal(local.Enrichments)
+	require.NoError(t, err)
+	remoteEnrichments, err := json.Marshal(remote.Enrichments)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(localEnrichments), string(remoteEnrichments))
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"crypto/tls"
 	"net"
 	"net/http"
 	"sync"
@@ -16,6 +17,13 @@ type HTTPClient interface {
 
 // DefaultHTTPClient returns a standard HTTP client with sane defaults and connection pooling
 func DefaultHTTPClient() HTTPClient {
+	return NewHTTPClientWithTLS(nil)
+}
+
+// NewHTTPClientWithTLS returns an HTTP client with the defaults of DefaultHTTPClient that uses
+// tlsConfig for its connections, e.g. to trust a private CA or to present a client certificate.
+// A nil tlsConfig uses the default TLS settings.
+func NewHTTPClientWithTLS(tlsConfig *tls.Config) HTTPClient {
 	return &http.Client{
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
@@ -23,6 +31,7 @@ func DefaultHTTPClient() HTTPClient {
 				Timeout:   10 * time.Second,
 				KeepAlive: 30 * time.Second,
 			}).DialContext,
+			TLSClientConfig:     tlsConfig,
 			TLSHandshakeTimeout: 10 * time.Second,
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 10,
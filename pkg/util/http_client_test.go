@@ -1,6 +1,7 @@
 package util
 
 import (
+	"crypto/tls"
 	"net/http"
 	"testing"
 	"time"
@@ -60,3 +61,17 @@ func TestGetSharedHTTPClient(t *testing.T) {
 	// Transport types should be the same
 	assert.IsType(t, defaultHTTPClient.Transport, sharedHTTPClient.Transport)
 }
+
+func TestNewHTTPClientWithTLS(t *testing.T) {
+	tlsConfig := &tls.Config{ServerName: "webhooks.internal", MinVersion: tls.VersionTLS12}
+	client := NewHTTPClientWithTLS(tlsConfig)
+
+	httpClient, ok := client.(*http.Client)
+	require.True(t, ok, "Expected client to be *http.Client")
+	assert.Equal(t, 30*time.Second, httpClient.Timeout)
+
+	transport, ok := httpClient.Transport.(*http.Transport)
+	require.True(t, ok, "Expected transport to be *http.Transport")
+	assert.Same(t, tlsConfig, transport.TLSClientConfig)
+	assert.Equal(t, 100, transport.MaxIdleConns)
+}
@@ -218,35 +218,3 @@ func (ba *BaseAction) GetFirstAlert(alertEvent *core_event.AlertManagerWorkflowE
 	}
 	return &alertEvent.GetAlertManagerEvent().Alerts[0], nil
 }
-
-// getMapParameter retrieves a map parameter from action configuration
-func (ba *BaseAction) getMapParameter(key string) map[string]string {
-	if value, exists := ba.GetParameter(key); exists {
-		if mapValue, ok := value.(map[string]interface{}); ok {
-			result := make(map[string]string)
-			for k, v := range mapValue {
-				if strValue, ok := v.(string); ok {
-					result[k] = strValue
-				}
-			}
-			return result
-		}
-	}
-	return make(map[string]string)
-}
-
-// getSliceParameter retrieves a slice parameter from action configuration
-func (ba *BaseAction) getSliceParameter(key string) []string {
-	if value, exists := ba.GetParameter(key); exists {
-		if sliceValue, ok := value.([]interface{}); ok {
-			result := make([]string, 0, len(sliceValue))
-			for _, v := range sliceValue {
-				if strValue, ok := v.(string); ok {
-					result = append(result, strValue)
-				}
-			}
-			return result
-		}
-	}
-	return make([]string, 0)
-}
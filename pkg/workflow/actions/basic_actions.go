@@ -10,6 +10,7 @@ import (
 
 	"go.uber.org/zap"
 
+	actions_config "github.com/kubecano/cano-collector/config/workflow/actions"
 	core_event "github.com/kubecano/cano-collector/pkg/core/event"
 	"github.com/kubecano/cano-collector/pkg/core/issue"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
@@ -20,17 +21,19 @@ import (
 // LabelFilterAction filters alerts based on label matching rules
 type LabelFilterAction struct {
 	*BaseAction
+	config actions_config.LabelFilterActionConfig
 }
 
 // NewLabelFilterAction creates a new LabelFilterAction
 func NewLabelFilterAction(
-	config actions_interfaces.ActionConfig,
+	config actions_config.LabelFilterActionConfig,
 	logger logger_interfaces.LoggerInterface,
 	metrics metric_interfaces.MetricsInterface,
 ) *LabelFilterAction {
-	baseAction := NewBaseAction(config, logger, metrics)
+	baseAction := NewBaseAction(config.ActionConfig, logger, metrics)
 	return &LabelFilterAction{
 		BaseAction: baseAction,
+		config:     config,
 	}
 }
 
@@ -89,8 +92,8 @@ func (a *LabelFilterAction) Execute(ctx context.Context, event core_event.Workfl
 // shouldPassFilter checks if alert labels match the configured filter rules
 func (a *LabelFilterAction) shouldPassFilter(labels map[string]string) (bool, string) {
 	// Get include labels filter
-	if includeLabels := a.getMapParameter("include_labels"); len(includeLabels) > 0 {
-		for labelKey, expectedValue := range includeLabels {
+	if len(a.config.IncludeLabels) > 0 {
+		for labelKey, expectedValue := range a.config.IncludeLabels {
 			actualValue, exists := labels[labelKey]
 			if !exists {
 				return false, "missing required include label: " + labelKey
@@ -102,8 +105,8 @@ func (a *LabelFilterAction) shouldPassFilter(labels map[string]string) (bool, st
 	}
 
 	// Get exclude labels filter
-	if excludeLabels := a.getMapParameter("exclude_labels"); len(excludeLabels) > 0 {
-		for labelKey, excludeValue := range excludeLabels {
+	if len(a.config.ExcludeLabels) > 0 {
+		for labelKey, excludeValue := range a.config.ExcludeLabels {
 			actualValue, exists := labels[labelKey]
 			if exists {
 				if excludeValue == "" || actualValue == excludeValue {
@@ -114,8 +117,8 @@ func (a *LabelFilterAction) shouldPassFilter(labels map[string]string) (bool, st
 	}
 
 	// Get required labels filter
-	if requiredLabels := a.getSliceParameter("required_labels"); len(requiredLabels) > 0 {
-		for _, requiredLabel := range requiredLabels {
+	if len(a.config.RequiredLabels) > 0 {
+		for _, requiredLabel := range a.config.RequiredLabels {
 			if _, exists := labels[requiredLabel]; !exists {
 				return false, "missing required label: " + requiredLabel
 			}
@@ -131,32 +134,25 @@ func (a *LabelFilterAction) Validate() error {
 		return err
 	}
 
-	// Validate that at least one filter type is configured
-	includeLabels := a.getMapParameter("include_labels")
-	excludeLabels := a.getMapParameter("exclude_labels")
-	requiredLabels := a.getSliceParameter("required_labels")
-
-	if len(includeLabels) == 0 && len(excludeLabels) == 0 && len(requiredLabels) == 0 {
-		return fmt.Errorf("label filter action must have at least one filter configured (include_labels, exclude_labels, or required_labels)")
-	}
-
-	return nil
+	return a.config.Validate()
 }
 
 // SeverityRouterAction routes alerts to different destinations based on severity level
 type SeverityRouterAction struct {
 	*BaseAction
+	config actions_config.SeverityRouterActionConfig
 }
 
 // NewSeverityRouterAction creates a new SeverityRouterAction
 func NewSeverityRouterAction(
-	config actions_interfaces.ActionConfig,
+	config actions_config.SeverityRouterActionConfig,
 	logger logger_interfaces.LoggerInterface,
 	metrics metric_interfaces.MetricsInterface,
 ) *SeverityRouterAction {
-	baseAction := NewBaseAction(config, logger, metrics)
+	baseAction := NewBaseAction(config.ActionConfig, logger, metrics)
 	return &SeverityRouterAction{
 		BaseAction: baseAction,
+		config:     config,
 	}
 }
 
@@ -247,7 +243,7 @@ func (a *SeverityRouterAction) extractSeverityFromAlert(alert core_event.Prometh
 
 // getDestinationForSeverity gets the destination mapping for a given severity
 func (a *SeverityRouterAction) getDestinationForSeverity(severity issue.Severity, originalLabel string) string {
-	severityMapping := a.getSeverityMapping()
+	severityMapping := a.config.SeverityMapping
 
 	// Try original Prometheus label value first (e.g., "critical")
 	if originalLabel != "" {
@@ -277,49 +273,13 @@ func (a *SeverityRouterAction) getDestinationForSeverity(severity issue.Severity
 	return ""
 }
 
-// getSeverityMapping retrieves severity to destination mapping from configuration
-func (a *SeverityRouterAction) getSeverityMapping() map[string]string {
-	if value, exists := a.GetParameter("severity_mapping"); exists {
-		if mapValue, ok := value.(map[string]interface{}); ok {
-			result := make(map[string]string)
-			for k, v := range mapValue {
-				if strValue, ok := v.(string); ok {
-					result[k] = strValue
-				}
-			}
-			return result
-		}
-	}
-	return make(map[string]string)
-}
-
 // Validate validates the SeverityRouterAction configuration
 func (a *SeverityRouterAction) Validate() error {
 	if err := a.ValidateBasicConfig(); err != nil {
 		return err
 	}
 
-	// Validate that severity mapping is configured
-	severityMapping := a.getSeverityMapping()
-	if len(severityMapping) == 0 {
-		return fmt.Errorf("severity router action must have severity_mapping parameter configured")
-	}
-
-	// Validate that all configured severities are valid
-	validSeverities := map[string]bool{
-		"debug": true, "info": true, "low": true, "high": true, "default": true,
-		"DEBUG": true, "INFO": true, "LOW": true, "HIGH": true,
-		// Also accept common Prometheus label values
-		"critical": true, "warning": true, "CRITICAL": true, "WARNING": true,
-	}
-
-	for severity := range severityMapping {
-		if !validSeverities[severity] {
-			return fmt.Errorf("invalid severity level in mapping: %s (valid: debug, info, low, high, default)", severity)
-		}
-	}
-
-	return nil
+	return a.config.Validate()
 }
 
 // ============================================================================
@@ -335,17 +295,19 @@ const (
 // This action focuses on adding enrichments, metadata, and custom processing to existing Issues
 type IssueEnrichmentAction struct {
 	*BaseAction
+	config actions_config.IssueEnrichmentActionConfig
 }
 
 // NewIssueEnrichmentAction creates a new IssueEnrichmentAction
 func NewIssueEnrichmentAction(
-	config actions_interfaces.ActionConfig,
+	config actions_config.IssueEnrichmentActionConfig,
 	logger logger_interfaces.LoggerInterface,
 	metrics metric_interfaces.MetricsInterface,
 ) *IssueEnrichmentAction {
-	baseAction := NewBaseAction(config, logger, metrics)
+	baseAction := NewBaseAction(config.ActionConfig, logger, metrics)
 	return &IssueEnrichmentAction{
 		BaseAction: baseAction,
+		config:     config,
 	}
 }
 
@@ -404,14 +366,14 @@ func (a *IssueEnrichmentAction) createIssueEnrichments(alertEvent *core_event.Al
 
 	// Create metadata enrichment if enabled
 	// Note: Labels and Annotations are already added by label_enrichment.go in the converter
-	if a.GetBoolParameter("include_metadata", true) {
+	if a.config.IncludeMetadata {
 		metadataEnrichment := a.createConsolidatedDetailsEnrichment(alertEvent)
 		enrichments = append(enrichments, *metadataEnrichment)
 	}
 
 	// Add custom title/description if configured
-	if customTitle := a.GetStringParameter("custom_title", ""); customTitle != "" {
-		titleEnrichment := a.createTitleEnrichment(customTitle, alertEvent)
+	if a.config.CustomTitle != "" {
+		titleEnrichment := a.createTitleEnrichment(a.config.CustomTitle, alertEvent)
 		enrichments = append(enrichments, *titleEnrichment)
 	}
 
@@ -425,7 +387,7 @@ func (a *IssueEnrichmentAction) createConsolidatedDetailsEnrichment(alertEvent *
 	enrichment := issue.NewEnrichmentWithType(issue.EnrichmentTypeAlertMetadata, "Alert Metadata")
 
 	// Add metadata section if enabled
-	if a.GetBoolParameter("include_metadata", true) {
+	if a.config.IncludeMetadata {
 		var rows [][]string
 		rows = append(rows, []string{"receiver", alertEvent.Receiver})
 		rows = append(rows, []string{"status", alertEvent.Status})
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	actions_config "github.com/kubecano/cano-collector/config/workflow/actions"
 	"github.com/kubecano/cano-collector/mocks"
 	"github.com/kubecano/cano-collector/pkg/core/event"
 	actions_interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
@@ -71,6 +72,27 @@ func createTestAlertManagerEventNoAlerts() event.WorkflowEvent {
 	return event.NewAlertManagerWorkflowEvent(alertManagerEvent)
 }
 
+// labelFilterConfig decodes the parameters of config like LabelFilterActionFactory, without validation
+func labelFilterConfig(t *testing.T, config actions_interfaces.ActionConfig) actions_config.LabelFilterActionConfig {
+	labelFilterConfig := actions_config.NewLabelFilterActionConfigWithDefaults(config)
+	require.NoError(t, labelFilterConfig.UpdateFromParameters(config.Parameters))
+	return labelFilterConfig
+}
+
+// severityRouterConfig decodes the parameters of config like SeverityRouterActionFactory, without validation
+func severityRouterConfig(t *testing.T, config actions_interfaces.ActionConfig) actions_config.SeverityRouterActionConfig {
+	severityRouterConfig := actions_config.NewSeverityRouterActionConfigWithDefaults(config)
+	require.NoError(t, severityRouterConfig.UpdateFromParameters(config.Parameters))
+	return severityRouterConfig
+}
+
+// issueEnrichmentConfig decodes the parameters of config like IssueEnrichmentActionFactory, without validation
+func issueEnrichmentConfig(t *testing.T, config actions_interfaces.ActionConfig) actions_config.IssueEnrichmentActionConfig {
+	issueEnrichmentConfig := actions_config.NewIssueEnrichmentActionConfigWithDefaults(config)
+	require.NoError(t, issueEnrichmentConfig.UpdateFromParameters(config.Parameters))
+	return issueEnrichmentConfig
+}
+
 func TestLabelFilterAction_Execute_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -93,7 +115,7 @@ func TestLabelFilterAction_Execute_Success(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewLabelFilterAction(config, mockLogger, mockMetrics)
+	action := NewLabelFilterAction(labelFilterConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event with matching labels
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "critical", "default", map[string]string{
@@ -128,7 +150,7 @@ func TestLabelFilterAction_Execute_FilteredOut_MissingIncludeLabel(t *testing.T)
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewLabelFilterAction(config, mockLogger, mockMetrics)
+	action := NewLabelFilterAction(labelFilterConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event missing required include label (no team label)
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "critical", "default", nil)
@@ -161,7 +183,7 @@ func TestLabelFilterAction_Execute_FilteredOut_ExcludeLabel(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewLabelFilterAction(config, mockLogger, mockMetrics)
+	action := NewLabelFilterAction(labelFilterConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event with excluded label
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "critical", "default", map[string]string{
@@ -193,7 +215,7 @@ func TestLabelFilterAction_Execute_FilteredOut_MissingRequiredLabel(t *testing.T
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewLabelFilterAction(config, mockLogger, mockMetrics)
+	action := NewLabelFilterAction(labelFilterConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event missing required label (missing namespace)
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "critical", "", nil)
@@ -226,7 +248,7 @@ func TestLabelFilterAction_Execute_NoAlertsInEvent(t *testing.T) {
 	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewLabelFilterAction(config, mockLogger, mockMetrics)
+	action := NewLabelFilterAction(labelFilterConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event with no alerts
 	alertEvent := createTestAlertManagerEventNoAlerts()
@@ -259,7 +281,7 @@ func TestSeverityRouterAction_Execute_Success(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewSeverityRouterAction(config, mockLogger, mockMetrics)
+	action := NewSeverityRouterAction(severityRouterConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event with critical severity
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "critical", "default", nil)
@@ -292,7 +314,7 @@ func TestSeverityRouterAction_Execute_NoMappingFound(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewSeverityRouterAction(config, mockLogger, mockMetrics)
+	action := NewSeverityRouterAction(severityRouterConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event with info severity (not in mapping)
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "info", "default", nil)
@@ -326,7 +348,7 @@ func TestSeverityRouterAction_Execute_DefaultMapping(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewSeverityRouterAction(config, mockLogger, mockMetrics)
+	action := NewSeverityRouterAction(severityRouterConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event with info severity (should use default)
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "info", "default", nil)
@@ -356,7 +378,7 @@ func TestLabelFilterAction_Validate_Success(t *testing.T) {
 		},
 	}
 
-	action := NewLabelFilterAction(config, mockLogger, mockMetrics)
+	action := NewLabelFilterAction(labelFilterConfig(t, config), mockLogger, mockMetrics)
 	err := action.Validate()
 	assert.NoError(t, err)
 }
@@ -374,7 +396,7 @@ func TestLabelFilterAction_Validate_NoFiltersConfigured(t *testing.T) {
 		Parameters: map[string]interface{}{},
 	}
 
-	action := NewLabelFilterAction(config, mockLogger, mockMetrics)
+	action := NewLabelFilterAction(labelFilterConfig(t, config), mockLogger, mockMetrics)
 	err := action.Validate()
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "must have at least one filter configured")
@@ -398,7 +420,7 @@ func TestSeverityRouterAction_Validate_Success(t *testing.T) {
 		},
 	}
 
-	action := NewSeverityRouterAction(config, mockLogger, mockMetrics)
+	action := NewSeverityRouterAction(severityRouterConfig(t, config), mockLogger, mockMetrics)
 	err := action.Validate()
 	assert.NoError(t, err)
 }
@@ -410,13 +432,12 @@ func TestSeverityRouterAction_Validate_NoMappingConfigured(t *testing.T) {
 	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
 
 	config := actions_interfaces.ActionConfig{
-		Name:       "test-severity-router",
-		Type:       "severity_router",
-		Enabled:    true,
-		Parameters: map[string]interface{}{},
+		Name:    "test-severity-router",
+		Type:    "severity_router",
+		Enabled: true,
 	}
 
-	action := NewSeverityRouterAction(config, mockLogger, mockMetrics)
+	action := NewSeverityRouterAction(actions_config.NewSeverityRouterActionConfigWithDefaults(config), mockLogger, mockMetrics)
 	err := action.Validate()
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "must have severity_mapping parameter configured")
@@ -439,7 +460,7 @@ func TestSeverityRouterAction_Validate_InvalidSeverity(t *testing.T) {
 		},
 	}
 
-	action := NewSeverityRouterAction(config, mockLogger, mockMetrics)
+	action := NewSeverityRouterAction(severityRouterConfig(t, config), mockLogger, mockMetrics)
 	err := action.Validate()
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid severity level in mapping: invalid")
@@ -472,6 +493,31 @@ func TestLabelFilterActionFactory_Create_Success(t *testing.T) {
 	assert.Equal(t, "test-label-filter", action.GetName())
 }
 
+func TestLabelFilterActionFactory_Create_InvalidParameters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockLogger := mocks.NewMockLoggerInterface(ctrl)
+	mockMetrics := mocks.NewMockMetricsInterface(ctrl)
+
+	factory := NewLabelFilterActionFactory(mockLogger, mockMetrics)
+
+	config := actions_interfaces.ActionConfig{
+		Name: "test-label-filter",
+		Type: "label_filter",
+		Parameters: map[string]interface{}{
+			"include_label": map[string]interface{}{"team": "payments"},
+		},
+	}
+	_, err := factory.Create(config)
+	assert.EqualError(t, err, `invalid parameters: unknown parameter "include_label"`)
+
+	// a filter without any rule is rejected when it is created, not when an alert arrives
+	config.Parameters = map[string]interface{}{}
+	_, err = factory.Create(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must have at least one filter configured")
+}
+
 func TestLabelFilterActionFactory_GetActionType(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -541,7 +587,7 @@ func TestIssueEnrichmentAction_Execute_Success(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewIssueEnrichmentAction(config, mockLogger, mockMetrics)
+	action := NewIssueEnrichmentAction(issueEnrichmentConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "critical", "default", map[string]string{
@@ -578,7 +624,7 @@ func TestIssueEnrichmentAction_Execute_MinimalConfig(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewIssueEnrichmentAction(config, mockLogger, mockMetrics)
+	action := NewIssueEnrichmentAction(issueEnrichmentConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "critical", "default", nil)
@@ -610,7 +656,7 @@ func TestIssueEnrichmentAction_Execute_NoAlertsInEvent(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewIssueEnrichmentAction(config, mockLogger, mockMetrics)
+	action := NewIssueEnrichmentAction(issueEnrichmentConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event with no alerts
 	alertEvent := createTestAlertManagerEventNoAlerts()
@@ -643,7 +689,7 @@ func TestIssueEnrichmentAction_Execute_OnlyCustomTitle(t *testing.T) {
 	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
 
-	action := NewIssueEnrichmentAction(config, mockLogger, mockMetrics)
+	action := NewIssueEnrichmentAction(issueEnrichmentConfig(t, config), mockLogger, mockMetrics)
 
 	// Create test event
 	alertEvent := createTestAlertManagerEvent("firing", "TestAlert", "critical", "default", nil)
@@ -671,7 +717,7 @@ func TestIssueEnrichmentAction_Validate_Success(t *testing.T) {
 		},
 	}
 
-	action := NewIssueEnrichmentAction(config, mockLogger, mockMetrics)
+	action := NewIssueEnrichmentAction(issueEnrichmentConfig(t, config), mockLogger, mockMetrics)
 	err := action.Validate()
 	assert.NoError(t, err)
 }
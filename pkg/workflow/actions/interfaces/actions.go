@@ -5,6 +5,7 @@ import (
 
 	"github.com/kubecano/cano-collector/pkg/core/event"
 	"github.com/kubecano/cano-collector/pkg/core/issue"
+	"github.com/kubecano/cano-collector/pkg/schema"
 )
 
 //go:generate mockgen -source=actions.go -destination=../../../../mocks/workflow_action_mock.go -package=mocks
@@ -97,4 +98,7 @@ type ActionFactory interface {
 
 	// ValidateConfig validates the action configuration
 	ValidateConfig(config ActionConfig) error
+
+	// ParameterSchema returns the JSON Schema of the action parameters
+	ParameterSchema() *schema.Schema
 }
@@ -41,6 +41,14 @@ func NewRealKubernetesClient(logger logger_interfaces.LoggerInterface) (*RealKub
 	}, nil
 }
 
+// NewKubernetesClient creates a client using clientset, for example a fake clientset
+func NewKubernetesClient(clientset kubernetes.Interface, logger logger_interfaces.LoggerInterface) *RealKubernetesClient {
+	return &RealKubernetesClient{
+		clientset: clientset,
+		logger:    logger,
+	}
+}
+
 // GetPodLogs retrieves pod logs using kubernetes client-go
 func (r *RealKubernetesClient) GetPodLogs(ctx context.Context, namespace, podName string, options map[string]interface{}) (string, error) {
 	r.logger.Info("Fetching pod logs",
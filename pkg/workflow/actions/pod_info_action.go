@@ -182,16 +182,23 @@ func (a *PodInfoAction) extractPodInfo(workflowEvent event.WorkflowEvent) (strin
 		namespace = "default"
 	}
 
-	// Check if pod name is provided in action parameters
-	if podName := a.GetStringParameter("pod_name", ""); podName != "" {
-		return podName, namespace
-	}
-
 	// Check if config has pod name override
 	if a.config.PodName != "" {
 		return a.config.PodName, namespace
 	}
 
+	// Use the issue subject if this event was built from a non-Alertmanager issue
+	if issueEvent, ok := workflowEvent.(*event.IssueWorkflowEvent); ok {
+		subject := issueEvent.GetIssue().Subject
+		if subject != nil && subject.SubjectType == issue.SubjectTypePod && subject.Name != "" {
+			a.logger.Info("Pod info extracted from issue subject",
+				zap.String("pod", subject.Name),
+				zap.String("namespace", namespace),
+			)
+			return subject.Name, namespace
+		}
+	}
+
 	// Try to extract from alert labels if this is an AlertManager event
 	if alertEvent, ok := workflowEvent.(*event.AlertManagerWorkflowEvent); ok {
 		labels := alertEvent.GetAlertManagerEvent().GetLabels()
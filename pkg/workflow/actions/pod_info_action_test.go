@@ -549,3 +549,30 @@ func TestPodInfoAction_ExtractPodInfo_FromConfig(t *testing.T) {
 	assert.Equal(t, "config-pod", podName)
 	assert.Equal(t, "test-namespace", namespace)
 }
+
+func TestPodInfoAction_ExtractPodInfo_FromIssueSubject(t *testing.T) {
+	config := pod_info_config.PodInfoActionConfig{
+		ActionConfig: actions_interfaces.ActionConfig{
+			Name: "test",
+			Type: "pod_info",
+		},
+	}
+
+	testLogger := logger.NewLogger("debug", "test")
+	testMetrics := metric.NewMetricsCollector(testLogger)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKubernetesClient(ctrl)
+
+	action := NewPodInfoAction(config, testLogger, testMetrics, mockClient)
+
+	iss := issue.NewIssue("Webhook issue", "WebhookIssue")
+	subject := issue.NewSubject("webhook-pod", issue.SubjectTypePod)
+	subject.Namespace = "payments"
+	iss.SetSubject(subject)
+
+	podName, namespace := action.extractPodInfo(event.NewIssueWorkflowEvent(iss))
+
+	assert.Equal(t, "webhook-pod", podName)
+	assert.Equal(t, "payments", namespace)
+}
@@ -6,6 +6,7 @@ import (
 	pod_info_config "github.com/kubecano/cano-collector/config/workflow/actions"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	"github.com/kubecano/cano-collector/pkg/schema"
 	actions_interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
 )
 
@@ -59,3 +60,30 @@ func (f *PodInfoActionFactory) Create(config actions_interfaces.ActionConfig) (a
 func (f *PodInfoActionFactory) GetActionType() string {
 	return "pod_info"
 }
+
+// ParameterSchema returns the JSON Schema of the pod_info parameters
+func (f *PodInfoActionFactory) ParameterSchema() *schema.Schema {
+	return schema.Reflect(pod_info_config.PodInfoActionConfig{})
+}
+
+// ValidateConfig validates the action configuration
+func (f *PodInfoActionFactory) ValidateConfig(config actions_interfaces.ActionConfig) error {
+	if config.Type != "pod_info" {
+		return fmt.Errorf("invalid action type for PodInfoActionFactory: %s", config.Type)
+	}
+
+	// Create a temporary config to validate
+	podInfoConfig := pod_info_config.NewPodInfoActionConfigWithDefaults(config)
+
+	// Update with parameters to test their validity
+	if err := podInfoConfig.UpdateFromParameters(config.Parameters); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	// Validate the complete configuration
+	if err := podInfoConfig.Validate(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return nil
+}
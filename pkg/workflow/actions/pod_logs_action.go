@@ -186,9 +186,21 @@ func (a *PodLogsAction) extractPodInfo(workflowEvent event.WorkflowEvent) (strin
 	var containerName string
 
 	// Check if pod name is provided in action parameters
-	if podName := a.GetStringParameter("pod_name", ""); podName != "" {
-		containerName = a.GetStringParameter("container", a.config.Container)
-		return podName, namespace, containerName
+	if a.config.PodName != "" {
+		return a.config.PodName, namespace, a.config.Container
+	}
+
+	// Use the issue subject if this event was built from a non-Alertmanager issue
+	if issueEvent, ok := workflowEvent.(*event.IssueWorkflowEvent); ok {
+		subject := issueEvent.GetIssue().Subject
+		if subject != nil && subject.SubjectType == issue.SubjectTypePod && subject.Name != "" {
+			a.logger.Info("Pod info extracted from issue subject",
+				zap.String("pod", subject.Name),
+				zap.String("container", subject.Container),
+				zap.String("namespace", namespace),
+			)
+			return subject.Name, namespace, subject.Container
+		}
 	}
 
 	// Try to extract from alert labels if this is an AlertManager event
@@ -238,13 +250,6 @@ func (a *PodLogsAction) extractPodInfo(workflowEvent event.WorkflowEvent) (strin
 		)
 	}
 
-	// Fallback: try to extract from alert name (simplified approach)
-	alertName := workflowEvent.GetAlertName()
-	if strings.Contains(alertName, "Pod") || strings.Contains(alertName, "pod") {
-		// Look for pod name in parameters
-		return a.GetStringParameter("pod_name", ""), namespace, containerName
-	}
-
 	return "", namespace, containerName
 }
 
@@ -483,6 +483,36 @@ func TestPodLogsAction_ExtractPodInfo_AlertManagerEvent(t *testing.T) {
 	assert.Equal(t, "test-container", containerName)
 }
 
+func TestPodLogsAction_ExtractPodInfo_IssueEvent(t *testing.T) {
+	logger := logger.NewLogger("debug", "test")
+	metrics := metric.NewMetricsCollector(logger)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKubernetesClient(ctrl)
+
+	config := pod_logs_config.PodLogsActionConfig{
+		ActionConfig: actions_interfaces.ActionConfig{
+			Name:    "test-pod-logs",
+			Type:    "pod_logs",
+			Enabled: true,
+		},
+	}
+
+	action := NewPodLogsAction(config, logger, metrics, mockClient)
+
+	iss := issue.NewIssue("Webhook issue", "WebhookIssue")
+	subject := issue.NewSubject("webhook-pod", issue.SubjectTypePod)
+	subject.Namespace = "payments"
+	subject.Container = "app"
+	iss.SetSubject(subject)
+
+	podName, namespace, containerName := action.extractPodInfo(event.NewIssueWorkflowEvent(iss))
+
+	assert.Equal(t, "webhook-pod", podName)
+	assert.Equal(t, "payments", namespace)
+	assert.Equal(t, "app", containerName)
+}
+
 func TestPodLogsAction_ExtractPodInfo_InstanceLabel(t *testing.T) {
 	logger := logger.NewLogger("debug", "test")
 	metrics := metric.NewMetricsCollector(logger)
@@ -545,12 +575,9 @@ func TestPodLogsAction_ExtractPodInfo_ActionParameters(t *testing.T) {
 			Name:    "test-pod-logs",
 			Type:    "pod_logs",
 			Enabled: true,
-			Parameters: map[string]interface{}{
-				"pod_name":  "param-pod",
-				"container": "param-container",
-			},
 		},
-		Container: "config-container",
+		PodName:   "param-pod",
+		Container: "param-container",
 	}
 
 	action := NewPodLogsAction(config, logger, metrics, mockClient)
@@ -779,7 +806,7 @@ func TestPodLogsActionFactory_ValidateConfig(t *testing.T) {
 					"max_lines": "not-a-number", // Should be int
 				},
 			},
-			wantErr: "max_lines must be an integer",
+			wantErr: `parameter "max_lines": expected an integer`,
 		},
 		{
 			name: "empty action name",
@@ -842,7 +869,7 @@ func TestPodLogsActionFactory_Create_Errors(t *testing.T) {
 					"timestamps": "invalid-bool", // Should be bool
 				},
 			},
-			wantErr: "timestamps must be a boolean",
+			wantErr: `parameter "timestamps": expected a boolean`,
 		},
 		{
 			name: "empty timestamp format",
@@ -976,8 +1003,8 @@ func TestPodLogsAction_ExtractPodInfo_FromAlertName(t *testing.T) {
 
 	podName, namespace, containerName := action.extractPodInfo(workflowEvent)
 
-	// Should extract from action parameters when alert name contains "Pod"
-	assert.Empty(t, podName) // No pod_name parameter set
+	// Neither the labels nor the pod_name parameter name a pod
+	assert.Empty(t, podName)
 	assert.Equal(t, "test-namespace", namespace)
 	assert.Empty(t, containerName)
 }
@@ -6,6 +6,7 @@ import (
 	pod_logs_config "github.com/kubecano/cano-collector/config/workflow/actions"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	"github.com/kubecano/cano-collector/pkg/schema"
 	actions_interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
 )
 
@@ -55,6 +56,11 @@ func (f *PodLogsActionFactory) GetActionType() string {
 	return "pod_logs"
 }
 
+// ParameterSchema returns the JSON Schema of the pod_logs parameters
+func (f *PodLogsActionFactory) ParameterSchema() *schema.Schema {
+	return schema.Reflect(pod_logs_config.PodLogsActionConfig{})
+}
+
 // ValidateConfig validates the action configuration
 func (f *PodLogsActionFactory) ValidateConfig(config actions_interfaces.ActionConfig) error {
 	if config.Type != "pod_logs" {
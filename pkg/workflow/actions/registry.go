@@ -7,9 +7,11 @@ import (
 
 	"go.uber.org/zap"
 
+	actions_config "github.com/kubecano/cano-collector/config/workflow/actions"
 	"github.com/kubecano/cano-collector/pkg/core/event"
 	logger_interfaces "github.com/kubecano/cano-collector/pkg/logger/interfaces"
 	metric_interfaces "github.com/kubecano/cano-collector/pkg/metric/interfaces"
+	"github.com/kubecano/cano-collector/pkg/schema"
 	actions_interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
 )
 
@@ -70,6 +72,19 @@ func (r *DefaultActionRegistry) Create(config actions_interfaces.ActionConfig) (
 	return factory.Create(config)
 }
 
+// ValidateConfig validates an action configuration with the factory of its type
+func (r *DefaultActionRegistry) ValidateConfig(config actions_interfaces.ActionConfig) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	factory, exists := r.factories[config.Type]
+	if !exists {
+		return fmt.Errorf("no factory registered for action type '%s'", config.Type)
+	}
+
+	return factory.ValidateConfig(config)
+}
+
 // GetRegisteredTypes returns all registered action types
 func (r *DefaultActionRegistry) GetRegisteredTypes() []string {
 	r.mu.RLock()
@@ -83,6 +98,35 @@ func (r *DefaultActionRegistry) GetRegisteredTypes() []string {
 	return types
 }
 
+// ParameterSchemas returns the JSON Schema of the parameters of every registered action type
+func (r *DefaultActionRegistry) ParameterSchemas() map[string]*schema.Schema {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schemas := make(map[string]*schema.Schema, len(r.factories))
+	for actionType, factory := range r.factories {
+		schemas[actionType] = factory.ParameterSchema()
+	}
+	return schemas
+}
+
+// RegisterDefaultActions registers the factories of all built-in workflow actions
+func RegisterDefaultActions(registry actions_interfaces.ActionRegistry, logger logger_interfaces.LoggerInterface, metrics metric_interfaces.MetricsInterface, kubeClient actions_interfaces.KubernetesClient) error {
+	factories := []actions_interfaces.ActionFactory{
+		NewPodLogsActionFactory(logger, metrics, kubeClient),
+		NewPodInfoActionFactory(logger, metrics, kubeClient),
+		NewLabelFilterActionFactory(logger, metrics),
+		NewSeverityRouterActionFactory(logger, metrics),
+		NewIssueEnrichmentActionFactory(logger, metrics),
+	}
+	for _, factory := range factories {
+		if err := registry.Register(factory.GetActionType(), factory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // DefaultActionExecutor implements ActionExecutor interface
 type DefaultActionExecutor struct {
 	registry actions_interfaces.ActionRegistry
@@ -237,11 +281,12 @@ func NewLabelFilterActionFactory(logger logger_interfaces.LoggerInterface, metri
 
 // Create creates a new LabelFilterAction instance
 func (f *LabelFilterActionFactory) Create(config actions_interfaces.ActionConfig) (actions_interfaces.WorkflowAction, error) {
-	if err := f.ValidateConfig(config); err != nil {
+	labelFilterConfig, err := f.newConfig(config)
+	if err != nil {
 		return nil, err
 	}
 
-	return NewLabelFilterAction(config, f.logger, f.metrics), nil
+	return NewLabelFilterAction(labelFilterConfig, f.logger, f.metrics), nil
 }
 
 // GetActionType returns the action type this factory creates
@@ -251,10 +296,28 @@ func (f *LabelFilterActionFactory) GetActionType() string {
 
 // ValidateConfig validates the action configuration
 func (f *LabelFilterActionFactory) ValidateConfig(config actions_interfaces.ActionConfig) error {
+	_, err := f.newConfig(config)
+	return err
+}
+
+// newConfig decodes and validates the label_filter parameters
+func (f *LabelFilterActionFactory) newConfig(config actions_interfaces.ActionConfig) (actions_config.LabelFilterActionConfig, error) {
+	labelFilterConfig := actions_config.NewLabelFilterActionConfigWithDefaults(config)
 	if config.Type != "label_filter" {
-		return fmt.Errorf("invalid action type for LabelFilterActionFactory: %s", config.Type)
+		return labelFilterConfig, fmt.Errorf("invalid action type for LabelFilterActionFactory: %s", config.Type)
 	}
-	return nil
+	if err := labelFilterConfig.UpdateFromParameters(config.Parameters); err != nil {
+		return labelFilterConfig, fmt.Errorf("invalid parameters: %w", err)
+	}
+	if err := labelFilterConfig.Validate(); err != nil {
+		return labelFilterConfig, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	return labelFilterConfig, nil
+}
+
+// ParameterSchema returns the JSON Schema of the label_filter parameters
+func (f *LabelFilterActionFactory) ParameterSchema() *schema.Schema {
+	return schema.Reflect(actions_config.LabelFilterActionConfig{})
 }
 
 // SeverityRouterActionFactory creates SeverityRouterAction instances
@@ -273,11 +336,12 @@ func NewSeverityRouterActionFactory(logger logger_interfaces.LoggerInterface, me
 
 // Create creates a new SeverityRouterAction instance
 func (f *SeverityRouterActionFactory) Create(config actions_interfaces.ActionConfig) (actions_interfaces.WorkflowAction, error) {
-	if err := f.ValidateConfig(config); err != nil {
+	severityRouterConfig, err := f.newConfig(config)
+	if err != nil {
 		return nil, err
 	}
 
-	return NewSeverityRouterAction(config, f.logger, f.metrics), nil
+	return NewSeverityRouterAction(severityRouterConfig, f.logger, f.metrics), nil
 }
 
 // GetActionType returns the action type this factory creates
@@ -287,10 +351,28 @@ func (f *SeverityRouterActionFactory) GetActionType() string {
 
 // ValidateConfig validates the action configuration
 func (f *SeverityRouterActionFactory) ValidateConfig(config actions_interfaces.ActionConfig) error {
+	_, err := f.newConfig(config)
+	return err
+}
+
+// newConfig decodes and validates the severity_router parameters
+func (f *SeverityRouterActionFactory) newConfig(config actions_interfaces.ActionConfig) (actions_config.SeverityRouterActionConfig, error) {
+	severityRouterConfig := actions_config.NewSeverityRouterActionConfigWithDefaults(config)
 	if config.Type != "severity_router" {
-		return fmt.Errorf("invalid action type for SeverityRouterActionFactory: %s", config.Type)
+		return severityRouterConfig, fmt.Errorf("invalid action type for SeverityRouterActionFactory: %s", config.Type)
 	}
-	return nil
+	if err := severityRouterConfig.UpdateFromParameters(config.Parameters); err != nil {
+		return severityRouterConfig, fmt.Errorf("invalid parameters: %w", err)
+	}
+	if err := severityRouterConfig.Validate(); err != nil {
+		return severityRouterConfig, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	return severityRouterConfig, nil
+}
+
+// ParameterSchema returns the JSON Schema of the severity_router parameters
+func (f *SeverityRouterActionFactory) ParameterSchema() *schema.Schema {
+	return schema.Reflect(actions_config.SeverityRouterActionConfig{})
 }
 
 // ============================================================================
@@ -313,10 +395,12 @@ func NewIssueEnrichmentActionFactory(logger logger_interfaces.LoggerInterface, m
 
 // Create creates a new IssueEnrichmentAction instance
 func (f *IssueEnrichmentActionFactory) Create(config actions_interfaces.ActionConfig) (actions_interfaces.WorkflowAction, error) {
-	if err := f.ValidateConfig(config); err != nil {
+	issueEnrichmentConfig, err := f.newConfig(config)
+	if err != nil {
 		return nil, err
 	}
-	return NewIssueEnrichmentAction(config, f.logger, f.metrics), nil
+
+	return NewIssueEnrichmentAction(issueEnrichmentConfig, f.logger, f.metrics), nil
 }
 
 // GetActionType returns the action type this factory creates
@@ -326,8 +410,26 @@ func (f *IssueEnrichmentActionFactory) GetActionType() string {
 
 // ValidateConfig validates the action configuration
 func (f *IssueEnrichmentActionFactory) ValidateConfig(config actions_interfaces.ActionConfig) error {
+	_, err := f.newConfig(config)
+	return err
+}
+
+// newConfig decodes and validates the issue_enrichment parameters
+func (f *IssueEnrichmentActionFactory) newConfig(config actions_interfaces.ActionConfig) (actions_config.IssueEnrichmentActionConfig, error) {
+	issueEnrichmentConfig := actions_config.NewIssueEnrichmentActionConfigWithDefaults(config)
 	if config.Type != "issue_enrichment" {
-		return fmt.Errorf("invalid action type for IssueEnrichmentActionFactory: %s", config.Type)
+		return issueEnrichmentConfig, fmt.Errorf("invalid action type for IssueEnrichmentActionFactory: %s", config.Type)
 	}
-	return nil
+	if err := issueEnrichmentConfig.UpdateFromParameters(config.Parameters); err != nil {
+		return issueEnrichmentConfig, fmt.Errorf("invalid parameters: %w", err)
+	}
+	if err := issueEnrichmentConfig.Validate(); err != nil {
+		return issueEnrichmentConfig, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	return issueEnrichmentConfig, nil
+}
+
+// ParameterSchema returns the JSON Schema of the issue_enrichment parameters
+func (f *IssueEnrichmentActionFactory) ParameterSchema() *schema.Schema {
+	return schema.Reflect(actions_config.IssueEnrichmentActionConfig{})
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/kubecano/cano-collector/pkg/core/event"
 	"github.com/kubecano/cano-collector/pkg/logger"
 	"github.com/kubecano/cano-collector/pkg/metric"
+	"github.com/kubecano/cano-collector/pkg/schema"
 	actions_interfaces "github.com/kubecano/cano-collector/pkg/workflow/actions/interfaces"
 )
 
@@ -39,6 +40,10 @@ func (m *mockActionFactory) ValidateConfig(config actions_interfaces.ActionConfi
 	return nil
 }
 
+func (m *mockActionFactory) ParameterSchema() *schema.Schema {
+	return schema.Object(nil)
+}
+
 type mockWorkflowAction struct {
 	name        string
 	validateErr error
@@ -227,6 +232,68 @@ func TestDefaultActionRegistry_ThreadSafety(t *testing.T) {
 	assert.Len(t, registry.GetRegisteredTypes(), 10)
 }
 
+func TestDefaultActionRegistry_ValidateConfig(t *testing.T) {
+	logger := logger.NewLogger("debug", "test")
+	metrics := metric.NewMetricsCollector(logger)
+	registry := NewDefaultActionRegistry(logger, metrics)
+	require.NoError(t, RegisterDefaultActions(registry, logger, metrics, NewPlaceholderKubernetesClient(logger)))
+
+	assert.ElementsMatch(t, []string{"pod_logs", "pod_info", "label_filter", "severity_router", "issue_enrichment"}, registry.GetRegisteredTypes())
+
+	err := registry.ValidateConfig(actions_interfaces.ActionConfig{
+		Type:       "pod_logs",
+		Name:       "pod_logs",
+		Parameters: map[string]interface{}{"tail_lines": 100},
+	})
+	require.NoError(t, err)
+
+	err = registry.ValidateConfig(actions_interfaces.ActionConfig{
+		Type:       "pod_logs",
+		Name:       "pod_logs",
+		Parameters: map[string]interface{}{"tail_lines": "many"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `parameter "tail_lines": expected an integer`)
+
+	err = registry.ValidateConfig(actions_interfaces.ActionConfig{
+		Type:       "pod_info",
+		Name:       "pod_info",
+		Parameters: map[string]interface{}{"min_restart_count": "often"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `parameter "min_restart_count": expected an integer`)
+
+	err = registry.ValidateConfig(actions_interfaces.ActionConfig{Type: "unknown", Name: "unknown"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no factory registered for action type 'unknown'")
+}
+
+func TestDefaultActionRegistry_ParameterSchemas(t *testing.T) {
+	logger := logger.NewLogger("debug", "test")
+	metrics := metric.NewMetricsCollector(logger)
+	registry := NewDefaultActionRegistry(logger, metrics)
+	require.NoError(t, RegisterDefaultActions(registry, logger, metrics, NewPlaceholderKubernetesClient(logger)))
+
+	schemas := registry.ParameterSchemas()
+
+	// every registered action is described, so /api/schema covers all of them
+	require.Len(t, schemas, 5)
+	for _, actionType := range registry.GetRegisteredTypes() {
+		assert.NotNil(t, schemas[actionType], "schema of %s", actionType)
+	}
+	podLogs := schemas["pod_logs"]
+	assert.Equal(t, "integer", podLogs.Properties["tail_lines"].Type)
+	assert.Equal(t, "string", podLogs.Properties["pod_name"].Type)
+	assert.NotContains(t, podLogs.Properties, "timeout", "common action fields are not parameters")
+	assert.Equal(t, []string{"severity_mapping"}, schemas["severity_router"].Required)
+	assert.Contains(t, schemas["label_filter"].Properties, "include_labels")
+	assert.Contains(t, schemas["issue_enrichment"].Properties, "custom_title")
+	podInfo := schemas["pod_info"]
+	assert.Equal(t, "integer", podInfo.Properties["min_restart_count"].Type)
+	assert.Equal(t, "boolean", podInfo.Properties["include_previous_state"].Type)
+	assert.NotContains(t, podInfo.Properties, "timeout", "common action fields are not parameters")
+}
+
 // Tests for DefaultActionExecutor
 
 func TestDefaultActionExecutor_NewDefaultActionExecutor(t *testing.T) {
@@ -21,6 +21,9 @@ type WorkflowEngine struct {
 	executor actions_interfaces.ActionExecutor
 	logger   logger_interfaces.LoggerInterface
 	metrics  metric_interfaces.MetricsInterface
+
+	// actions holds the actions created by PrepareActions by workflow name
+	actions map[string][]actions_interfaces.WorkflowAction
 }
 
 // NewWorkflowEngine creates a new workflow engine
@@ -33,6 +36,37 @@ func NewWorkflowEngine(config *workflow.WorkflowConfig, executor actions_interfa
 	}
 }
 
+// PrepareActions creates the actions of all active workflows, so unknown action types and invalid
+// parameters are reported when the configuration is loaded instead of when a matching alert fires.
+// The created actions are reused by every workflow execution.
+func (we *WorkflowEngine) PrepareActions() error {
+	if we.executor == nil {
+		return fmt.Errorf("action executor is not configured")
+	}
+
+	prepared := make(map[string][]actions_interfaces.WorkflowAction, len(we.config.ActiveWorkflows))
+	for i := range we.config.ActiveWorkflows {
+		wf := &we.config.ActiveWorkflows[i]
+		workflowActions, err := we.createActions(wf)
+		if err != nil {
+			return fmt.Errorf("workflow '%s': %w", wf.Name, err)
+		}
+		prepared[wf.Name] = workflowActions
+	}
+	we.actions = prepared
+
+	return nil
+}
+
+// createActions creates the actions of a workflow from its action definitions
+func (we *WorkflowEngine) createActions(wf *workflow.WorkflowDefinition) ([]actions_interfaces.WorkflowAction, error) {
+	actionConfigs, err := BuildActionConfigs(wf)
+	if err != nil {
+		return nil, err
+	}
+	return we.executor.CreateActionsFromConfig(actionConfigs)
+}
+
 // SelectWorkflows returns workflows that match the given event
 func (we *WorkflowEngine) SelectWorkflows(event event.WorkflowEvent) []*workflow.WorkflowDefinition {
 	var matchingWorkflows []*workflow.WorkflowDefinition
@@ -112,21 +146,19 @@ func (we *WorkflowEngine) ExecuteWorkflowWithEnrichments(ctx context.Context, wf
 		return nil, fmt.Errorf("action executor is not configured")
 	}
 
-	// Convert workflow action definitions to action configs
-	actionConfigs, err := we.createActionConfigs(wf)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create workflow actions from configs
-	workflowActions, err := we.executor.CreateActionsFromConfig(actionConfigs)
-	if err != nil {
-		if we.logger != nil {
-			we.logger.Error("Failed to create actions for workflow",
-				zap.String("workflow", wf.Name),
-				zap.Error(err))
+	// Reuse the prepared actions; workflows that were not prepared create them for this execution
+	workflowActions, prepared := we.actions[wf.Name]
+	if !prepared {
+		var err error
+		workflowActions, err = we.createActions(wf)
+		if err != nil {
+			if we.logger != nil {
+				we.logger.Error("Failed to create actions for workflow",
+					zap.String("workflow", wf.Name),
+					zap.Error(err))
+			}
+			return nil, err
 		}
-		return nil, err
 	}
 
 	// Execute actions in sequence using the provided context
@@ -189,9 +221,9 @@ func (we *WorkflowEngine) ExecuteWorkflowWithEnrichments(ctx context.Context, wf
 	return allEnrichments, nil
 }
 
-// createActionConfigs converts workflow action definitions to action configs
-// This method extracts the common logic shared between ExecuteWorkflow and ExecuteWorkflowWithEnrichments
-func (we *WorkflowEngine) createActionConfigs(wf *workflow.WorkflowDefinition) ([]actions_interfaces.ActionConfig, error) {
+// BuildActionConfigs converts workflow action definitions to action configs
+// This function extracts the common logic shared by workflow execution and configuration validation
+func BuildActionConfigs(wf *workflow.WorkflowDefinition) ([]actions_interfaces.ActionConfig, error) {
 	actionConfigs := make([]actions_interfaces.ActionConfig, 0, len(wf.Actions))
 
 	for i, actionDef := range wf.Actions {
@@ -260,7 +292,10 @@ func (we *WorkflowEngine) createActionConfigs(wf *workflow.WorkflowDefinition) (
 			}
 		} else {
 			// Backward compatibility: use all RawData as parameters
-			parameters = actionDef.RawData
+			parameters = make(map[string]interface{}, len(actionDef.RawData))
+			for key, value := range actionDef.RawData {
+				parameters[key] = value
+			}
 		}
 
 		actionConfig := actions_interfaces.ActionConfig{
@@ -274,7 +309,8 @@ func (we *WorkflowEngine) createActionConfigs(wf *workflow.WorkflowDefinition) (
 		// For backward compatibility: extract specific parameters if they exist in RawData
 		if actionData, exists := actionDef.RawData[actionType]; exists {
 			if actionDataMap, ok := actionData.(map[string]interface{}); ok {
-				// Merge action-specific data into parameters
+				// Merge action-specific data into parameters; the action type itself is not a parameter
+				delete(actionConfig.Parameters, actionType)
 				for key, value := range actionDataMap {
 					actionConfig.Parameters[key] = value
 				}
@@ -468,10 +468,8 @@ func (m *mockActionExecutor) ExecuteActions(ctx context.Context, actions []actio
 	return []*actions_interfaces.ActionResult{}, nil
 }
 
-// Test createActionConfigs method
-func TestWorkflowEngine_CreateActionConfigs(t *testing.T) {
-	engine := createTestEngine(&workflow.WorkflowConfig{})
-
+// Test BuildActionConfigs function
+func TestBuildActionConfigs(t *testing.T) {
 	tests := []struct {
 		name          string
 		workflowDef   *workflow.WorkflowDefinition
@@ -552,7 +550,7 @@ func TestWorkflowEngine_CreateActionConfigs(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			configs, err := engine.createActionConfigs(tt.workflowDef)
+			configs, err := BuildActionConfigs(tt.workflowDef)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -572,6 +570,34 @@ func TestWorkflowEngine_CreateActionConfigs(t *testing.T) {
 	}
 }
 
+func TestBuildActionConfigs_Parameters(t *testing.T) {
+	params := map[string]interface{}{"tail_lines": 50}
+	notations := map[string]map[string]interface{}{
+		"data":   {"action_type": "pod_logs", "data": map[string]interface{}{"tail_lines": 50}},
+		"inline": {"action_type": "pod_logs", "tail_lines": 50},
+		"keyed":  {"pod_logs": map[string]interface{}{"tail_lines": 50}},
+	}
+
+	for name, rawData := range notations {
+		t.Run(name, func(t *testing.T) {
+			wf := &workflow.WorkflowDefinition{
+				Name:    "test-workflow",
+				Actions: []workflow.ActionDefinition{{RawData: rawData}},
+			}
+
+			configs, err := BuildActionConfigs(wf)
+			require.NoError(t, err)
+			require.Len(t, configs, 1)
+			assert.Equal(t, "pod_logs", configs[0].Type)
+			assert.Equal(t, params, configs[0].Parameters)
+		})
+	}
+
+	// the definition is not modified, so it can be built again
+	keyed := notations["keyed"]
+	assert.Len(t, keyed, 1)
+}
+
 // Test ExecuteWorkflowWithEnrichments method
 func TestWorkflowEngine_ExecuteWorkflowWithEnrichments(t *testing.T) {
 	// Create a mock executor that returns enrichments
@@ -606,6 +632,46 @@ func TestWorkflowEngine_ExecuteWorkflowWithEnrichments(t *testing.T) {
 	assert.Equal(t, "Test Enrichment", enrichments[0].Title)
 }
 
+func TestWorkflowEngine_PrepareActions(t *testing.T) {
+	created := 0
+	mockExecutor := &mockActionExecutor{
+		createActionsFromConfigFunc: func(configs []actions_interfaces.ActionConfig) ([]actions_interfaces.WorkflowAction, error) {
+			created++
+			return []actions_interfaces.WorkflowAction{}, nil
+		},
+	}
+
+	config := createBasicWorkflowConfig()
+	engine := NewWorkflowEngine(config, mockExecutor, nil, nil)
+	require.NoError(t, engine.PrepareActions())
+	assert.Equal(t, len(config.ActiveWorkflows), created)
+
+	// prepared actions are reused by every execution
+	ctx := context.Background()
+	workflowEvent := createTestWorkflowEvent("firing", "TestAlert", "warning", "default")
+	for i := 0; i < 3; i++ {
+		_, err := engine.ExecuteWorkflowWithEnrichments(ctx, &config.ActiveWorkflows[0], workflowEvent)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, len(config.ActiveWorkflows), created)
+}
+
+func TestWorkflowEngine_PrepareActions_Errors(t *testing.T) {
+	mockExecutor := &mockActionExecutor{
+		createActionsFromConfigFunc: func(configs []actions_interfaces.ActionConfig) ([]actions_interfaces.WorkflowAction, error) {
+			return nil, fmt.Errorf("failed to create action 0 (%s): unknown action type", configs[0].Type)
+		},
+	}
+
+	err := NewWorkflowEngine(createBasicWorkflowConfig(), mockExecutor, nil, nil).PrepareActions()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workflow 'test-firing-workflow': failed to create action 0 (create_issue)")
+
+	err = NewWorkflowEngine(createBasicWorkflowConfig(), nil, nil, nil).PrepareActions()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "action executor is not configured")
+}
+
 // Test ExecuteWorkflowsWithEnrichments method
 func TestWorkflowEngine_ExecuteWorkflowsWithEnrichments(t *testing.T) {
 	// Create a mock executor that returns enrichments